@@ -118,6 +118,11 @@ type AgentCatalogSpec struct {
 	// Skills are OCI image references for loaded skills
 	// +optional
 	Skills []string `json:"skills,omitempty"`
+	// RequiredSkills are the skill catalog entries the agent depends on, by
+	// name or as name@version. Deployments of the agent fail until each one
+	// is published.
+	// +optional
+	RequiredSkills []string `json:"requiredSkills,omitempty"`
 	// TelemetryEndpoint is the endpoint for telemetry data
 	// +optional
 	TelemetryEndpoint string `json:"telemetryEndpoint,omitempty"`
@@ -136,6 +141,11 @@ type AgentCatalogSpec struct {
 	// McpServers are the MCP server configurations for the agent
 	// +optional
 	McpServers []McpServerConfig `json:"mcpServers,omitempty"`
+	// VisibilityGroups restricts who can see the entry on the public API to
+	// callers whose OIDC token carries one of these groups. Empty means
+	// visible to everyone; admins always see every entry.
+	// +optional
+	VisibilityGroups []string `json:"visibilityGroups,omitempty"`
 	// Metadata contains additional metadata for the agent (stars, verification, etc.)
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -163,6 +173,11 @@ type AgentCatalogStatus struct {
 	// For managed resources: set by RegistryDeployment
 	// +optional
 	Deployment *DeploymentRef `json:"deployment,omitempty"`
+	// DeploymentCount is the number of RegistryDeployments created from this
+	// version that went live, counted when each first reaches the Pending or
+	// Running phase. Deployments that only ever failed are not counted.
+	// +optional
+	DeploymentCount int64 `json:"deploymentCount,omitempty"`
 	// Publisher holds trust/verification badges set by the verification controller
 	// +optional
 	Publisher *PublisherVerification `json:"publisher,omitempty"`
@@ -48,6 +48,23 @@ type KeyValueInput struct {
 	// Required indicates if this input is required
 	// +optional
 	Required bool `json:"required,omitempty"`
+	// SecretRef takes the value from a Secret in the deployment's target
+	// namespace instead of Value, so it never appears in a generated
+	// manifest. Only honoured for package environment variables.
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
+}
+
+// SecretKeyRef selects a key of a Kubernetes Secret
+type SecretKeyRef struct {
+	// SecretName is the name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+	// Key is the key within the Secret. For an environment variable it
+	// defaults to the variable name, which it must match when set since KMCP
+	// exposes each key under its own name.
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // Argument represents a command-line argument
@@ -86,6 +103,12 @@ type Package struct {
 	// FileSHA256 is the SHA-256 hash for integrity verification
 	// +optional
 	FileSHA256 string `json:"fileSha256,omitempty"`
+	// Digest pins an OCI image to a content digest (sha256:<hex>). When set,
+	// the image is referenced as identifier@digest so a moving tag cannot
+	// change what runs.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest,omitempty"`
 	// RuntimeHint suggests the appropriate runtime for the package
 	// +optional
 	RuntimeHint string `json:"runtimeHint,omitempty"`
@@ -114,6 +137,25 @@ const (
 	CatalogStatusDeleted CatalogStatus = "deleted"
 )
 
+const (
+	// AnnotationDeprecated marks a catalog entry as deprecated when set to "true"
+	AnnotationDeprecated = "agentregistry.dev/deprecated"
+	// AnnotationSoftDeleted marks a catalog entry as deleted, without removing
+	// the resource, when set to "true"
+	AnnotationSoftDeleted = "agentregistry.dev/soft-deleted"
+	// AnnotationAttestations names the ConfigMap, in the entry's namespace,
+	// holding the attestations attached to a catalog entry
+	AnnotationAttestations = "agentregistry.dev/attestations"
+	// AnnotationFeatured marks a catalog entry as recommended by the registry
+	// curators when set to "true". Only admins can set it through the API.
+	AnnotationFeatured = "agentregistry.dev/featured"
+	// AnnotationPackagePrefix prefixes annotations on discovered MCPServers
+	// listing equivalent packages in other registries, keyed by registry type,
+	// e.g. agentregistry.dev/package.npm: "@modelcontextprotocol/server-everything@2025.9.25".
+	// The value is the package identifier, optionally followed by @version.
+	AnnotationPackagePrefix = "agentregistry.dev/package."
+)
+
 // CatalogConditionType represents the type of condition
 type CatalogConditionType string
 
@@ -122,6 +164,22 @@ const (
 	CatalogConditionReady CatalogConditionType = "Ready"
 	// CatalogConditionPublished indicates whether the catalog entry is published
 	CatalogConditionPublished CatalogConditionType = "Published"
+	// CatalogConditionDiscoveryIncomplete indicates that the discovered source
+	// resource could not be mapped to the catalog entry without losing information
+	CatalogConditionDiscoveryIncomplete CatalogConditionType = "DiscoveryIncomplete"
+	// CatalogConditionDuplicate indicates that an older catalog entry in the
+	// namespace has the same name and version
+	CatalogConditionDuplicate CatalogConditionType = "Duplicate"
+	// CatalogConditionUnresolvedMCPServers indicates that an agent deployment
+	// references registry MCP servers that could not be resolved from the
+	// catalog and are missing from the agent's configuration
+	CatalogConditionUnresolvedMCPServers CatalogConditionType = "UnresolvedMCPServers"
+	// CatalogConditionCatalogMissing indicates that the catalog entry of a
+	// deployed version was deleted while its resources are still deployed
+	CatalogConditionCatalogMissing CatalogConditionType = "CatalogMissing"
+	// CatalogConditionFluxUnavailable indicates that a Helm chart deployment
+	// targets clusters without the Flux CRDs that install the chart
+	CatalogConditionFluxUnavailable CatalogConditionType = "FluxUnavailable"
 )
 
 // Common label keys used across all catalog resources
@@ -149,6 +207,18 @@ const (
 	ResourceSourceImport     = "import"
 )
 
+// ResourceSourceOf returns how the catalog entry with labels was created.
+// Entries without a source label were created by hand.
+func ResourceSourceOf(labels map[string]string) string {
+	if labels["agentregistry.dev/discovered"] == "true" {
+		return ResourceSourceDiscovery
+	}
+	if source := labels[LabelResourceSource]; source != "" {
+		return source
+	}
+	return ResourceSourceManual
+}
+
 // ManagementType indicates how the resource is managed
 type ManagementType string
 
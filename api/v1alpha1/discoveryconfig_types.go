@@ -46,11 +46,16 @@ type Environment struct {
 	// +optional
 	Namespaces []string `json:"namespaces,omitempty"`
 
-	// ResourceTypes specifies which types to discover (MCPServer, Agent, Skill, ModelConfig)
+	// ResourceTypes specifies which types to discover (MCPServer, Agent, ModelConfig, RemoteMCPServer)
 	// Empty means all types
 	// +optional
 	ResourceTypes []string `json:"resourceTypes,omitempty"`
 
+	// LabelSelector limits discovery to resources matching it. Resources whose
+	// labels stop matching have their catalog entries removed.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
 	// A2AEndpoint is the kagent A2A base URL for this environment.
 	// Agent A2A URLs are derived as {a2aEndpoint}/api/a2a/{namespace}/{agent-name}/
 	// +optional
@@ -121,6 +126,13 @@ type RegistryConfig struct {
 	// +optional
 	// +kubebuilder:default=true
 	UseWorkloadIdentity bool `json:"useWorkloadIdentity,omitempty"`
+
+	// ImagePullSecrets names the Secrets, in the target namespace, used to
+	// pull images from this registry. Agent and package-based MCP server
+	// deployments into the environment use them unless they list their own.
+	// +optional
+	// +listType=set
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 }
 
 // DiscoveryConfigStatus defines the observed state of DiscoveryConfig
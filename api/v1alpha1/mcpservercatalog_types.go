@@ -20,6 +20,11 @@ type MCPServerCatalogSpec struct {
 	// WebsiteURL is the URL to the server's website or documentation
 	// +optional
 	WebsiteURL string `json:"websiteUrl,omitempty"`
+	// IconURL is the https URL of an icon shown for the server in the UI.
+	// It is served through GET /v0/servers/{name}/icon.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https://`
+	IconURL string `json:"iconUrl,omitempty"`
 	// Repository is the source code repository information
 	// +optional
 	Repository *Repository `json:"repository,omitempty"`
@@ -32,6 +37,14 @@ type MCPServerCatalogSpec struct {
 	// Remotes are the remote transport configurations (streamable-http endpoints)
 	// +optional
 	Remotes []Transport `json:"remotes,omitempty"`
+	// ReplacedBy points to the server that supersedes this one once it is deprecated
+	// +optional
+	ReplacedBy *CatalogEntryReference `json:"replacedBy,omitempty"`
+	// VisibilityGroups restricts who can see the entry on the public API to
+	// callers whose OIDC token carries one of these groups. Empty means
+	// visible to everyone; admins always see every entry.
+	// +optional
+	VisibilityGroups []string `json:"visibilityGroups,omitempty"`
 	// Metadata contains additional metadata for the server (stars, verification, etc.)
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -39,6 +52,16 @@ type MCPServerCatalogSpec struct {
 	Metadata *apiextensionsv1.JSON `json:"_meta,omitempty"`
 }
 
+// CatalogEntryReference identifies a catalog entry by canonical name and,
+// optionally, version
+type CatalogEntryReference struct {
+	// Name is the canonical name of the referenced entry
+	Name string `json:"name"`
+	// Version is the referenced version. When empty, the latest version is used.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 // SourceReference points to a deployed resource to monitor
 type SourceReference struct {
 	// Kind is the resource kind (e.g., MCPServer)
@@ -85,6 +108,11 @@ type MCPServerCatalogStatus struct {
 	// UsedBy lists the agents that reference this MCP server
 	// +optional
 	UsedBy []MCPServerUsageRef `json:"usedBy,omitempty"`
+	// DeploymentCount is the number of RegistryDeployments created from this
+	// version that went live, counted when each first reaches the Pending or
+	// Running phase. Deployments that only ever failed are not counted.
+	// +optional
+	DeploymentCount int64 `json:"deploymentCount,omitempty"`
 	// Publisher holds trust/verification badges set by the verification controller
 	// +optional
 	Publisher *PublisherVerification `json:"publisher,omitempty"`
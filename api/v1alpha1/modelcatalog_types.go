@@ -44,6 +44,11 @@ type ModelCatalogSpec struct {
 	// SourceRef references the deployed ModelConfig resource
 	// +optional
 	SourceRef *SourceReference `json:"sourceRef,omitempty"`
+	// VisibilityGroups restricts who can see the entry on the public API to
+	// callers whose OIDC token carries one of these groups. Empty means
+	// visible to everyone; admins always see every entry.
+	// +optional
+	VisibilityGroups []string `json:"visibilityGroups,omitempty"`
 }
 
 // ModelCatalogStatus defines the observed state of ModelCatalog
@@ -20,6 +20,8 @@ type RuntimeType string
 const (
 	// RuntimeTypeKubernetes indicates Kubernetes deployment
 	RuntimeTypeKubernetes RuntimeType = "kubernetes"
+	// RuntimeTypeHelm indicates deployment of the entry's Helm chart package
+	RuntimeTypeHelm RuntimeType = "helm"
 )
 
 // DeploymentPhase represents the current phase of a deployment
@@ -32,9 +34,18 @@ const (
 	DeploymentPhaseRunning DeploymentPhase = "Running"
 	// DeploymentPhaseFailed indicates the deployment has failed
 	DeploymentPhaseFailed DeploymentPhase = "Failed"
+	// DeploymentPhasePartiallyDeployed indicates some, but not all, of the
+	// deployment's resources were applied
+	DeploymentPhasePartiallyDeployed DeploymentPhase = "PartiallyDeployed"
+	// DeploymentPhasePaused indicates the deployment is paused and its
+	// resources were removed
+	DeploymentPhasePaused DeploymentPhase = "Paused"
 )
 
 // RegistryDeploymentSpec defines the desired state of RegistryDeployment
+// +kubebuilder:validation:XValidation:rule="!has(self.__namespace__) || !has(self.namespaces)",message="namespace and namespaces are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.environment) || !has(self.environments)",message="environment and environments are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.imagePullSecrets) || self.runtime != 'helm'",message="imagePullSecrets are not supported for Helm chart deployments"
 type RegistryDeploymentSpec struct {
 	// ResourceName is the name of the resource in the catalog (matches spec.name in catalog CRs)
 	ResourceName string `json:"resourceName"`
@@ -42,21 +53,80 @@ type RegistryDeploymentSpec struct {
 	Version string `json:"version"`
 	// ResourceType is the type of resource (mcp, agent)
 	ResourceType ResourceType `json:"resourceType"`
-	// Runtime is the deployment runtime (local, kubernetes)
+	// Runtime is the deployment runtime (kubernetes, helm)
 	Runtime RuntimeType `json:"runtime"`
 	// PreferRemote indicates whether to prefer remote transport when available
 	// +optional
 	PreferRemote bool `json:"preferRemote,omitempty"`
+	// PackageIndex selects which of the catalog entry's packages an MCP server
+	// deployment runs, e.g. its npm rather than its OCI package. Defaults to
+	// the first package.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PackageIndex int32 `json:"packageIndex,omitempty"`
 	// Config contains deployment configuration (environment variables, etc.)
 	// +optional
 	Config map[string]string `json:"config,omitempty"`
-	// Namespace is the target namespace for Kubernetes deployments
+	// Namespace is the target namespace for Kubernetes deployments. When
+	// empty, it defaults to the environment's cluster namespace, else its
+	// first discovery namespace, else "kagent". When Environment is set, an
+	// explicit namespace must be one the environment declares, if it
+	// declares any.
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// Namespaces deploys the resource into each of the listed namespaces
+	// instead of a single one, e.g. dev and staging. Mutually exclusive with
+	// Namespace. When Environment is set, each must be one the environment
+	// declares, if it declares any. Removing a namespace deletes the
+	// resources deployed into it.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// ImagePullSecrets names the Secrets, in the target namespace, used to
+	// pull the agent or MCP server image from a private registry. When empty,
+	// the ImagePullSecrets of the environment's registry are used. MCP
+	// servers get them through the ServiceAccount kmcp runs their pods
+	// under; remote servers and Helm charts run no image and reject them.
+	// +optional
+	// +listType=set
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 	// Environment is the target environment name (from DiscoveryConfig) for remote cluster deployment.
 	// If empty, deploys to the local cluster.
 	// +optional
 	Environment string `json:"environment,omitempty"`
+	// Environments deploys the resource into each of the listed environments
+	// instead of a single one, e.g. dev and staging. Mutually exclusive with
+	// Environment. The namespaces are resolved against each environment in
+	// turn. Removing an environment deletes the resources deployed into it.
+	// +optional
+	// +listType=set
+	Environments []string `json:"environments,omitempty"`
+	// ResourceLabels are added to every managed resource (MCPServer, Agent,
+	// ConfigMap, ...). Tracking labels set by the controller take precedence.
+	// +optional
+	ResourceLabels map[string]string `json:"resourceLabels,omitempty"`
+	// ResourceAnnotations are added to every managed resource
+	// +optional
+	ResourceAnnotations map[string]string `json:"resourceAnnotations,omitempty"`
+	// CommandOverride replaces the container command derived from the
+	// catalog package. Only valid for package-based MCP server deployments.
+	// +optional
+	CommandOverride string `json:"commandOverride,omitempty"`
+	// ArgsOverride replaces the container arguments derived from the catalog
+	// package. Only valid for package-based MCP server deployments.
+	// +optional
+	ArgsOverride []string `json:"argsOverride,omitempty"`
+	// Paused removes the deployment's managed resources while keeping the
+	// deployment and its config. Unpausing applies them again.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// NotifyWebhookSecretRef selects the key of a Secret, in the deployment's
+	// namespace, holding the https URL that receives a JSON POST each time the
+	// deployment changes phase, e.g. a Slack incoming webhook. Key defaults
+	// to "url". The URL must resolve to a public address. Delivery is
+	// best-effort. Overrides the controller's --deployment-webhook-url.
+	// +optional
+	NotifyWebhookSecretRef *SecretKeyRef `json:"notifyWebhookSecretRef,omitempty"`
 }
 
 // RegistryDeploymentStatus defines the observed state of RegistryDeployment
@@ -82,6 +152,27 @@ type RegistryDeploymentStatus struct {
 	// ObservedGeneration is the generation last observed by the controller
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// EffectiveCommand is the command and arguments the deployed MCP server
+	// container runs with. Empty when the image's own entrypoint is used.
+	// +optional
+	EffectiveCommand []string `json:"effectiveCommand,omitempty"`
+	// Timeline is a bounded, chronological history of what happened to the
+	// deployment, oldest first
+	// +optional
+	Timeline []TimelineEntry `json:"timeline,omitempty"`
+	// Counted records that the deployment was counted in the DeploymentCount
+	// of its catalog entry, which happens once it first reaches the Pending
+	// or Running phase
+	// +optional
+	Counted bool `json:"counted,omitempty"`
+}
+
+// TimelineEntry is one event in a deployment's timeline
+type TimelineEntry struct {
+	// Time is when the event happened
+	Time metav1.Time `json:"time"`
+	// Message describes the event
+	Message string `json:"message"`
 }
 
 // ManagedResource represents a Kubernetes resource managed by a deployment
@@ -98,6 +189,9 @@ type ManagedResource struct {
 	// Cluster is the cluster name where this resource is deployed (empty = local)
 	// +optional
 	Cluster string `json:"cluster,omitempty"`
+	// Digest is the pinned image digest the resource runs, if any
+	// +optional
+	Digest string `json:"digest,omitempty"`
 }
 
 // +kubebuilder:object:root=true
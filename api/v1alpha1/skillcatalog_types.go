@@ -68,6 +68,11 @@ type SkillCatalogSpec struct {
 	// Remotes are the remote endpoints for the skill
 	// +optional
 	Remotes []SkillRemote `json:"remotes,omitempty"`
+	// VisibilityGroups restricts who can see the entry on the public API to
+	// callers whose OIDC token carries one of these groups. Empty means
+	// visible to everyone; admins always see every entry.
+	// +optional
+	VisibilityGroups []string `json:"visibilityGroups,omitempty"`
 	// Metadata contains additional metadata for the skill (stars, verification, etc.)
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
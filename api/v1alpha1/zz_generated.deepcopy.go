@@ -85,6 +85,11 @@ func (in *AgentCatalogSpec) DeepCopyInto(out *AgentCatalogSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RequiredSkills != nil {
+		in, out := &in.RequiredSkills, &out.RequiredSkills
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Repository != nil {
 		in, out := &in.Repository, &out.Repository
 		*out = new(Repository)
@@ -111,6 +116,11 @@ func (in *AgentCatalogSpec) DeepCopyInto(out *AgentCatalogSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VisibilityGroups != nil {
+		in, out := &in.VisibilityGroups, &out.VisibilityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(v1.JSON)
@@ -250,6 +260,21 @@ func (in *CatalogCondition) DeepCopy() *CatalogCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogEntryReference) DeepCopyInto(out *CatalogEntryReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogEntryReference.
+func (in *CatalogEntryReference) DeepCopy() *CatalogEntryReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogEntryReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
@@ -417,7 +442,7 @@ func (in *DiscoveryConfigStatus) DeepCopy() *DiscoveryConfigStatus {
 func (in *Environment) DeepCopyInto(out *Environment) {
 	*out = *in
 	out.Cluster = in.Cluster
-	out.Registry = in.Registry
+	in.Registry.DeepCopyInto(&out.Registry)
 	if in.AllowedGroups != nil {
 		in, out := &in.AllowedGroups, &out.AllowedGroups
 		*out = make([]string, len(*in))
@@ -433,6 +458,11 @@ func (in *Environment) DeepCopyInto(out *Environment) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -475,6 +505,11 @@ func (in *EnvironmentStatus) DeepCopy() *EnvironmentStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyValueInput) DeepCopyInto(out *KeyValueInput) {
 	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyValueInput.
@@ -573,6 +608,16 @@ func (in *MCPServerCatalogSpec) DeepCopyInto(out *MCPServerCatalogSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReplacedBy != nil {
+		in, out := &in.ReplacedBy, &out.ReplacedBy
+		*out = new(CatalogEntryReference)
+		**out = **in
+	}
+	if in.VisibilityGroups != nil {
+		in, out := &in.VisibilityGroups, &out.VisibilityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(v1.JSON)
@@ -767,6 +812,11 @@ func (in *ModelCatalogSpec) DeepCopyInto(out *ModelCatalogSpec) {
 		*out = new(SourceReference)
 		**out = **in
 	}
+	if in.VisibilityGroups != nil {
+		in, out := &in.VisibilityGroups, &out.VisibilityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCatalogSpec.
@@ -847,7 +897,9 @@ func (in *Package) DeepCopyInto(out *Package) {
 	if in.EnvironmentVariables != nil {
 		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
 		*out = make([]KeyValueInput, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -888,6 +940,11 @@ func (in *PublisherVerification) DeepCopy() *PublisherVerification {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryConfig) DeepCopyInto(out *RegistryConfig) {
 	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryConfig.
@@ -969,6 +1026,45 @@ func (in *RegistryDeploymentSpec) DeepCopyInto(out *RegistryDeploymentSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceLabels != nil {
+		in, out := &in.ResourceLabels, &out.ResourceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceAnnotations != nil {
+		in, out := &in.ResourceAnnotations, &out.ResourceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ArgsOverride != nil {
+		in, out := &in.ArgsOverride, &out.ArgsOverride
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotifyWebhookSecretRef != nil {
+		in, out := &in.NotifyWebhookSecretRef, &out.NotifyWebhookSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryDeploymentSpec.
@@ -1004,6 +1100,18 @@ func (in *RegistryDeploymentStatus) DeepCopyInto(out *RegistryDeploymentStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EffectiveCommand != nil {
+		in, out := &in.EffectiveCommand, &out.EffectiveCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]TimelineEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryDeploymentStatus.
@@ -1031,6 +1139,21 @@ func (in *Repository) DeepCopy() *Repository {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SkillCatalog) DeepCopyInto(out *SkillCatalog) {
 	*out = *in
@@ -1110,6 +1233,11 @@ func (in *SkillCatalogSpec) DeepCopyInto(out *SkillCatalogSpec) {
 		*out = make([]SkillRemote, len(*in))
 		copy(*out, *in)
 	}
+	if in.VisibilityGroups != nil {
+		in, out := &in.VisibilityGroups, &out.VisibilityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(v1.JSON)
@@ -1258,13 +1386,31 @@ func (in *SourceReference) DeepCopy() *SourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimelineEntry) DeepCopyInto(out *TimelineEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimelineEntry.
+func (in *TimelineEntry) DeepCopy() *TimelineEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(TimelineEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Transport) DeepCopyInto(out *Transport) {
 	*out = *in
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make([]KeyValueInput, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Names accepted by --enable-controllers
+const (
+	controllerMCPServerCatalog   = "mcpservercatalog"
+	controllerAgentCatalog       = "agentcatalog"
+	controllerSkillCatalog       = "skillcatalog"
+	controllerRegistryDeployment = "registrydeployment"
+	controllerDiscoveryConfig    = "discoveryconfig"
+)
+
+// allControllers lists every reconciler in setup order
+var allControllers = []string{
+	controllerMCPServerCatalog,
+	controllerAgentCatalog,
+	controllerSkillCatalog,
+	controllerRegistryDeployment,
+	controllerDiscoveryConfig,
+}
+
+// controllerSet is the set of reconcilers enabled with --enable-controllers
+type controllerSet map[string]bool
+
+// parseEnabledControllers parses the comma-separated --enable-controllers
+// value. An empty value or "all" enables every controller.
+func parseEnabledControllers(value string) (controllerSet, error) {
+	enabled := controllerSet{}
+	if v := strings.TrimSpace(value); v == "" || v == "all" {
+		for _, name := range allControllers {
+			enabled[name] = true
+		}
+		return enabled, nil
+	}
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(allControllers, name) {
+			return nil, fmt.Errorf("unknown controller %q: must be one of %s", name, strings.Join(allControllers, ", "))
+		}
+		enabled[name] = true
+	}
+	if len(enabled) == 0 {
+		return nil, fmt.Errorf("no controllers enabled: must be one or more of %s", strings.Join(allControllers, ", "))
+	}
+	return enabled, nil
+}
+
+// names returns the enabled controllers in setup order
+func (s controllerSet) names() []string {
+	names := make([]string, 0, len(s))
+	for _, name := range allControllers {
+		if s[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// namedController pairs a controller name with the function registering its
+// reconciler with the manager
+type namedController struct {
+	name  string
+	setup func(mgr ctrl.Manager) error
+}
+
+// setupControllers registers the enabled controllers with mgr, skipping the
+// others entirely so their watches and informers are never started. It
+// returns the names of the controllers it registered.
+func setupControllers(mgr ctrl.Manager, controllers []namedController, enabled controllerSet) ([]string, error) {
+	var registered []string
+	for _, c := range controllers {
+		if !enabled[c.name] {
+			continue
+		}
+		if err := c.setup(mgr); err != nil {
+			return registered, fmt.Errorf("unable to create controller %s: %w", c.name, err)
+		}
+		registered = append(registered, c.name)
+	}
+	return registered, nil
+}
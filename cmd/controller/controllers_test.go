@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestParseEnabledControllers(t *testing.T) {
+	all, err := parseEnabledControllers("all")
+	require.NoError(t, err)
+	assert.Equal(t, allControllers, all.names())
+
+	empty, err := parseEnabledControllers("")
+	require.NoError(t, err)
+	assert.Equal(t, allControllers, empty.names())
+
+	subset, err := parseEnabledControllers(" SkillCatalog, mcpservercatalog ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{controllerMCPServerCatalog, controllerSkillCatalog}, subset.names())
+
+	_, err = parseEnabledControllers("mcpservercatalog,deployments")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown controller "deployments"`)
+
+	_, err = parseEnabledControllers(",")
+	assert.Error(t, err)
+}
+
+func TestSetupControllers_SkipsDisabled(t *testing.T) {
+	var called []string
+	controllers := make([]namedController, 0, len(allControllers))
+	for _, name := range allControllers {
+		controllers = append(controllers, namedController{name, func(ctrl.Manager) error {
+			called = append(called, name)
+			return nil
+		}})
+	}
+
+	enabled, err := parseEnabledControllers("mcpservercatalog,agentcatalog,skillcatalog")
+	require.NoError(t, err)
+
+	registered, err := setupControllers(nil, controllers, enabled)
+	require.NoError(t, err)
+	assert.Equal(t, []string{controllerMCPServerCatalog, controllerAgentCatalog, controllerSkillCatalog}, registered)
+	assert.Equal(t, registered, called)
+	assert.NotContains(t, called, controllerRegistryDeployment)
+	assert.NotContains(t, called, controllerDiscoveryConfig)
+}
+
+func TestSetupControllers_ReportsSetupError(t *testing.T) {
+	controllers := []namedController{
+		{controllerMCPServerCatalog, func(ctrl.Manager) error { return nil }},
+		{controllerAgentCatalog, func(ctrl.Manager) error { return errors.New("no kind registered") }},
+	}
+	enabled, err := parseEnabledControllers("")
+	require.NoError(t, err)
+
+	registered, err := setupControllers(nil, controllers, enabled)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "agentcatalog")
+	assert.Equal(t, []string{controllerMCPServerCatalog}, registered)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	logFormatConsole = "console"
+	logFormatJSON    = "json"
+)
+
+// logOptions holds the logging flags. Format and level are independent; when
+// no format is given it follows the level, as before --log-format existed.
+type logOptions struct {
+	level  string
+	format string
+}
+
+func (o *logOptions) bindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.level, "log-level", "info", "Log level (trace, debug, info, warn, error)")
+	fs.StringVar(&o.format, "log-format", "",
+		"Log format (console, json). Defaults to console for trace, debug and info, and json otherwise.")
+}
+
+// resolvedFormat returns the log format to use
+func (o *logOptions) resolvedFormat() (string, error) {
+	switch o.format {
+	case logFormatConsole, logFormatJSON:
+		return o.format, nil
+	case "":
+		// Use console writer for better readability in development and
+		// JSON output for production (warn, error)
+		if o.level == "trace" || o.level == "debug" || o.level == "info" {
+			return logFormatConsole, nil
+		}
+		return logFormatJSON, nil
+	default:
+		return "", fmt.Errorf("invalid log format %q: must be console or json", o.format)
+	}
+}
+
+// globalLevel returns the zerolog level for the level flag. Unknown levels
+// report false and leave the global level unchanged.
+func (o *logOptions) globalLevel() (zerolog.Level, bool) {
+	switch o.level {
+	case "trace":
+		return zerolog.TraceLevel, true
+	case "debug":
+		return zerolog.DebugLevel, true
+	case "info":
+		return zerolog.InfoLevel, true
+	case "warn":
+		return zerolog.WarnLevel, true
+	case "error":
+		return zerolog.ErrorLevel, true
+	default:
+		return zerolog.NoLevel, false
+	}
+}
+
+// newLogger builds the root logger writing to out in the given format. The
+// HTTP server, MCP server and reconcilers all derive their loggers from it.
+func newLogger(out io.Writer, format string) zerolog.Logger {
+	if format == logFormatConsole {
+		out = zerolog.ConsoleWriter{
+			Out:        out,
+			TimeFormat: "2006-01-02 15:04:05.000",
+			PartsOrder: []string{
+				zerolog.TimestampFieldName,
+				zerolog.LevelFieldName,
+				zerolog.CallerFieldName,
+				zerolog.MessageFieldName,
+			},
+		}
+	}
+	return zerolog.New(out).With().Timestamp().Caller().Logger()
+}
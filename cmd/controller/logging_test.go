@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogOptions_ResolvedFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", args: nil, want: logFormatConsole},
+		{name: "debug defaults to console", args: []string{"--log-level=debug"}, want: logFormatConsole},
+		{name: "warn defaults to json", args: []string{"--log-level=warn"}, want: logFormatJSON},
+		{name: "json at debug", args: []string{"--log-level=debug", "--log-format=json"}, want: logFormatJSON},
+		{name: "console at error", args: []string{"--log-level=error", "--log-format=console"}, want: logFormatConsole},
+		{name: "invalid format", args: []string{"--log-format=xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts logOptions
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			opts.bindFlags(fs)
+			require.NoError(t, fs.Parse(tt.args))
+
+			got, err := opts.resolvedFormat()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLogOptions_GlobalLevel(t *testing.T) {
+	opts := logOptions{level: "debug"}
+	level, ok := opts.globalLevel()
+	assert.True(t, ok)
+	assert.Equal(t, zerolog.DebugLevel, level)
+
+	opts.level = "verbose"
+	_, ok = opts.globalLevel()
+	assert.False(t, ok)
+}
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf, logFormatJSON)
+	logger.Info().Str("component", "httpapi").Msg("hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), "json format should emit one JSON object per line")
+	assert.Equal(t, "hello", entry["message"])
+	assert.Equal(t, "httpapi", entry["component"])
+
+	buf.Reset()
+	logger = newLogger(&buf, logFormatConsole)
+	logger.Info().Msg("hello")
+	assert.Contains(t, buf.String(), "hello")
+	assert.False(t, json.Valid(buf.Bytes()), "console format should not emit JSON")
+}
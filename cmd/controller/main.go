@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"flag"
 	"io/fs"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -12,21 +15,27 @@ import (
 	"github.com/go-logr/zerologr"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/cluster"
 	arconfig "github.com/agentregistry-dev/agentregistry/internal/config"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/enrichment"
 	"github.com/agentregistry-dev/agentregistry/internal/httpapi"
 	registrymcp "github.com/agentregistry-dev/agentregistry/internal/mcp"
+	"github.com/agentregistry-dev/agentregistry/internal/tlsconfig"
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 
 	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
@@ -63,7 +72,17 @@ func main() {
 		httpAPIAddr          string
 		mcpAddr              string
 		enableHTTPAPI        bool
-		logLevel             string
+		logOpts              logOptions
+		startupJitter        time.Duration
+		discoveryStatus      time.Duration
+		discoveryResync      time.Duration
+		maxManagedResources  int
+		pruneCatalogMissing  bool
+		deploymentWebhook    string
+		deploymentWorkers    int
+		discoveryWorkers     int
+		enableControllers    string
+		enableWebhooks       bool
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8081", "The address the metric endpoint binds to.")
@@ -74,46 +93,61 @@ func main() {
 	flag.StringVar(&httpAPIAddr, "http-api-address", ":8080", "The address the HTTP API server binds to.")
 	flag.StringVar(&mcpAddr, "mcp-address", ":8083", "The address the MCP server binds to.")
 	flag.BoolVar(&enableHTTPAPI, "enable-http-api", true, "Enable the HTTP API server.")
-	flag.StringVar(&logLevel, "log-level", "info", "Log level (trace, debug, info, warn, error)")
+	logOpts.bindFlags(flag.CommandLine)
+	flag.DurationVar(&startupJitter, "startup-reconcile-jitter", controller.DefaultStartupJitterWindow,
+		"Window over which initial reconciles are randomly spread after startup. Set to 0 to disable.")
+	flag.DurationVar(&discoveryStatus, "discovery-status-interval", controller.DefaultDiscoveryStatusInterval,
+		"Minimum interval between DiscoveryConfig status writes triggered by discovered resource changes. Set to 0 to write on every change.")
+	flag.DurationVar(&discoveryResync, "discovery-resync-period", controller.DefaultDiscoveryResyncPeriod,
+		"How often discovery informers replay every discovered resource, recreating catalog entries that drifted after a missed watch event. Set to 0 to disable.")
+	flag.IntVar(&maxManagedResources, "max-managed-resources", controller.DefaultMaxManagedResources,
+		"Maximum number of resources a single RegistryDeployment may apply; a deployment rendering more fails.")
+	flag.BoolVar(&pruneCatalogMissing, "prune-on-catalog-missing", false,
+		"Delete the resources of a deployment whose catalog entry was deleted instead of leaving them running.")
+	flag.StringVar(&deploymentWebhook, "deployment-webhook-url", "",
+		"URL POSTed a JSON notification each time a RegistryDeployment without spec.notifyWebhookSecretRef changes phase. Empty disables.")
+	flag.IntVar(&deploymentWorkers, "max-concurrent-deployment-reconciles", 1,
+		"Number of RegistryDeployments reconciled concurrently.")
+	flag.IntVar(&discoveryWorkers, "max-concurrent-discovery-reconciles", 1,
+		"Number of DiscoveryConfigs reconciled concurrently.")
+	flag.StringVar(&enableControllers, "enable-controllers", "all",
+		"Comma-separated controllers to run ("+strings.Join(allControllers, ", ")+"), or all.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the validating admission webhook enforcing catalog size limits. Requires a serving certificate.")
 
 	// Parse flags (controller-runtime adds --kubeconfig flag automatically)
 	flag.Parse()
 
-	// Set up structured logging with zerolog (re-apply configuration with proper log level)
-	if logLevel == "trace" || logLevel == "debug" || logLevel == "info" {
-		// Use console writer for better readability in development
-		log.Logger = log.Output(zerolog.ConsoleWriter{
-			Out:        os.Stderr,
-			TimeFormat: "2006-01-02 15:04:05.000",
-			PartsOrder: []string{
-				zerolog.TimestampFieldName,
-				zerolog.LevelFieldName,
-				zerolog.CallerFieldName,
-				zerolog.MessageFieldName,
-			},
-		}).With().Timestamp().Caller().Logger()
-	} else {
-		// Use JSON output for production (warn, error)
-		log.Logger = zerolog.New(os.Stderr).With().Timestamp().Caller().Logger()
+	// Set up structured logging with zerolog (re-apply configuration with proper level and format)
+	logFormat, err := logOpts.resolvedFormat()
+	if err != nil {
+		log.Error().Err(err).Msg("invalid logging configuration")
+		os.Exit(1)
 	}
-
-	// Set log level
-	switch logLevel {
-	case "trace":
-		zerolog.SetGlobalLevel(zerolog.TraceLevel)
-	case "debug":
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	case "info":
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	case "warn":
-		zerolog.SetGlobalLevel(zerolog.WarnLevel)
-	case "error":
-		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	log.Logger = newLogger(os.Stderr, logFormat)
+	if level, ok := logOpts.globalLevel(); ok {
+		zerolog.SetGlobalLevel(level)
 	}
 
 	// Set up controller-runtime logger using zerologr
 	logf.SetLogger(zerologr.New(&log.Logger))
 
+	// Export traces when an OTLP endpoint is configured; a no-op otherwise
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Error().Err(err).Msg("unable to set up tracing")
+		os.Exit(1)
+	}
+	if tracing.Enabled() {
+		log.Info().Msg("OpenTelemetry tracing enabled")
+	}
+
+	enabledControllers, err := parseEnabledControllers(enableControllers)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid --enable-controllers")
+		os.Exit(1)
+	}
+
 	log.Info().
 		Str("version", version.Version).
 		Str("commit", version.GitCommit).
@@ -124,7 +158,9 @@ func main() {
 		Str("http-api-addr", httpAPIAddr).
 		Str("mcp-addr", mcpAddr).
 		Bool("enable-http-api", enableHTTPAPI).
-		Str("log-level", logLevel).
+		Str("log-level", logOpts.level).
+		Str("log-format", logFormat).
+		Dur("startup-reconcile-jitter", startupJitter).
 		Msg("starting agent registry controller")
 
 	// Get Kubernetes config (uses --kubeconfig flag or KUBECONFIG env var or in-cluster)
@@ -145,6 +181,13 @@ func main() {
 	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme: scheme,
 		Cache:  cacheOpts,
+		// Pods and ServiceAccounts are only read to apply the pull secrets of
+		// MCP servers, which does not warrant caching them cluster-wide.
+		// Secrets are read one at a time from the controller's namespace,
+		// the only one its Role grants access to.
+		Client: client.Options{Cache: &client.CacheOptions{
+			DisableFor: []client.Object{&corev1.Pod{}, &corev1.ServiceAccount{}, &corev1.Secret{}},
+		}},
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
@@ -166,60 +209,88 @@ func main() {
 	// Create controller logger
 	ctrlLogger := log.Logger.With().Str("component", "controller").Logger()
 
-	// Set up MCPServerCatalog reconciler
-	if err := (&controller.MCPServerCatalogReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Logger: ctrlLogger.With().Str("controller", "mcpservercatalog").Logger(),
-	}).SetupWithManager(mgr); err != nil {
-		log.Error().Err(err).Str("controller", "MCPServerCatalog").Msg("unable to create controller")
-		os.Exit(1)
-	}
-
-	// Set up AgentCatalog reconciler
-	if err := (&controller.AgentCatalogReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Logger: ctrlLogger.With().Str("controller", "agentcatalog").Logger(),
-	}).SetupWithManager(mgr); err != nil {
-		log.Error().Err(err).Str("controller", "AgentCatalog").Msg("unable to create controller")
-		os.Exit(1)
-	}
+	// Each reconciler gets its own jitter so that keys are tracked per kind
+	newJitter := func() *controller.StartupJitter { return controller.NewStartupJitter(startupJitter) }
 
-	// Set up SkillCatalog reconciler
-	if err := (&controller.SkillCatalogReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Logger: ctrlLogger.With().Str("controller", "skillcatalog").Logger(),
-	}).SetupWithManager(mgr); err != nil {
-		log.Error().Err(err).Str("controller", "SkillCatalog").Msg("unable to create controller")
+	// Load the TLS trust settings for remote clusters and external registries.
+	// The API reader is used because the cache has not started yet.
+	tlsCtx, cancelTLS := context.WithTimeout(context.Background(), 30*time.Second)
+	tlsOpts, err := tlsconfig.Load(tlsCtx, mgr.GetAPIReader(), log.Logger)
+	cancelTLS()
+	if err != nil {
+		log.Error().Err(err).Msg("unable to load TLS CA bundle")
 		os.Exit(1)
 	}
+	enrichment.SetSharedTLSConfig(tlsOpts.TLSConfig())
+	controller.SetMCPTLSConfig(tlsOpts.TLSConfig())
 
 	// Initialize remote client factory for multi-cluster support (discovery + deployment)
 	clusterFactory := cluster.NewFactory(mgr.GetClient(), ctrlLogger)
+	clusterFactory.SetTLSOptions(tlsOpts)
 	remoteClientFactory := clusterFactory.CreateClientFunc()
 	controller.RemoteClientFactory = remoteClientFactory
 	log.Info().Msg("initialized remote client factory for multi-cluster support")
 
-	// Set up RegistryDeployment reconciler
-	if err := (&controller.RegistryDeploymentReconciler{
-		Client:              mgr.GetClient(),
-		Scheme:              mgr.GetScheme(),
-		Logger:              ctrlLogger.With().Str("controller", "registrydeployment").Logger(),
-		RemoteClientFactory: remoteClientFactory,
-	}).SetupWithManager(mgr); err != nil {
-		log.Error().Err(err).Str("controller", "RegistryDeployment").Msg("unable to create controller")
+	// DiscoveryConfig reconciler (discovers resources from target clusters)
+	discoveryReconciler := &controller.DiscoveryConfigReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Logger:                  ctrlLogger.With().Str("controller", "discoveryconfig").Logger(),
+		StatusInterval:          discoveryStatus,
+		ResyncPeriod:            discoveryResync,
+		MaxConcurrentReconciles: discoveryWorkers,
+	}
+
+	controllers := []namedController{
+		{controllerMCPServerCatalog, (&controller.MCPServerCatalogReconciler{
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			Logger:        ctrlLogger.With().Str("controller", "mcpservercatalog").Logger(),
+			StartupJitter: newJitter(),
+		}).SetupWithManager},
+		{controllerAgentCatalog, (&controller.AgentCatalogReconciler{
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			Logger:        ctrlLogger.With().Str("controller", "agentcatalog").Logger(),
+			StartupJitter: newJitter(),
+		}).SetupWithManager},
+		{controllerSkillCatalog, (&controller.SkillCatalogReconciler{
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			Logger:        ctrlLogger.With().Str("controller", "skillcatalog").Logger(),
+			StartupJitter: newJitter(),
+		}).SetupWithManager},
+		{controllerRegistryDeployment, (&controller.RegistryDeploymentReconciler{
+			Client:                  mgr.GetClient(),
+			Scheme:                  mgr.GetScheme(),
+			Logger:                  ctrlLogger.With().Str("controller", "registrydeployment").Logger(),
+			RemoteClientFactory:     remoteClientFactory,
+			StartupJitter:           newJitter(),
+			MaxManagedResources:     maxManagedResources,
+			PruneOnCatalogMissing:   pruneCatalogMissing,
+			NotifyWebhookURL:        deploymentWebhook,
+			MaxConcurrentReconciles: deploymentWorkers,
+		}).SetupWithManager},
+		{controllerDiscoveryConfig, discoveryReconciler.SetupWithManager},
+	}
+	registered, err := setupControllers(mgr, controllers, enabledControllers)
+	if err != nil {
+		log.Error().Err(err).Msg("unable to create controller")
 		os.Exit(1)
 	}
+	log.Info().Strs("controllers", registered).Msg("controllers registered")
+
+	if enableWebhooks {
+		if err := controller.SetupMCPServerCatalogWebhook(mgr, arconfig.GetCatalogLimits()); err != nil {
+			log.Error().Err(err).Msg("unable to set up MCPServerCatalog webhook")
+			os.Exit(1)
+		}
+		log.Info().Msg("validating webhook registered")
+	}
 
-	// Set up DiscoveryConfig reconciler (discovers resources from target clusters)
-	if err := (&controller.DiscoveryConfigReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Logger: ctrlLogger.With().Str("controller", "discoveryconfig").Logger(),
-	}).SetupWithManager(mgr); err != nil {
-		log.Error().Err(err).Str("controller", "DiscoveryConfig").Msg("unable to create controller")
+	// Catalog, deployment and discovery gauges, served on the metrics endpoint
+	if err := metrics.Registry.Register(controller.NewCatalogMetricsCollector(mgr.GetCache())); err != nil {
+		log.Error().Err(err).Msg("unable to register catalog metrics")
 		os.Exit(1)
 	}
 
@@ -239,6 +310,7 @@ func main() {
 			mgr.GetClient(),
 			mgr.GetCache(),
 			apiLogger,
+			httpapi.WithImportTLSConfig(tlsOpts.TLSConfig()),
 		)
 		if err := mgr.Add(httpServer.Runnable(httpAPIAddr)); err != nil {
 			log.Error().Err(err).Msg("unable to add HTTP API server")
@@ -268,9 +340,24 @@ func main() {
 		log.Error().Err(err).Msg("unable to set up ready check")
 		os.Exit(1)
 	}
+	if enabledControllers[controllerDiscoveryConfig] {
+		if err := mgr.AddReadyzCheck("discovery", discoveryReconciler.ReadyCheck); err != nil {
+			log.Error().Err(err).Msg("unable to set up discovery ready check")
+			os.Exit(1)
+		}
+	}
 
 	log.Info().Msg("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	// Flush the spans still buffered by the exporter
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Warn().Err(err).Msg("failed to flush traces")
+	}
+	cancelFlush()
+
+	if err != nil {
 		log.Error().Err(err).Msg("problem running manager")
 		os.Exit(1)
 	}
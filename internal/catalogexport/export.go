@@ -0,0 +1,119 @@
+// Package catalogexport reads the catalog into a bundle that can be imported
+// into another registry.
+package catalogexport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/conversion"
+)
+
+// Catalog types that can be exported on their own
+const (
+	TypeServers = "servers"
+	TypeAgents  = "agents"
+	TypeSkills  = "skills"
+	TypeModels  = "models"
+)
+
+// Bundle is an export of the catalog. Servers use the MCP registry format
+// accepted by /admin/v0/import, so a bundle can be re-imported into another
+// registry as is. Agents, skills and models, which that format does not
+// cover, are exported as their catalog specs.
+type Bundle struct {
+	Servers  []conversion.ExternalServerJSON          `json:"servers,omitempty"`
+	Agents   []agentregistryv1alpha1.AgentCatalogSpec `json:"agents,omitempty"`
+	Skills   []agentregistryv1alpha1.SkillCatalogSpec `json:"skills,omitempty"`
+	Models   []agentregistryv1alpha1.ModelCatalogSpec `json:"models,omitempty"`
+	Metadata BundleMetadata                           `json:"metadata"`
+}
+
+// BundleMetadata describes an export
+type BundleMetadata struct {
+	Count       int       `json:"count"`
+	Environment string    `json:"environment,omitempty"`
+	ExportedAt  time.Time `json:"exportedAt"`
+}
+
+// Export reads the catalog into a bundle, optionally restricted to one
+// catalog type and to the entries discovered in environment. Entries are
+// sorted by name and version.
+func Export(ctx context.Context, reader client.Reader, catalogType, environment string) (*Bundle, error) {
+	switch catalogType {
+	case "", TypeServers, TypeAgents, TypeSkills, TypeModels:
+	default:
+		return nil, fmt.Errorf("unsupported catalog type %q", catalogType)
+	}
+
+	var opts []client.ListOption
+	if environment != "" {
+		opts = append(opts, client.MatchingLabels{controller.EnvironmentLabel: environment})
+	}
+	want := func(t string) bool { return catalogType == "" || catalogType == t }
+
+	bundle := &Bundle{Metadata: BundleMetadata{Environment: environment, ExportedAt: time.Now().UTC()}}
+
+	if want(TypeServers) {
+		var list agentregistryv1alpha1.MCPServerCatalogList
+		if err := reader.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		sort.Slice(list.Items, func(i, j int) bool {
+			return nameVersionLess(list.Items[i].Spec.Name, list.Items[i].Spec.Version, list.Items[j].Spec.Name, list.Items[j].Spec.Version)
+		})
+		for _, item := range list.Items {
+			bundle.Servers = append(bundle.Servers, conversion.ExternalServerFromCRD(item.Spec))
+		}
+	}
+	if want(TypeAgents) {
+		var list agentregistryv1alpha1.AgentCatalogList
+		if err := reader.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list agents: %w", err)
+		}
+		sort.Slice(list.Items, func(i, j int) bool {
+			return nameVersionLess(list.Items[i].Spec.Name, list.Items[i].Spec.Version, list.Items[j].Spec.Name, list.Items[j].Spec.Version)
+		})
+		for _, item := range list.Items {
+			bundle.Agents = append(bundle.Agents, item.Spec)
+		}
+	}
+	if want(TypeSkills) {
+		var list agentregistryv1alpha1.SkillCatalogList
+		if err := reader.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list skills: %w", err)
+		}
+		sort.Slice(list.Items, func(i, j int) bool {
+			return nameVersionLess(list.Items[i].Spec.Name, list.Items[i].Spec.Version, list.Items[j].Spec.Name, list.Items[j].Spec.Version)
+		})
+		for _, item := range list.Items {
+			bundle.Skills = append(bundle.Skills, item.Spec)
+		}
+	}
+	if want(TypeModels) {
+		var list agentregistryv1alpha1.ModelCatalogList
+		if err := reader.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list models: %w", err)
+		}
+		sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Spec.Name < list.Items[j].Spec.Name })
+		for _, item := range list.Items {
+			bundle.Models = append(bundle.Models, item.Spec)
+		}
+	}
+
+	bundle.Metadata.Count = len(bundle.Servers) + len(bundle.Agents) + len(bundle.Skills) + len(bundle.Models)
+	return bundle, nil
+}
+
+func nameVersionLess(nameA, versionA, nameB, versionB string) bool {
+	if nameA != nameB {
+		return nameA < nameB
+	}
+	return versionA < versionB
+}
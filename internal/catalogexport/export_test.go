@@ -0,0 +1,68 @@
+package catalogexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func setupExportTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestExport_SortsAndFilters(t *testing.T) {
+	server := func(name, specName, version, env string) *agentregistryv1alpha1.MCPServerCatalog {
+		obj := &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: specName, Version: version},
+		}
+		if env != "" {
+			obj.Labels = map[string]string{controller.EnvironmentLabel: env}
+		}
+		return obj
+	}
+	c := setupExportTestClient(t,
+		server("weather", "io.example/weather", "0.1.0", "prod"),
+		server("search-2", "io.example/search", "2.0.0", ""),
+		server("search-1", "io.example/search", "1.0.0", "prod"),
+		&agentregistryv1alpha1.ModelCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpt", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.ModelCatalogSpec{Name: "gpt"},
+		},
+	)
+	ctx := context.Background()
+
+	bundle, err := Export(ctx, c, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, 4, bundle.Metadata.Count)
+	require.Len(t, bundle.Servers, 3)
+	assert.Equal(t, "io.example/search", bundle.Servers[0].Name)
+	assert.Equal(t, "1.0.0", bundle.Servers[0].Version)
+	assert.Equal(t, "2.0.0", bundle.Servers[1].Version)
+	assert.Equal(t, "io.example/weather", bundle.Servers[2].Name)
+	require.Len(t, bundle.Models, 1)
+
+	bundle, err = Export(ctx, c, TypeServers, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, 2, bundle.Metadata.Count)
+	assert.Equal(t, "prod", bundle.Metadata.Environment)
+	assert.Len(t, bundle.Servers, 2)
+	assert.Empty(t, bundle.Models)
+}
+
+func TestExport_UnsupportedType(t *testing.T) {
+	_, err := Export(context.Background(), setupExportTestClient(t), "deployments", "")
+	assert.ErrorContains(t, err, `unsupported catalog type "deployments"`)
+}
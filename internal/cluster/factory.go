@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/tlsconfig"
 )
 
 const (
@@ -44,6 +45,7 @@ type Factory struct {
 	localClient client.Client
 	logger      zerolog.Logger
 	cacheTTL    time.Duration
+	tls         tlsconfig.Options
 
 	mu     sync.RWMutex
 	cache  map[string]*cachedClient
@@ -60,6 +62,15 @@ func NewFactory(localClient client.Client, logger zerolog.Logger) *Factory {
 	}
 }
 
+// SetTLSOptions sets the TLS trust settings applied to every remote cluster
+// client. Cached clients are dropped so that they pick up the new settings.
+func (f *Factory) SetTLSOptions(opts tlsconfig.Options) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tls = opts
+	f.cache = make(map[string]*cachedClient)
+}
+
 // GetClient returns a client for the specified environment.
 func (f *Factory) GetClient(ctx context.Context, env *agentregistryv1alpha1.Environment, scheme *runtime.Scheme) (client.WithWatch, error) {
 	f.scheme = scheme
@@ -180,6 +191,13 @@ func (f *Factory) createClient(ctx context.Context, env *agentregistryv1alpha1.E
 		return nil, fmt.Errorf("failed to create config: %w", err)
 	}
 
+	f.mu.RLock()
+	err = f.tls.ApplyToRESTConfig(config, f.logger.With().Str("environment", env.Name).Logger())
+	f.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the client
 	remoteClient, err := client.NewWithWatch(config, client.Options{
 		Scheme: f.scheme,
@@ -2,13 +2,19 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	// DefaultNamespace is the default namespace for Agent Registry resources
 	DefaultNamespace = "agentregistry"
 
+	// FallbackDeployNamespace is the namespace catalog items are deployed
+	// into when neither the deployment nor the operator names one
+	FallbackDeployNamespace = "kagent"
+
 	// DefaultHTTPPort is the default port for the HTTP API
 	DefaultHTTPPort = ":8080"
 
@@ -36,28 +42,44 @@ func GetEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// DefaultDeployNamespace returns the namespace catalog items are deployed into
+// when a RegistryDeployment does not set one and its environment declares no
+// namespaces. The precedence is: Spec.Namespace, then the
+// AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE env var, then FallbackDeployNamespace.
+func DefaultDeployNamespace() string {
+	if ns := strings.TrimSpace(os.Getenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE")); ns != "" {
+		return ns
+	}
+	return FallbackDeployNamespace
+}
+
 // AllowedDeploymentNamespaces returns the set of namespaces that catalog items
 // may be deployed into, as a lookup map.
 //
-// By default only the controller namespace (GetNamespace) is allowed, so a
-// caller cannot schedule workloads into arbitrary namespaces using the
-// controller's cluster-wide RBAC. Operators can widen this with a
-// comma-separated AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES env var.
+// By default only the controller namespace (GetNamespace) and the default
+// deploy namespace (DefaultDeployNamespace) are allowed, so a caller cannot
+// schedule workloads into arbitrary namespaces using the controller's
+// cluster-wide RBAC. Operators can replace the default deploy namespace with a
+// comma-separated AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES env var; it must then
+// be listed there to stay allowed.
 func AllowedDeploymentNamespaces() map[string]bool {
 	allowed := map[string]bool{GetNamespace(): true}
-	if raw := os.Getenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES"); raw != "" {
-		for ns := range strings.SplitSeq(raw, ",") {
-			if ns = strings.TrimSpace(ns); ns != "" {
-				allowed[ns] = true
-			}
+	configured := false
+	for ns := range strings.SplitSeq(os.Getenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES"), ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			allowed[ns] = true
+			configured = true
 		}
 	}
+	if !configured {
+		allowed[DefaultDeployNamespace()] = true
+	}
 	return allowed
 }
 
 // IsDeploymentNamespaceAllowed reports whether ns is permitted as a deployment
-// target. An empty ns is treated as the default (controller) namespace and is
-// always allowed.
+// target. An empty ns leaves the namespace to the deployment's environment and
+// is always allowed; callers resolve the default deploy namespace first.
 func IsDeploymentNamespaceAllowed(ns string) bool {
 	if ns == "" {
 		return true
@@ -65,6 +87,31 @@ func IsDeploymentNamespaceAllowed(ns string) bool {
 	return AllowedDeploymentNamespaces()[ns]
 }
 
+// AllowedRegistryTypes returns the package registry types (npm, pypi, oci, ...)
+// that MCP servers may be deployed from, as a lookup map. It returns nil, meaning
+// every type is allowed, unless operators restrict it with a comma-separated
+// AGENTREGISTRY_ALLOWED_REGISTRY_TYPES env var, e.g. "oci" to only run pre-built
+// images instead of installing packages at runtime.
+func AllowedRegistryTypes() map[string]bool {
+	raw := os.Getenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES")
+	if raw == "" {
+		return nil
+	}
+	allowed := map[string]bool{}
+	for t := range strings.SplitSeq(raw, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			allowed[t] = true
+		}
+	}
+	return allowed
+}
+
+// IsRegistryTypeAllowed reports whether packages of registryType may be deployed
+func IsRegistryTypeAllowed(registryType string) bool {
+	allowed := AllowedRegistryTypes()
+	return allowed == nil || allowed[strings.ToLower(registryType)]
+}
+
 // IsAuthEnabled returns whether the optional Bearer-token auth is enabled for
 // the MCP server and reflected in the UI auth-config flag.
 //
@@ -76,3 +123,170 @@ func IsDeploymentNamespaceAllowed(ns string) bool {
 func IsAuthEnabled() bool {
 	return os.Getenv("AGENTREGISTRY_AUTH_ENABLED") == "true"
 }
+
+// IsOIDCConfigured reports whether Azure AD (OIDC) sign-in is configured for
+// the UI, via AZURE_AD_TENANT_ID and AZURE_AD_CLIENT_ID.
+func IsOIDCConfigured() bool {
+	return os.Getenv("AZURE_AD_TENANT_ID") != "" && os.Getenv("AZURE_AD_CLIENT_ID") != ""
+}
+
+// OIDCIssuer returns the issuer of the Azure AD v2 tokens whose groups claim
+// scopes catalog visibility
+func OIDCIssuer() string {
+	return "https://login.microsoftonline.com/" + os.Getenv("AZURE_AD_TENANT_ID") + "/v2.0"
+}
+
+// OIDCJWKSURL returns where the signing keys of OIDCIssuer are published
+func OIDCJWKSURL() string {
+	return "https://login.microsoftonline.com/" + os.Getenv("AZURE_AD_TENANT_ID") + "/discovery/v2.0/keys"
+}
+
+// OIDCAudience returns the audience OIDC tokens must be issued for, the
+// AZURE_AD_CLIENT_ID of the registry's app registration
+func OIDCAudience() string {
+	return os.Getenv("AZURE_AD_CLIENT_ID")
+}
+
+// RequireVerifiedPublisher reports whether deployments are blocked unless the
+// catalog entry's publisher is verified (org_is_verified and
+// publisher_identity_verified_by_jwt). It defaults to true; set
+// AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER=false to relax the check, e.g. in
+// dev clusters or for internally-authored servers.
+func RequireVerifiedPublisher() bool {
+	return os.Getenv("AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER") != "false"
+}
+
+// RequireSBOMAttestation reports whether MCP server deployments are blocked
+// unless the catalog version carries a valid SBOM attestation. It is off by
+// default; set AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION=true to enable it.
+func RequireSBOMAttestation() bool {
+	return os.Getenv("AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION") == "true"
+}
+
+// StrictPublicVisibility reports whether the public (/v0) API and the MCP read
+// tools hide every catalog entry that is not published and active, i.e. also
+// deprecated and soft-deleted ones. It is off by default; set
+// AGENTREGISTRY_STRICT_PUBLIC_VISIBILITY=true to enable it.
+func StrictPublicVisibility() bool {
+	return os.Getenv("AGENTREGISTRY_STRICT_PUBLIC_VISIBILITY") == "true"
+}
+
+// Duplicate catalog entry policies, see DuplicatePolicy
+const (
+	// DuplicatePolicyReport keeps duplicate entries and flags all but the
+	// oldest with a Duplicate condition
+	DuplicatePolicyReport = "report"
+	// DuplicatePolicyReject additionally refuses to create an entry whose
+	// name and version are already cataloged under another object name
+	DuplicatePolicyReject = "reject"
+)
+
+// DuplicatePolicy returns how catalog entries sharing a name and version
+// within a namespace are handled, from AGENTREGISTRY_DUPLICATE_POLICY.
+// Unknown values fall back to DuplicatePolicyReport.
+func DuplicatePolicy() string {
+	if strings.EqualFold(os.Getenv("AGENTREGISTRY_DUPLICATE_POLICY"), DuplicatePolicyReject) {
+		return DuplicatePolicyReject
+	}
+	return DuplicatePolicyReport
+}
+
+// Default catalog entry size limits, see GetCatalogLimits
+const (
+	DefaultMaxDescriptionLength = 4096
+	DefaultMaxPackages          = 20
+	DefaultMaxRemotes           = 20
+	DefaultMaxEnvVars           = 100
+	DefaultMaxArguments         = 100
+)
+
+// CatalogLimits bounds the size of MCP server catalog entries so a
+// pathological submission cannot create an enormous object
+type CatalogLimits struct {
+	MaxDescriptionLength int
+	MaxPackages          int
+	MaxRemotes           int
+	// MaxEnvVars bounds the environment variables of each package
+	MaxEnvVars int
+	// MaxArguments bounds the runtime and the package arguments of each
+	// package, separately
+	MaxArguments int
+}
+
+// GetCatalogLimits returns the catalog entry size limits, read from
+// AGENTREGISTRY_MAX_DESCRIPTION_LENGTH, AGENTREGISTRY_MAX_PACKAGES,
+// AGENTREGISTRY_MAX_REMOTES, AGENTREGISTRY_MAX_ENV_VARS and
+// AGENTREGISTRY_MAX_ARGUMENTS. Unset or invalid values use the defaults.
+func GetCatalogLimits() CatalogLimits {
+	return CatalogLimits{
+		MaxDescriptionLength: positiveIntEnv("AGENTREGISTRY_MAX_DESCRIPTION_LENGTH", DefaultMaxDescriptionLength),
+		MaxPackages:          positiveIntEnv("AGENTREGISTRY_MAX_PACKAGES", DefaultMaxPackages),
+		MaxRemotes:           positiveIntEnv("AGENTREGISTRY_MAX_REMOTES", DefaultMaxRemotes),
+		MaxEnvVars:           positiveIntEnv("AGENTREGISTRY_MAX_ENV_VARS", DefaultMaxEnvVars),
+		MaxArguments:         positiveIntEnv("AGENTREGISTRY_MAX_ARGUMENTS", DefaultMaxArguments),
+	}
+}
+
+const (
+	DefaultImportMaxServers  = 5000
+	DefaultImportTimeout     = 5 * time.Minute
+	DefaultImportConcurrency = 8
+)
+
+// ImportLimits bounds a single import from an external registry
+type ImportLimits struct {
+	// MaxServers is the most servers a source may list; larger imports are
+	// rejected before anything is written
+	MaxServers int
+	// Timeout bounds the whole import, fetching included
+	Timeout time.Duration
+	// Concurrency is the number of servers created or updated at once
+	Concurrency int
+}
+
+// GetImportLimits returns the import limits, read from
+// AGENTREGISTRY_IMPORT_MAX_SERVERS, AGENTREGISTRY_IMPORT_TIMEOUT (a Go
+// duration) and AGENTREGISTRY_IMPORT_CONCURRENCY. Unset or invalid values use
+// the defaults.
+func GetImportLimits() ImportLimits {
+	limits := ImportLimits{
+		MaxServers:  positiveIntEnv("AGENTREGISTRY_IMPORT_MAX_SERVERS", DefaultImportMaxServers),
+		Timeout:     DefaultImportTimeout,
+		Concurrency: positiveIntEnv("AGENTREGISTRY_IMPORT_CONCURRENCY", DefaultImportConcurrency),
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(os.Getenv("AGENTREGISTRY_IMPORT_TIMEOUT"))); err == nil && d > 0 {
+		limits.Timeout = d
+	}
+	return limits
+}
+
+// positiveIntEnv returns the positive integer in the environment variable key,
+// or defaultValue when it is unset or not a positive integer
+func positiveIntEnv(key string, defaultValue int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || n <= 0 {
+		return defaultValue
+	}
+	return n
+}
+
+// TLSCABundleSecret returns the Secret in the controller namespace holding a
+// PEM CA bundle trusted, in addition to the system roots, for remote clusters
+// and import sources. It is read from AGENTREGISTRY_TLS_CA_BUNDLE_SECRET as
+// "name" or "name/key"; the key defaults to ca.crt.
+func TLSCABundleSecret() string {
+	return strings.TrimSpace(os.Getenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET"))
+}
+
+// TLSCABundleConfigMap is the ConfigMap counterpart of TLSCABundleSecret, read
+// from AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP
+func TLSCABundleConfigMap() string {
+	return strings.TrimSpace(os.Getenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP"))
+}
+
+// TLSInsecureSkipVerify reports whether TLS certificate verification is
+// disabled for remote clusters and import sources. It is never on by default;
+// set AGENTREGISTRY_TLS_INSECURE_SKIP_VERIFY=true only for test environments.
+func TLSInsecureSkipVerify() bool {
+	return os.Getenv("AGENTREGISTRY_TLS_INSECURE_SKIP_VERIFY") == "true"
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestGetNamespace(t *testing.T) {
@@ -99,7 +100,7 @@ func TestIsDeploymentNamespaceAllowed(t *testing.T) {
 
 	os.Unsetenv("POD_NAMESPACE") // default namespace == "agentregistry"
 
-	// Default: only the controller namespace (and empty == default) allowed.
+	// Default: only the controller and default deploy namespaces allowed.
 	os.Unsetenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES")
 	if !IsDeploymentNamespaceAllowed("") {
 		t.Error("empty namespace should be allowed (defaults to controller ns)")
@@ -107,6 +108,9 @@ func TestIsDeploymentNamespaceAllowed(t *testing.T) {
 	if !IsDeploymentNamespaceAllowed(DefaultNamespace) {
 		t.Errorf("controller namespace %q should be allowed by default", DefaultNamespace)
 	}
+	if !IsDeploymentNamespaceAllowed(DefaultDeployNamespace()) {
+		t.Errorf("default deploy namespace %q should be allowed by default", DefaultDeployNamespace())
+	}
 	if IsDeploymentNamespaceAllowed("kube-system") {
 		t.Error("arbitrary namespace must be rejected by default")
 	}
@@ -125,6 +129,36 @@ func TestIsDeploymentNamespaceAllowed(t *testing.T) {
 	if IsDeploymentNamespaceAllowed("team-c") {
 		t.Error("namespace outside the allowlist must be rejected")
 	}
+	if IsDeploymentNamespaceAllowed(DefaultDeployNamespace()) {
+		t.Errorf("default deploy namespace %q must be rejected when the allowlist omits it", DefaultDeployNamespace())
+	}
+
+	// Listing the default deploy namespace keeps it allowed.
+	os.Setenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES", "team-a,"+FallbackDeployNamespace)
+	if !IsDeploymentNamespaceAllowed(FallbackDeployNamespace) {
+		t.Error("default deploy namespace should be allowed when listed in the allowlist")
+	}
+}
+
+func TestDefaultDeployNamespace(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", "")
+	if got := DefaultDeployNamespace(); got != FallbackDeployNamespace {
+		t.Errorf("DefaultDeployNamespace() = %q, want %q when unset", got, FallbackDeployNamespace)
+	}
+	if !IsDeploymentNamespaceAllowed(FallbackDeployNamespace) {
+		t.Errorf("default deploy namespace %q should be allowed", FallbackDeployNamespace)
+	}
+
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", " agents ")
+	if got := DefaultDeployNamespace(); got != "agents" {
+		t.Errorf("DefaultDeployNamespace() = %q, want %q", got, "agents")
+	}
+	if !IsDeploymentNamespaceAllowed("agents") {
+		t.Error("configured default deploy namespace should be allowed")
+	}
+	if IsDeploymentNamespaceAllowed(FallbackDeployNamespace) {
+		t.Errorf("%q should not be allowed once another default is configured", FallbackDeployNamespace)
+	}
 }
 
 func TestIsAuthEnabled(t *testing.T) {
@@ -169,3 +203,120 @@ func TestIsAuthEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireVerifiedPublisher(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     bool
+	}{
+		{
+			name:     "required by default",
+			envValue: "",
+			want:     true,
+		},
+		{
+			name:     "explicitly required",
+			envValue: "true",
+			want:     true,
+		},
+		{
+			name:     "relaxed",
+			envValue: "false",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER", tt.envValue)
+
+			got := RequireVerifiedPublisher()
+			if got != tt.want {
+				t.Errorf("RequireVerifiedPublisher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRegistryTypeAllowed(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", "")
+	if AllowedRegistryTypes() != nil {
+		t.Errorf("AllowedRegistryTypes() = %v, want nil (allow all)", AllowedRegistryTypes())
+	}
+	if !IsRegistryTypeAllowed("pypi") {
+		t.Errorf("IsRegistryTypeAllowed(pypi) = false, want true by default")
+	}
+
+	t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", " oci, NPM ")
+	for registryType, want := range map[string]bool{"oci": true, "npm": true, "OCI": true, "pypi": false} {
+		if got := IsRegistryTypeAllowed(registryType); got != want {
+			t.Errorf("IsRegistryTypeAllowed(%q) = %v, want %v", registryType, got, want)
+		}
+	}
+}
+
+func TestRequireSBOMAttestation(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION", "")
+	if RequireSBOMAttestation() {
+		t.Errorf("RequireSBOMAttestation() = true, want false by default")
+	}
+
+	t.Setenv("AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION", "true")
+	if !RequireSBOMAttestation() {
+		t.Errorf("RequireSBOMAttestation() = false, want true")
+	}
+}
+
+func TestDuplicatePolicy(t *testing.T) {
+	for value, want := range map[string]string{"": DuplicatePolicyReport, "report": DuplicatePolicyReport, "Reject": DuplicatePolicyReject, "merge": DuplicatePolicyReport} {
+		t.Setenv("AGENTREGISTRY_DUPLICATE_POLICY", value)
+		if got := DuplicatePolicy(); got != want {
+			t.Errorf("DuplicatePolicy() with %q = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestTLSInsecureSkipVerify(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "false": false, "1": false, "true": true} {
+		t.Setenv("AGENTREGISTRY_TLS_INSECURE_SKIP_VERIFY", value)
+		if got := TLSInsecureSkipVerify(); got != want {
+			t.Errorf("TLSInsecureSkipVerify() with %q = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestGetCatalogLimits(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_MAX_DESCRIPTION_LENGTH", "")
+	t.Setenv("AGENTREGISTRY_MAX_PACKAGES", "5")
+	t.Setenv("AGENTREGISTRY_MAX_REMOTES", "-1")
+	t.Setenv("AGENTREGISTRY_MAX_ENV_VARS", "lots")
+	t.Setenv("AGENTREGISTRY_MAX_ARGUMENTS", " 7 ")
+
+	want := CatalogLimits{
+		MaxDescriptionLength: DefaultMaxDescriptionLength,
+		MaxPackages:          5,
+		MaxRemotes:           DefaultMaxRemotes,
+		MaxEnvVars:           DefaultMaxEnvVars,
+		MaxArguments:         7,
+	}
+	if got := GetCatalogLimits(); got != want {
+		t.Errorf("GetCatalogLimits() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetImportLimits(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_IMPORT_MAX_SERVERS", "100")
+	t.Setenv("AGENTREGISTRY_IMPORT_TIMEOUT", "90s")
+	t.Setenv("AGENTREGISTRY_IMPORT_CONCURRENCY", "0")
+
+	want := ImportLimits{MaxServers: 100, Timeout: 90 * time.Second, Concurrency: DefaultImportConcurrency}
+	if got := GetImportLimits(); got != want {
+		t.Errorf("GetImportLimits() = %+v, want %+v", got, want)
+	}
+
+	t.Setenv("AGENTREGISTRY_IMPORT_TIMEOUT", "-1m")
+	if got := GetImportLimits().Timeout; got != DefaultImportTimeout {
+		t.Errorf("GetImportLimits().Timeout with a negative value = %v, want %v", got, DefaultImportTimeout)
+	}
+}
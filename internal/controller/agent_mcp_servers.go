@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+)
+
+// mcpServerTypeRegistry is the McpServerConfig type referencing a catalog entry
+const mcpServerTypeRegistry = "registry"
+
+// unresolvedMCPServersRequeueInterval is how often a deployment whose agent
+// references unresolved MCP servers is reconciled again, so that it picks
+// them up once they are published or deployed
+const unresolvedMCPServersRequeueInterval = time.Minute
+
+// resolveAgentMCPServers resolves the registry MCP servers an agent catalog
+// entry references to the connections written to the agent's
+// mcp-servers.json. Remote servers are reached at their remote URL; package
+// servers through the in-cluster service of the MCPServer deployed for them
+// in the agent's namespace, which must exist in serverReader's cluster; a nil
+// serverReader skips that check. References that cannot be resolved are
+// returned as issues rather than failing the deployment.
+func (r *RegistryDeploymentReconciler) resolveAgentMCPServers(ctx context.Context, catalog *agentregistryv1alpha1.AgentCatalog, deployment *agentregistryv1alpha1.RegistryDeployment, serverReader client.Reader) ([]api.ResolvedMCPServerConfig, []string, error) {
+	agentNamespace := deployment.Spec.Namespace
+	if agentNamespace == "" {
+		agentNamespace = config.DefaultDeployNamespace()
+	}
+
+	var resolved []api.ResolvedMCPServerConfig
+	var issues []string
+	for _, ref := range catalog.Spec.McpServers {
+		if ref.Type != mcpServerTypeRegistry || ref.RegistryServerName == "" {
+			continue
+		}
+		label := ref.RegistryServerName
+		if ref.RegistryServerVersion != "" {
+			label += "@" + ref.RegistryServerVersion
+		}
+
+		server, err := r.findReferencedServer(ctx, ref.RegistryServerName, ref.RegistryServerVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve MCP server %s: %w", label, err)
+		}
+		if server == nil {
+			issues = append(issues, fmt.Sprintf("%s: not found in catalog", label))
+			continue
+		}
+
+		config := api.ResolvedMCPServerConfig{Name: generateInternalName(server.Spec.Name)}
+		switch {
+		case len(server.Spec.Remotes) > 0 && (ref.RegistryServerPreferRemote || len(server.Spec.Packages) == 0):
+			remote := server.Spec.Remotes[0]
+			config.Type = "remote"
+			config.URL = remote.URL
+			headers := make(map[string]string)
+			for _, h := range remote.Headers {
+				if h.Value != "" {
+					headers[h.Name] = h.Value
+				}
+			}
+			for name, value := range ref.Headers {
+				headers[name] = value
+			}
+			for _, h := range remote.Headers {
+				if v, ok := deployment.Spec.Config[h.Name]; ok {
+					headers[h.Name] = v
+				}
+			}
+			if len(headers) > 0 {
+				config.Headers = headers
+			}
+		case len(server.Spec.Packages) > 0:
+			// The agent runtime derives the URL of a command server from its
+			// name, which is the service of the MCPServer deployed for it
+			config.Type = "command"
+			if serverReader != nil {
+				key := client.ObjectKey{Namespace: agentNamespace, Name: kagent.MCPServerResourceName(config.Name)}
+				if err := serverReader.Get(ctx, key, &kmcpv1alpha1.MCPServer{}); err != nil {
+					if !apierrors.IsNotFound(err) {
+						return nil, nil, fmt.Errorf("failed to look up MCPServer of %s: %w", label, err)
+					}
+					issues = append(issues, fmt.Sprintf("%s: MCPServer %s/%s is not deployed", label, key.Namespace, key.Name))
+					continue
+				}
+			}
+		default:
+			issues = append(issues, fmt.Sprintf("%s: has no remotes or packages", label))
+			continue
+		}
+		resolved = append(resolved, config)
+	}
+
+	if len(issues) > 0 {
+		r.Logger.Warn().
+			Str("deployment", deployment.Namespace+"/"+deployment.Name).
+			Strs("issues", issues).
+			Msg("agent references MCP servers that could not be resolved, deploying without them")
+	}
+	return resolved, issues, nil
+}
+
+// findReferencedServer returns the catalog entry for name at version, or at
+// its latest version when version is empty or "latest". It returns nil when
+// there is no such entry.
+func (r *RegistryDeploymentReconciler) findReferencedServer(ctx context.Context, name, version string) (*agentregistryv1alpha1.MCPServerCatalog, error) {
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	fields := client.MatchingFields{IndexMCPServerNameVersion: NameVersionKey(name, version)}
+	if version == "" || version == "latest" {
+		fields = client.MatchingFields{IndexMCPServerName: name, IndexMCPServerIsLatest: "true"}
+	}
+	if err := r.List(ctx, &list, fields); err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	return &list.Items[0], nil
+}
+
+// setUnresolvedMCPServersCondition records on a deployment's conditions the
+// MCP server references that could not be resolved, removing the condition
+// once they all are
+func setUnresolvedMCPServersCondition(conditions *[]agentregistryv1alpha1.CatalogCondition, issues []string) bool {
+	if len(issues) == 0 {
+		return removeCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers)
+	}
+	return setCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers, "UnresolvedReferences", strings.Join(issues, "; "))
+}
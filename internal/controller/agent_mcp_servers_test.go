@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+)
+
+func TestRegistryDeploymentReconciler_TranslateAgent_ResolvesMCPServers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	server := func(objName, name, version string, latest bool, spec agentregistryv1alpha1.MCPServerCatalogSpec) client.Object {
+		spec.Name, spec.Version = name, version
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: "agentregistry"},
+			Spec:       spec,
+			Status:     agentregistryv1alpha1.MCPServerCatalogStatus{IsLatest: latest},
+		}
+	}
+	remote := agentregistryv1alpha1.Transport{
+		Type: "streamable-http",
+		URL:  "https://search.example.com/mcp",
+		Headers: []agentregistryv1alpha1.KeyValueInput{
+			{Name: "X-Tenant", Value: "default"},
+			{Name: "Authorization"},
+		},
+	}
+	pkg := agentregistryv1alpha1.Package{RegistryType: "npm", Identifier: "@example/files", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerIsLatest, func(obj client.Object) []string {
+			if obj.(*agentregistryv1alpha1.MCPServerCatalog).Status.IsLatest {
+				return []string{"true"}
+			}
+			return nil
+		}).
+		WithObjects(
+			server("search-1-0-0", "io.example/search", "1.0.0", true, agentregistryv1alpha1.MCPServerCatalogSpec{Remotes: []agentregistryv1alpha1.Transport{remote}}),
+			server("files-1-0-0", "io.example/files", "1.0.0", false, agentregistryv1alpha1.MCPServerCatalogSpec{Packages: []agentregistryv1alpha1.Package{pkg}}),
+			server("files-2-0-0", "io.example/files", "2.0.0", true, agentregistryv1alpha1.MCPServerCatalogSpec{
+				Packages: []agentregistryv1alpha1.Package{pkg},
+				Remotes:  []agentregistryv1alpha1.Transport{{Type: "sse", URL: "https://files.example.com/sse"}},
+			}),
+			&kmcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "io-example-files", Namespace: "default"}},
+		).
+		Build()
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+
+	catalog := &agentregistryv1alpha1.AgentCatalog{
+		Spec: agentregistryv1alpha1.AgentCatalogSpec{
+			Name:    "helper",
+			Version: "1.0.0",
+			Image:   "registry.io/helper:1.0.0",
+			McpServers: []agentregistryv1alpha1.McpServerConfig{
+				{Type: "registry", Name: "search", RegistryServerName: "io.example/search", Headers: map[string]string{"X-Tenant": "acme"}},
+				{Type: "registry", Name: "files", RegistryServerName: "io.example/files", RegistryServerVersion: "1.0.0"},
+				{Type: "registry", Name: "files-remote", RegistryServerName: "io.example/files", RegistryServerPreferRemote: true},
+				{Type: "registry", Name: "missing", RegistryServerName: "io.example/missing", RegistryServerVersion: "9.9.9"},
+				{Type: "remote", Name: "inline", URL: "https://inline.example.com/mcp"},
+			},
+		},
+	}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "helper", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "helper",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeAgent,
+			Namespace:    "default",
+			Config:       map[string]string{"Authorization": "Bearer secret"},
+		},
+	}
+
+	runtimeConfig, err := r.translateAgent(context.Background(), catalog, deployment, c)
+	require.NoError(t, err)
+
+	require.Len(t, runtimeConfig.Kubernetes.ConfigMaps, 1)
+	var servers []api.ResolvedMCPServerConfig
+	require.NoError(t, json.Unmarshal([]byte(runtimeConfig.Kubernetes.ConfigMaps[0].Data["mcp-servers.json"]), &servers))
+	assert.Equal(t, []api.ResolvedMCPServerConfig{
+		{
+			Name:    "io-example-search",
+			Type:    "remote",
+			URL:     "https://search.example.com/mcp",
+			Headers: map[string]string{"X-Tenant": "acme", "Authorization": "Bearer secret"},
+		},
+		{Name: "io-example-files", Type: "command"},
+		{Name: "io-example-files", Type: "remote", URL: "https://files.example.com/sse"},
+	}, servers)
+
+	require.Len(t, runtimeConfig.Kubernetes.Agents, 1)
+	assert.NotEmpty(t, runtimeConfig.Kubernetes.Agents[0].Spec.BYO.Deployment.VolumeMounts)
+
+	require.Len(t, deployment.Status.Conditions, 1)
+	cond := deployment.Status.Conditions[0]
+	assert.Equal(t, agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers, cond.Type)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, "io.example/missing@9.9.9: not found in catalog", cond.Message)
+
+	// The condition is cleared once every reference resolves
+	catalog.Spec.McpServers = catalog.Spec.McpServers[:3]
+	_, err = r.translateAgent(context.Background(), catalog, deployment, c)
+	require.NoError(t, err)
+	assert.Empty(t, deployment.Status.Conditions)
+
+	// A package server whose MCPServer is not deployed in the agent's
+	// namespace is left out rather than pointing the agent at missing tools
+	deployment.Spec.Namespace = "team-a"
+	runtimeConfig, err = r.translateAgent(context.Background(), catalog, deployment, c)
+	require.NoError(t, err)
+	servers = nil
+	require.NoError(t, json.Unmarshal([]byte(runtimeConfig.Kubernetes.ConfigMaps[0].Data["mcp-servers.json"]), &servers))
+	assert.Len(t, servers, 2)
+	assert.NotContains(t, servers, api.ResolvedMCPServerConfig{Name: "io-example-files", Type: "command"})
+	require.Len(t, deployment.Status.Conditions, 1)
+	assert.Equal(t, "io.example/files@1.0.0: MCPServer team-a/io-example-files is not deployed", deployment.Status.Conditions[0].Message)
+}
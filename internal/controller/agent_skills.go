@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
+)
+
+// ResolvedSkill is a required skill of an agent resolved against the skill
+// catalog
+type ResolvedSkill struct {
+	// Ref is the reference as written in the agent's RequiredSkills
+	Ref  string `json:"ref"`
+	Name string `json:"name"`
+	// Version is the resolved version; for an unpinned reference, the latest
+	// published one
+	Version   string `json:"version,omitempty"`
+	Found     bool   `json:"found"`
+	Published bool   `json:"published"`
+}
+
+// parseRequiredSkill splits a required skill reference of the form name or
+// name@version
+func parseRequiredSkill(ref string) (name, version string) {
+	if i := strings.LastIndex(ref, "@"); i > 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// ResolveAgentSkills resolves an agent's required skill references against
+// the skill catalog. A reference pinned to a version resolves to that
+// version; an unpinned one, or one pinned to "latest", to the latest
+// published version.
+func ResolveAgentSkills(ctx context.Context, reader client.Reader, refs []string) ([]ResolvedSkill, error) {
+	resolved := make([]ResolvedSkill, 0, len(refs))
+	for _, ref := range refs {
+		name, version := parseRequiredSkill(ref)
+		skill := ResolvedSkill{Ref: ref, Name: name, Version: version}
+
+		var list agentregistryv1alpha1.SkillCatalogList
+		if err := reader.List(ctx, &list, client.MatchingFields{IndexSkillName: name}); err != nil {
+			return nil, fmt.Errorf("failed to list skills for %s: %w", ref, err)
+		}
+		index := -1
+		if version != "" && version != "latest" {
+			for i := range list.Items {
+				if list.Items[i].Spec.Version == version {
+					index = i
+					break
+				}
+			}
+		} else {
+			published := list.Items[:0:0]
+			for _, item := range list.Items {
+				if item.Status.Published {
+					published = append(published, item)
+				}
+			}
+			if len(published) == 0 {
+				// Report an unpublished entry rather than a missing one
+				published = list.Items
+			}
+			list.Items = published
+			index = semver.LatestIndex(list.Items, func(s agentregistryv1alpha1.SkillCatalog) string { return s.Spec.Version })
+		}
+		if index >= 0 {
+			entry := list.Items[index]
+			skill.Version = entry.Spec.Version
+			skill.Found = true
+			skill.Published = entry.Status.Published
+		}
+		resolved = append(resolved, skill)
+	}
+	return resolved, nil
+}
+
+// checkAgentSkills fails when any skill the agent requires is missing from the
+// catalog or not published
+func (r *RegistryDeploymentReconciler) checkAgentSkills(ctx context.Context, catalog *agentregistryv1alpha1.AgentCatalog) error {
+	if len(catalog.Spec.RequiredSkills) == 0 {
+		return nil
+	}
+	skills, err := ResolveAgentSkills(ctx, r.Client, catalog.Spec.RequiredSkills)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, skill := range skills {
+		switch {
+		case !skill.Found:
+			problems = append(problems, skill.Ref+" (not found)")
+		case !skill.Published:
+			problems = append(problems, fmt.Sprintf("%s@%s (not published)", skill.Name, skill.Version))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("agent requires skills that are not available: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func setupSkillTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, IndexSkillName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.SkillCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, IndexAgentNameVersion, AgentNameVersionIndex).
+		WithObjects(objs...).
+		Build()
+}
+
+func testSkill(objName, name, version string, published bool) *agentregistryv1alpha1.SkillCatalog {
+	return &agentregistryv1alpha1.SkillCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: name, Version: version},
+		Status:     agentregistryv1alpha1.SkillCatalogStatus{Published: published},
+	}
+}
+
+func TestResolveAgentSkills(t *testing.T) {
+	c := setupSkillTestClient(t,
+		testSkill("summarize-1-0-0", "summarize", "1.0.0", true),
+		testSkill("summarize-1-1-0", "summarize", "1.1.0", true),
+		testSkill("summarize-2-0-0", "summarize", "2.0.0", false),
+		testSkill("translate-0-1-0", "translate", "0.1.0", false),
+	)
+
+	skills, err := ResolveAgentSkills(context.Background(), c, []string{"summarize", "summarize@2.0.0", "translate", "missing", "summarize@9.9.9"})
+	require.NoError(t, err)
+	assert.Equal(t, []ResolvedSkill{
+		{Ref: "summarize", Name: "summarize", Version: "1.1.0", Found: true, Published: true},
+		{Ref: "summarize@2.0.0", Name: "summarize", Version: "2.0.0", Found: true, Published: false},
+		{Ref: "translate", Name: "translate", Version: "0.1.0", Found: true, Published: false},
+		{Ref: "missing", Name: "missing"},
+		{Ref: "summarize@9.9.9", Name: "summarize", Version: "9.9.9"},
+	}, skills)
+}
+
+func TestRegistryDeploymentReconciler_CheckAgentSkills(t *testing.T) {
+	c := setupSkillTestClient(t,
+		testSkill("summarize-1-0-0", "summarize", "1.0.0", true),
+		testSkill("translate-0-1-0", "translate", "0.1.0", false),
+	)
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: c.Scheme(), Logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	satisfied := &agentregistryv1alpha1.AgentCatalog{Spec: agentregistryv1alpha1.AgentCatalogSpec{
+		RequiredSkills: []string{"summarize", "summarize@1.0.0"},
+	}}
+	assert.NoError(t, r.checkAgentSkills(ctx, satisfied))
+
+	unsatisfied := &agentregistryv1alpha1.AgentCatalog{Spec: agentregistryv1alpha1.AgentCatalogSpec{
+		RequiredSkills: []string{"summarize", "translate", "missing"},
+	}}
+	err := r.checkAgentSkills(ctx, unsatisfied)
+	require.Error(t, err)
+	assert.Equal(t, "agent requires skills that are not available: translate@0.1.0 (not published), missing (not found)", err.Error())
+}
+
+func TestRegistryDeploymentReconciler_ReconcileAgentDeployment_MissingSkill(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER", "false")
+	agent := &agentregistryv1alpha1.AgentCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "helper-1-0-0", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.AgentCatalogSpec{
+			Name:           "helper",
+			Version:        "1.0.0",
+			Image:          "registry.io/helper:1.0.0",
+			RequiredSkills: []string{"summarize"},
+		},
+	}
+	c := setupSkillTestClient(t, agent)
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: c.Scheme(), Logger: zerolog.Nop()}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "helper-1-0-0", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "helper",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeAgent,
+		},
+	}
+
+	err := r.reconcileAgentDeployment(context.Background(), deployment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "summarize (not found)")
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, failedPhase(err))
+}
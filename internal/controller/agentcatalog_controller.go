@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -12,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
 )
 
 const usedByCleanupFinalizer = "agentregistry.dev/usedby-cleanup"
@@ -19,8 +21,9 @@ const usedByCleanupFinalizer = "agentregistry.dev/usedby-cleanup"
 // AgentCatalogReconciler reconciles an AgentCatalog object
 type AgentCatalogReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Logger zerolog.Logger
+	Scheme        *runtime.Scheme
+	Logger        zerolog.Logger
+	StartupJitter *StartupJitter
 }
 
 // +kubebuilder:rbac:groups=agentregistry.dev,resources=agentcatalogs,verbs=get;list;watch;create;update;patch;delete
@@ -33,6 +36,12 @@ type AgentCatalogReconciler struct {
 func (r *AgentCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Logger.With().Str("name", req.Name).Str("namespace", req.Namespace).Logger()
 
+	// Stagger the first reconcile of each object after controller startup
+	if delay := r.StartupJitter.Delay(req.NamespacedName); delay > 0 {
+		logger.Debug().Dur("delay", delay).Msg("delaying initial reconcile")
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
 	// Fetch the AgentCatalog
 	var agent agentregistryv1alpha1.AgentCatalog
 	if err := r.Get(ctx, req.NamespacedName, &agent); err != nil {
@@ -43,6 +52,10 @@ func (r *AgentCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		Str("specName", agent.Spec.Name).
 		Str("version", agent.Spec.Version).
 		Msg("reconciling AgentCatalog")
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.KeyResourceName.String(agent.Spec.Name),
+		tracing.KeyVersion.String(agent.Spec.Version),
+	)
 
 	// Handle deletion: clean up UsedBy refs and remove finalizer
 	if !agent.DeletionTimestamp.IsZero() {
@@ -82,8 +95,29 @@ func (r *AgentCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// Derive the lifecycle status from published, deprecation and soft-delete state
+	statusChanged := false
+	if status := agentCatalogStatus(&agent); status != agent.Status.Status {
+		logger.Info().
+			Str("from", string(agent.Status.Status)).
+			Str("to", string(status)).
+			Msg("catalog entry status changed")
+		agent.Status.Status = status
+		statusChanged = true
+	}
+
+	// Flag an entry duplicating the name and version of an older entry
+	if changed, err := syncDuplicateCondition(ctx, r.Client, &agentregistryv1alpha1.AgentCatalogList{}, &agent, agent.Spec.Name, agent.Spec.Version, &agent.Status.Conditions); err != nil {
+		logger.Warn().Err(err).Msg("failed to check for duplicate entries")
+	} else if changed {
+		if hasCatalogCondition(agent.Status.Conditions, agentregistryv1alpha1.CatalogConditionDuplicate) {
+			logger.Warn().Str("specName", agent.Spec.Name).Str("version", agent.Spec.Version).Msg("duplicate catalog entry")
+		}
+		statusChanged = true
+	}
+
 	// Update observed generation
-	if agent.Status.ObservedGeneration != agent.Generation {
+	if agent.Status.ObservedGeneration != agent.Generation || statusChanged {
 		agent.Status.ObservedGeneration = agent.Generation
 		if err := r.Status().Update(ctx, &agent); err != nil {
 			if apierrors.IsConflict(err) {
@@ -289,5 +323,5 @@ func (r *AgentCatalogReconciler) updateLatestVersion(ctx context.Context, agent
 func (r *AgentCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentregistryv1alpha1.AgentCatalog{}).
-		Complete(r)
+		Complete(countReconcileErrors("agentcatalog", traceReconcile("AgentCatalog", r)))
 }
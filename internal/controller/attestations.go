@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
+)
+
+// Attestation types accepted for catalog entries
+const (
+	AttestationTypeProvenance = "provenance"
+	AttestationTypeSBOM       = "sbom"
+	AttestationTypeOther      = "other"
+)
+
+const (
+	// MaxInlineAttestationSize caps the content stored inline for a single attestation
+	MaxInlineAttestationSize = 256 * 1024
+	// maxAttestationsConfigMapSize keeps the attestations ConfigMap below the
+	// 1MiB object size limit, leaving room for metadata
+	maxAttestationsConfigMapSize = 900 * 1024
+)
+
+var (
+	// ErrAttestationExists is returned when an identical attestation is already attached
+	ErrAttestationExists = errors.New("attestation already attached")
+	// ErrAttestationsFull is returned when the attestations ConfigMap has no room left
+	ErrAttestationsFull = errors.New("attestation storage for this entry is full")
+)
+
+// Attestation is a supply-chain attestation (SLSA provenance, SBOM, ...)
+// attached to a catalog version. It is either stored inline in Content or
+// referenced by URI, with Digest pinning the referenced object.
+type Attestation struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	PredicateType string    `json:"predicateType,omitempty"`
+	MediaType     string    `json:"mediaType,omitempty"`
+	URI           string    `json:"uri,omitempty"`
+	Digest        string    `json:"digest,omitempty"`
+	Content       string    `json:"content,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ValidateAttestation checks the fields a caller supplies when attaching an attestation
+func ValidateAttestation(a *Attestation) error {
+	switch a.Type {
+	case AttestationTypeProvenance, AttestationTypeSBOM, AttestationTypeOther:
+	default:
+		return fmt.Errorf("invalid attestation type %q: must be provenance, sbom or other", a.Type)
+	}
+	if (a.URI == "") == (a.Content == "") {
+		return errors.New("exactly one of uri or content must be set")
+	}
+	if len(a.Content) > MaxInlineAttestationSize {
+		return fmt.Errorf("inline attestation content is %d bytes, exceeding the %d byte limit", len(a.Content), MaxInlineAttestationSize)
+	}
+	if a.Digest != "" && validation.ValidateDigest(a.Digest) != nil {
+		return fmt.Errorf("invalid digest %q: must be of the form sha256:<64 lowercase hex characters>", a.Digest)
+	}
+	return nil
+}
+
+// AttestationsConfigMapName returns the name of the ConfigMap holding the
+// attestations of the catalog object with the given name
+func AttestationsConfigMapName(catalogName string) string {
+	return catalogName + "-attestations"
+}
+
+// attestationID derives a stable ID from what the attestation points at, so
+// attaching the same attestation twice is detected
+func attestationID(a *Attestation) string {
+	sum := sha256.Sum256([]byte(a.Type + "\n" + a.URI + "\n" + a.Digest + "\n" + a.Content))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// ListAttestations returns the attestations attached to a catalog entry,
+// oldest first. Entries without the attestations annotation have none.
+func ListAttestations(ctx context.Context, reader client.Reader, obj client.Object) ([]Attestation, error) {
+	name := obj.GetAnnotations()[agentregistryv1alpha1.AnnotationAttestations]
+	if name == "" {
+		return nil, nil
+	}
+
+	var cm corev1.ConfigMap
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decodeAttestations(&cm)
+}
+
+func decodeAttestations(cm *corev1.ConfigMap) ([]Attestation, error) {
+	attestations := make([]Attestation, 0, len(cm.Data))
+	for key, raw := range cm.Data {
+		var a Attestation
+		if err := json.Unmarshal([]byte(raw), &a); err != nil {
+			return nil, fmt.Errorf("invalid attestation %s in %s: %w", key, cm.Name, err)
+		}
+		attestations = append(attestations, a)
+	}
+	sort.Slice(attestations, func(i, j int) bool {
+		if !attestations[i].CreatedAt.Equal(attestations[j].CreatedAt) {
+			return attestations[i].CreatedAt.Before(attestations[j].CreatedAt)
+		}
+		return attestations[i].ID < attestations[j].ID
+	})
+	return attestations, nil
+}
+
+// AddAttestation stores a validated attestation in the entry's attestations
+// ConfigMap, creating it owned by the entry on first use, and records the
+// ConfigMap name in the entry's attestations annotation.
+func AddAttestation(ctx context.Context, c client.Client, obj client.Object, a Attestation) (*Attestation, error) {
+	if err := ValidateAttestation(&a); err != nil {
+		return nil, err
+	}
+	a.ID = attestationID(&a)
+	a.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	raw, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: AttestationsConfigMapName(obj.GetName())}
+	err = c.Get(ctx, key, cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{}
+		cm.Name = key.Name
+		cm.Namespace = key.Namespace
+		cm.Labels = map[string]string{
+			agentregistryv1alpha1.LabelManagedBy: "agentregistry",
+		}
+		if err := controllerutil.SetOwnerReference(obj, cm, c.Scheme()); err != nil {
+			return nil, err
+		}
+		cm.Data = map[string]string{a.ID: string(raw)}
+		if err := c.Create(ctx, cm); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if _, ok := cm.Data[a.ID]; ok {
+			return nil, ErrAttestationExists
+		}
+		size := len(raw)
+		for _, v := range cm.Data {
+			size += len(v)
+		}
+		if size > maxAttestationsConfigMapSize {
+			return nil, ErrAttestationsFull
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[a.ID] = string(raw)
+		if err := c.Update(ctx, cm); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.GetAnnotations()[agentregistryv1alpha1.AnnotationAttestations] != cm.Name {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[agentregistryv1alpha1.AnnotationAttestations] = cm.Name
+		obj.SetAnnotations(annotations)
+		if err := c.Patch(ctx, obj, patch); err != nil {
+			return nil, err
+		}
+	}
+	return &a, nil
+}
+
+// HasValidSBOM reports whether any attestation is an SBOM that can be
+// trusted without fetching it: inline CycloneDX or SPDX JSON, or a reference
+// pinned by digest.
+func HasValidSBOM(attestations []Attestation) bool {
+	for _, a := range attestations {
+		if a.Type != AttestationTypeSBOM {
+			continue
+		}
+		if a.URI != "" {
+			if a.Digest != "" {
+				return true
+			}
+			continue
+		}
+		var doc struct {
+			BOMFormat   string `json:"bomFormat"`
+			SPDXVersion string `json:"spdxVersion"`
+		}
+		if json.Unmarshal([]byte(a.Content), &doc) == nil && (doc.BOMFormat == "CycloneDX" || doc.SPDXVersion != "") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestHasValidSBOM(t *testing.T) {
+	tests := []struct {
+		name        string
+		attestation Attestation
+		want        bool
+	}{
+		{"inline CycloneDX", Attestation{Type: AttestationTypeSBOM, Content: `{"bomFormat":"CycloneDX"}`}, true},
+		{"inline SPDX", Attestation{Type: AttestationTypeSBOM, Content: `{"spdxVersion":"SPDX-2.3"}`}, true},
+		{"inline unknown format", Attestation{Type: AttestationTypeSBOM, Content: `{"packages":[]}`}, false},
+		{"inline not JSON", Attestation{Type: AttestationTypeSBOM, Content: "not json"}, false},
+		{"pinned reference", Attestation{Type: AttestationTypeSBOM, URI: "https://example.com/sbom.json", Digest: "sha256:abc"}, true},
+		{"unpinned reference", Attestation{Type: AttestationTypeSBOM, URI: "https://example.com/sbom.json"}, false},
+		{"provenance only", Attestation{Type: AttestationTypeProvenance, Content: `{"bomFormat":"CycloneDX"}`}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HasValidSBOM([]Attestation{tt.attestation}))
+		})
+	}
+}
+
+func TestRegistryDeploymentReconciler_CheckSBOMAttestation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	entry := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "1.0.0"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(entry).Build()
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	// Not required by default
+	require.NoError(t, r.checkSBOMAttestation(ctx, entry))
+
+	t.Setenv("AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION", "true")
+	err := r.checkSBOMAttestation(ctx, entry)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no valid SBOM attestation")
+
+	_, err = AddAttestation(ctx, c, entry, Attestation{Type: AttestationTypeSBOM, Content: `{"spdxVersion":"SPDX-2.3"}`})
+	require.NoError(t, err)
+
+	var updated agentregistryv1alpha1.MCPServerCatalog
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(entry), &updated))
+	assert.Equal(t, AttestationsConfigMapName(entry.Name), updated.Annotations[agentregistryv1alpha1.AnnotationAttestations])
+	assert.NoError(t, r.checkSBOMAttestation(ctx, &updated))
+}
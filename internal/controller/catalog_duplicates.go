@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// ErrDuplicateEntry is returned when a catalog entry with the same name and
+// version already exists under another object name
+var ErrDuplicateEntry = errors.New("duplicate catalog entry")
+
+// catalogEntriesWithVersion returns the catalog objects in list's kind with
+// the given spec name and version in namespace, oldest first. An empty
+// namespace searches all namespaces.
+func catalogEntriesWithVersion(ctx context.Context, reader client.Reader, list client.ObjectList, namespace, name, version string) ([]client.Object, error) {
+	// Every catalog kind indexes its spec name under the same field name
+	if err := reader.List(ctx, list, client.InNamespace(namespace), client.MatchingFields{IndexMCPServerName: name}); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []client.Object
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if ok && catalogEntryVersion(item) == version {
+			entries = append(entries, obj)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ti, tj := entries[i].GetCreationTimestamp(), entries[j].GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return entries[i].GetName() < entries[j].GetName()
+	})
+	return entries, nil
+}
+
+func catalogEntryVersion(obj runtime.Object) string {
+	switch o := obj.(type) {
+	case *agentregistryv1alpha1.MCPServerCatalog:
+		return o.Spec.Version
+	case *agentregistryv1alpha1.AgentCatalog:
+		return o.Spec.Version
+	case *agentregistryv1alpha1.SkillCatalog:
+		return o.Spec.Version
+	}
+	return ""
+}
+
+// CheckDuplicateEntry returns an error wrapping ErrDuplicateEntry when an
+// object other than objectName already catalogs name and version in
+// namespace. list selects the catalog kind.
+func CheckDuplicateEntry(ctx context.Context, reader client.Reader, list client.ObjectList, namespace, objectName, name, version string) error {
+	entries, err := catalogEntriesWithVersion(ctx, reader, list, namespace, name, version)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.GetName() != objectName {
+			return fmt.Errorf("%w: %s %s is already cataloged as %s/%s", ErrDuplicateEntry, name, version, entry.GetNamespace(), entry.GetName())
+		}
+	}
+	return nil
+}
+
+// syncDuplicateCondition flags obj with a Duplicate condition when an older
+// object in its namespace catalogs the same name and version, and clears it
+// otherwise. It reports whether the conditions changed.
+func syncDuplicateCondition(ctx context.Context, reader client.Reader, list client.ObjectList, obj client.Object, name, version string, conditions *[]agentregistryv1alpha1.CatalogCondition) (bool, error) {
+	entries, err := catalogEntriesWithVersion(ctx, reader, list, obj.GetNamespace(), name, version)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 || entries[0].GetName() == obj.GetName() {
+		return removeCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionDuplicate), nil
+	}
+	return setCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionDuplicate, "DuplicateNameVersion",
+		fmt.Sprintf("%s %s is already cataloged as %s; this entry duplicates it", name, version, entries[0].GetName())), nil
+}
+
+// setCatalogCondition sets a True condition of the given type, keeping the
+// transition time when only the message is unchanged. It reports whether the
+// conditions changed.
+func setCatalogCondition(conditions *[]agentregistryv1alpha1.CatalogCondition, condType agentregistryv1alpha1.CatalogConditionType, reason, message string) bool {
+	for i := range *conditions {
+		c := &(*conditions)[i]
+		if c.Type != condType {
+			continue
+		}
+		if c.Status == metav1.ConditionTrue && c.Reason == reason && c.Message == message {
+			return false
+		}
+		*c = agentregistryv1alpha1.CatalogCondition{
+			Type:               condType,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reason,
+			Message:            message,
+		}
+		return true
+	}
+	*conditions = append(*conditions, agentregistryv1alpha1.CatalogCondition{
+		Type:               condType,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
+
+// removeCatalogCondition removes the condition of the given type, reporting
+// whether it was present
+func removeCatalogCondition(conditions *[]agentregistryv1alpha1.CatalogCondition, condType agentregistryv1alpha1.CatalogConditionType) bool {
+	for i := range *conditions {
+		if (*conditions)[i].Type == condType {
+			*conditions = append((*conditions)[:i], (*conditions)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// hasCatalogCondition reports whether a condition of the given type is set
+func hasCatalogCondition(conditions []agentregistryv1alpha1.CatalogCondition, condType agentregistryv1alpha1.CatalogConditionType) bool {
+	for _, c := range conditions {
+		if c.Type == condType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestSyncDuplicateCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	created := time.Now().Add(-time.Hour)
+	newAgent := func(objectName, version string, age time.Duration) *agentregistryv1alpha1.AgentCatalog {
+		return &agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              objectName,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created.Add(age)),
+			},
+			Spec: agentregistryv1alpha1.AgentCatalogSpec{Name: "research-agent", Version: version},
+		}
+	}
+	original := newAgent("dev-default-research-agent", "1.0.0", 0)
+	duplicate := newAgent("prod-default-research-agent", "1.0.0", time.Minute)
+	other := newAgent("research-agent-2-0-0", "2.0.0", time.Minute)
+	c := newTestClientWithAgentIndexes(scheme, original, duplicate, other)
+	ctx := context.Background()
+
+	var conditions []agentregistryv1alpha1.CatalogCondition
+	changed, err := syncDuplicateCondition(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, original, "research-agent", "1.0.0", &conditions)
+	require.NoError(t, err)
+	assert.False(t, changed, "the oldest entry is not a duplicate")
+
+	changed, err = syncDuplicateCondition(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, duplicate, "research-agent", "1.0.0", &conditions)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.Len(t, conditions, 1)
+	assert.Equal(t, agentregistryv1alpha1.CatalogConditionDuplicate, conditions[0].Type)
+	assert.Contains(t, conditions[0].Message, original.Name)
+
+	changed, err = syncDuplicateCondition(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, duplicate, "research-agent", "1.0.0", &conditions)
+	require.NoError(t, err)
+	assert.False(t, changed, "an unchanged condition is left alone")
+
+	// Once the original is gone the remaining entry is no longer a duplicate
+	require.NoError(t, c.Delete(ctx, original))
+	changed, err = syncDuplicateCondition(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, duplicate, "research-agent", "1.0.0", &conditions)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Empty(t, conditions)
+}
+
+func TestCheckDuplicateEntry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	existing := &agentregistryv1alpha1.AgentCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "research-agent-1-0-0", Namespace: "default"},
+		Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "research-agent", Version: "1.0.0"},
+	}
+	c := newTestClientWithAgentIndexes(scheme, existing)
+	ctx := context.Background()
+
+	err := CheckDuplicateEntry(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, "default", "copy", "research-agent", "1.0.0")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrDuplicateEntry))
+	assert.Contains(t, err.Error(), "default/research-agent-1-0-0")
+
+	// The same object, another version or another namespace is not a duplicate
+	assert.NoError(t, CheckDuplicateEntry(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, "default", existing.Name, "research-agent", "1.0.0"))
+	assert.NoError(t, CheckDuplicateEntry(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, "default", "copy", "research-agent", "2.0.0"))
+	assert.NoError(t, CheckDuplicateEntry(ctx, c, &agentregistryv1alpha1.AgentCatalogList{}, "other", "copy", "research-agent", "1.0.0"))
+}
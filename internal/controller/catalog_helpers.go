@@ -2,15 +2,12 @@ package controller
 
 import (
 	"context"
-	"strings"
-	"time"
 
-	"golang.org/x/mod/semver"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
-	"github.com/agentregistry-dev/agentregistry/internal/validation"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
 )
 
 // CatalogVersionInfo represents version metadata for a catalog item
@@ -22,16 +19,30 @@ type CatalogVersionInfo struct {
 	Published   bool
 }
 
-// updateLatestVersionForMCPServers updates isLatest flag for all versions of an MCP server
-func updateLatestVersionForMCPServers(ctx context.Context, c client.Client, serverName string) error {
+// updateLatestVersionForMCPServers updates isLatest flag for all versions of
+// server's name. The flag of server itself is set in memory only, along with
+// the rest of its status, so the caller's status update does not conflict with
+// this one; the other versions are updated here.
+func updateLatestVersionForMCPServers(ctx context.Context, c client.Client, server *agentregistryv1alpha1.MCPServerCatalog) error {
 	var serverList agentregistryv1alpha1.MCPServerCatalogList
 	if err := c.List(ctx, &serverList, client.MatchingFields{
-		IndexMCPServerName: serverName,
+		IndexMCPServerName: server.Spec.Name,
 	}); err != nil {
 		return err
 	}
 
-	// Extract version info
+	// Extract version info, using the in-memory state of server, which the
+	// cache may not have caught up with yet
+	found := false
+	for i := range serverList.Items {
+		if serverList.Items[i].Name == server.Name && serverList.Items[i].Namespace == server.Namespace {
+			serverList.Items[i] = *server
+			found = true
+		}
+	}
+	if !found {
+		serverList.Items = append(serverList.Items, *server)
+	}
 	versions := make([]CatalogVersionInfo, len(serverList.Items))
 	for i := range serverList.Items {
 		s := &serverList.Items[i]
@@ -48,8 +59,12 @@ func updateLatestVersionForMCPServers(ctx context.Context, c client.Client, serv
 	latestName := findLatestVersion(versions)
 
 	// Update isLatest flag for all versions
+	server.Status.IsLatest = latestName != "" && server.Name == latestName
 	for i := range serverList.Items {
 		s := &serverList.Items[i]
+		if s.Name == server.Name && s.Namespace == server.Namespace {
+			continue
+		}
 		shouldBeLatest := (latestName != "" && s.Name == latestName)
 
 		if s.Status.IsLatest != shouldBeLatest {
@@ -104,16 +119,29 @@ func updateLatestVersionForAgents(ctx context.Context, c client.Client, agentNam
 	return nil
 }
 
-// updateLatestVersionForSkills updates isLatest flag for all versions of a skill
-func updateLatestVersionForSkills(ctx context.Context, c client.Client, skillName string) error {
+// updateLatestVersionForSkills updates isLatest flag for all versions of a
+// skill. The flag of skill itself is only set in memory, so the caller writes
+// it along with the rest of its status instead of racing this update.
+func updateLatestVersionForSkills(ctx context.Context, c client.Client, skill *agentregistryv1alpha1.SkillCatalog) error {
 	var skillList agentregistryv1alpha1.SkillCatalogList
 	if err := c.List(ctx, &skillList, client.MatchingFields{
-		IndexSkillName: skillName,
+		IndexSkillName: skill.Spec.Name,
 	}); err != nil {
 		return err
 	}
 
-	// Extract version info
+	// Extract version info, using the in-memory state of skill, which the
+	// cache may not have caught up with yet
+	found := false
+	for i := range skillList.Items {
+		if skillList.Items[i].Name == skill.Name && skillList.Items[i].Namespace == skill.Namespace {
+			skillList.Items[i] = *skill
+			found = true
+		}
+	}
+	if !found {
+		skillList.Items = append(skillList.Items, *skill)
+	}
 	versions := make([]CatalogVersionInfo, len(skillList.Items))
 	for i := range skillList.Items {
 		s := &skillList.Items[i]
@@ -130,8 +158,12 @@ func updateLatestVersionForSkills(ctx context.Context, c client.Client, skillNam
 	latestName := findLatestVersion(versions)
 
 	// Update isLatest flag for all versions
+	skill.Status.IsLatest = latestName != "" && skill.Name == latestName
 	for i := range skillList.Items {
 		s := &skillList.Items[i]
+		if s.Name == skill.Name && s.Namespace == skill.Namespace {
+			continue
+		}
 		shouldBeLatest := (latestName != "" && s.Name == latestName)
 
 		if s.Status.IsLatest != shouldBeLatest {
@@ -147,87 +179,12 @@ func updateLatestVersionForSkills(ctx context.Context, c client.Client, skillNam
 
 // findLatestVersion finds the latest version from a list of catalog items
 // All catalog entries are now considered (Published filter removed for unified inventory)
+// The ordering is semver.Compare, shared with the HTTP API and MCP tools so
+// both agree on which version is latest.
 func findLatestVersion(versions []CatalogVersionInfo) string {
-	var latest *CatalogVersionInfo
-	var latestTimestamp time.Time
-
-	for i := range versions {
-		v := &versions[i]
-
-		// All versions are now considered visible in the unified inventory
-		// No filtering by Published status
-
-		if latest == nil {
-			latest = v
-			if v.PublishedAt != nil {
-				latestTimestamp = v.PublishedAt.Time
-			}
-			continue
-		}
-
-		var vTimestamp time.Time
-		if v.PublishedAt != nil {
-			vTimestamp = v.PublishedAt.Time
-		}
-
-		cmp := compareVersions(v.Version, latest.Version, vTimestamp, latestTimestamp)
-		if cmp > 0 {
-			latest = v
-			latestTimestamp = vTimestamp
-		}
-	}
-
-	if latest == nil {
+	latest := semver.LatestIndex(versions, func(v CatalogVersionInfo) string { return v.Version })
+	if latest < 0 {
 		return ""
 	}
-	return latest.Name
-}
-
-// Version comparison utilities
-
-// isSemanticVersion checks if a version string follows semantic versioning format
-func isSemanticVersion(version string) bool {
-	return validation.IsSemanticVersion(version)
-}
-
-// ensureVPrefix adds a "v" prefix if not present
-func ensureVPrefix(version string) string {
-	if !strings.HasPrefix(version, "v") {
-		return "v" + version
-	}
-	return version
-}
-
-// compareSemanticVersions compares two semantic version strings
-func compareSemanticVersions(version1 string, version2 string) int {
-	v1 := ensureVPrefix(version1)
-	v2 := ensureVPrefix(version2)
-	return semver.Compare(v1, v2)
-}
-
-// compareVersions implements the versioning strategy:
-// 1. If both versions are valid semver, use semantic version comparison
-// 2. If neither are valid semver, use publication timestamp
-// 3. If one is semver and one is not, the semver version is always considered higher
-func compareVersions(version1 string, version2 string, timestamp1 time.Time, timestamp2 time.Time) int {
-	isSemver1 := isSemanticVersion(version1)
-	isSemver2 := isSemanticVersion(version2)
-
-	if isSemver1 && isSemver2 {
-		return compareSemanticVersions(version1, version2)
-	}
-
-	if !isSemver1 && !isSemver2 {
-		if timestamp1.Before(timestamp2) {
-			return -1
-		} else if timestamp1.After(timestamp2) {
-			return 1
-		}
-		return 0
-	}
-
-	if isSemver1 && !isSemver2 {
-		return 1
-	}
-	return -1
+	return versions[latest].Name
 }
@@ -36,12 +36,12 @@ func TestFindLatestVersion(t *testing.T) {
 			want: "server-v2.0.0",
 		},
 		{
-			name: "non-semver versions - uses timestamps",
+			name: "non-semver versions - compared lexically like the API",
 			versions: []CatalogVersionInfo{
-				{Name: "server-latest", Version: "latest", Published: true, PublishedAt: &earlier},
-				{Name: "server-main", Version: "main", Published: true, PublishedAt: &later},
+				{Name: "server-main", Version: "main", Published: true, PublishedAt: &earlier},
+				{Name: "server-latest", Version: "latest", Published: true, PublishedAt: &later},
 			},
-			want: "server-main", // later timestamp wins
+			want: "server-main", // timestamps are ignored
 		},
 		{
 			name: "mix of semver and non-semver - semver wins",
@@ -85,7 +85,7 @@ func TestFindLatestVersion(t *testing.T) {
 				{Name: "server-latest", Version: "latest", Published: true, PublishedAt: nil},
 				{Name: "server-main", Version: "main", Published: true, PublishedAt: &now},
 			},
-			want: "server-main", // version with timestamp wins
+			want: "server-main",
 		},
 		{
 			name: "prerelease versions",
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// catalogLifecycle holds the state a catalog entry's Status.Status is derived
+// from. The catalog reconcilers are the only writers of Status.Status; publish,
+// unpublish, deprecation and soft-delete change these inputs instead, so the
+// status cannot drift from them.
+//
+// The inputs are checked in this order and the first match wins:
+//
+//	soft-deleted (agentregistry.dev/soft-deleted=true)  -> deleted
+//	deprecated (agentregistry.dev/deprecated=true, a
+//	  replacedBy reference, or a discovered source that
+//	  no longer exists)                                 -> deprecated
+//	published                                           -> active
+//	unpublished after having been published             -> deprecated
+//	never published                                     -> "" (no status yet)
+type catalogLifecycle struct {
+	SoftDeleted bool
+	Deprecated  bool
+	Published   bool
+	// WasPublished is set once the entry has a PublishedAt timestamp
+	WasPublished bool
+}
+
+// Status returns the catalog status for the lifecycle state
+func (l catalogLifecycle) Status() agentregistryv1alpha1.CatalogStatus {
+	switch {
+	case l.SoftDeleted:
+		return agentregistryv1alpha1.CatalogStatusDeleted
+	case l.Deprecated:
+		return agentregistryv1alpha1.CatalogStatusDeprecated
+	case l.Published:
+		return agentregistryv1alpha1.CatalogStatusActive
+	case l.WasPublished:
+		return agentregistryv1alpha1.CatalogStatusDeprecated
+	default:
+		return ""
+	}
+}
+
+// newCatalogLifecycle reads the lifecycle annotations of obj along with its
+// publish state
+func newCatalogLifecycle(obj metav1.Object, published bool, publishedAt *metav1.Time) catalogLifecycle {
+	annotations := obj.GetAnnotations()
+	return catalogLifecycle{
+		SoftDeleted:  annotations[agentregistryv1alpha1.AnnotationSoftDeleted] == "true",
+		Deprecated:   annotations[agentregistryv1alpha1.AnnotationDeprecated] == "true",
+		Published:    published,
+		WasPublished: publishedAt != nil,
+	}
+}
+
+// mcpServerCatalogStatus derives the status of server. sourceGone reports
+// that the discovered resource the entry was created from no longer exists.
+func mcpServerCatalogStatus(server *agentregistryv1alpha1.MCPServerCatalog, sourceGone bool) agentregistryv1alpha1.CatalogStatus {
+	l := newCatalogLifecycle(server, server.Status.Published, server.Status.PublishedAt)
+	l.Deprecated = l.Deprecated || server.Spec.ReplacedBy != nil || sourceGone
+	return l.Status()
+}
+
+// agentCatalogStatus derives the status of agent
+func agentCatalogStatus(agent *agentregistryv1alpha1.AgentCatalog) agentregistryv1alpha1.CatalogStatus {
+	return newCatalogLifecycle(agent, agent.Status.Published, agent.Status.PublishedAt).Status()
+}
+
+// skillCatalogStatus derives the status of skill
+func skillCatalogStatus(skill *agentregistryv1alpha1.SkillCatalog) agentregistryv1alpha1.CatalogStatus {
+	return newCatalogLifecycle(skill, skill.Status.Published, skill.Status.PublishedAt).Status()
+}
+
+// modelCatalogStatus derives the status of model
+func modelCatalogStatus(model *agentregistryv1alpha1.ModelCatalog) agentregistryv1alpha1.CatalogStatus {
+	return newCatalogLifecycle(model, model.Status.Published, model.Status.PublishedAt).Status()
+}
+
+// CatalogEntryActive reports whether obj is a catalog entry that is published
+// and neither deprecated nor soft-deleted. Besides the lifecycle inputs it
+// honours the recorded status, which also reflects state the inputs do not
+// carry, such as a discovered source that no longer exists. Objects that are
+// not catalog entries are always active.
+func CatalogEntryActive(obj client.Object) bool {
+	var derived, recorded agentregistryv1alpha1.CatalogStatus
+	switch entry := obj.(type) {
+	case *agentregistryv1alpha1.MCPServerCatalog:
+		derived, recorded = mcpServerCatalogStatus(entry, false), entry.Status.Status
+	case *agentregistryv1alpha1.AgentCatalog:
+		derived, recorded = agentCatalogStatus(entry), entry.Status.Status
+	case *agentregistryv1alpha1.SkillCatalog:
+		derived, recorded = skillCatalogStatus(entry), entry.Status.Status
+	case *agentregistryv1alpha1.ModelCatalog:
+		derived, recorded = modelCatalogStatus(entry), entry.Status.Status
+	default:
+		return true
+	}
+	return derived == agentregistryv1alpha1.CatalogStatusActive &&
+		recorded != agentregistryv1alpha1.CatalogStatusDeprecated &&
+		recorded != agentregistryv1alpha1.CatalogStatusDeleted
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestCatalogLifecycle_Status(t *testing.T) {
+	tests := []struct {
+		name      string
+		lifecycle catalogLifecycle
+		want      agentregistryv1alpha1.CatalogStatus
+	}{
+		{name: "never published", lifecycle: catalogLifecycle{}, want: ""},
+		{name: "published", lifecycle: catalogLifecycle{Published: true, WasPublished: true}, want: agentregistryv1alpha1.CatalogStatusActive},
+		{name: "unpublished", lifecycle: catalogLifecycle{WasPublished: true}, want: agentregistryv1alpha1.CatalogStatusDeprecated},
+		{name: "deprecated while published", lifecycle: catalogLifecycle{Deprecated: true, Published: true}, want: agentregistryv1alpha1.CatalogStatusDeprecated},
+		{name: "soft-deleted while published", lifecycle: catalogLifecycle{SoftDeleted: true, Published: true}, want: agentregistryv1alpha1.CatalogStatusDeleted},
+		{name: "soft-deleted and deprecated", lifecycle: catalogLifecycle{SoftDeleted: true, Deprecated: true}, want: agentregistryv1alpha1.CatalogStatusDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.lifecycle.Status())
+		})
+	}
+}
+
+func TestMCPServerCatalogStatus(t *testing.T) {
+	now := metav1.Now()
+	newServer := func() *agentregistryv1alpha1.MCPServerCatalog {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			Status: agentregistryv1alpha1.MCPServerCatalogStatus{Published: true, PublishedAt: &now},
+		}
+	}
+
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusActive, mcpServerCatalogStatus(newServer(), false))
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusDeprecated, mcpServerCatalogStatus(newServer(), true))
+
+	replaced := newServer()
+	replaced.Spec.ReplacedBy = &agentregistryv1alpha1.CatalogEntryReference{Name: "successor"}
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusDeprecated, mcpServerCatalogStatus(replaced, false))
+
+	annotated := newServer()
+	annotated.Annotations = map[string]string{agentregistryv1alpha1.AnnotationDeprecated: "true"}
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusDeprecated, mcpServerCatalogStatus(annotated, false))
+}
+
+func TestCatalogEntryActive(t *testing.T) {
+	now := metav1.Now()
+	published := agentregistryv1alpha1.SkillCatalogStatus{Published: true, PublishedAt: &now}
+
+	assert.True(t, CatalogEntryActive(&agentregistryv1alpha1.SkillCatalog{Status: published}))
+	assert.False(t, CatalogEntryActive(&agentregistryv1alpha1.SkillCatalog{}), "never published")
+	assert.False(t, CatalogEntryActive(&agentregistryv1alpha1.SkillCatalog{
+		Status: agentregistryv1alpha1.SkillCatalogStatus{PublishedAt: &now},
+	}), "unpublished")
+	assert.False(t, CatalogEntryActive(&agentregistryv1alpha1.SkillCatalog{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{agentregistryv1alpha1.AnnotationSoftDeleted: "true"}},
+		Status:     published,
+	}), "soft-deleted")
+
+	sourceGone := published
+	sourceGone.Status = agentregistryv1alpha1.CatalogStatusDeprecated
+	assert.False(t, CatalogEntryActive(&agentregistryv1alpha1.SkillCatalog{Status: sourceGone}), "recorded as deprecated")
+
+	assert.False(t, CatalogEntryActive(&agentregistryv1alpha1.MCPServerCatalog{
+		Spec:   agentregistryv1alpha1.MCPServerCatalogSpec{ReplacedBy: &agentregistryv1alpha1.CatalogEntryReference{Name: "successor"}},
+		Status: agentregistryv1alpha1.MCPServerCatalogStatus{Published: true, PublishedAt: &now},
+	}), "replaced")
+	assert.True(t, CatalogEntryActive(&agentregistryv1alpha1.RegistryDeployment{}), "not a catalog entry")
+}
+
+func TestSkillCatalogReconciler_Reconcile_StatusTransitions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+
+	skill := &agentregistryv1alpha1.SkillCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "lint-skill-v1.0.0", Namespace: "default"},
+		Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: "lint-skill", Version: "1.0.0"},
+	}
+	c := newTestClientWithSkillIndexes(scheme, skill)
+	r := &SkillCatalogReconciler{Client: c, Scheme: scheme, Logger: zerolog.New(nil)}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: skill.Name, Namespace: skill.Namespace}
+	reconcileStatus := func(mutate func(*agentregistryv1alpha1.SkillCatalog)) agentregistryv1alpha1.CatalogStatus {
+		t.Helper()
+		var current agentregistryv1alpha1.SkillCatalog
+		require.NoError(t, c.Get(ctx, key, &current))
+		// Update and Status().Update each persist only their half of the
+		// object, so apply the mutation before both
+		mutate(&current)
+		require.NoError(t, c.Update(ctx, &current))
+		mutate(&current)
+		require.NoError(t, c.Status().Update(ctx, &current))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+		require.NoError(t, c.Get(ctx, key, &current))
+		return current.Status.Status
+	}
+
+	// Never published: no status yet
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatus(""), reconcileStatus(func(*agentregistryv1alpha1.SkillCatalog) {}))
+
+	// publish -> active
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusActive, reconcileStatus(func(s *agentregistryv1alpha1.SkillCatalog) {
+		now := metav1.Now()
+		s.Status.Published = true
+		s.Status.PublishedAt = &now
+	}))
+
+	// unpublish -> deprecated
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusDeprecated, reconcileStatus(func(s *agentregistryv1alpha1.SkillCatalog) {
+		s.Status.Published = false
+	}))
+
+	// soft-delete -> deleted
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusDeleted, reconcileStatus(func(s *agentregistryv1alpha1.SkillCatalog) {
+		s.Annotations = map[string]string{agentregistryv1alpha1.AnnotationSoftDeleted: "true"}
+	}))
+
+	// restoring and republishing -> active again
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusActive, reconcileStatus(func(s *agentregistryv1alpha1.SkillCatalog) {
+		s.Annotations = nil
+		s.Status.Published = true
+	}))
+}
+
+func TestDiscoveryConfigReconciler_DiscoveredEntryStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.New(nil)}
+
+	server := &kagentv1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "tools"},
+		Spec:       kagentv1alpha2.RemoteMCPServerSpec{URL: "http://search.tools:8080/mcp"},
+	}
+	env := &agentregistryv1alpha1.Environment{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev"}}
+
+	ctx := context.Background()
+	require.NoError(t, r.handleRemoteMCPServerAdd(ctx, server, env))
+
+	var catalog agentregistryv1alpha1.MCPServerCatalog
+	key := types.NamespacedName{Name: generateCatalogName(server.Namespace, server.Name), Namespace: testNamespace}
+	require.NoError(t, c.Get(ctx, key, &catalog))
+	assert.True(t, catalog.Status.Published)
+	assert.NotNil(t, catalog.Status.PublishedAt)
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusActive, catalog.Status.Status)
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+// Checks reported by ValidateServerEntry and ValidateAgentEntry
+const (
+	CheckPackages    = "packages"
+	CheckPublisher   = "publisher"
+	CheckModel       = "model"
+	CheckSkill       = "skill"
+	CheckMCPServer   = "mcpServer"
+	CheckEnvironment = "environment"
+)
+
+// ValidationFinding is the outcome of one check of whether a catalog entry can
+// be deployed
+type ValidationFinding struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// ValidateServerEntry checks whether an MCP server catalog entry can be
+// deployed into environment, or the local cluster when environment is empty,
+// without deploying it: it must have a package or remote to run and a
+// verified publisher, and the environment must allow deployments.
+func ValidateServerEntry(ctx context.Context, reader client.Reader, entry *agentregistryv1alpha1.MCPServerCatalog, environment string) ([]ValidationFinding, error) {
+	findings := []ValidationFinding{
+		{Check: CheckPackages, Passed: true, Message: fmt.Sprintf("%d package(s) and %d remote(s)", len(entry.Spec.Packages), len(entry.Spec.Remotes))},
+		publisherFinding(entry.Spec.Metadata),
+	}
+	if len(entry.Spec.Packages) == 0 && len(entry.Spec.Remotes) == 0 {
+		findings[0] = ValidationFinding{Check: CheckPackages, Message: "no packages or remotes to deploy"}
+	}
+	env, err := environmentFinding(ctx, reader, environment)
+	if err != nil {
+		return nil, err
+	}
+	return append(findings, env), nil
+}
+
+// ValidateAgentEntry checks whether an agent catalog entry can be deployed
+// into environment, or the local cluster when environment is empty, without
+// deploying it. Besides an image and a verified publisher, the model config,
+// required skills and registry MCP servers it references must be in the
+// catalog.
+func ValidateAgentEntry(ctx context.Context, reader client.Reader, entry *agentregistryv1alpha1.AgentCatalog, environment string) ([]ValidationFinding, error) {
+	findings := []ValidationFinding{{Check: CheckPackages, Passed: true, Message: "image " + entry.Spec.Image}}
+	if entry.Spec.Image == "" {
+		findings[0] = ValidationFinding{Check: CheckPackages, Message: "no image to deploy"}
+	}
+	findings = append(findings, publisherFinding(entry.Spec.Metadata))
+
+	if ref := entry.Spec.ModelConfigRef; ref != "" {
+		var models agentregistryv1alpha1.ModelCatalogList
+		if err := reader.List(ctx, &models); err != nil {
+			return nil, fmt.Errorf("failed to list models: %w", err)
+		}
+		finding := ValidationFinding{Check: CheckModel, Message: fmt.Sprintf("model config %s is not in the catalog", ref)}
+		for _, model := range models.Items {
+			if model.Spec.Name == ref || strings.HasSuffix(model.Spec.Name, "/"+ref) {
+				finding = ValidationFinding{Check: CheckModel, Passed: true, Message: fmt.Sprintf("model config %s resolves to %s", ref, model.Spec.Name)}
+				break
+			}
+		}
+		findings = append(findings, finding)
+	}
+
+	skills, err := ResolveAgentSkills(ctx, reader, entry.Spec.RequiredSkills)
+	if err != nil {
+		return nil, err
+	}
+	for _, skill := range skills {
+		finding := ValidationFinding{Check: CheckSkill, Passed: true, Message: fmt.Sprintf("skill %s resolves to version %s", skill.Ref, skill.Version)}
+		if !skill.Found {
+			finding = ValidationFinding{Check: CheckSkill, Message: fmt.Sprintf("skill %s is not in the catalog", skill.Ref)}
+		}
+		findings = append(findings, finding)
+	}
+
+	for _, server := range entry.Spec.McpServers {
+		if server.Type != "registry" || server.RegistryServerName == "" {
+			continue
+		}
+		var list agentregistryv1alpha1.MCPServerCatalogList
+		if err := reader.List(ctx, &list, client.MatchingFields{IndexMCPServerName: server.RegistryServerName}); err != nil {
+			return nil, fmt.Errorf("failed to list MCP servers for %s: %w", server.RegistryServerName, err)
+		}
+		ref := server.RegistryServerName
+		if server.RegistryServerVersion != "" {
+			ref += "@" + server.RegistryServerVersion
+		}
+		finding := ValidationFinding{Check: CheckMCPServer, Message: fmt.Sprintf("MCP server %s is not in the catalog", ref)}
+		for _, item := range list.Items {
+			if server.RegistryServerVersion == "" || server.RegistryServerVersion == "latest" || item.Spec.Version == server.RegistryServerVersion {
+				finding = ValidationFinding{Check: CheckMCPServer, Passed: true, Message: fmt.Sprintf("MCP server %s is in the catalog", ref)}
+				break
+			}
+		}
+		findings = append(findings, finding)
+	}
+
+	env, err := environmentFinding(ctx, reader, environment)
+	if err != nil {
+		return nil, err
+	}
+	return append(findings, env), nil
+}
+
+// publisherFinding reports validatePublisherIdentity, passing an unverified
+// publisher when config.RequireVerifiedPublisher is off as the reconciler does
+func publisherFinding(metadata *apiextensionsv1.JSON) ValidationFinding {
+	err := validatePublisherIdentity(metadata)
+	switch {
+	case err == nil:
+		return ValidationFinding{Check: CheckPublisher, Passed: true, Message: "organization and publisher identity are verified"}
+	case !config.RequireVerifiedPublisher():
+		return ValidationFinding{Check: CheckPublisher, Passed: true, Message: err.Error() + "; allowed since AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER=false"}
+	default:
+		return ValidationFinding{Check: CheckPublisher, Message: err.Error()}
+	}
+}
+
+// environmentFinding reports whether environment exists and allows
+// deployments. The local cluster, an empty environment, always does.
+func environmentFinding(ctx context.Context, reader client.Reader, environment string) (ValidationFinding, error) {
+	if environment == "" {
+		return ValidationFinding{Check: CheckEnvironment, Passed: true, Message: "deploys into the local cluster"}, nil
+	}
+	// RegistryDeployments are created in agentregistry, so its DiscoveryConfigs
+	// declare the environments they may target
+	_, err := FindDeployEnvironment(ctx, reader, "agentregistry", environment)
+	switch {
+	case err == nil:
+		return ValidationFinding{Check: CheckEnvironment, Passed: true, Message: fmt.Sprintf("environment %s allows deployments", environment)}, nil
+	case errors.Is(err, ErrEnvironmentNotFound), errors.Is(err, ErrEnvironmentDeployDisabled):
+		return ValidationFinding{Check: CheckEnvironment, Message: err.Error()}, nil
+	default:
+		return ValidationFinding{}, err
+	}
+}
+
+// Deployable reports whether every finding passed
+func Deployable(findings []ValidationFinding) bool {
+	for _, finding := range findings {
+		if !finding.Passed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func newValidationClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	objs = append(objs, &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "prod", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "prod-gke"}, DeployEnabled: true},
+				{Name: "audit", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "audit-gke"}},
+			},
+		},
+	})
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, IndexSkillName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.SkillCatalog).Spec.Name}
+		}).
+		Build()
+}
+
+// findingsByCheck indexes findings by check, for checks reported once
+func findingsByCheck(findings []ValidationFinding) map[string]ValidationFinding {
+	byCheck := make(map[string]ValidationFinding, len(findings))
+	for _, finding := range findings {
+		byCheck[finding.Check] = finding
+	}
+	return byCheck
+}
+
+func TestValidateServerEntry(t *testing.T) {
+	c := newValidationClient(t)
+	ctx := context.Background()
+
+	valid := newOCIServerCatalog("org/valid", "1.0.0", testImageDigest)
+	findings, err := ValidateServerEntry(ctx, c, valid, "prod")
+	require.NoError(t, err)
+	assert.True(t, Deployable(findings))
+	assert.Equal(t, []string{CheckPackages, CheckPublisher, CheckEnvironment}, []string{findings[0].Check, findings[1].Check, findings[2].Check})
+	for _, finding := range findings {
+		assert.True(t, finding.Passed, finding.Message)
+	}
+
+	t.Run("missing packages and unverified publisher", func(t *testing.T) {
+		empty := &agentregistryv1alpha1.MCPServerCatalog{
+			Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/empty", Version: "1.0.0"},
+		}
+		findings, err := ValidateServerEntry(ctx, c, empty, "")
+		require.NoError(t, err)
+		assert.False(t, Deployable(findings))
+		byCheck := findingsByCheck(findings)
+		assert.False(t, byCheck[CheckPackages].Passed)
+		assert.Equal(t, "no packages or remotes to deploy", byCheck[CheckPackages].Message)
+		assert.False(t, byCheck[CheckPublisher].Passed)
+		assert.Contains(t, byCheck[CheckPublisher].Message, "missing publisher metadata")
+		assert.True(t, byCheck[CheckEnvironment].Passed, "the local cluster always allows deployments")
+	})
+
+	t.Run("unverified publisher allowed by config", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER", "false")
+		unverified := newOCIServerCatalog("org/unverified", "1.0.0", testImageDigest)
+		unverified.Spec.Metadata = nil
+		findings, err := ValidateServerEntry(ctx, c, unverified, "")
+		require.NoError(t, err)
+		assert.True(t, Deployable(findings))
+		assert.Contains(t, findingsByCheck(findings)[CheckPublisher].Message, "AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER=false")
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		for environment, message := range map[string]string{
+			"audit":   "deployment to environment not allowed",
+			"staging": "environment not found",
+		} {
+			findings, err := ValidateServerEntry(ctx, c, valid, environment)
+			require.NoError(t, err)
+			env := findingsByCheck(findings)[CheckEnvironment]
+			assert.False(t, env.Passed, environment)
+			assert.Contains(t, env.Message, message)
+		}
+	})
+}
+
+func TestValidateAgentEntry(t *testing.T) {
+	c := newValidationClient(t,
+		&agentregistryv1alpha1.ModelCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "kagent-default-model", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.ModelCatalogSpec{Name: "kagent/default-model", Provider: "OpenAI", Model: "gpt-4o"},
+		},
+		&agentregistryv1alpha1.SkillCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "summarize-1-0-0", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: "summarize", Version: "1.0.0"},
+			Status:     agentregistryv1alpha1.SkillCatalogStatus{Published: true},
+		},
+		newRemoteServerCatalog("org/search", "1.0.0"),
+	)
+	agent := &agentregistryv1alpha1.AgentCatalog{
+		Spec: agentregistryv1alpha1.AgentCatalogSpec{
+			Name:           "org/researcher",
+			Version:        "1.0.0",
+			Image:          "ghcr.io/org/researcher:1.0.0",
+			ModelConfigRef: "default-model",
+			RequiredSkills: []string{"summarize"},
+			McpServers: []agentregistryv1alpha1.McpServerConfig{
+				{Type: "registry", Name: "search", RegistryServerName: "org/search", RegistryServerVersion: "1.0.0"},
+			},
+			Metadata: &apiextensionsv1.JSON{Raw: []byte(verifiedPublisherMetadata)},
+		},
+	}
+	ctx := context.Background()
+
+	findings, err := ValidateAgentEntry(ctx, c, agent, "prod")
+	require.NoError(t, err)
+	assert.True(t, Deployable(findings), "%+v", findings)
+	assert.Len(t, findings, 6)
+
+	agent.Spec.Image = ""
+	agent.Spec.ModelConfigRef = "missing-model"
+	agent.Spec.RequiredSkills = []string{"summarize", "translate@2.0.0"}
+	agent.Spec.McpServers[0].RegistryServerVersion = "2.0.0"
+	findings, err = ValidateAgentEntry(ctx, c, agent, "")
+	require.NoError(t, err)
+	assert.False(t, Deployable(findings))
+	var failed []string
+	for _, finding := range findings {
+		if !finding.Passed {
+			failed = append(failed, finding.Message)
+		}
+	}
+	assert.Equal(t, []string{
+		"no image to deploy",
+		"model config missing-model is not in the catalog",
+		"skill translate@2.0.0 is not in the catalog",
+		"MCP server org/search@2.0.0 is not in the catalog",
+	}, failed)
+}
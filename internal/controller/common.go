@@ -3,6 +3,8 @@ package controller
 import (
 	"fmt"
 	"strings"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 )
 
 // Discovery label constants shared across discovery handlers
@@ -13,6 +15,12 @@ const (
 	sourceNSLabel   = "agentregistry.dev/source-namespace"
 )
 
+// Labels set on discovered catalog entries identifying where they were found
+const (
+	EnvironmentLabel = "agentregistry.dev/environment"
+	ClusterLabel     = "agentregistry.dev/cluster"
+)
+
 // getEnvironmentFromNamespace extracts environment from namespace
 // Returns the namespace as environment if not recognized
 func getEnvironmentFromNamespace(namespace string) string {
@@ -114,3 +122,14 @@ func generateSkillCatalogName(name, version string) string {
 
 	return combined
 }
+
+// setDiscoveryIncompleteCondition records on a discovered catalog entry's
+// conditions whether its source could only be mapped lossily, removing the
+// condition once the mapping is complete. It reports whether the conditions
+// changed.
+func setDiscoveryIncompleteCondition(conditions *[]agentregistryv1alpha1.CatalogCondition, issues []string) bool {
+	if len(issues) == 0 {
+		return removeCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionDiscoveryIncomplete)
+	}
+	return setCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionDiscoveryIncomplete, "LossyMapping", strings.Join(issues, "; "))
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// catalogMissingRequeueInterval is how often a deployment whose catalog entry
+// was deleted checks whether the entry has been restored; catalog changes do
+// not trigger deployment reconciles.
+const catalogMissingRequeueInterval = 5 * time.Minute
+
+// catalogMissingError reports that the catalog entry of a deployment that has
+// already applied resources no longer exists
+type catalogMissingError struct {
+	kind    string
+	name    string
+	version string
+}
+
+func (e *catalogMissingError) Error() string {
+	return fmt.Sprintf("%s %s version %s not found", e.kind, e.name, e.version)
+}
+
+// catalogEntryNotFound returns the error for a deployment whose catalog entry
+// cannot be found. Only a version that was successfully applied and still has
+// live resources gets a catalogMissingError, so they are not disturbed; a
+// deployment of a version that never existed simply fails.
+func catalogEntryNotFound(deployment *agentregistryv1alpha1.RegistryDeployment, kind string) error {
+	missing := &catalogMissingError{kind: kind, name: deployment.Spec.ResourceName, version: deployment.Spec.Version}
+	applied, ok := readSpecSnapshot(deployment, AppliedSpecAnnotation)
+	if !ok || applied.Version != deployment.Spec.Version || len(deployment.Status.ManagedResources) == 0 {
+		return fmt.Errorf("%s", missing.Error())
+	}
+	return missing
+}
+
+// handleCatalogMissing records the CatalogMissing condition on a deployment
+// whose catalog entry was deleted after it was deployed. Its managed resources
+// keep running, and it keeps reporting their readiness, unless
+// PruneOnCatalogMissing is set, in which case they are deleted and the
+// deployment fails.
+func (r *RegistryDeploymentReconciler) handleCatalogMissing(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment, missing *catalogMissingError) {
+	setCatalogCondition(&deployment.Status.Conditions, agentregistryv1alpha1.CatalogConditionCatalogMissing, "CatalogEntryDeleted",
+		fmt.Sprintf("catalog entry %s %s was deleted after it was deployed", missing.name, missing.version))
+
+	if !r.PruneOnCatalogMissing {
+		ready, message := r.checkManagedResourcesReady(ctx, deployment)
+		if ready {
+			deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhaseRunning
+		} else {
+			deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhasePending
+		}
+		deployment.Status.Message = fmt.Sprintf("%s; its managed resources are kept running", missing.Error())
+		if !ready && message != "" {
+			deployment.Status.Message += ": " + message
+		}
+		return
+	}
+
+	targets, err := r.resolveTargets(ctx, deployment)
+	if err != nil {
+		r.Logger.Warn().Err(err).Str("deployment", deployment.Name).
+			Msg("failed to resolve some targets, keeping their managed resources")
+	}
+	var remaining []agentregistryv1alpha1.ManagedResource
+	for _, res := range deployment.Status.ManagedResources {
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			remaining = append(remaining, res)
+			continue
+		}
+		if err := r.deleteObj(ctx, target.mcpURL, target.client, res); err != nil {
+			r.Logger.Error().Err(err).Str("kind", res.Kind).Str("name", res.Name).
+				Str("namespace", res.Namespace).Msg("failed to delete managed resource of deployment with missing catalog entry")
+			remaining = append(remaining, res)
+		}
+	}
+	deployment.Status.ManagedResources = remaining
+	deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhaseFailed
+	deployment.Status.Message = fmt.Sprintf("%s; its managed resources were removed", missing.Error())
+	if len(remaining) > 0 {
+		deployment.Status.Message = fmt.Sprintf("%s; %d of its managed resources could not be removed", missing.Error(), len(remaining))
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestRegistryDeploymentReconciler_Reconcile_CatalogDeletedAfterDeploy(t *testing.T) {
+	for _, prune := range []bool{false, true} {
+		scheme := runtime.NewScheme()
+		_ = agentregistryv1alpha1.AddToScheme(scheme)
+		_ = kagentv1alpha2.AddToScheme(scheme)
+		_ = kmcpv1alpha1.AddToScheme(scheme)
+
+		catalog := newRemoteServerCatalog("orphan-server", "1.0.0")
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+			WithObjects(
+				&agentregistryv1alpha1.RegistryDeployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "orphan-server", Namespace: "default", Finalizers: []string{finalizerName}},
+					Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+						ResourceName: "orphan-server",
+						Version:      "1.0.0",
+						ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+						Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+						Namespace:    "target-ns",
+					},
+				},
+				catalog,
+			).
+			WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+			Build()
+
+		r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop(), PruneOnCatalogMissing: prune}
+		ctx := context.Background()
+		key := types.NamespacedName{Name: "orphan-server", Namespace: "default"}
+		reconcileAndGet := func() (reconcile.Result, *agentregistryv1alpha1.RegistryDeployment) {
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			require.NoError(t, err)
+			var got agentregistryv1alpha1.RegistryDeployment
+			require.NoError(t, c.Get(ctx, key, &got))
+			return result, &got
+		}
+
+		_, got := reconcileAndGet()
+		require.Len(t, got.Status.ManagedResources, 1)
+		managed := got.Status.ManagedResources[0]
+		remoteKey := client.ObjectKey{Name: managed.Name, Namespace: managed.Namespace}
+		require.NoError(t, c.Get(ctx, remoteKey, &kagentv1alpha2.RemoteMCPServer{}))
+		phaseBefore := got.Status.Phase
+
+		// Someone deletes the catalog entry while the deployment is live
+		require.NoError(t, c.Delete(ctx, catalog))
+		result, got := reconcileAndGet()
+		assert.Equal(t, catalogMissingRequeueInterval, result.RequeueAfter)
+		require.True(t, hasCatalogCondition(got.Status.Conditions, agentregistryv1alpha1.CatalogConditionCatalogMissing))
+		assert.Equal(t, "CatalogEntryDeleted", got.Status.Conditions[len(got.Status.Conditions)-1].Reason)
+
+		err := c.Get(ctx, remoteKey, &kagentv1alpha2.RemoteMCPServer{})
+		if prune {
+			assert.True(t, apierrors.IsNotFound(err), "resources are removed when configured to")
+			assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, got.Status.Phase)
+			assert.Empty(t, got.Status.ManagedResources)
+			assert.Contains(t, got.Status.Message, "were removed")
+			continue
+		}
+		require.NoError(t, err, "resources keep running by default")
+		assert.Equal(t, phaseBefore, got.Status.Phase)
+		assert.Equal(t, []agentregistryv1alpha1.ManagedResource{managed}, got.Status.ManagedResources)
+		assert.Contains(t, got.Status.Message, "version 1.0.0 not found; its managed resources are kept running")
+
+		// Restoring the entry clears the condition
+		restored := newRemoteServerCatalog("orphan-server", "1.0.0")
+		require.NoError(t, c.Create(ctx, restored))
+		_, got = reconcileAndGet()
+		assert.False(t, hasCatalogCondition(got.Status.Conditions, agentregistryv1alpha1.CatalogConditionCatalogMissing))
+	}
+}
+
+func TestCatalogEntryNotFound_NeverDeployedVersionFails(t *testing.T) {
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{ResourceName: "org/server", Version: "2.0.0"},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{
+			ManagedResources: []agentregistryv1alpha1.ManagedResource{{Kind: "MCPServer", Name: "server"}},
+		},
+	}
+	// Resources from 1.0.0 are live, but 2.0.0 was never applied
+	require.NoError(t, writeSpecSnapshot(deployment, AppliedSpecAnnotation, DeploymentSpecSnapshot{Version: "1.0.0"}))
+
+	err := catalogEntryNotFound(deployment, "MCP server")
+	var missing *catalogMissingError
+	assert.False(t, errors.As(err, &missing))
+	assert.EqualError(t, err, "MCP server org/server version 2.0.0 not found")
+
+	require.NoError(t, writeSpecSnapshot(deployment, AppliedSpecAnnotation, DeploymentSpecSnapshot{Version: "2.0.0"}))
+	assert.True(t, errors.As(catalogEntryNotFound(deployment, "MCP server"), &missing))
+}
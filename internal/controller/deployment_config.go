@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// ConfigIssues describes how a deployment's config deviates from the inputs
+// its catalog entry declares
+type ConfigIssues struct {
+	// Unknown lists config keys the catalog entry does not declare; they are
+	// ignored when the deployment is translated
+	Unknown []string `json:"unknown,omitempty"`
+	// MissingRequired lists required inputs that are neither configured nor
+	// have a default value or Secret reference
+	MissingRequired []string `json:"missingRequired,omitempty"`
+}
+
+// Empty reports whether there are no issues
+func (i ConfigIssues) Empty() bool {
+	return len(i.Unknown) == 0 && len(i.MissingRequired) == 0
+}
+
+// Warnings renders the issues as human-readable messages
+func (i ConfigIssues) Warnings() []string {
+	var warnings []string
+	if len(i.Unknown) > 0 {
+		warnings = append(warnings, fmt.Sprintf("unknown config keys are ignored: %s", strings.Join(i.Unknown, ", ")))
+	}
+	if len(i.MissingRequired) > 0 {
+		warnings = append(warnings, fmt.Sprintf("required config keys are missing: %s", strings.Join(i.MissingRequired, ", ")))
+	}
+	return warnings
+}
+
+// ValidateDeploymentConfig checks the config of an MCP server deployment
+// against the inputs declared by the transport it will use: the headers of the
+// first remote, or the environment variables and runtime and package
+// arguments of the package selected by PackageIndex, mirroring
+// convertCatalogToMCPServer. Helm deployments take chart values and are not
+// checked. It returns an error wrapping ErrPackageIndexOutOfRange when
+// PackageIndex selects a package the catalog entry does not have.
+func ValidateDeploymentConfig(catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (ConfigIssues, error) {
+	var issues ConfigIssues
+	if deployment.Spec.Runtime == agentregistryv1alpha1.RuntimeTypeHelm {
+		return issues, nil
+	}
+
+	declared := make(map[string]bool)
+	require := func(name string, satisfied bool) {
+		declared[name] = true
+		if !satisfied {
+			if _, ok := deployment.Spec.Config[name]; !ok {
+				issues.MissingRequired = append(issues.MissingRequired, name)
+			}
+		}
+	}
+	switch {
+	case usesRemote(catalog, deployment):
+		for _, h := range catalog.Spec.Remotes[0].Headers {
+			require(h.Name, !h.Required || h.Value != "")
+		}
+	case len(catalog.Spec.Packages) > 0:
+		pkg, err := DeploymentPackage(catalog, deployment)
+		if err != nil {
+			return ConfigIssues{}, err
+		}
+		for _, envVar := range pkg.EnvironmentVariables {
+			require(envVar.Name, !envVar.Required || envVar.Value != "" || envVar.SecretRef != nil)
+		}
+		for _, arg := range slices.Concat(pkg.RuntimeArguments, pkg.PackageArguments) {
+			require(arg.Name, !arg.Required || arg.Value != "")
+		}
+	}
+
+	for key := range deployment.Spec.Config {
+		if !declared[key] {
+			issues.Unknown = append(issues.Unknown, key)
+		}
+	}
+	slices.Sort(issues.Unknown)
+	slices.Sort(issues.MissingRequired)
+	issues.MissingRequired = slices.Compact(issues.MissingRequired)
+	return issues, nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestValidateDeploymentConfig(t *testing.T) {
+	catalog := &agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/github",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{{
+				RegistryType: "npm",
+				Identifier:   "@org/github",
+				EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+					{Name: "GITHUB_TOKEN", Required: true},
+					{Name: "GITHUB_HOST", Required: true, Value: "github.com"},
+					{Name: "API_KEY", Required: true, SecretRef: &agentregistryv1alpha1.SecretKeyRef{SecretName: "github"}},
+					{Name: "LOG_LEVEL"},
+				},
+				RuntimeArguments: []agentregistryv1alpha1.Argument{{Name: "--max-old-space-size"}},
+				PackageArguments: []agentregistryv1alpha1.Argument{{Name: "toolset", Required: true}},
+			}},
+			Remotes: []agentregistryv1alpha1.Transport{{
+				Type:    "streamable-http",
+				URL:     "https://api.example.com/mcp",
+				Headers: []agentregistryv1alpha1.KeyValueInput{{Name: "Authorization", Required: true}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     agentregistryv1alpha1.RegistryDeploymentSpec
+		expected ConfigIssues
+	}{
+		{
+			name: "declared keys with all required inputs",
+			spec: agentregistryv1alpha1.RegistryDeploymentSpec{Config: map[string]string{
+				"GITHUB_TOKEN": "token", "toolset": "repos", "LOG_LEVEL": "debug",
+			}},
+		},
+		{
+			name:     "missing required keys without a default or Secret",
+			spec:     agentregistryv1alpha1.RegistryDeploymentSpec{Config: map[string]string{"LOG_LEVEL": "debug"}},
+			expected: ConfigIssues{MissingRequired: []string{"GITHUB_TOKEN", "toolset"}},
+		},
+		{
+			name: "unknown keys",
+			spec: agentregistryv1alpha1.RegistryDeploymentSpec{Config: map[string]string{
+				"GITHUB_TOKEN": "token", "toolset": "repos", "GITHUB_TOKNE": "typo", "Authorization": "Bearer x",
+			}},
+			expected: ConfigIssues{Unknown: []string{"Authorization", "GITHUB_TOKNE"}},
+		},
+		{
+			name:     "remote deployments declare the remote's headers",
+			spec:     agentregistryv1alpha1.RegistryDeploymentSpec{PreferRemote: true, Config: map[string]string{"GITHUB_TOKEN": "token"}},
+			expected: ConfigIssues{Unknown: []string{"GITHUB_TOKEN"}, MissingRequired: []string{"Authorization"}},
+		},
+		{
+			name: "helm deployments are not checked",
+			spec: agentregistryv1alpha1.RegistryDeploymentSpec{Runtime: agentregistryv1alpha1.RuntimeTypeHelm, Config: map[string]string{"replicaCount": "2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := ValidateDeploymentConfig(catalog, &agentregistryv1alpha1.RegistryDeployment{Spec: tt.spec})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, issues)
+			assert.Equal(t, tt.expected.Empty(), issues.Empty())
+		})
+	}
+
+	// A package the entry does not have is reported on its own rather than
+	// as every key being unknown
+	_, err := ValidateDeploymentConfig(catalog, &agentregistryv1alpha1.RegistryDeployment{Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+		PackageIndex: 3, Config: map[string]string{"GITHUB_TOKEN": "token"},
+	}})
+	require.ErrorIs(t, err, ErrPackageIndexOutOfRange)
+
+	issues := ConfigIssues{Unknown: []string{"A", "B"}, MissingRequired: []string{"C"}}
+	assert.Equal(t, []string{
+		"unknown config keys are ignored: A, B",
+		"required config keys are missing: C",
+	}, issues.Warnings())
+}
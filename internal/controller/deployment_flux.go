@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/helm"
+)
+
+// errFluxNotInstalled is returned for a Helm chart deployment into a cluster
+// without the Flux CRDs that install the chart
+var errFluxNotInstalled = errors.New("flux is not installed")
+
+// checkFluxInstalled returns an error wrapping errFluxNotInstalled when
+// target's cluster does not serve the Flux HelmRepository and HelmRelease
+// kinds. Clusters reached through an MCP tool server are not checked.
+func checkFluxInstalled(target deploymentTarget) error {
+	if target.mcpURL != "" {
+		return nil
+	}
+	var missing []string
+	for _, gvk := range []schema.GroupVersionKind{helm.HelmRepositoryGVK, helm.HelmReleaseGVK} {
+		if _, err := target.client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return fmt.Errorf("failed to look up %s in %s: %w", gvk.Kind, targetDescription(target), err)
+			}
+			missing = append(missing, gvk.GroupVersion().String()+" "+gvk.Kind)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s does not serve %s", errFluxNotInstalled, targetDescription(target), strings.Join(missing, " and "))
+	}
+	return nil
+}
+
+// targetDescription names target's cluster in messages
+func targetDescription(target deploymentTarget) string {
+	if target.env == nil {
+		return "the local cluster"
+	}
+	return fmt.Sprintf("the cluster of environment %q", target.env.Name)
+}
+
+// setFluxUnavailableCondition records on a deployment's conditions the
+// clusters a Helm chart could not be deployed into for lack of Flux, removing
+// the condition once there are none
+func setFluxUnavailableCondition(conditions *[]agentregistryv1alpha1.CatalogCondition, errs []error) bool {
+	if len(errs) == 0 {
+		return removeCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionFluxUnavailable)
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return setCatalogCondition(conditions, agentregistryv1alpha1.CatalogConditionFluxUnavailable, "FluxCRDsMissing", strings.Join(msgs, "; "))
+}
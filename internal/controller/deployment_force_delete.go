@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// OrphanedResourcesKey is the key of the orphaned resources ConfigMap that
+// lists, as JSON, the managed resources a forced deletion could not clean up
+// and left behind in the target cluster.
+const OrphanedResourcesKey = "resources"
+
+// OrphanedResourcesConfigMapName returns the name of the ConfigMap recording
+// the resources orphaned by force deleting the named deployment
+func OrphanedResourcesConfigMapName(deploymentName string) string {
+	return deploymentName + "-orphaned-resources"
+}
+
+// ForceDeleteDeployment is the escape hatch for a RegistryDeployment whose
+// deletion cannot complete, e.g. because its target cluster is gone. It marks
+// the deployment for deletion if it is not already, deletes its managed
+// resources on a best-effort basis and removes the finalizer without waiting
+// for the reconciler. The resources that could not be deleted are returned
+// and recorded in a ConfigMap named by OrphanedResourcesConfigMapName. The
+// ConfigMap is not owned by the deployment, so the record outlives it.
+func ForceDeleteDeployment(ctx context.Context, c client.Client, logger zerolog.Logger, deployment *agentregistryv1alpha1.RegistryDeployment) ([]agentregistryv1alpha1.ManagedResource, error) {
+	if deployment.DeletionTimestamp.IsZero() {
+		if err := c.Delete(ctx, deployment); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+	}
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: c.Scheme(), Logger: logger}
+	var orphaned []agentregistryv1alpha1.ManagedResource
+	targets, err := r.resolveTargets(ctx, deployment)
+	if err != nil {
+		logger.Warn().Err(err).Str("deployment", deployment.Name).Msg("cannot reach deployment target, orphaning its managed resources")
+	}
+	for _, res := range deployment.Status.ManagedResources {
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			orphaned = append(orphaned, res)
+			continue
+		}
+		if err := r.deleteObj(ctx, target.mcpURL, target.client, res); err != nil {
+			logger.Warn().Err(err).Str("kind", res.Kind).Str("name", res.Name).
+				Str("namespace", res.Namespace).Msg("failed to delete managed resource, orphaning it")
+			orphaned = append(orphaned, res)
+		}
+	}
+
+	if len(orphaned) > 0 {
+		if err := recordOrphanedResources(ctx, c, deployment, orphaned); err != nil {
+			return nil, err
+		}
+	}
+	controllerutil.RemoveFinalizer(deployment, finalizerName)
+	if err := c.Update(ctx, deployment); err != nil {
+		return orphaned, client.IgnoreNotFound(err)
+	}
+	return orphaned, nil
+}
+
+// recordOrphanedResources stores orphaned in the deployment's orphaned
+// resources ConfigMap, replacing the record of an earlier deployment with the
+// same name
+func recordOrphanedResources(ctx context.Context, c client.Client, deployment *agentregistryv1alpha1.RegistryDeployment, orphaned []agentregistryv1alpha1.ManagedResource) error {
+	raw, err := json.Marshal(orphaned)
+	if err != nil {
+		return fmt.Errorf("failed to encode orphaned resources: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	cm.Name = OrphanedResourcesConfigMapName(deployment.Name)
+	cm.Namespace = deployment.Namespace
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[agentregistryv1alpha1.LabelManagedBy] = "agentregistry"
+		cm.Data = map[string]string{
+			OrphanedResourcesKey: string(raw),
+			"deletedAt":          time.Now().UTC().Format(time.RFC3339),
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to record orphaned resources: %w", err)
+	}
+	return nil
+}
+
+// ListOrphanedResources returns the resources recorded as orphaned by force
+// deleting the named deployment, or nil when none were
+func ListOrphanedResources(ctx context.Context, reader client.Reader, namespace, deploymentName string) ([]agentregistryv1alpha1.ManagedResource, error) {
+	var cm corev1.ConfigMap
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: OrphanedResourcesConfigMapName(deploymentName)}, &cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	var orphaned []agentregistryv1alpha1.ManagedResource
+	if err := json.Unmarshal([]byte(cm.Data[OrphanedResourcesKey]), &orphaned); err != nil {
+		return nil, fmt.Errorf("invalid orphaned resources in %s: %w", cm.Name, err)
+	}
+	return orphaned, nil
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+// resolveTargetNamespace returns the namespace the resources of deployment are
+// created in on its target cluster. An explicit Spec.Namespace always wins but,
+// with an environment, must be one of the namespaces the environment declares,
+// if it declares any. An empty Spec.Namespace is derived from the environment:
+// its cluster namespace, else its first discovery namespace. Otherwise it falls
+// back to config.DefaultDeployNamespace, which is
+// AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE or, when that is unset, kagent.
+func resolveTargetNamespace(deployment *agentregistryv1alpha1.RegistryDeployment, env *agentregistryv1alpha1.Environment) (string, error) {
+	var allowed []string
+	if env != nil {
+		allowed = environmentNamespaces(env)
+	}
+
+	if deployment.Spec.Namespace == "" {
+		if len(allowed) > 0 {
+			return allowed[0], nil
+		}
+		return config.DefaultDeployNamespace(), nil
+	}
+
+	if len(allowed) > 0 && !slices.Contains(allowed, deployment.Spec.Namespace) {
+		return "", fmt.Errorf("namespace %q is not covered by environment %q, which targets %s; omit namespace to deploy into %q",
+			deployment.Spec.Namespace, env.Name, strings.Join(allowed, ", "), allowed[0])
+	}
+	return deployment.Spec.Namespace, nil
+}
+
+// resolveTargetNamespaces returns the namespaces the resources of deployment
+// are created in: each of Spec.Namespaces, checked against the environment
+// like an explicit Spec.Namespace, or else the single namespace
+// resolveTargetNamespace picks
+func resolveTargetNamespaces(deployment *agentregistryv1alpha1.RegistryDeployment, env *agentregistryv1alpha1.Environment) ([]string, error) {
+	if len(deployment.Spec.Namespaces) == 0 {
+		namespace, err := resolveTargetNamespace(deployment, env)
+		if err != nil {
+			return nil, err
+		}
+		return []string{namespace}, nil
+	}
+	if deployment.Spec.Namespace != "" {
+		return nil, fmt.Errorf("namespace and namespaces are mutually exclusive")
+	}
+
+	var allowed []string
+	if env != nil {
+		allowed = environmentNamespaces(env)
+	}
+	var namespaces []string
+	for _, ns := range deployment.Spec.Namespaces {
+		if slices.Contains(namespaces, ns) {
+			continue
+		}
+		if len(allowed) > 0 && !slices.Contains(allowed, ns) {
+			return nil, fmt.Errorf("namespace %q is not covered by environment %q, which targets %s",
+				ns, env.Name, strings.Join(allowed, ", "))
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
+
+// environmentNamespaces lists the namespaces env declares, its cluster
+// namespace first
+func environmentNamespaces(env *agentregistryv1alpha1.Environment) []string {
+	var namespaces []string
+	if env.Cluster.Namespace != "" {
+		namespaces = append(namespaces, env.Cluster.Namespace)
+	}
+	for _, ns := range env.Namespaces {
+		if !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
@@ -0,0 +1,302 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+)
+
+func TestResolveTargetNamespace(t *testing.T) {
+	clusterNS := &agentregistryv1alpha1.Environment{
+		Name:       "prod",
+		Cluster:    agentregistryv1alpha1.ClusterConfig{Name: "prod-gke", Namespace: "ai-prod"},
+		Namespaces: []string{"agents", "ai-prod"},
+	}
+	discoveryOnly := &agentregistryv1alpha1.Environment{Name: "staging", Namespaces: []string{"agents", "tools"}}
+	unconstrained := &agentregistryv1alpha1.Environment{Name: "dev"}
+
+	tests := []struct {
+		name      string
+		namespace string
+		env       *agentregistryv1alpha1.Environment
+		want      string
+		wantErr   string
+	}{
+		{name: "local cluster, default", want: config.FallbackDeployNamespace},
+		{name: "local cluster, explicit", namespace: "team-a", want: "team-a"},
+		{name: "environment cluster namespace", env: clusterNS, want: "ai-prod"},
+		{name: "environment discovery namespace", env: discoveryOnly, want: "agents"},
+		{name: "environment without namespaces", env: unconstrained, want: config.FallbackDeployNamespace},
+		{name: "override within environment", namespace: "agents", env: clusterNS, want: "agents"},
+		{name: "override matching cluster namespace", namespace: "ai-prod", env: clusterNS, want: "ai-prod"},
+		{name: "override of unconstrained environment", namespace: "team-a", env: unconstrained, want: "team-a"},
+		{
+			name:      "contradicts environment",
+			namespace: "team-a",
+			env:       clusterNS,
+			wantErr:   `namespace "team-a" is not covered by environment "prod", which targets ai-prod, agents; omit namespace to deploy into "ai-prod"`,
+		},
+		{
+			name:      "contradicts discovery namespaces",
+			namespace: "team-a",
+			env:       discoveryOnly,
+			wantErr:   `namespace "team-a" is not covered by environment "staging"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &agentregistryv1alpha1.RegistryDeployment{
+				Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Namespace: tt.namespace},
+			}
+			got, err := resolveTargetNamespace(deployment, tt.env)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveTargetNamespace_DefaultDeployNamespace(t *testing.T) {
+	resolve := func(namespace string) string {
+		t.Helper()
+		deployment := &agentregistryv1alpha1.RegistryDeployment{
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Namespace: namespace},
+		}
+		got, err := resolveTargetNamespace(deployment, nil)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", "")
+	assert.Equal(t, "kagent", resolve(""), "falls back to kagent when unconfigured")
+
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", "agents")
+	assert.Equal(t, "agents", resolve(""), "configured default applies to an empty spec")
+	assert.Equal(t, "team-a", resolve("team-a"), "spec overrides the configured default")
+
+	// The translation fallback follows the same default
+	catalog := &agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/search",
+			Version: "1.0.0",
+			Remotes: []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "https://search.example.com/mcp"}},
+		},
+	}
+	r := &RegistryDeploymentReconciler{}
+	server, err := r.convertCatalogToMCPServer(catalog, &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{PreferRemote: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "agents", server.Namespace)
+}
+
+func TestRegistryDeploymentReconciler_EnvironmentNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	newDeployment := func(name, namespace string) *agentregistryv1alpha1.RegistryDeployment {
+		return &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry", Finalizers: []string{finalizerName}},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: "env-server",
+				Version:      "1.0.0",
+				ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+				Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+				Namespace:    namespace,
+				Environment:  "prod",
+			},
+		}
+	}
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{{
+				Name:          "prod",
+				Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "prod-gke", Namespace: "ai-prod"},
+				DeployEnabled: true,
+			}},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(
+			newDeployment("defaulted", ""),
+			newDeployment("contradicting", "team-a"),
+			discovery,
+			newRemoteServerCatalog("env-server", "1.0.0"),
+		).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+		RemoteClientFactory: func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+			return c, nil
+		},
+	}
+	ctx := context.Background()
+	reconcileAndGet := func(name string) *agentregistryv1alpha1.RegistryDeployment {
+		key := types.NamespacedName{Name: name, Namespace: "agentregistry"}
+		_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		var got agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, key, &got))
+		return &got
+	}
+
+	// An empty namespace deploys into the environment's cluster namespace,
+	// without rewriting the stored spec
+	got := reconcileAndGet("defaulted")
+	require.NotEmpty(t, got.Status.ManagedResources, got.Status.Message)
+	for _, res := range got.Status.ManagedResources {
+		assert.Equal(t, "ai-prod", res.Namespace, res.Kind)
+	}
+	assert.Empty(t, got.Spec.Namespace)
+
+	got = reconcileAndGet("contradicting")
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, got.Status.Phase)
+	assert.Contains(t, got.Status.Message, `namespace "team-a" is not covered by environment "prod"`)
+	assert.Empty(t, got.Status.ManagedResources)
+}
+
+func TestResolveTargetNamespaces(t *testing.T) {
+	env := &agentregistryv1alpha1.Environment{Name: "prod", Namespaces: []string{"dev", "staging"}}
+	resolve := func(namespace string, namespaces []string, env *agentregistryv1alpha1.Environment) ([]string, error) {
+		return resolveTargetNamespaces(&agentregistryv1alpha1.RegistryDeployment{
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Namespace: namespace, Namespaces: namespaces},
+		}, env)
+	}
+
+	got, err := resolve("", nil, env)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev"}, got, "without namespaces the single namespace is resolved")
+
+	got, err = resolve("", []string{"staging", "dev", "staging"}, env)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging", "dev"}, got, "duplicates are dropped, order is kept")
+
+	_, err = resolve("", []string{"dev", "team-a"}, env)
+	assert.ErrorContains(t, err, `namespace "team-a" is not covered by environment "prod"`)
+
+	got, err = resolve("", []string{"dev", "team-a"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "team-a"}, got)
+
+	_, err = resolve("dev", []string{"staging"}, nil)
+	assert.ErrorContains(t, err, "mutually exclusive")
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_MultipleNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "fanout", Namespace: "agentregistry", Finalizers: []string{finalizerName}},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "fanout-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespaces:   []string{"dev", "staging"},
+		},
+	}
+
+	// Applying a RemoteMCPServer again overwrites its status in the fake
+	// client, so readiness is reported on read instead
+	readyNamespaces := map[string]bool{}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newRemoteServerCatalog("fanout-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				gvk := obj.GetObjectKind().GroupVersionKind()
+				err := c.Patch(ctx, obj, patch, opts...)
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+				return err
+			},
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if remote, ok := obj.(*kagentv1alpha2.RemoteMCPServer); ok && readyNamespaces[key.Namespace] {
+					remote.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}}
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "fanout", Namespace: "agentregistry"}
+	reconcileAndGet := func() *agentregistryv1alpha1.RegistryDeployment {
+		t.Helper()
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+		var got agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, key, &got))
+		return &got
+	}
+	remoteNamespaces := func() []string {
+		t.Helper()
+		var remotes kagentv1alpha2.RemoteMCPServerList
+		require.NoError(t, c.List(ctx, &remotes))
+		var namespaces []string
+		for _, remote := range remotes.Items {
+			namespaces = append(namespaces, remote.Namespace)
+		}
+		return namespaces
+	}
+
+	// One RemoteMCPServer is applied into each namespace and tracked
+	got := reconcileAndGet()
+	require.Len(t, got.Status.ManagedResources, 2, got.Status.Message)
+	assert.ElementsMatch(t, []string{"dev", "staging"},
+		[]string{got.Status.ManagedResources[0].Namespace, got.Status.ManagedResources[1].Namespace})
+	assert.ElementsMatch(t, []string{"dev", "staging"}, remoteNamespaces())
+	assert.Empty(t, got.Spec.Namespace, "the stored spec is not rewritten")
+
+	// Readiness aggregates across the namespaces
+	readyNamespaces["dev"] = true
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, reconcileAndGet().Status.Phase)
+	readyNamespaces["staging"] = true
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseRunning, reconcileAndGet().Status.Phase)
+
+	// Removing a namespace deletes what was deployed into it
+	require.NoError(t, c.Get(ctx, key, got))
+	got.Spec.Namespaces = []string{"dev"}
+	require.NoError(t, c.Update(ctx, got))
+	got = reconcileAndGet()
+	require.Len(t, got.Status.ManagedResources, 1)
+	assert.Equal(t, "dev", got.Status.ManagedResources[0].Namespace)
+	assert.Equal(t, []string{"dev"}, remoteNamespaces())
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseRunning, got.Status.Phase)
+}
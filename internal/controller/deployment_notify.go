@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/safehttp"
+)
+
+// notifyTimeout bounds the delivery of a single phase notification
+const notifyTimeout = 5 * time.Second
+
+// defaultNotifyWebhookKey is the Secret key read when a deployment's
+// NotifyWebhookSecretRef sets none
+const defaultNotifyWebhookKey = "url"
+
+var (
+	// notifyHTTPClient delivers phase notifications to the webhook the
+	// operator configured on the controller
+	notifyHTTPClient = &http.Client{
+		Timeout: notifyTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	// deploymentNotifyHTTPClient delivers phase notifications to the webhook
+	// set on a deployment. Whoever can create deployments chooses that URL,
+	// so it may not reach private, loopback or link-local addresses.
+	deploymentNotifyHTTPClient = safehttp.NewClient(notifyTimeout, nil)
+)
+
+// PhaseNotification is the JSON body POSTed to a deployment's webhook when it
+// changes phase
+type PhaseNotification struct {
+	Deployment    string                                `json:"deployment"`
+	Namespace     string                                `json:"namespace"`
+	ResourceName  string                                `json:"resourceName"`
+	Version       string                                `json:"version"`
+	ResourceType  agentregistryv1alpha1.ResourceType    `json:"resourceType"`
+	Environment   string                                `json:"environment,omitempty"`
+	PreviousPhase agentregistryv1alpha1.DeploymentPhase `json:"previousPhase,omitempty"`
+	Phase         agentregistryv1alpha1.DeploymentPhase `json:"phase"`
+	Message       string                                `json:"message,omitempty"`
+	// Text summarizes the transition, so the body can be posted to a Slack
+	// incoming webhook as is
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// notifyPhaseTransition POSTs a PhaseNotification to the deployment's webhook
+// when its phase differs from previous. A phase already notified for the
+// deployment is not sent again, so a reconcile retried after a failed status
+// write does not repeat it. Delivery, including reading the webhook URL of the
+// deployment's Secret, runs in the background and failures are only logged.
+func (r *RegistryDeploymentReconciler) notifyPhaseTransition(deployment *agentregistryv1alpha1.RegistryDeployment, previous agentregistryv1alpha1.DeploymentPhase) {
+	phase := deployment.Status.Phase
+	if phase == previous {
+		return
+	}
+	secretRef := deployment.Spec.NotifyWebhookSecretRef
+	if secretRef == nil && r.NotifyWebhookURL == "" {
+		return
+	}
+	key := types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}
+	if last, ok := r.notifiedPhases.Swap(key, phase); ok && last == phase {
+		return
+	}
+
+	notification := PhaseNotification{
+		Deployment:    deployment.Name,
+		Namespace:     deployment.Namespace,
+		ResourceName:  deployment.Spec.ResourceName,
+		Version:       deployment.Spec.Version,
+		ResourceType:  deployment.Spec.ResourceType,
+		Environment:   deployment.Spec.Environment,
+		PreviousPhase: previous,
+		Phase:         phase,
+		Message:       deployment.Status.Message,
+		Text:          fmt.Sprintf("Deployment %s/%s of %s %s became %s", deployment.Namespace, deployment.Name, deployment.Spec.ResourceName, deployment.Spec.Version, phase),
+		Time:          time.Now().UTC(),
+	}
+	if notification.Message != "" {
+		notification.Text += ": " + notification.Message
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		url, httpClient := r.NotifyWebhookURL, notifyHTTPClient
+		if secretRef != nil {
+			var err error
+			if url, err = r.deploymentWebhookURL(ctx, deployment.Namespace, secretRef); err != nil {
+				r.Logger.Warn().Err(err).
+					Str("deployment", key.String()).
+					Str("phase", string(phase)).
+					Msg("failed to read phase notification webhook URL")
+				return
+			}
+			httpClient = deploymentNotifyHTTPClient
+		}
+		if err := postPhaseNotification(ctx, httpClient, url, notification); err != nil {
+			r.Logger.Warn().Err(err).
+				Str("deployment", key.String()).
+				Str("phase", string(phase)).
+				Msg("failed to deliver phase notification")
+		}
+	}()
+}
+
+// deploymentWebhookURL reads the webhook URL a deployment's
+// NotifyWebhookSecretRef selects from the Secret in namespace
+func (r *RegistryDeploymentReconciler) deploymentWebhookURL(ctx context.Context, namespace string, ref *agentregistryv1alpha1.SecretKeyRef) (string, error) {
+	key := ref.Key
+	if key == "" {
+		key = defaultNotifyWebhookKey
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.SecretName}, &secret); err != nil {
+		return "", fmt.Errorf("failed to get Secret %s/%s: %w", namespace, ref.SecretName, err)
+	}
+	raw, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %q", namespace, ref.SecretName, key)
+	}
+	u, err := safehttp.ValidateURL(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook URL in Secret %s/%s: %w", namespace, ref.SecretName, err)
+	}
+	return u.String(), nil
+}
+
+// postPhaseNotification POSTs notification as JSON to url with httpClient
+func postPhaseNotification(ctx context.Context, httpClient *http.Client, url string, notification PhaseNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
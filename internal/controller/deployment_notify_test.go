@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/safehttp"
+)
+
+// newWebhookReceiver starts an https server recording the phase notifications
+// POSTed to it. Until the test ends, notifications are delivered with a client
+// that trusts its certificate and may reach it on the loopback address.
+func newWebhookReceiver(t *testing.T) (*httptest.Server, <-chan PhaseNotification) {
+	t.Helper()
+	received := make(chan PhaseNotification, 10)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		var notification PhaseNotification
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&notification))
+		received <- notification
+	}))
+	t.Cleanup(server.Close)
+
+	notifyClient, deploymentClient := notifyHTTPClient, deploymentNotifyHTTPClient
+	notifyHTTPClient, deploymentNotifyHTTPClient = server.Client(), server.Client()
+	t.Cleanup(func() { notifyHTTPClient, deploymentNotifyHTTPClient = notifyClient, deploymentClient })
+	return server, received
+}
+
+// newWebhookSecret returns a Secret holding url under the default key
+func newWebhookSecret(name, namespace, url string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string][]byte{defaultNotifyWebhookKey: []byte(url)},
+	}
+}
+
+func receiveNotification(t *testing.T, received <-chan PhaseNotification) PhaseNotification {
+	t.Helper()
+	select {
+	case notification := <-received:
+		return notification
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+		return PhaseNotification{}
+	}
+}
+
+func assertNoNotification(t *testing.T, received <-chan PhaseNotification) {
+	t.Helper()
+	select {
+	case notification := <-received:
+		t.Fatalf("unexpected notification: %+v", notification)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNotifyPhaseTransition(t *testing.T) {
+	deploymentHook, deploymentReceived := newWebhookReceiver(t)
+	defaultHook, defaultReceived := newWebhookReceiver(t)
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newWebhookSecret("search-webhook", "agentregistry", deploymentHook.URL+"\n")).
+		Build()
+	r := &RegistryDeploymentReconciler{Client: c, Logger: zerolog.Nop(), NotifyWebhookURL: defaultHook.URL}
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName:           "org/search",
+			Version:                "1.0.0",
+			ResourceType:           agentregistryv1alpha1.ResourceTypeMCP,
+			NotifyWebhookSecretRef: &agentregistryv1alpha1.SecretKeyRef{SecretName: "search-webhook"},
+		},
+	}
+	transition := func(previous, phase agentregistryv1alpha1.DeploymentPhase, message string) {
+		deployment.Status.Phase, deployment.Status.Message = phase, message
+		r.notifyPhaseTransition(deployment, previous)
+	}
+
+	transition("", agentregistryv1alpha1.DeploymentPhasePending, "waiting for RemoteMCPServer")
+	notification := receiveNotification(t, deploymentReceived)
+	assert.Equal(t, "search", notification.Deployment)
+	assert.Equal(t, "agentregistry", notification.Namespace)
+	assert.Equal(t, "org/search", notification.ResourceName)
+	assert.Empty(t, notification.PreviousPhase)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, notification.Phase)
+	assert.Equal(t, "waiting for RemoteMCPServer", notification.Message)
+	assert.Equal(t, "Deployment agentregistry/search of org/search 1.0.0 became Pending: waiting for RemoteMCPServer", notification.Text)
+
+	// Reconciles that keep the phase send nothing
+	transition(agentregistryv1alpha1.DeploymentPhasePending, agentregistryv1alpha1.DeploymentPhasePending, "still waiting")
+	assertNoNotification(t, deploymentReceived)
+
+	transition(agentregistryv1alpha1.DeploymentPhasePending, agentregistryv1alpha1.DeploymentPhaseRunning, "")
+	notification = receiveNotification(t, deploymentReceived)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, notification.PreviousPhase)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseRunning, notification.Phase)
+
+	// A transition repeated after a failed status write is not notified again
+	transition(agentregistryv1alpha1.DeploymentPhasePending, agentregistryv1alpha1.DeploymentPhaseRunning, "")
+	assertNoNotification(t, deploymentReceived)
+
+	// A Secret that cannot be read sends nothing, not even to the
+	// controller's webhook
+	deployment.Spec.NotifyWebhookSecretRef = &agentregistryv1alpha1.SecretKeyRef{SecretName: "search-webhook", Key: "missing"}
+	transition(agentregistryv1alpha1.DeploymentPhaseRunning, agentregistryv1alpha1.DeploymentPhasePending, "")
+	assertNoNotification(t, deploymentReceived)
+	assertNoNotification(t, defaultReceived)
+
+	// Without its own webhook a deployment notifies the controller's
+	deployment.Spec.NotifyWebhookSecretRef = nil
+	transition(agentregistryv1alpha1.DeploymentPhasePending, agentregistryv1alpha1.DeploymentPhaseFailed, "image pull failed")
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, receiveNotification(t, defaultReceived).Phase)
+	assertNoNotification(t, deploymentReceived)
+}
+
+func TestDeploymentWebhookURL(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newWebhookSecret("slack", "agentregistry", "https://hooks.slack.com/services/T0/B0/x"),
+		newWebhookSecret("plain", "agentregistry", "http://hooks.example.com/notify"),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "agentregistry"},
+			Data:       map[string][]byte{"webhook": []byte("https://hooks.example.com/notify")},
+		},
+	).Build()
+	r := &RegistryDeploymentReconciler{Client: c, Logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	url, err := r.deploymentWebhookURL(ctx, "agentregistry", &agentregistryv1alpha1.SecretKeyRef{SecretName: "slack"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/services/T0/B0/x", url)
+	url, err = r.deploymentWebhookURL(ctx, "agentregistry", &agentregistryv1alpha1.SecretKeyRef{SecretName: "custom", Key: "webhook"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/notify", url)
+
+	_, err = r.deploymentWebhookURL(ctx, "agentregistry", &agentregistryv1alpha1.SecretKeyRef{SecretName: "plain"})
+	assert.ErrorContains(t, err, "only https URLs are allowed")
+	_, err = r.deploymentWebhookURL(ctx, "other", &agentregistryv1alpha1.SecretKeyRef{SecretName: "slack"})
+	assert.Error(t, err)
+}
+
+func TestPostPhaseNotification_RefusesInternalTargets(t *testing.T) {
+	hits := 0
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		hits++
+	}))
+	defer internal.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+	ctx := context.Background()
+
+	// A deployment's webhook cannot reach loopback, private or link-local
+	// addresses
+	err := postPhaseNotification(ctx, deploymentNotifyHTTPClient, internal.URL, PhaseNotification{})
+	assert.ErrorIs(t, err, safehttp.ErrBlockedAddress)
+
+	// Redirects are not followed, not even for the controller's webhook
+	err = postPhaseNotification(ctx, notifyHTTPClient, redirect.URL, PhaseNotification{})
+	assert.ErrorContains(t, err, "307")
+	assert.Zero(t, hits)
+}
+
+func TestNotifyPhaseTransition_DoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-release }))
+	defer slow.Close()
+	defer close(release)
+
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop(), NotifyWebhookURL: slow.URL}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow", Namespace: "agentregistry"},
+		Status:     agentregistryv1alpha1.RegistryDeploymentStatus{Phase: agentregistryv1alpha1.DeploymentPhaseRunning},
+	}
+
+	start := time.Now()
+	r.notifyPhaseTransition(deployment, agentregistryv1alpha1.DeploymentPhasePending)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_NotifiesPhaseTransitions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	hook, received := newWebhookReceiver(t)
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify-server", Namespace: "default", Finalizers: []string{finalizerName}},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName:           "notify-server",
+			Version:                "1.0.0",
+			ResourceType:           agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:                agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:              "target-ns",
+			NotifyWebhookSecretRef: &agentregistryv1alpha1.SecretKeyRef{SecretName: "notify-webhook"},
+		},
+	}
+
+	// Applying the RemoteMCPServer again overwrites its status in the fake
+	// client, so readiness is reported on read instead
+	remoteReady := false
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newRemoteServerCatalog("notify-server", "1.0.0"), newWebhookSecret("notify-webhook", "default", hook.URL)).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if remote, ok := obj.(*kagentv1alpha2.RemoteMCPServer); ok && remoteReady {
+					remote.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}}
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "notify-server", Namespace: "default"}}
+	reconcileDeployment := func() {
+		t.Helper()
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+	}
+
+	reconcileDeployment()
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, receiveNotification(t, received).Phase)
+
+	reconcileDeployment()
+	assertNoNotification(t, received)
+
+	remoteReady = true
+	reconcileDeployment()
+	notification := receiveNotification(t, received)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, notification.PreviousPhase)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseRunning, notification.Phase)
+}
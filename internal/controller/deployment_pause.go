@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// pauseDeployment removes the managed resources of a paused deployment. The
+// deployment, its config and its finalizer are kept so unpausing applies the
+// resources again. Resources that could not be removed, or whose cluster
+// cannot be reached, stay tracked and are retried on the next reconcile.
+func (r *RegistryDeploymentReconciler) pauseDeployment(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	if len(deployment.Status.ManagedResources) == 0 {
+		return nil
+	}
+
+	targets, err := r.resolveTargets(ctx, deployment)
+	var errs []error
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve target to remove managed resources: %w", err))
+	}
+
+	var remaining []agentregistryv1alpha1.ManagedResource
+	for _, res := range deployment.Status.ManagedResources {
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			remaining = append(remaining, res)
+			continue
+		}
+		if err := r.deleteObj(ctx, target.mcpURL, target.client, res); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s %s: %w", res.Kind, res.Name, err))
+			remaining = append(remaining, res)
+			continue
+		}
+		r.Logger.Info().Str("deployment", deployment.Name).Str("kind", res.Kind).Str("name", res.Name).
+			Str("namespace", res.Namespace).Msg("deleted resource of paused deployment")
+	}
+	deployment.Status.ManagedResources = remaining
+	return errors.Join(errs...)
+}
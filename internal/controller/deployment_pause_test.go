@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestRegistryDeploymentReconciler_Reconcile_PauseAndResume(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "paused-server",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "paused-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "target-ns",
+			Config:       map[string]string{"LOG_LEVEL": "debug"},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newRemoteServerCatalog("paused-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		// The fake client clears TypeMeta on typed objects after a patch; keep
+		// it so managed resources are recorded with their kind.
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				gvk := obj.GetObjectKind().GroupVersionKind()
+				err := c.Patch(ctx, obj, patch, opts...)
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+				return err
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "paused-server", Namespace: "default"}}
+	reconcileDeployment := func() (agentregistryv1alpha1.RegistryDeployment, reconcile.Result) {
+		t.Helper()
+		result, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		var updated agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, req.NamespacedName, &updated))
+		return updated, result
+	}
+	remoteServers := func() []kagentv1alpha2.RemoteMCPServer {
+		t.Helper()
+		var remotes kagentv1alpha2.RemoteMCPServerList
+		require.NoError(t, c.List(ctx, &remotes, client.InNamespace("target-ns")))
+		return remotes.Items
+	}
+
+	updated, _ := reconcileDeployment()
+	require.Len(t, updated.Status.ManagedResources, 1)
+	require.Len(t, remoteServers(), 1)
+
+	// Pausing removes the resources but keeps the deployment and its config
+	updated.Spec.Paused = true
+	updated.Generation = 2
+	require.NoError(t, c.Update(ctx, &updated))
+	updated, result := reconcileDeployment()
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePaused, updated.Status.Phase)
+	assert.Empty(t, updated.Status.ManagedResources)
+	assert.Empty(t, remoteServers())
+	assert.Zero(t, result.RequeueAfter, "a paused deployment is not polled")
+	assert.Contains(t, updated.Finalizers, finalizerName)
+	assert.Equal(t, "debug", updated.Spec.Config["LOG_LEVEL"])
+	assert.Contains(t, timelineMessages(updated.Status), "became Paused")
+
+	// Resuming applies them again
+	updated.Spec.Paused = false
+	updated.Generation = 3
+	require.NoError(t, c.Update(ctx, &updated))
+	updated, _ = reconcileDeployment()
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, updated.Status.Phase)
+	require.Len(t, updated.Status.ManagedResources, 1)
+	assert.Len(t, remoteServers(), 1)
+}
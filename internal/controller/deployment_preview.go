@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+)
+
+var (
+	// ErrPreviewCatalogNotFound is returned by RenderDeployment when the
+	// referenced catalog entry version does not exist.
+	ErrPreviewCatalogNotFound = errors.New("catalog entry not found")
+	// ErrPreviewInvalid is returned by RenderDeployment when the deployment
+	// cannot be rendered, e.g. because translation fails.
+	ErrPreviewInvalid = errors.New("deployment cannot be rendered")
+
+	errReadOnlyClient      = errors.New("writes are not allowed while rendering a deployment")
+	errReadOnlySubResource = errors.New("subresources cannot be read while rendering a deployment")
+)
+
+// previewScheme holds the types a rendered deployment is made of
+var previewScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(previewScheme))
+	utilruntime.Must(agentregistryv1alpha1.AddToScheme(previewScheme))
+	utilruntime.Must(kagentv1alpha2.AddToScheme(previewScheme))
+	utilruntime.Must(kmcpv1alpha1.AddToScheme(previewScheme))
+}
+
+// readOnlyClient serves the reads of the reconciler's translation from a
+// client.Reader, such as the API server's cache, and refuses writes, so
+// rendering a deployment cannot change anything
+type readOnlyClient struct {
+	reader client.Reader
+}
+
+var _ client.Client = readOnlyClient{}
+
+func (c readOnlyClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c readOnlyClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func (readOnlyClient) Apply(context.Context, runtime.ApplyConfiguration, ...client.ApplyOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlyClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlyClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlyClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlyClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlyClient) DeleteAllOf(context.Context, client.Object, ...client.DeleteAllOfOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlyClient) Status() client.SubResourceWriter {
+	return readOnlySubResourceClient{}
+}
+
+func (readOnlyClient) SubResource(string) client.SubResourceClient {
+	return readOnlySubResourceClient{}
+}
+
+func (readOnlyClient) Scheme() *runtime.Scheme {
+	return previewScheme
+}
+
+// RESTMapper returns a mapper without mappings: rendering does not reach the
+// API server to discover them
+func (readOnlyClient) RESTMapper() meta.RESTMapper {
+	return meta.NewDefaultRESTMapper(nil)
+}
+
+func (c readOnlyClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return apiutil.GVKForObject(obj, c.Scheme())
+}
+
+func (c readOnlyClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return apiutil.IsObjectNamespaced(obj, c.Scheme(), c.RESTMapper())
+}
+
+// readOnlySubResourceClient refuses the status and other subresource reads and
+// writes of a readOnlyClient: the reader it serves reads from has none
+type readOnlySubResourceClient struct{}
+
+func (readOnlySubResourceClient) Get(context.Context, client.Object, client.Object, ...client.SubResourceGetOption) error {
+	return errReadOnlySubResource
+}
+
+func (readOnlySubResourceClient) Create(context.Context, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlySubResourceClient) Update(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return errReadOnlyClient
+}
+
+func (readOnlySubResourceClient) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+	return errReadOnlyClient
+}
+
+// RenderDeployment returns the Kubernetes resources the RegistryDeployment
+// reconciler would apply for deployment, without applying anything. The
+// resources carry the same ownership labels as applied ones.
+func RenderDeployment(ctx context.Context, reader client.Reader, deployment *agentregistryv1alpha1.RegistryDeployment) ([]client.Object, error) {
+	if err := validateResourceMetadata(deployment); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPreviewInvalid, err)
+	}
+
+	r := &RegistryDeploymentReconciler{Client: readOnlyClient{reader: reader}}
+	var runtimeConfig *api.AIRuntimeConfig
+
+	switch deployment.Spec.ResourceType {
+	case agentregistryv1alpha1.ResourceTypeMCP:
+		var list agentregistryv1alpha1.MCPServerCatalogList
+		if err := reader.List(ctx, &list, client.MatchingFields{IndexMCPServerName: deployment.Spec.ResourceName}); err != nil {
+			return nil, fmt.Errorf("failed to list MCP servers: %w", err)
+		}
+		var catalogEntry *agentregistryv1alpha1.MCPServerCatalog
+		for i := range list.Items {
+			if list.Items[i].Spec.Version == deployment.Spec.Version {
+				catalogEntry = &list.Items[i]
+				break
+			}
+		}
+		if catalogEntry == nil {
+			return nil, fmt.Errorf("%w: MCP server %s version %s", ErrPreviewCatalogNotFound, deployment.Spec.ResourceName, deployment.Spec.Version)
+		}
+		if err := r.checkPublisherIdentity(deployment, catalogEntry.Spec.Metadata); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPreviewInvalid, err)
+		}
+		if err := r.checkSBOMAttestation(ctx, catalogEntry); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPreviewInvalid, err)
+		}
+
+		var err error
+		if runtimeConfig, err = r.translateMCPServer(ctx, catalogEntry, deployment); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPreviewInvalid, err)
+		}
+
+	case agentregistryv1alpha1.ResourceTypeAgent:
+		var list agentregistryv1alpha1.AgentCatalogList
+		if err := reader.List(ctx, &list, client.MatchingFields{IndexAgentName: deployment.Spec.ResourceName}); err != nil {
+			return nil, fmt.Errorf("failed to list agents: %w", err)
+		}
+		var catalogEntry *agentregistryv1alpha1.AgentCatalog
+		for i := range list.Items {
+			if list.Items[i].Spec.Version == deployment.Spec.Version {
+				catalogEntry = &list.Items[i]
+				break
+			}
+		}
+		if catalogEntry == nil {
+			return nil, fmt.Errorf("%w: agent %s version %s", ErrPreviewCatalogNotFound, deployment.Spec.ResourceName, deployment.Spec.Version)
+		}
+		if err := r.checkPublisherIdentity(deployment, catalogEntry.Spec.Metadata); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPreviewInvalid, err)
+		}
+
+		// The target cluster is not reached while rendering, so the
+		// MCPServers of the agent's package servers are not checked
+		var err error
+		if runtimeConfig, err = r.translateAgent(ctx, catalogEntry, deployment, nil); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPreviewInvalid, err)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: unknown resource type: %s", ErrPreviewInvalid, deployment.Spec.ResourceType)
+	}
+
+	// Same order in which the reconciler applies the resources.
+	var objs []client.Object
+	for _, sa := range runtimeConfig.Kubernetes.ServiceAccounts {
+		objs = append(objs, sa)
+	}
+	for _, cm := range runtimeConfig.Kubernetes.ConfigMaps {
+		objs = append(objs, cm)
+	}
+	for _, agent := range runtimeConfig.Kubernetes.Agents {
+		objs = append(objs, agent)
+	}
+	for _, mcpServer := range runtimeConfig.Kubernetes.MCPServers {
+		objs = append(objs, mcpServer)
+	}
+	for _, remoteMCP := range runtimeConfig.Kubernetes.RemoteMCPServers {
+		objs = append(objs, remoteMCP)
+	}
+	for _, repo := range runtimeConfig.Kubernetes.HelmRepositories {
+		objs = append(objs, repo)
+	}
+	for _, release := range runtimeConfig.Kubernetes.HelmReleases {
+		objs = append(objs, release)
+	}
+	for _, obj := range objs {
+		r.setOwnerLabels(obj, deployment)
+	}
+	return objs, nil
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	catalog := &agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"}}
+	c := readOnlyClient{reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(catalog).Build()}
+	ctx := context.Background()
+
+	// Reads are served from the reader
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(catalog), &agentregistryv1alpha1.MCPServerCatalog{}))
+
+	// Writes, including status and subresource writes, are refused
+	assert.ErrorIs(t, c.Create(ctx, &corev1.ConfigMap{}), errReadOnlyClient)
+	assert.ErrorIs(t, c.Status().Update(ctx, catalog), errReadOnlyClient)
+	assert.ErrorIs(t, c.SubResource("scale").Patch(ctx, catalog, client.Merge), errReadOnlyClient)
+	assert.ErrorIs(t, c.SubResource("status").Get(ctx, catalog, &agentregistryv1alpha1.MCPServerCatalog{}), errReadOnlySubResource)
+
+	// Type lookups resolve against the rendered types
+	gvk, err := c.GroupVersionKindFor(&kmcpv1alpha1.MCPServer{})
+	require.NoError(t, err)
+	assert.Equal(t, "MCPServer", gvk.Kind)
+	assert.NotNil(t, c.RESTMapper())
+	_, err = c.IsObjectNamespaced(&kmcpv1alpha1.MCPServer{})
+	assert.Error(t, err)
+}
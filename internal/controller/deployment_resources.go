@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// ErrManagedResourcesNotReadable is returned by GetManagedResources for
+// deployments whose resources are applied through an environment's MCP tool
+// server, which offers no way to read them back.
+var ErrManagedResourcesNotReadable = errors.New("managed resources cannot be read back from an MCP tool server target")
+
+// LiveManagedResource is the live state of one of a deployment's managed
+// resources in its target cluster
+type LiveManagedResource struct {
+	agentregistryv1alpha1.ManagedResource
+	// Object is the live object, nil when it could not be read
+	Object map[string]any
+	// Missing is set when the resource no longer exists
+	Missing bool
+	// Error describes why the resource could not be read
+	Error string
+}
+
+// GetManagedResources fetches the live objects of a deployment's managed
+// resources from its target cluster, in the order of
+// Status.ManagedResources. A resource that is gone, cannot be read or is on a
+// cluster that cannot be reached is reported on its entry; the call only fails
+// when no target can be reached.
+func GetManagedResources(ctx context.Context, c client.Client, logger zerolog.Logger, deployment *agentregistryv1alpha1.RegistryDeployment) ([]LiveManagedResource, error) {
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: c.Scheme(), Logger: logger}
+	targets, resolveErr := r.resolveTargets(ctx, deployment)
+	if resolveErr != nil && len(targets) == 0 {
+		return nil, resolveErr
+	}
+	for _, target := range targets {
+		if target.mcpURL != "" {
+			return nil, fmt.Errorf("%w: environment %q is managed through %s", ErrManagedResourcesNotReadable, target.env.Name, target.mcpURL)
+		}
+	}
+
+	resources := make([]LiveManagedResource, 0, len(deployment.Status.ManagedResources))
+	for _, res := range deployment.Status.ManagedResources {
+		live := LiveManagedResource{ManagedResource: res}
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			live.Error = fmt.Sprintf("cluster %q cannot be reached", res.Cluster)
+			if resolveErr != nil {
+				live.Error = resolveErr.Error()
+			}
+			resources = append(resources, live)
+			continue
+		}
+		obj, err := managedResourceObject(res)
+		if err == nil {
+			err = target.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			live.Missing = true
+		case err != nil:
+			live.Error = err.Error()
+		default:
+			if live.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+				live.Error = fmt.Sprintf("failed to convert object: %v", err)
+				break
+			}
+			// Typed objects read through the client carry no type metadata
+			live.Object["apiVersion"] = res.APIVersion
+			live.Object["kind"] = res.Kind
+		}
+		resources = append(resources, live)
+	}
+	return resources, nil
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+const (
+	// AppliedSpecAnnotation records the deployment spec that was last reconciled successfully.
+	AppliedSpecAnnotation = "agentregistry.dev/applied-spec"
+	// PreviousSpecAnnotation records the successfully applied spec that preceded the
+	// current one. RollbackDeployment restores it.
+	PreviousSpecAnnotation = "agentregistry.dev/previous-spec"
+)
+
+// DeploymentSpecSnapshot is the subset of a RegistryDeployment spec that rollback restores.
+type DeploymentSpecSnapshot struct {
+	Version string            `json:"version"`
+	Config  map[string]string `json:"config,omitempty"`
+}
+
+func snapshotDeploymentSpec(deployment *agentregistryv1alpha1.RegistryDeployment) DeploymentSpecSnapshot {
+	return DeploymentSpecSnapshot{
+		Version: deployment.Spec.Version,
+		Config:  maps.Clone(deployment.Spec.Config),
+	}
+}
+
+func (s DeploymentSpecSnapshot) equal(other DeploymentSpecSnapshot) bool {
+	return s.Version == other.Version && maps.Equal(s.Config, other.Config)
+}
+
+// readSpecSnapshot decodes a snapshot annotation. The bool is false when the
+// annotation is absent or cannot be decoded.
+func readSpecSnapshot(deployment *agentregistryv1alpha1.RegistryDeployment, key string) (DeploymentSpecSnapshot, bool) {
+	raw, ok := deployment.Annotations[key]
+	if !ok || raw == "" {
+		return DeploymentSpecSnapshot{}, false
+	}
+	var snap DeploymentSpecSnapshot
+	if err := json.Unmarshal([]byte(raw), &snap); err != nil {
+		return DeploymentSpecSnapshot{}, false
+	}
+	return snap, true
+}
+
+func writeSpecSnapshot(deployment *agentregistryv1alpha1.RegistryDeployment, key string, snap DeploymentSpecSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec snapshot: %w", err)
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = make(map[string]string)
+	}
+	deployment.Annotations[key] = string(data)
+	return nil
+}
+
+// recordAppliedSpec persists the spec that was just reconciled successfully.
+// When it differs from the previously applied spec, the older one is shifted
+// into the previous-spec annotation so it can be rolled back to later.
+func (r *RegistryDeploymentReconciler) recordAppliedSpec(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	current := snapshotDeploymentSpec(deployment)
+	applied, hasApplied := readSpecSnapshot(deployment, AppliedSpecAnnotation)
+	if hasApplied && applied.equal(current) {
+		return nil
+	}
+
+	patch := client.MergeFrom(deployment.DeepCopy())
+	if hasApplied {
+		if err := writeSpecSnapshot(deployment, PreviousSpecAnnotation, applied); err != nil {
+			return err
+		}
+	}
+	if err := writeSpecSnapshot(deployment, AppliedSpecAnnotation, current); err != nil {
+		return err
+	}
+	return r.Patch(ctx, deployment, patch)
+}
+
+// RollbackDeployment rewrites the deployment spec in place to the previously
+// applied version and config. If the current spec never reconciled
+// successfully, the last applied spec is restored instead. The spec being
+// replaced is stored as the new previous spec, so a second rollback undoes the
+// first. Callers persist the object; the spec change triggers a reconcile.
+func RollbackDeployment(deployment *agentregistryv1alpha1.RegistryDeployment) (DeploymentSpecSnapshot, error) {
+	current := snapshotDeploymentSpec(deployment)
+
+	target, ok := readSpecSnapshot(deployment, AppliedSpecAnnotation)
+	if !ok || target.equal(current) {
+		target, ok = readSpecSnapshot(deployment, PreviousSpecAnnotation)
+	}
+	if !ok || target.equal(current) {
+		return DeploymentSpecSnapshot{}, fmt.Errorf("no previous spec recorded for deployment %s", deployment.Name)
+	}
+
+	if err := writeSpecSnapshot(deployment, PreviousSpecAnnotation, current); err != nil {
+		return DeploymentSpecSnapshot{}, err
+	}
+	deployment.Spec.Version = target.Version
+	deployment.Spec.Config = maps.Clone(target.Config)
+	return target, nil
+}
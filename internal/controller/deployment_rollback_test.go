@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+)
+
+const verifiedPublisherMetadata = `{"io.modelcontextprotocol.registry/publisher-provided":{"aregistry.ai/metadata":{"identity":{"org_is_verified":true,"publisher_identity_verified_by_jwt":true}}}}`
+
+func newRemoteServerCatalog(name, version string) *agentregistryv1alpha1.MCPServerCatalog {
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-" + version,
+			Namespace: "default",
+		},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:     name,
+			Version:  version,
+			Metadata: &apiextensionsv1.JSON{Raw: []byte(verifiedPublisherMetadata)},
+			Remotes: []agentregistryv1alpha1.Transport{
+				{Type: "streamable-http", URL: "https://example.com/mcp"},
+			},
+		},
+	}
+}
+
+func TestRegistryDeploymentReconciler_RollbackRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "rollback-server",
+			Namespace:  "default",
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "rollback-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Config:       map[string]string{"LOG_LEVEL": "info"},
+			Namespace:    "target-ns",
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(
+			deployment,
+			newRemoteServerCatalog("rollback-server", "1.0.0"),
+			newRemoteServerCatalog("rollback-server", "2.0.0"),
+		).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rollback-server", Namespace: "default"}
+	reconcileAndGet := func() *agentregistryv1alpha1.RegistryDeployment {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+		var got agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, key, &got))
+		return &got
+	}
+
+	// First successful reconcile records 1.0.0 as applied; nothing to roll back to yet.
+	got := reconcileAndGet()
+	applied, ok := readSpecSnapshot(got, AppliedSpecAnnotation)
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", applied.Version)
+	_, ok = readSpecSnapshot(got, PreviousSpecAnnotation)
+	assert.False(t, ok)
+	_, err := RollbackDeployment(got.DeepCopy())
+	assert.Error(t, err)
+
+	// Bump the version and config; 1.0.0 becomes the previous spec.
+	got.Spec.Version = "2.0.0"
+	got.Spec.Config = map[string]string{"LOG_LEVEL": "debug"}
+	require.NoError(t, c.Update(ctx, got))
+	got = reconcileAndGet()
+	previous, ok := readSpecSnapshot(got, PreviousSpecAnnotation)
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", previous.Version)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info"}, previous.Config)
+
+	// Roll back: spec returns to 1.0.0 and 2.0.0 becomes the previous spec.
+	target, err := RollbackDeployment(got)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", target.Version)
+	require.NoError(t, c.Update(ctx, got))
+	got = reconcileAndGet()
+	assert.Equal(t, "1.0.0", got.Spec.Version)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info"}, got.Spec.Config)
+	previous, ok = readSpecSnapshot(got, PreviousSpecAnnotation)
+	require.True(t, ok)
+	assert.Equal(t, "2.0.0", previous.Version)
+	applied, ok = readSpecSnapshot(got, AppliedSpecAnnotation)
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", applied.Version)
+
+	// Rolling back again swaps forward to 2.0.0.
+	target, err = RollbackDeployment(got)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", target.Version)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, got.Spec.Config)
+}
+
+func TestRollbackDeployment_RestoresLastAppliedWhenCurrentNeverApplied(t *testing.T) {
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken"},
+		Spec:       agentregistryv1alpha1.RegistryDeploymentSpec{Version: "3.0.0"},
+	}
+	require.NoError(t, writeSpecSnapshot(deployment, AppliedSpecAnnotation, DeploymentSpecSnapshot{Version: "2.0.0"}))
+	require.NoError(t, writeSpecSnapshot(deployment, PreviousSpecAnnotation, DeploymentSpecSnapshot{Version: "1.0.0"}))
+
+	target, err := RollbackDeployment(deployment)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", target.Version)
+	assert.Equal(t, "2.0.0", deployment.Spec.Version)
+
+	previous, ok := readSpecSnapshot(deployment, PreviousSpecAnnotation)
+	require.True(t, ok)
+	assert.Equal(t, "3.0.0", previous.Version)
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
+)
+
+// deploymentTarget is a cluster a deployment's resources are applied to and
+// how to reach it: a client, or the MCP tool server managing it
+type deploymentTarget struct {
+	// env is nil for the local cluster
+	env     *agentregistryv1alpha1.Environment
+	client  client.Client
+	cluster string
+	mcpURL  string
+	// retired is set for the cluster of an environment the deployment no
+	// longer lists, which is only reached to delete what is left there
+	retired bool
+}
+
+// deploymentTargets are the targets of a deployment, its listed environments
+// first and in order
+type deploymentTargets []deploymentTarget
+
+// find returns the target for cluster, the cluster name recorded on a
+// ManagedResource
+func (t deploymentTargets) find(cluster string) (deploymentTarget, bool) {
+	for _, target := range t {
+		if target.cluster == cluster {
+			return target, true
+		}
+	}
+	return deploymentTarget{}, false
+}
+
+// active returns the targets objs rendered for a deployment are applied to
+// and its stale resources pruned on: those rendered, listed in rendered, and
+// the retired ones. A listed environment skipped this reconcile is left out so
+// what was deployed there is kept.
+func (t deploymentTargets) active(rendered []string) deploymentTargets {
+	var active deploymentTargets
+	for _, target := range t {
+		if target.retired || slices.Contains(rendered, target.cluster) {
+			active = append(active, target)
+		}
+	}
+	return active
+}
+
+// deploymentEnvironments returns the names of the environments deployment
+// deploys into: each of Spec.Environments, or else Spec.Environment, which is
+// empty for the local cluster
+func deploymentEnvironments(deployment *agentregistryv1alpha1.RegistryDeployment) []string {
+	if len(deployment.Spec.Environments) == 0 {
+		return []string{deployment.Spec.Environment}
+	}
+	var environments []string
+	for _, env := range deployment.Spec.Environments {
+		if !slices.Contains(environments, env) {
+			environments = append(environments, env)
+		}
+	}
+	return environments
+}
+
+// resolveTargets resolves the target of each environment deployment deploys
+// into. Each is resolved on its own: one that cannot be, e.g. removed from its
+// DiscoveryConfig or unreachable, is left out and its error returned joined
+// with the others' alongside the targets that did resolve. Callers must leave
+// the resources on a cluster with no target alone rather than reach them
+// through another target.
+//
+// The clusters of its managed resources that no listed environment targets,
+// e.g. of an environment removed from Spec.Environments, are added as retired
+// targets when a DiscoveryConfig still declares them, so what is left there
+// can be deleted.
+func (r *RegistryDeploymentReconciler) resolveTargets(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) (deploymentTargets, error) {
+	if deployment.Spec.Environment != "" && len(deployment.Spec.Environments) > 0 {
+		return nil, fmt.Errorf("environment and environments are mutually exclusive")
+	}
+	environments := deploymentEnvironments(deployment)
+	var targets deploymentTargets
+	var errs []error
+	for _, name := range environments {
+		target, err := r.resolveTarget(ctx, deployment.Namespace, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve target: %w", err))
+			continue
+		}
+		targets = append(targets, target)
+	}
+
+	for _, res := range deployment.Status.ManagedResources {
+		if _, ok := targets.find(res.Cluster); ok {
+			continue
+		}
+		if res.Cluster == "" {
+			// Left on the local cluster by an earlier Spec.Environment
+			targets = append(targets, deploymentTarget{client: r.Client, retired: true})
+			continue
+		}
+		env, err := findClusterEnvironment(ctx, r.Client, deployment.Namespace, res.Cluster)
+		if err != nil || env == nil || slices.Contains(environments, env.Name) {
+			// Left tracked until its cluster can be reached again
+			continue
+		}
+		target, err := r.environmentTarget(env)
+		if err != nil {
+			r.Logger.Warn().Err(err).Str("deployment", deployment.Name).Str("cluster", res.Cluster).
+				Msg("cannot reach cluster of a removed environment")
+			continue
+		}
+		target.retired = true
+		targets = append(targets, target)
+	}
+	return targets, errors.Join(errs...)
+}
+
+// resolveTarget resolves the environment named envName for a deployment in
+// namespace. An empty envName is the local cluster. Otherwise the environment
+// is looked up in the DiscoveryConfig resources and reached through its MCP
+// tool server or a remote client.
+func (r *RegistryDeploymentReconciler) resolveTarget(ctx context.Context, namespace, envName string) (_ deploymentTarget, err error) {
+	if envName == "" {
+		return deploymentTarget{client: r.Client}, nil
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "RegistryDeployment.resolveTarget",
+		trace.WithAttributes(tracing.KeyEnvironment.String(envName)))
+	defer func() { tracing.End(span, err) }()
+
+	// The API checks the environment when the deployment is created; check
+	// again in case it was removed since. An environment that had deployment
+	// disabled since is still reached, to clean up what is deployed there.
+	env, _, err := findEnvironment(ctx, r.Client, namespace, envName)
+	if err != nil {
+		return deploymentTarget{}, err
+	}
+	return r.environmentTarget(env)
+}
+
+// checkDeployEnabled returns an error wrapping ErrEnvironmentDeployDisabled
+// when target's environment no longer allows deployments. The local cluster
+// always does.
+func (t deploymentTarget) checkDeployEnabled() error {
+	if t.env != nil && !t.env.DeployEnabled {
+		return fmt.Errorf("%w: %q has deployEnabled false", ErrEnvironmentDeployDisabled, t.env.Name)
+	}
+	return nil
+}
+
+// environmentTarget returns the target reaching env's cluster
+func (r *RegistryDeploymentReconciler) environmentTarget(env *agentregistryv1alpha1.Environment) (deploymentTarget, error) {
+	target := deploymentTarget{env: env, cluster: env.Cluster.Name, mcpURL: env.MCPToolServerURL}
+	// If MCP tool server is available, we don't need a K8s client
+	if target.mcpURL != "" {
+		return target, nil
+	}
+
+	factory := r.RemoteClientFactory
+	if factory == nil {
+		factory = RemoteClientFactory
+	}
+	if factory == nil {
+		return deploymentTarget{}, fmt.Errorf("remote client factory not configured, cannot deploy to environment %q", env.Name)
+	}
+	remoteClient, err := factory(env, r.Scheme)
+	if err != nil {
+		return deploymentTarget{}, fmt.Errorf("failed to create remote client for environment %q: %w", env.Name, err)
+	}
+	target.client = remoteClient
+	return target, nil
+}
+
+// findClusterEnvironment returns the environment declared by a DiscoveryConfig
+// in namespace whose cluster is named cluster, or nil when there is none
+func findClusterEnvironment(ctx context.Context, reader client.Reader, namespace, cluster string) (*agentregistryv1alpha1.Environment, error) {
+	var dcList agentregistryv1alpha1.DiscoveryConfigList
+	if err := reader.List(ctx, &dcList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list DiscoveryConfigs: %w", err)
+	}
+	for i := range dcList.Items {
+		for j := range dcList.Items[i].Spec.Environments {
+			if env := &dcList.Items[i].Spec.Environments[j]; env.Cluster.Name == cluster {
+				return env, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestDeploymentEnvironments(t *testing.T) {
+	environments := func(environment string, environments ...string) []string {
+		return deploymentEnvironments(&agentregistryv1alpha1.RegistryDeployment{
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Environment: environment, Environments: environments},
+		})
+	}
+	assert.Equal(t, []string{""}, environments(""), "the local cluster")
+	assert.Equal(t, []string{"prod"}, environments("prod"))
+	assert.Equal(t, []string{"staging", "dev"}, environments("", "staging", "dev", "staging"), "duplicates are dropped, order is kept")
+
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	_, err := r.resolveTargets(context.Background(), &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Environment: "dev", Environments: []string{"staging"}},
+	})
+	assert.ErrorContains(t, err, "mutually exclusive")
+}
+
+// multiEnvironmentFixture is a RegistryDeployment of an MCP server into the
+// dev and staging environments, each a cluster with its own fake client
+type multiEnvironmentFixture struct {
+	c       client.Client
+	r       *RegistryDeploymentReconciler
+	remotes map[string]client.WithWatch
+	key     types.NamespacedName
+}
+
+func newMultiEnvironmentFixture(t *testing.T) *multiEnvironmentFixture {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "rollout", Namespace: "agentregistry", Finalizers: []string{finalizerName}},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "rollout-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Environments: []string{"dev", "staging"},
+		},
+	}
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-kind", Namespace: "ai-dev"}, DeployEnabled: true},
+				{Name: "staging", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "staging-gke", Namespace: "ai-staging"}, DeployEnabled: true},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, discovery, newRemoteServerCatalog("rollout-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	// Each environment's cluster is its own client
+	newRemote := func() client.WithWatch {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					gvk := obj.GetObjectKind().GroupVersionKind()
+					err := c.Patch(ctx, obj, patch, opts...)
+					obj.GetObjectKind().SetGroupVersionKind(gvk)
+					return err
+				},
+			}).
+			Build()
+	}
+	remotes := map[string]client.WithWatch{"dev": newRemote(), "staging": newRemote()}
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+		RemoteClientFactory: func(env *agentregistryv1alpha1.Environment, _ *runtime.Scheme) (client.WithWatch, error) {
+			return remotes[env.Name], nil
+		},
+	}
+	return &multiEnvironmentFixture{c: c, r: r, remotes: remotes, key: types.NamespacedName{Name: "rollout", Namespace: "agentregistry"}}
+}
+
+// reconcile reconciles the deployment and returns it, or nil once it is gone
+func (f *multiEnvironmentFixture) reconcile(t *testing.T) (*agentregistryv1alpha1.RegistryDeployment, reconcile.Result, error) {
+	t.Helper()
+	result, reconcileErr := f.r.Reconcile(context.Background(), reconcile.Request{NamespacedName: f.key})
+	var got agentregistryv1alpha1.RegistryDeployment
+	if err := f.c.Get(context.Background(), f.key, &got); err != nil {
+		require.True(t, apierrors.IsNotFound(err), err)
+		return nil, result, reconcileErr
+	}
+	return &got, result, reconcileErr
+}
+
+// remoteNamespaces returns the namespaces of the RemoteMCPServers in env's
+// cluster
+func (f *multiEnvironmentFixture) remoteNamespaces(t *testing.T, env string) []string {
+	t.Helper()
+	var list kagentv1alpha2.RemoteMCPServerList
+	require.NoError(t, f.remotes[env].List(context.Background(), &list))
+	var namespaces []string
+	for _, remote := range list.Items {
+		namespaces = append(namespaces, remote.Namespace)
+	}
+	return namespaces
+}
+
+// setEnvironments replaces the environments declared by the DiscoveryConfig
+func (f *multiEnvironmentFixture) setEnvironments(t *testing.T, environments ...agentregistryv1alpha1.Environment) {
+	t.Helper()
+	var discovery agentregistryv1alpha1.DiscoveryConfig
+	require.NoError(t, f.c.Get(context.Background(), types.NamespacedName{Name: "discovery", Namespace: "agentregistry"}, &discovery))
+	discovery.Spec.Environments = environments
+	require.NoError(t, f.c.Update(context.Background(), &discovery))
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_MultipleEnvironments(t *testing.T) {
+	f := newMultiEnvironmentFixture(t)
+	ctx := context.Background()
+
+	// A RemoteMCPServer is applied into each environment's cluster, in its
+	// namespace, and tracked with the cluster it runs on
+	got, _, err := f.reconcile(t)
+	require.NoError(t, err)
+	require.Len(t, got.Status.ManagedResources, 2, got.Status.Message)
+	clusters := map[string]string{}
+	for _, res := range got.Status.ManagedResources {
+		assert.Equal(t, "RemoteMCPServer", res.Kind)
+		clusters[res.Cluster] = res.Namespace
+	}
+	assert.Equal(t, map[string]string{"dev-kind": "ai-dev", "staging-gke": "ai-staging"}, clusters)
+	assert.Equal(t, []string{"ai-dev"}, f.remoteNamespaces(t, "dev"))
+	assert.Equal(t, []string{"ai-staging"}, f.remoteNamespaces(t, "staging"))
+	var local kagentv1alpha2.RemoteMCPServerList
+	require.NoError(t, f.c.List(ctx, &local))
+	assert.Empty(t, local.Items, "nothing is deployed into the local cluster")
+
+	// Removing an environment deletes what was deployed into it
+	got.Spec.Environments = []string{"dev"}
+	require.NoError(t, f.c.Update(ctx, got))
+	got, _, err = f.reconcile(t)
+	require.NoError(t, err)
+	require.Len(t, got.Status.ManagedResources, 1)
+	assert.Equal(t, "dev-kind", got.Status.ManagedResources[0].Cluster)
+	assert.Equal(t, []string{"ai-dev"}, f.remoteNamespaces(t, "dev"))
+	assert.Empty(t, f.remoteNamespaces(t, "staging"))
+
+	// Deleting the deployment removes its resources from every cluster
+	got.Spec.Environments = []string{"dev", "staging"}
+	require.NoError(t, f.c.Update(ctx, got))
+	got, _, err = f.reconcile(t)
+	require.NoError(t, err)
+	require.Len(t, got.Status.ManagedResources, 2)
+	require.NoError(t, f.c.Delete(ctx, got))
+	got, result, err := f.reconcile(t)
+	require.NoError(t, err)
+	assert.Nil(t, got, "the finalizer is removed")
+	assert.Zero(t, result.RequeueAfter)
+	assert.Empty(t, f.remoteNamespaces(t, "dev"))
+	assert.Empty(t, f.remoteNamespaces(t, "staging"))
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_EnvironmentUnavailable(t *testing.T) {
+	f := newMultiEnvironmentFixture(t)
+	ctx := context.Background()
+	dev := agentregistryv1alpha1.Environment{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-kind", Namespace: "ai-dev"}, DeployEnabled: true}
+	staging := agentregistryv1alpha1.Environment{Name: "staging", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "staging-gke", Namespace: "ai-staging"}, DeployEnabled: true}
+
+	got, _, err := f.reconcile(t)
+	require.NoError(t, err)
+	require.Len(t, got.Status.ManagedResources, 2, got.Status.Message)
+
+	// An environment that no longer allows deployments is skipped: the other
+	// is still deployed, and what runs in the skipped one is left alone
+	staging.DeployEnabled = false
+	f.setEnvironments(t, dev, staging)
+	got, _, err = f.reconcile(t)
+	require.ErrorIs(t, err, ErrEnvironmentDeployDisabled)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePartiallyDeployed, got.Status.Phase)
+	assert.Contains(t, got.Status.Message, `"staging" has deployEnabled false`)
+	assert.Len(t, got.Status.ManagedResources, 2)
+	assert.Equal(t, []string{"ai-staging"}, f.remoteNamespaces(t, "staging"))
+
+	// Deleting the deployment while an environment cannot be resolved cleans
+	// up the others, and keeps the finalizer until the last one is cleaned up
+	f.setEnvironments(t, dev)
+	require.NoError(t, f.c.Get(ctx, f.key, got))
+	require.NoError(t, f.c.Delete(ctx, got))
+	got, result, err := f.reconcile(t)
+	require.NoError(t, err)
+	require.NotNil(t, got, "the finalizer is kept")
+	assert.Positive(t, result.RequeueAfter)
+	assert.Empty(t, f.remoteNamespaces(t, "dev"))
+	assert.Equal(t, []string{"ai-staging"}, f.remoteNamespaces(t, "staging"))
+	require.Len(t, got.Status.ManagedResources, 1)
+	assert.Equal(t, "staging-gke", got.Status.ManagedResources[0].Cluster)
+	var local kagentv1alpha2.RemoteMCPServerList
+	require.NoError(t, f.c.List(ctx, &local))
+	assert.Empty(t, local.Items)
+
+	// Once the environment is back, deletion completes
+	f.setEnvironments(t, dev, staging)
+	got, _, err = f.reconcile(t)
+	require.NoError(t, err)
+	assert.Nil(t, got, "the finalizer is removed")
+	assert.Empty(t, f.remoteNamespaces(t, "staging"))
+	_, tracked := f.r.transientRetries.Load(f.key)
+	assert.False(t, tracked, "the retry count of a deleted deployment is dropped")
+}
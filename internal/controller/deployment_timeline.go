@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// MaxTimelineEntries caps Status.Timeline of a RegistryDeployment; the oldest
+// entries are dropped first
+const MaxTimelineEntries = 30
+
+// recordTimeline appends message to the timeline of status, unless it repeats
+// the latest entry, and trims the timeline to MaxTimelineEntries
+func recordTimeline(status *agentregistryv1alpha1.RegistryDeploymentStatus, message string) {
+	if n := len(status.Timeline); n > 0 && status.Timeline[n-1].Message == message {
+		return
+	}
+	status.Timeline = append(status.Timeline, agentregistryv1alpha1.TimelineEntry{Time: metav1.Now(), Message: message})
+	if excess := len(status.Timeline) - MaxTimelineEntries; excess > 0 {
+		status.Timeline = append([]agentregistryv1alpha1.TimelineEntry(nil), status.Timeline[excess:]...)
+	}
+}
+
+// recordPhaseTimeline records a transition of the deployment into phase. The
+// message is only included for phases other than Running, which need no
+// explanation.
+func recordPhaseTimeline(status *agentregistryv1alpha1.RegistryDeploymentStatus, previous agentregistryv1alpha1.DeploymentPhase) {
+	if status.Phase == previous {
+		return
+	}
+	switch {
+	case status.Phase == agentregistryv1alpha1.DeploymentPhaseRunning:
+		recordTimeline(status, "became Running")
+	case status.Message != "" && status.Message != string(status.Phase):
+		recordTimeline(status, fmt.Sprintf("became %s: %s", status.Phase, status.Message))
+	default:
+		recordTimeline(status, fmt.Sprintf("became %s", status.Phase))
+	}
+}
+
+// recordAppliedTimeline records the resources in applied that were not
+// already managed before
+func recordAppliedTimeline(status *agentregistryv1alpha1.RegistryDeploymentStatus, previous, applied []agentregistryv1alpha1.ManagedResource) {
+	for _, res := range applied {
+		if _, ok := findManagedResource(previous, res); ok {
+			continue
+		}
+		name := res.Name
+		if res.Namespace != "" {
+			name = res.Namespace + "/" + name
+		}
+		recordTimeline(status, fmt.Sprintf("applied %s %s", res.Kind, name))
+	}
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func timelineMessages(status agentregistryv1alpha1.RegistryDeploymentStatus) []string {
+	messages := make([]string, 0, len(status.Timeline))
+	for _, entry := range status.Timeline {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+func TestRecordTimeline(t *testing.T) {
+	var status agentregistryv1alpha1.RegistryDeploymentStatus
+	recordTimeline(&status, "created")
+	recordTimeline(&status, "created")
+	assert.Equal(t, []string{"created"}, timelineMessages(status), "consecutive duplicates are dropped")
+
+	for i := range MaxTimelineEntries + 5 {
+		recordTimeline(&status, fmt.Sprintf("event %d", i))
+	}
+	require.Len(t, status.Timeline, MaxTimelineEntries)
+	assert.Equal(t, "event 5", status.Timeline[0].Message, "the oldest entries are dropped")
+	assert.Equal(t, fmt.Sprintf("event %d", MaxTimelineEntries+4), status.Timeline[MaxTimelineEntries-1].Message)
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_RecordsTimeline(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "timeline-server",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "timeline-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "target-ns",
+		},
+	}
+
+	// Applying the RemoteMCPServer again overwrites its status in the fake
+	// client, so readiness is reported on read instead
+	remoteReady := false
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newRemoteServerCatalog("timeline-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		// The fake client clears TypeMeta on typed objects after a patch; keep
+		// it so managed resources are recorded with their kind.
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				gvk := obj.GetObjectKind().GroupVersionKind()
+				err := c.Patch(ctx, obj, patch, opts...)
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+				return err
+			},
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if remote, ok := obj.(*kagentv1alpha2.RemoteMCPServer); ok && remoteReady {
+					remote.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}}
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "timeline-server", Namespace: "default"}}
+	reconcileTimeline := func() []string {
+		t.Helper()
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		var updated agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, req.NamespacedName, &updated))
+		return timelineMessages(updated.Status)
+	}
+
+	// The applied RemoteMCPServer is not ready yet
+	timeline := reconcileTimeline()
+	require.Len(t, timeline, 3)
+	assert.Equal(t, "created", timeline[0])
+	assert.Regexp(t, `^applied RemoteMCPServer target-ns/`, timeline[1])
+	assert.Equal(t, "became Pending", timeline[2])
+
+	// Requeues without a change add nothing
+	assert.Len(t, reconcileTimeline(), 3)
+
+	remoteReady = true
+	assert.Equal(t, "became Running", reconcileTimeline()[3])
+
+	var current agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, req.NamespacedName, &current))
+	current.Spec.Config = map[string]string{"LOG_LEVEL": "debug"}
+	current.Generation = 2
+	require.NoError(t, c.Update(ctx, &current))
+	timeline = reconcileTimeline()
+	require.Len(t, timeline, 5)
+	assert.Equal(t, "spec updated (generation 2)", timeline[4])
+}
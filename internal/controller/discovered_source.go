@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// GetDiscoveredSource retrieves the resource ref points to from the discovered
+// cache. MCPServer, RemoteMCPServer, Agent and ModelConfig sources are
+// supported.
+func GetDiscoveredSource(ctx context.Context, ref agentregistryv1alpha1.SourceReference) (client.Object, error) {
+	switch ref.Kind {
+	case "MCPServer":
+		return GetMCPServer(ctx, ref.Namespace, ref.Name)
+	case "RemoteMCPServer":
+		return GetRemoteMCPServer(ctx, ref.Namespace, ref.Name)
+	case "Agent":
+		return GetAgent(ctx, ref.Namespace, ref.Name)
+	case "ModelConfig":
+		return GetModelConfig(ctx, ref.Namespace, ref.Name)
+	default:
+		return nil, fmt.Errorf("unsupported source kind %q", ref.Kind)
+	}
+}
+
+// SetDiscoveredSource records obj in the discovered cache as if a discovery
+// informer had seen it, so consumers of the cache outside this package can be
+// tested without running informers
+func SetDiscoveredSource(obj client.Object) {
+	switch o := obj.(type) {
+	case *kmcpv1alpha1.MCPServer:
+		setDiscoveredMCPServer(o)
+	case *kagentv1alpha2.RemoteMCPServer:
+		setDiscoveredRemoteMCPServer(o)
+	case *kagentv1alpha2.Agent:
+		setDiscoveredAgent(o)
+	case *kagentv1alpha2.ModelConfig:
+		setDiscoveredModelConfig(o)
+	}
+}
+
+// DeleteDiscoveredSource removes obj from the discovered cache
+func DeleteDiscoveredSource(obj client.Object) {
+	switch obj.(type) {
+	case *kmcpv1alpha1.MCPServer:
+		deleteDiscoveredMCPServer(obj.GetNamespace(), obj.GetName())
+	case *kagentv1alpha2.RemoteMCPServer:
+		deleteDiscoveredRemoteMCPServer(obj.GetNamespace(), obj.GetName())
+	case *kagentv1alpha2.Agent:
+		deleteDiscoveredAgent(obj.GetNamespace(), obj.GetName())
+	case *kagentv1alpha2.ModelConfig:
+		deleteDiscoveredModelConfig(obj.GetNamespace(), obj.GetName())
+	}
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// connectionCheckTimeout bounds a single environment connection check
+const connectionCheckTimeout = 10 * time.Second
+
+// EnvironmentConnection is the outcome of CheckEnvironmentConnection
+type EnvironmentConnection struct {
+	Environment string `json:"environment"`
+	Cluster     string `json:"cluster"`
+	// Namespace and ResourceType are what was listed to test the connection
+	Namespace    string `json:"namespace,omitempty"`
+	ResourceType string `json:"resourceType"`
+	Connected    bool   `json:"connected"`
+	Error        string `json:"error,omitempty"`
+	// LatencyMs is how long creating the client and listing took
+	LatencyMs int64 `json:"latencyMs"`
+}
+
+// CheckEnvironmentConnection tests that discovery can reach env's cluster: it
+// creates a client with RemoteClientFactory and lists at most one resource of
+// the first type discovery would watch, in the first namespace it would
+// watch. No informer is started.
+func CheckEnvironmentConnection(ctx context.Context, env *agentregistryv1alpha1.Environment, scheme *runtime.Scheme) EnvironmentConnection {
+	result := EnvironmentConnection{Environment: env.Name, Cluster: env.Cluster.Name}
+	if len(env.Namespaces) > 0 {
+		result.Namespace = env.Namespaces[0]
+	}
+	if resourceTypes := discoveryResourceTypes(env); len(resourceTypes) > 0 {
+		result.ResourceType = resourceTypes[0]
+	}
+	if RemoteClientFactory == nil {
+		result.Error = "remote client factory not configured"
+		return result
+	}
+	list, err := discoveryList(result.ResourceType)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectionCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	remoteClient, err := RemoteClientFactory(env, scheme)
+	if err == nil {
+		err = remoteClient.List(ctx, list, client.InNamespace(result.Namespace), client.Limit(1))
+	}
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Connected = true
+	return result
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestCheckEnvironmentConnection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+	env := &agentregistryv1alpha1.Environment{
+		Name:          "dev",
+		Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "dev-gke"},
+		Namespaces:    []string{"tools", "agents"},
+		ResourceTypes: []string{"MCPServer"},
+	}
+
+	var listed []client.ListOption
+	remote := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&kmcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "tools"}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listed = opts
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	oldFactory := RemoteClientFactory
+	t.Cleanup(func() { RemoteClientFactory = oldFactory })
+	ctx := context.Background()
+
+	RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+		return remote, nil
+	}
+	result := CheckEnvironmentConnection(ctx, env, scheme)
+	assert.True(t, result.Connected, result.Error)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, "dev-gke", result.Cluster)
+	assert.Equal(t, "tools", result.Namespace)
+	assert.Equal(t, "MCPServer", result.ResourceType)
+	assert.GreaterOrEqual(t, result.LatencyMs, int64(0))
+	listOpts := (&client.ListOptions{}).ApplyOptions(listed)
+	assert.Equal(t, "tools", listOpts.Namespace)
+	assert.Equal(t, int64(1), listOpts.Limit, "only one resource is listed")
+
+	t.Run("factory fails", func(t *testing.T) {
+		RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+			return nil, errors.New("invalid kubeconfig")
+		}
+		result := CheckEnvironmentConnection(ctx, env, scheme)
+		assert.False(t, result.Connected)
+		assert.Equal(t, "invalid kubeconfig", result.Error)
+	})
+
+	t.Run("list fails", func(t *testing.T) {
+		failing := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+					return errors.New("Unauthorized")
+				},
+			}).
+			Build()
+		RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+			return failing, nil
+		}
+		result := CheckEnvironmentConnection(ctx, env, scheme)
+		assert.False(t, result.Connected)
+		assert.Equal(t, "Unauthorized", result.Error)
+	})
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// DiscoveryScanAnnotation requests a discovery scan of a DiscoveryConfig. It
+// is stamped with the request time and removed by the reconciler once the scan
+// is complete, so callers can wait for it to disappear.
+const DiscoveryScanAnnotation = "agentregistry.dev/trigger-discovery"
+
+// DiscoveryScanTimeout bounds how long a scan waits for informers that do not
+// sync, e.g. because their cluster is unreachable
+const DiscoveryScanTimeout = 2 * time.Minute
+
+// discoveryScanPollInterval is how often a pending scan checks its informers
+const discoveryScanPollInterval = time.Second
+
+// TriggerDiscoveryScan stamps DiscoveryScanAnnotation on config with the
+// current time, which makes the controller reconcile it and refresh its
+// per-environment status right away
+func TriggerDiscoveryScan(ctx context.Context, c client.Client, config *agentregistryv1alpha1.DiscoveryConfig) error {
+	patch := client.MergeFrom(config.DeepCopy())
+	if config.Annotations == nil {
+		config.Annotations = make(map[string]string)
+	}
+	config.Annotations[DiscoveryScanAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	return c.Patch(ctx, config, patch)
+}
+
+// DiscoveryScanPending reports whether a scan of config was requested and has
+// not completed yet
+func DiscoveryScanPending(config *agentregistryv1alpha1.DiscoveryConfig) bool {
+	_, ok := config.Annotations[DiscoveryScanAnnotation]
+	return ok
+}
+
+// DiscoveryScanComplete reports whether every environment of config has
+// either synced its informers or failed to set them up. The environment
+// statuses only count once the Ready condition has observed the current
+// generation, so a status written for an earlier spec is not mistaken for
+// the result of the scan.
+func DiscoveryScanComplete(config *agentregistryv1alpha1.DiscoveryConfig) bool {
+	ready := meta.FindStatusCondition(config.Status.Conditions, "Ready")
+	if ready == nil || ready.ObservedGeneration != config.Generation {
+		return false
+	}
+	statuses := make(map[string]agentregistryv1alpha1.EnvironmentStatus, len(config.Status.Environments))
+	for _, status := range config.Status.Environments {
+		statuses[status.Name] = status
+	}
+	for _, env := range config.Spec.Environments {
+		status, ok := statuses[env.Name]
+		if !ok || (!status.Connected && status.Error == "") {
+			return false
+		}
+	}
+	return true
+}
+
+// finishDiscoveryScan removes the scan request from config once its status,
+// just written, shows the scan complete, or once the scan has timed out.
+// Until then it requeues config to check again.
+func (r *DiscoveryConfigReconciler) finishDiscoveryScan(ctx context.Context, config *agentregistryv1alpha1.DiscoveryConfig) (ctrl.Result, error) {
+	requested, err := time.Parse(time.RFC3339Nano, config.Annotations[DiscoveryScanAnnotation])
+	timedOut := err != nil || time.Since(requested) > DiscoveryScanTimeout
+	if !DiscoveryScanComplete(config) && !timedOut {
+		return ctrl.Result{RequeueAfter: discoveryScanPollInterval}, nil
+	}
+
+	patch := client.MergeFrom(config.DeepCopy())
+	delete(config.Annotations, DiscoveryScanAnnotation)
+	if err := r.Patch(ctx, config, patch); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestDiscoveryConfigReconciler_DiscoveryScan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	ctx := context.Background()
+
+	scan := func(t *testing.T, env agentregistryv1alpha1.Environment, requested time.Time) (ctrl.Result, *agentregistryv1alpha1.DiscoveryConfig) {
+		t.Helper()
+		config := &agentregistryv1alpha1.DiscoveryConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "discovery",
+				Namespace:   "agentregistry",
+				Annotations: map[string]string{DiscoveryScanAnnotation: requested.UTC().Format(time.RFC3339Nano)},
+			},
+			Spec: agentregistryv1alpha1.DiscoveryConfigSpec{Environments: []agentregistryv1alpha1.Environment{env}},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).WithStatusSubresource(config).Build()
+		r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+		require.NoError(t, err)
+		var updated agentregistryv1alpha1.DiscoveryConfig
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(config), &updated))
+		return result, &updated
+	}
+
+	t.Run("completes once every environment has synced or failed", func(t *testing.T) {
+		result, updated := scan(t, agentregistryv1alpha1.Environment{Name: "dev", Namespaces: []string{"default"}, ResourceTypes: []string{"Bogus"}}, time.Now())
+		assert.Zero(t, result)
+		assert.False(t, DiscoveryScanPending(updated))
+		assert.True(t, DiscoveryScanComplete(updated))
+	})
+
+	t.Run("requeues while informers have not synced", func(t *testing.T) {
+		result, updated := scan(t, agentregistryv1alpha1.Environment{Name: "dev"}, time.Now())
+		assert.Equal(t, discoveryScanPollInterval, result.RequeueAfter)
+		assert.True(t, DiscoveryScanPending(updated))
+		assert.False(t, DiscoveryScanComplete(updated))
+	})
+
+	t.Run("gives up after the scan timeout", func(t *testing.T) {
+		result, updated := scan(t, agentregistryv1alpha1.Environment{Name: "dev"}, time.Now().Add(-DiscoveryScanTimeout-time.Second))
+		assert.Zero(t, result)
+		assert.False(t, DiscoveryScanPending(updated))
+		assert.False(t, DiscoveryScanComplete(updated))
+	})
+}
+
+func TestDiscoveryScanComplete_ObservedGeneration(t *testing.T) {
+	config := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry", Generation: 2},
+		Spec:       agentregistryv1alpha1.DiscoveryConfigSpec{Environments: []agentregistryv1alpha1.Environment{{Name: "dev"}}},
+		Status: agentregistryv1alpha1.DiscoveryConfigStatus{
+			Environments: []agentregistryv1alpha1.EnvironmentStatus{{Name: "dev", Connected: true}},
+			Conditions:   []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 1}},
+		},
+	}
+	// The Connected status was written for the previous spec
+	assert.False(t, DiscoveryScanComplete(config))
+
+	config.Status.Conditions[0].ObservedGeneration = 2
+	assert.True(t, DiscoveryScanComplete(config))
+
+	config.Status.Conditions = nil
+	assert.False(t, DiscoveryScanComplete(config))
+}
+
+func TestTriggerDiscoveryScan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	config := &agentregistryv1alpha1.DiscoveryConfig{ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).Build()
+
+	require.NoError(t, TriggerDiscoveryScan(context.Background(), c, config))
+
+	var updated agentregistryv1alpha1.DiscoveryConfig
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(config), &updated))
+	requested, err := time.Parse(time.RFC3339Nano, updated.Annotations[DiscoveryScanAnnotation])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), requested, time.Minute)
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+)
+
+// DiscoveryScope is one namespace and resource type watched by a single
+// discovery informer
+type DiscoveryScope struct {
+	// Key identifies the informer, see DiscoveryInformerKey
+	Key          string
+	Environment  string
+	Namespace    string
+	ResourceType string
+}
+
+// DiscoveryInformerKey returns the key under which the informer watching
+// resourceType in namespace of an environment is tracked
+func DiscoveryInformerKey(configName, envName, namespace, resourceType string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", configName, envName, namespace, resourceType)
+}
+
+// discoveryEnvironmentHash hashes the fields of env the informers watching it
+// are built from. Namespaces and resource types are left out: they are part
+// of the informer keys.
+func discoveryEnvironmentHash(env *agentregistryv1alpha1.Environment) string {
+	data, _ := json.Marshal(struct {
+		Cluster       agentregistryv1alpha1.ClusterConfig
+		Provider      string
+		LabelSelector *metav1.LabelSelector
+		Labels        map[string]string
+		A2AEndpoint   string
+	}{env.Cluster, env.Provider, env.LabelSelector, env.Labels, env.A2AEndpoint})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// DiscoveryScopes returns the informer scopes the reconciler sets up for a
+// DiscoveryConfig spec, sorted by key. Unsupported resource types are skipped
+// as they are during reconciliation.
+func DiscoveryScopes(configName string, spec *agentregistryv1alpha1.DiscoveryConfigSpec) []DiscoveryScope {
+	var scopes []DiscoveryScope
+	seen := make(map[string]bool)
+	for i := range spec.Environments {
+		env := &spec.Environments[i]
+		for _, ns := range env.Namespaces {
+			for _, resourceType := range discoveryResourceTypes(env) {
+				if !slices.Contains(supportedDiscoveryResourceTypes, resourceType) {
+					continue
+				}
+				key := DiscoveryInformerKey(configName, env.Name, ns, resourceType)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				scopes = append(scopes, DiscoveryScope{
+					Key:          key,
+					Environment:  env.Name,
+					Namespace:    ns,
+					ResourceType: resourceType,
+				})
+			}
+		}
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i].Key < scopes[j].Key })
+	return scopes
+}
+
+// DiscoveredEntryListOptions returns the options selecting the catalog entries
+// discovered within scope, along with an empty list of the catalog kind the
+// scope's resource type is recorded as.
+func DiscoveredEntryListOptions(scope DiscoveryScope, catalogNamespace string) (client.ObjectList, []client.ListOption, error) {
+	var list client.ObjectList
+	switch scope.ResourceType {
+	case "MCPServer", "RemoteMCPServer":
+		list = &agentregistryv1alpha1.MCPServerCatalogList{}
+	case "Agent":
+		list = &agentregistryv1alpha1.AgentCatalogList{}
+	case "ModelConfig":
+		list = &agentregistryv1alpha1.ModelCatalogList{}
+	default:
+		return nil, nil, fmt.Errorf("unsupported resource type: %s", scope.ResourceType)
+	}
+	return list, []client.ListOption{
+		client.InNamespace(catalogNamespace),
+		client.MatchingLabels{
+			discoveryLabel:   "true",
+			sourceKindLabel:  scope.ResourceType,
+			sourceNSLabel:    scope.Namespace,
+			EnvironmentLabel: scope.Environment,
+		},
+	}, nil
+}
+
+// ListDiscoverableResources returns the names of the resources an informer
+// for scope would discover in env's cluster, honouring the environment's
+// label selector. It needs RemoteClientFactory to be configured.
+func ListDiscoverableResources(ctx context.Context, env *agentregistryv1alpha1.Environment, scope DiscoveryScope, scheme *runtime.Scheme) ([]string, error) {
+	if RemoteClientFactory == nil {
+		return nil, fmt.Errorf("remote client factory not configured")
+	}
+	selector, err := discoverySelector(env)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	list, err := discoveryList(scope.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteClient, err := RemoteClientFactory(env, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote client: %w", err)
+	}
+	if err := remoteClient.List(ctx, list, client.InNamespace(scope.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			names = append(names, obj.GetName())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// discoveryList returns an empty list of the discoverable resourceType
+func discoveryList(resourceType string) (client.ObjectList, error) {
+	switch resourceType {
+	case "MCPServer":
+		return &kmcpv1alpha1.MCPServerList{}, nil
+	case "Agent":
+		return &kagentv1alpha2.AgentList{}, nil
+	case "ModelConfig":
+		return &kagentv1alpha2.ModelConfigList{}, nil
+	case "RemoteMCPServer":
+		return &kagentv1alpha2.RemoteMCPServerList{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+}
+
+// stopStaleInformers stops the informers of a DiscoveryConfig whose scope is
+// no longer part of its spec, returning the keys it stopped
+func (r *DiscoveryConfigReconciler) stopStaleInformers(configName string, scopes []DiscoveryScope) []string {
+	wanted := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		wanted[scope.Key] = true
+	}
+
+	r.informersMu.Lock()
+	defer r.informersMu.Unlock()
+
+	var stopped []string
+	for key, stopCh := range r.stopChans {
+		if !strings.HasPrefix(key, configName+"/") || wanted[key] {
+			continue
+		}
+		close(stopCh)
+		delete(r.stopChans, key)
+		delete(r.informers, key)
+		delete(r.informerHashes, key)
+		r.clearInformerErrors(key)
+		stopped = append(stopped, key)
+	}
+	sort.Strings(stopped)
+	return stopped
+}
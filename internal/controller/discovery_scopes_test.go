@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/cache"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestDiscoveryScopes(t *testing.T) {
+	spec := &agentregistryv1alpha1.DiscoveryConfigSpec{
+		Environments: []agentregistryv1alpha1.Environment{
+			{Name: "prod", Namespaces: []string{"tools"}, ResourceTypes: []string{"Agent", "Bogus"}},
+			{Name: "dev", Namespaces: []string{"a", "a"}, ResourceTypes: []string{"MCPServer"}},
+		},
+	}
+
+	var keys []string
+	for _, scope := range DiscoveryScopes("discovery", spec) {
+		keys = append(keys, scope.Key)
+	}
+	// Sorted, deduplicated and without unsupported resource types
+	assert.Equal(t, []string{"discovery/dev/a/MCPServer", "discovery/prod/tools/Agent"}, keys)
+}
+
+func TestDiscoveryConfigReconciler_StopStaleInformers(t *testing.T) {
+	r := &DiscoveryConfigReconciler{
+		Logger:    zerolog.Nop(),
+		informers: map[string]cache.SharedIndexInformer{},
+		stopChans: map[string]chan struct{}{},
+	}
+	for _, key := range []string{"discovery/dev/a/MCPServer", "discovery/dev/b/MCPServer", "other/dev/b/MCPServer"} {
+		r.informers[key] = nil
+		r.stopChans[key] = make(chan struct{})
+	}
+	removed := r.stopChans["discovery/dev/b/MCPServer"]
+
+	stopped := r.stopStaleInformers("discovery", []DiscoveryScope{{Key: "discovery/dev/a/MCPServer"}})
+	assert.Equal(t, []string{"discovery/dev/b/MCPServer"}, stopped)
+	assert.Contains(t, r.stopChans, "discovery/dev/a/MCPServer")
+	// Informers of other DiscoveryConfigs are left alone
+	assert.Contains(t, r.stopChans, "other/dev/b/MCPServer")
+	assert.NotContains(t, r.informers, "discovery/dev/b/MCPServer")
+	_, open := <-removed
+	assert.False(t, open)
+}
+
+func TestDiscoveryConfigReconciler_StopStaleInformers_ClearsErrors(t *testing.T) {
+	r := &DiscoveryConfigReconciler{
+		Logger:    zerolog.Nop(),
+		informers: map[string]cache.SharedIndexInformer{"discovery/dev/b/MCPServer": nil},
+		stopChans: map[string]chan struct{}{"discovery/dev/b/MCPServer": make(chan struct{})},
+		errorTracker: map[string]*informerError{
+			"discovery/dev/b/MCPServer/mcpserver/b/search": {err: errors.New("conflict"), retryCount: maxRetries + 1},
+			"discovery/dev/a/MCPServer/mcpserver/a/search": {err: errors.New("conflict"), retryCount: maxRetries + 1},
+		},
+	}
+
+	// Errors of a stopped informer no longer keep the controller not-ready
+	r.stopStaleInformers("discovery", []DiscoveryScope{{Key: "discovery/dev/a/MCPServer"}})
+	assert.NotContains(t, r.errorTracker, "discovery/dev/b/MCPServer/mcpserver/b/search")
+	assert.Contains(t, r.errorTracker, "discovery/dev/a/MCPServer/mcpserver/a/search")
+
+	r.clearInformerErrors("discovery/dev/a/MCPServer/mcpserver/a/search")
+	assert.NoError(t, r.ReadyCheck(nil))
+}
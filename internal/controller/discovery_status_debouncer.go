@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultDiscoveryStatusInterval is the default minimum interval between
+// status refreshes of a DiscoveryConfig triggered by informer events.
+const DefaultDiscoveryStatusInterval = 5 * time.Second
+
+// statusDebouncer coalesces the status refresh requests informers raise for
+// a DiscoveryConfig. The first request for an object schedules a refresh
+// after interval; requests arriving before it fires are folded into it, so a
+// burst of events, e.g. during the initial sync of a busy namespace, leads to
+// a single status write per interval. A zero interval refreshes immediately.
+type statusDebouncer struct {
+	interval time.Duration
+	flush    func(client.Object)
+
+	mu      sync.Mutex
+	pending map[types.NamespacedName]client.Object
+}
+
+func newStatusDebouncer(interval time.Duration, flush func(client.Object)) *statusDebouncer {
+	return &statusDebouncer{
+		interval: interval,
+		flush:    flush,
+		pending:  make(map[types.NamespacedName]client.Object),
+	}
+}
+
+// notify requests a status refresh of obj
+func (d *statusDebouncer) notify(obj client.Object) {
+	if d.interval <= 0 {
+		d.flush(obj)
+		return
+	}
+
+	key := client.ObjectKeyFromObject(obj)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, scheduled := d.pending[key]; scheduled {
+		d.pending[key] = obj
+		return
+	}
+	d.pending[key] = obj
+	time.AfterFunc(d.interval, func() {
+		d.mu.Lock()
+		latest := d.pending[key]
+		delete(d.pending, key)
+		d.mu.Unlock()
+		d.flush(latest)
+	})
+}
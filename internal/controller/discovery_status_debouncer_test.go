@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func discoveryConfig(name string) *agentregistryv1alpha1.DiscoveryConfig {
+	return &agentregistryv1alpha1.DiscoveryConfig{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"}}
+}
+
+func TestStatusDebouncer_CoalescesBursts(t *testing.T) {
+	r := &DiscoveryConfigReconciler{statusEvents: make(chan event.GenericEvent, 1024)}
+	r.statusDebounce = newStatusDebouncer(50*time.Millisecond, r.enqueueStatusRefresh)
+
+	for i := 0; i < 500; i++ {
+		r.notifyStatusChange(discoveryConfig("default"))
+		r.notifyStatusChange(discoveryConfig("other"))
+	}
+	assert.Empty(t, r.statusEvents, "refreshes are delayed until the interval elapses")
+
+	time.Sleep(200 * time.Millisecond)
+	var names []string
+	for len(r.statusEvents) > 0 {
+		names = append(names, (<-r.statusEvents).Object.GetName())
+	}
+	assert.ElementsMatch(t, []string{"default", "other"}, names, "one refresh per DiscoveryConfig")
+
+	// Events after a flush schedule a new refresh
+	r.notifyStatusChange(discoveryConfig("default"))
+	assert.Eventually(t, func() bool { return len(r.statusEvents) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestStatusDebouncer_ZeroIntervalFlushesImmediately(t *testing.T) {
+	var flushed atomic.Int32
+	d := newStatusDebouncer(0, func(client.Object) { flushed.Add(1) })
+	for i := 0; i < 3; i++ {
+		d.notify(discoveryConfig("default"))
+	}
+	assert.Equal(t, int32(3), flushed.Load())
+}
+
+func TestNotifyStatusChange_NotSetUp(t *testing.T) {
+	r := &DiscoveryConfigReconciler{}
+	assert.NotPanics(t, func() { r.notifyStatusChange(discoveryConfig("default")) })
+}
@@ -2,20 +2,32 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/config"
@@ -41,12 +53,38 @@ type DiscoveryConfigReconciler struct {
 	informersMu sync.RWMutex
 	informers   map[string]cache.SharedIndexInformer
 	stopChans   map[string]chan struct{}
+	// informerHashes records the discoveryEnvironmentHash each informer was
+	// set up with, so informers are restarted when their environment changes
+	informerHashes map[string]string
 
-	// errorTracker tracks errors from informer handlers for retry
+	// errorTracker tracks errors from informer handlers for retry, keyed by
+	// the informer's key followed by the resource's
 	errorTrackerMu sync.RWMutex
 	errorTracker   map[string]*informerError
+
+	// StatusInterval is the minimum interval between the status refreshes
+	// informer events trigger; see DefaultDiscoveryStatusInterval
+	StatusInterval time.Duration
+
+	// ResyncPeriod is how often the discovery informers replay every cached
+	// resource to their handlers, repairing catalog entries that drifted after
+	// a missed watch event; see DefaultDiscoveryResyncPeriod. 0 disables it.
+	ResyncPeriod time.Duration
+
+	// MaxConcurrentReconciles is how many DiscoveryConfigs are reconciled at
+	// once; 0 uses the controller-runtime default of 1
+	MaxConcurrentReconciles int
+
+	// statusEvents requeues a DiscoveryConfig when its informers add or remove
+	// resources, so the discovered counts in its status stay current
+	statusEvents   chan event.GenericEvent
+	statusDebounce *statusDebouncer
 }
 
+// DefaultDiscoveryResyncPeriod is the default ResyncPeriod of the discovery
+// informers
+const DefaultDiscoveryResyncPeriod = 10 * time.Minute
+
 // RemoteClientFactory creates clients for remote clusters (injectable for testing)
 var RemoteClientFactory func(env *agentregistryv1alpha1.Environment, scheme *runtime.Scheme) (client.WithWatch, error)
 
@@ -181,65 +219,85 @@ func deleteDiscoveredRemoteMCPServer(namespace, name string) {
 func (r *DiscoveryConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Logger.With().Str("discoveryconfig", req.Name).Logger()
 
-	// Initialize maps
-	if r.informers == nil {
-		r.informersMu.Lock()
-		r.informers = make(map[string]cache.SharedIndexInformer)
-		r.stopChans = make(map[string]chan struct{})
-		r.informersMu.Unlock()
-	}
-	if r.errorTracker == nil {
-		r.errorTrackerMu.Lock()
-		r.errorTracker = make(map[string]*informerError)
-		r.errorTrackerMu.Unlock()
-	}
+	r.initTracking()
 
 	// Fetch DiscoveryConfig
 	var config agentregistryv1alpha1.DiscoveryConfig
 	if err := r.Get(ctx, req.NamespacedName, &config); err != nil {
 		if apierrors.IsNotFound(err) {
-			logger.Info().Msg("DiscoveryConfig deleted, stopping all informers")
-			r.stopAllInformers()
+			logger.Info().Msg("DiscoveryConfig deleted, stopping its informers")
+			for _, key := range r.stopStaleInformers(req.Name, nil) {
+				logger.Info().Str("key", key).Msg("stopped informer")
+			}
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
 	logger.Trace().Int("environments", len(config.Spec.Environments)).Msg("reconciling DiscoveryConfig")
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("agentregistry.environments", len(config.Spec.Environments)))
 
-	// Set up informers for each environment/namespace/resourceType
+	// Reject unknown resource types up front: a typo would otherwise set up
+	// nothing for that type without any visible error
+	setupErrors := make(map[string]string)
+	var invalidTypes []string
 	for _, env := range config.Spec.Environments {
-		resourceTypes := env.ResourceTypes
-		if len(resourceTypes) == 0 {
-			// Default to all types
-			resourceTypes = []string{"MCPServer", "Agent", "ModelConfig", "RemoteMCPServer"}
+		if err := validateDiscoveryResourceTypes(&env); err != nil {
+			logger.Warn().Err(err).Str("environment", env.Name).Msg("invalid resource types")
+			setupErrors[env.Name] = err.Error()
+			invalidTypes = append(invalidTypes, fmt.Sprintf("environment %s: %s", env.Name, err))
 		}
+	}
 
+	// Set up informers for each environment/namespace/resourceType. Running
+	// informers are kept unless the environment fields they were built from
+	// changed, in which case they are restarted.
+	for _, env := range config.Spec.Environments {
+		envHash := discoveryEnvironmentHash(&env)
 		for _, ns := range env.Namespaces {
-			for _, resourceType := range resourceTypes {
-				envKey := fmt.Sprintf("%s/%s/%s/%s", config.Name, env.Name, ns, resourceType)
+			for _, resourceType := range discoveryResourceTypes(&env) {
+				if !slices.Contains(supportedDiscoveryResourceTypes, resourceType) {
+					continue
+				}
+				envKey := DiscoveryInformerKey(config.Name, env.Name, ns, resourceType)
 
 				r.informersMu.RLock()
 				_, exists := r.informers[envKey]
+				unchanged := r.informerHashes[envKey] == envHash
 				r.informersMu.RUnlock()
 
-				if exists {
+				if exists && unchanged {
 					logger.Debug().Str("key", envKey).Msg("informer already running")
 					continue
 				}
+				if exists {
+					r.stopInformer(envKey)
+					logger.Info().Str("key", envKey).Msg("environment changed, restarting informer")
+				}
 
-				if err := r.setupInformerForResource(ctx, &env, ns, resourceType, envKey, logger); err != nil {
+				if err := r.setupInformerForResource(ctx, &config, &env, ns, resourceType, envKey, logger); err != nil {
 					logger.Error().Err(err).Str("key", envKey).Msg("failed to setup informer")
+					setupErrors[env.Name] = err.Error()
 					continue
 				}
+				r.informersMu.Lock()
+				r.informerHashes[envKey] = envHash
+				r.informersMu.Unlock()
 				logger.Info().Str("key", envKey).Msg("informer started")
 			}
 		}
 	}
 
+	// Stop informers for environments, namespaces or resource types that were
+	// removed from the spec
+	for _, key := range r.stopStaleInformers(config.Name, DiscoveryScopes(config.Name, &config.Spec)) {
+		logger.Info().Str("key", key).Msg("stopped informer")
+	}
+
 	// Update status
 	now := metav1.Now()
 	config.Status.LastSyncTime = &now
+	config.Status.Environments = r.environmentStatuses(&config, setupErrors, now)
 	config.Status.Conditions = []metav1.Condition{{
 		Type:               "Ready",
 		Status:             metav1.ConditionTrue,
@@ -248,6 +306,16 @@ func (r *DiscoveryConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		Reason:             "InformersStarted",
 		Message:            fmt.Sprintf("Watching %d environments", len(config.Spec.Environments)),
 	}}
+	if len(invalidTypes) > 0 {
+		config.Status.Conditions = append(config.Status.Conditions, metav1.Condition{
+			Type:               "Degraded",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: config.Generation,
+			LastTransitionTime: now,
+			Reason:             "InvalidResourceType",
+			Message:            strings.Join(invalidTypes, "; "),
+		})
+	}
 
 	if err := r.Status().Update(ctx, &config); err != nil {
 		if apierrors.IsConflict(err) {
@@ -256,12 +324,86 @@ func (r *DiscoveryConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	if DiscoveryScanPending(&config) {
+		return r.finishDiscoveryScan(ctx, &config)
+	}
 	return ctrl.Result{}, nil
 }
 
+// supportedDiscoveryResourceTypes lists the resource types an environment can discover
+var supportedDiscoveryResourceTypes = []string{"MCPServer", "Agent", "ModelConfig", "RemoteMCPServer"}
+
+// discoveryResourceTypes returns the resource types watched in env, defaulting
+// to all supported types.
+func discoveryResourceTypes(env *agentregistryv1alpha1.Environment) []string {
+	if len(env.ResourceTypes) == 0 {
+		return supportedDiscoveryResourceTypes
+	}
+	return env.ResourceTypes
+}
+
+// discoverySelector returns the label selector scoping discovery in env. An
+// unset selector matches every resource.
+func discoverySelector(env *agentregistryv1alpha1.Environment) (labels.Selector, error) {
+	if env.LabelSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(env.LabelSelector)
+}
+
+// removeDiscoveredEntries deletes the catalog entries discovered in env from
+// the source resource of the given kind.
+func (r *DiscoveryConfigReconciler) removeDiscoveredEntries(ctx context.Context, kind, namespace, name string, env *agentregistryv1alpha1.Environment) error {
+	var obj client.Object
+	switch kind {
+	case "MCPServer", "RemoteMCPServer":
+		obj = &agentregistryv1alpha1.MCPServerCatalog{}
+	case "Agent":
+		obj = &agentregistryv1alpha1.AgentCatalog{}
+	case "ModelConfig":
+		obj = &agentregistryv1alpha1.ModelCatalog{}
+	default:
+		return fmt.Errorf("unsupported resource type: %s", kind)
+	}
+
+	r.Logger.Info().
+		Str("kind", kind).
+		Str("namespace", namespace).
+		Str("name", name).
+		Str("environment", env.Name).
+		Msg("resource no longer matches the discovery label selector, removing catalog entries")
+	return r.DeleteAllOf(ctx, obj,
+		client.InNamespace(config.GetNamespace()),
+		client.MatchingLabels{
+			discoveryLabel:   "true",
+			sourceKindLabel:  kind,
+			sourceNameLabel:  name,
+			sourceNSLabel:    namespace,
+			EnvironmentLabel: env.Name,
+		},
+	)
+}
+
+// validateDiscoveryResourceTypes reports the resource types of env that are
+// not supported, listing the accepted values.
+func validateDiscoveryResourceTypes(env *agentregistryv1alpha1.Environment) error {
+	var invalid []string
+	for _, resourceType := range env.ResourceTypes {
+		if !slices.Contains(supportedDiscoveryResourceTypes, resourceType) {
+			invalid = append(invalid, fmt.Sprintf("%q", resourceType))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unsupported resource type(s) %s: accepted values are %s",
+		strings.Join(invalid, ", "), strings.Join(supportedDiscoveryResourceTypes, ", "))
+}
+
 // setupInformerForResource creates a SharedIndexInformer for a specific resource type
 func (r *DiscoveryConfigReconciler) setupInformerForResource(
 	ctx context.Context,
+	config *agentregistryv1alpha1.DiscoveryConfig,
 	env *agentregistryv1alpha1.Environment,
 	namespace string,
 	resourceType string,
@@ -270,6 +412,11 @@ func (r *DiscoveryConfigReconciler) setupInformerForResource(
 ) error {
 	logger = logger.With().Str("namespace", namespace).Str("cluster", env.Cluster.Name).Str("resourceType", resourceType).Logger()
 
+	selector, err := discoverySelector(env)
+	if err != nil {
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+
 	// Get client for remote cluster
 	remoteClient, err := r.getRemoteClient(env)
 	if err != nil {
@@ -280,17 +427,27 @@ func (r *DiscoveryConfigReconciler) setupInformerForResource(
 
 	switch resourceType {
 	case "MCPServer":
-		informer = r.createMCPServerInformer(ctx, remoteClient, namespace, env, logger)
+		informer = r.createMCPServerInformer(ctx, envKey, remoteClient, namespace, selector, env, logger)
 	case "Agent":
-		informer = r.createAgentInformer(ctx, remoteClient, namespace, env, logger)
+		informer = r.createAgentInformer(ctx, envKey, remoteClient, namespace, selector, env, logger)
 	case "ModelConfig":
-		informer = r.createModelConfigInformer(ctx, remoteClient, namespace, env, logger)
+		informer = r.createModelConfigInformer(ctx, envKey, remoteClient, namespace, selector, env, logger)
 	case "RemoteMCPServer":
-		informer = r.createRemoteMCPServerInformer(ctx, remoteClient, namespace, env, logger)
+		informer = r.createRemoteMCPServerInformer(ctx, envKey, remoteClient, namespace, selector, env, logger)
 	default:
 		return fmt.Errorf("unsupported resource type: %s", resourceType)
 	}
 
+	// Refresh the DiscoveryConfig's discovered counts whenever the set of
+	// resources seen by this informer changes
+	owner := &agentregistryv1alpha1.DiscoveryConfig{}
+	owner.SetName(config.Name)
+	owner.SetNamespace(config.Namespace)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { r.notifyStatusChange(owner) },
+		DeleteFunc: func(interface{}) { r.notifyStatusChange(owner) },
+	})
+
 	// Store informer and stop channel
 	stopCh := make(chan struct{})
 	r.informersMu.Lock()
@@ -313,8 +470,10 @@ func (r *DiscoveryConfigReconciler) setupInformerForResource(
 // createMCPServerInformer creates an informer for MCPServer resources
 func (r *DiscoveryConfigReconciler) createMCPServerInformer(
 	ctx context.Context,
+	informerKey string,
 	remoteClient client.WithWatch,
 	namespace string,
+	selector labels.Selector,
 	env *agentregistryv1alpha1.Environment,
 	logger zerolog.Logger,
 ) cache.SharedIndexInformer {
@@ -322,15 +481,15 @@ func (r *DiscoveryConfigReconciler) createMCPServerInformer(
 		&cache.ListWatch{
 			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 				list := &kmcpv1alpha1.MCPServerList{}
-				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace))
+				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 				return list, err
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return remoteClient.Watch(context.Background(), &kmcpv1alpha1.MCPServerList{}, client.InNamespace(namespace))
+				return remoteClient.Watch(context.Background(), &kmcpv1alpha1.MCPServerList{}, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 			},
 		},
 		&kmcpv1alpha1.MCPServer{},
-		0,
+		r.ResyncPeriod,
 		cache.Indexers{},
 	)
 
@@ -338,9 +497,12 @@ func (r *DiscoveryConfigReconciler) createMCPServerInformer(
 		AddFunc: func(obj interface{}) {
 			mcpServer := obj.(*kmcpv1alpha1.MCPServer)
 			logger.Trace().Str("mcpserver", mcpServer.Name).Msg("MCPServer added")
+			if !selector.Matches(labels.Set(mcpServer.Labels)) {
+				return
+			}
 			// Add to discovery cache for SourceRef lookups
 			setDiscoveredMCPServer(mcpServer)
-			resourceKey := fmt.Sprintf("mcpserver/%s/%s", mcpServer.Namespace, mcpServer.Name)
+			resourceKey := fmt.Sprintf("%s/mcpserver/%s/%s", informerKey, mcpServer.Namespace, mcpServer.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleMCPServerAdd(ctx, mcpServer, env)
 			}, logger)
@@ -348,9 +510,17 @@ func (r *DiscoveryConfigReconciler) createMCPServerInformer(
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			mcpServer := newObj.(*kmcpv1alpha1.MCPServer)
 			logger.Trace().Str("mcpserver", mcpServer.Name).Msg("MCPServer updated")
+			resourceKey := fmt.Sprintf("%s/mcpserver/%s/%s", informerKey, mcpServer.Namespace, mcpServer.Name)
+			if !selector.Matches(labels.Set(mcpServer.Labels)) {
+				// No longer selected for discovery: drop its catalog entries
+				deleteDiscoveredMCPServer(mcpServer.Namespace, mcpServer.Name)
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "MCPServer", mcpServer.Namespace, mcpServer.Name, env)
+				}, logger)
+				return
+			}
 			// Update discovery cache
 			setDiscoveredMCPServer(mcpServer)
-			resourceKey := fmt.Sprintf("mcpserver/%s/%s", mcpServer.Namespace, mcpServer.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleMCPServerAdd(ctx, mcpServer, env)
 			}, logger)
@@ -358,6 +528,17 @@ func (r *DiscoveryConfigReconciler) createMCPServerInformer(
 		DeleteFunc: func(obj interface{}) {
 			mcpServer := obj.(*kmcpv1alpha1.MCPServer)
 			logger.Trace().Str("mcpserver", mcpServer.Name).Msg("MCPServer deleted")
+			resourceKey := fmt.Sprintf("%s/mcpserver/%s/%s", informerKey, mcpServer.Namespace, mcpServer.Name)
+			if !selector.Matches(labels.Set(mcpServer.Labels)) {
+				// A label-selected watch reports resources whose labels stopped
+				// matching as deleted, while they still exist
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "MCPServer", mcpServer.Namespace, mcpServer.Name, env)
+				}, logger)
+			} else {
+				// Errors recorded for a deleted resource would never be cleared
+				r.clearInformerErrors(resourceKey)
+			}
 			// Remove from discovery cache
 			deleteDiscoveredMCPServer(mcpServer.Namespace, mcpServer.Name)
 			// TODO: Handle deletion - mark catalog entry as deleted or remove it
@@ -370,8 +551,10 @@ func (r *DiscoveryConfigReconciler) createMCPServerInformer(
 // createAgentInformer creates an informer for Agent resources
 func (r *DiscoveryConfigReconciler) createAgentInformer(
 	ctx context.Context,
+	informerKey string,
 	remoteClient client.WithWatch,
 	namespace string,
+	selector labels.Selector,
 	env *agentregistryv1alpha1.Environment,
 	logger zerolog.Logger,
 ) cache.SharedIndexInformer {
@@ -379,15 +562,15 @@ func (r *DiscoveryConfigReconciler) createAgentInformer(
 		&cache.ListWatch{
 			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 				list := &kagentv1alpha2.AgentList{}
-				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace))
+				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 				return list, err
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return remoteClient.Watch(context.Background(), &kagentv1alpha2.AgentList{}, client.InNamespace(namespace))
+				return remoteClient.Watch(context.Background(), &kagentv1alpha2.AgentList{}, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 			},
 		},
 		&kagentv1alpha2.Agent{},
-		0,
+		r.ResyncPeriod,
 		cache.Indexers{},
 	)
 
@@ -395,9 +578,12 @@ func (r *DiscoveryConfigReconciler) createAgentInformer(
 		AddFunc: func(obj interface{}) {
 			agent := obj.(*kagentv1alpha2.Agent)
 			logger.Trace().Str("agent", agent.Name).Msg("Agent added")
+			if !selector.Matches(labels.Set(agent.Labels)) {
+				return
+			}
 			// Add to discovery cache
 			setDiscoveredAgent(agent)
-			resourceKey := fmt.Sprintf("agent/%s/%s", agent.Namespace, agent.Name)
+			resourceKey := fmt.Sprintf("%s/agent/%s/%s", informerKey, agent.Namespace, agent.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleAgentAdd(ctx, agent, env)
 			}, logger)
@@ -405,9 +591,17 @@ func (r *DiscoveryConfigReconciler) createAgentInformer(
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			agent := newObj.(*kagentv1alpha2.Agent)
 			logger.Trace().Str("agent", agent.Name).Msg("Agent updated")
+			resourceKey := fmt.Sprintf("%s/agent/%s/%s", informerKey, agent.Namespace, agent.Name)
+			if !selector.Matches(labels.Set(agent.Labels)) {
+				// No longer selected for discovery: drop its catalog entries
+				deleteDiscoveredAgent(agent.Namespace, agent.Name)
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "Agent", agent.Namespace, agent.Name, env)
+				}, logger)
+				return
+			}
 			// Update discovery cache
 			setDiscoveredAgent(agent)
-			resourceKey := fmt.Sprintf("agent/%s/%s", agent.Namespace, agent.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleAgentAdd(ctx, agent, env)
 			}, logger)
@@ -415,6 +609,17 @@ func (r *DiscoveryConfigReconciler) createAgentInformer(
 		DeleteFunc: func(obj interface{}) {
 			agent := obj.(*kagentv1alpha2.Agent)
 			logger.Trace().Str("agent", agent.Name).Msg("Agent deleted")
+			resourceKey := fmt.Sprintf("%s/agent/%s/%s", informerKey, agent.Namespace, agent.Name)
+			if !selector.Matches(labels.Set(agent.Labels)) {
+				// A label-selected watch reports resources whose labels stopped
+				// matching as deleted, while they still exist
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "Agent", agent.Namespace, agent.Name, env)
+				}, logger)
+			} else {
+				// Errors recorded for a deleted resource would never be cleared
+				r.clearInformerErrors(resourceKey)
+			}
 			// Remove from discovery cache
 			deleteDiscoveredAgent(agent.Namespace, agent.Name)
 			// TODO: Handle deletion
@@ -427,8 +632,10 @@ func (r *DiscoveryConfigReconciler) createAgentInformer(
 // createModelConfigInformer creates an informer for ModelConfig resources
 func (r *DiscoveryConfigReconciler) createModelConfigInformer(
 	ctx context.Context,
+	informerKey string,
 	remoteClient client.WithWatch,
 	namespace string,
+	selector labels.Selector,
 	env *agentregistryv1alpha1.Environment,
 	logger zerolog.Logger,
 ) cache.SharedIndexInformer {
@@ -436,15 +643,15 @@ func (r *DiscoveryConfigReconciler) createModelConfigInformer(
 		&cache.ListWatch{
 			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 				list := &kagentv1alpha2.ModelConfigList{}
-				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace))
+				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 				return list, err
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return remoteClient.Watch(context.Background(), &kagentv1alpha2.ModelConfigList{}, client.InNamespace(namespace))
+				return remoteClient.Watch(context.Background(), &kagentv1alpha2.ModelConfigList{}, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 			},
 		},
 		&kagentv1alpha2.ModelConfig{},
-		0,
+		r.ResyncPeriod,
 		cache.Indexers{},
 	)
 
@@ -452,9 +659,12 @@ func (r *DiscoveryConfigReconciler) createModelConfigInformer(
 		AddFunc: func(obj interface{}) {
 			model := obj.(*kagentv1alpha2.ModelConfig)
 			logger.Trace().Str("modelconfig", model.Name).Msg("ModelConfig added")
+			if !selector.Matches(labels.Set(model.Labels)) {
+				return
+			}
 			// Add to discovery cache
 			setDiscoveredModelConfig(model)
-			resourceKey := fmt.Sprintf("model/%s/%s", model.Namespace, model.Name)
+			resourceKey := fmt.Sprintf("%s/model/%s/%s", informerKey, model.Namespace, model.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleModelConfigAdd(ctx, model, env)
 			}, logger)
@@ -462,9 +672,17 @@ func (r *DiscoveryConfigReconciler) createModelConfigInformer(
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			model := newObj.(*kagentv1alpha2.ModelConfig)
 			logger.Trace().Str("modelconfig", model.Name).Msg("ModelConfig updated")
+			resourceKey := fmt.Sprintf("%s/model/%s/%s", informerKey, model.Namespace, model.Name)
+			if !selector.Matches(labels.Set(model.Labels)) {
+				// No longer selected for discovery: drop its catalog entries
+				deleteDiscoveredModelConfig(model.Namespace, model.Name)
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "ModelConfig", model.Namespace, model.Name, env)
+				}, logger)
+				return
+			}
 			// Update discovery cache
 			setDiscoveredModelConfig(model)
-			resourceKey := fmt.Sprintf("model/%s/%s", model.Namespace, model.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleModelConfigAdd(ctx, model, env)
 			}, logger)
@@ -472,6 +690,17 @@ func (r *DiscoveryConfigReconciler) createModelConfigInformer(
 		DeleteFunc: func(obj interface{}) {
 			model := obj.(*kagentv1alpha2.ModelConfig)
 			logger.Trace().Str("modelconfig", model.Name).Msg("ModelConfig deleted")
+			resourceKey := fmt.Sprintf("%s/model/%s/%s", informerKey, model.Namespace, model.Name)
+			if !selector.Matches(labels.Set(model.Labels)) {
+				// A label-selected watch reports resources whose labels stopped
+				// matching as deleted, while they still exist
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "ModelConfig", model.Namespace, model.Name, env)
+				}, logger)
+			} else {
+				// Errors recorded for a deleted resource would never be cleared
+				r.clearInformerErrors(resourceKey)
+			}
 			// Remove from discovery cache
 			deleteDiscoveredModelConfig(model.Namespace, model.Name)
 			// TODO: Handle deletion
@@ -484,8 +713,10 @@ func (r *DiscoveryConfigReconciler) createModelConfigInformer(
 // createRemoteMCPServerInformer creates an informer for RemoteMCPServer resources
 func (r *DiscoveryConfigReconciler) createRemoteMCPServerInformer(
 	ctx context.Context,
+	informerKey string,
 	remoteClient client.WithWatch,
 	namespace string,
+	selector labels.Selector,
 	env *agentregistryv1alpha1.Environment,
 	logger zerolog.Logger,
 ) cache.SharedIndexInformer {
@@ -493,15 +724,15 @@ func (r *DiscoveryConfigReconciler) createRemoteMCPServerInformer(
 		&cache.ListWatch{
 			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 				list := &kagentv1alpha2.RemoteMCPServerList{}
-				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace))
+				err := remoteClient.List(context.Background(), list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 				return list, err
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return remoteClient.Watch(context.Background(), &kagentv1alpha2.RemoteMCPServerList{}, client.InNamespace(namespace))
+				return remoteClient.Watch(context.Background(), &kagentv1alpha2.RemoteMCPServerList{}, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 			},
 		},
 		&kagentv1alpha2.RemoteMCPServer{},
-		0,
+		r.ResyncPeriod,
 		cache.Indexers{},
 	)
 
@@ -509,8 +740,11 @@ func (r *DiscoveryConfigReconciler) createRemoteMCPServerInformer(
 		AddFunc: func(obj interface{}) {
 			server := obj.(*kagentv1alpha2.RemoteMCPServer)
 			logger.Trace().Str("remotemcpserver", server.Name).Msg("RemoteMCPServer added")
+			if !selector.Matches(labels.Set(server.Labels)) {
+				return
+			}
 			setDiscoveredRemoteMCPServer(server)
-			resourceKey := fmt.Sprintf("remotemcpserver/%s/%s", server.Namespace, server.Name)
+			resourceKey := fmt.Sprintf("%s/remotemcpserver/%s/%s", informerKey, server.Namespace, server.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleRemoteMCPServerAdd(ctx, server, env)
 			}, logger)
@@ -518,8 +752,16 @@ func (r *DiscoveryConfigReconciler) createRemoteMCPServerInformer(
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			server := newObj.(*kagentv1alpha2.RemoteMCPServer)
 			logger.Trace().Str("remotemcpserver", server.Name).Msg("RemoteMCPServer updated")
+			resourceKey := fmt.Sprintf("%s/remotemcpserver/%s/%s", informerKey, server.Namespace, server.Name)
+			if !selector.Matches(labels.Set(server.Labels)) {
+				// No longer selected for discovery: drop its catalog entries
+				deleteDiscoveredRemoteMCPServer(server.Namespace, server.Name)
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "RemoteMCPServer", server.Namespace, server.Name, env)
+				}, logger)
+				return
+			}
 			setDiscoveredRemoteMCPServer(server)
-			resourceKey := fmt.Sprintf("remotemcpserver/%s/%s", server.Namespace, server.Name)
 			r.executeWithRetry(ctx, resourceKey, func() error {
 				return r.handleRemoteMCPServerAdd(ctx, server, env)
 			}, logger)
@@ -527,6 +769,17 @@ func (r *DiscoveryConfigReconciler) createRemoteMCPServerInformer(
 		DeleteFunc: func(obj interface{}) {
 			server := obj.(*kagentv1alpha2.RemoteMCPServer)
 			logger.Trace().Str("remotemcpserver", server.Name).Msg("RemoteMCPServer deleted")
+			resourceKey := fmt.Sprintf("%s/remotemcpserver/%s/%s", informerKey, server.Namespace, server.Name)
+			if !selector.Matches(labels.Set(server.Labels)) {
+				// A label-selected watch reports resources whose labels stopped
+				// matching as deleted, while they still exist
+				r.executeWithRetry(ctx, resourceKey, func() error {
+					return r.removeDiscoveredEntries(ctx, "RemoteMCPServer", server.Namespace, server.Name, env)
+				}, logger)
+			} else {
+				// Errors recorded for a deleted resource would never be cleared
+				r.clearInformerErrors(resourceKey)
+			}
 			deleteDiscoveredRemoteMCPServer(server.Namespace, server.Name)
 		},
 	})
@@ -573,8 +826,8 @@ func (r *DiscoveryConfigReconciler) handleRemoteMCPServerAdd(
 	labels[sourceKindLabel] = "RemoteMCPServer"
 	labels[sourceNameLabel] = server.Name
 	labels[sourceNSLabel] = server.Namespace
-	labels["agentregistry.dev/environment"] = env.Name
-	labels["agentregistry.dev/cluster"] = env.Cluster.Name
+	labels[EnvironmentLabel] = env.Name
+	labels[ClusterLabel] = env.Cluster.Name
 
 	catalog := agentregistryv1alpha1.MCPServerCatalog{
 		ObjectMeta: metav1.ObjectMeta{
@@ -603,12 +856,17 @@ func (r *DiscoveryConfigReconciler) handleRemoteMCPServerAdd(
 	err := r.Get(ctx, client.ObjectKey{Name: catalogName, Namespace: namespace}, existing)
 
 	if apierrors.IsNotFound(err) {
+		if skip, err := r.rejectDuplicate(ctx, &agentregistryv1alpha1.MCPServerCatalogList{}, &catalog, catalog.Spec.Name, catalog.Spec.Version); err != nil || skip {
+			return err
+		}
 		if err := r.Create(ctx, &catalog); err != nil {
 			return err
 		}
 		catalog.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		catalog.Status.Published = true
-		catalog.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		now := metav1.Now()
+		catalog.Status.PublishedAt = &now
+		catalog.Status.Status = mcpServerCatalogStatus(&catalog, false)
 		syncRemoteMCPServerDeploymentStatus(&catalog, server)
 		return r.Status().Update(ctx, &catalog)
 	} else if err != nil {
@@ -624,7 +882,11 @@ func (r *DiscoveryConfigReconciler) handleRemoteMCPServerAdd(
 	if existing.Status.ManagementType == "" {
 		existing.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		existing.Status.Published = true
-		existing.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		if existing.Status.PublishedAt == nil {
+			now := metav1.Now()
+			existing.Status.PublishedAt = &now
+		}
+		existing.Status.Status = mcpServerCatalogStatus(existing, false)
 		needsUpdate = true
 	}
 	if existing.Status.ManagementType == agentregistryv1alpha1.ManagementTypeExternal {
@@ -699,10 +961,13 @@ func (r *DiscoveryConfigReconciler) handleMCPServerAdd(
 		description = d
 	}
 
-	// Build transport
-	transportType := "stdio"
-	if mcpServer.Spec.TransportType == "http" {
-		transportType = "streamable-http"
+	packages, remotes, issues := mcpServerCatalogTransports(mcpServer)
+	if len(issues) > 0 {
+		r.Logger.Debug().
+			Str("mcpserver", mcpServer.Name).
+			Str("namespace", mcpServer.Namespace).
+			Strs("issues", issues).
+			Msg("MCPServer spec could not be fully mapped to a catalog entry")
 	}
 
 	// Build labels
@@ -714,8 +979,8 @@ func (r *DiscoveryConfigReconciler) handleMCPServerAdd(
 	labels[sourceKindLabel] = "MCPServer"
 	labels[sourceNameLabel] = mcpServer.Name
 	labels[sourceNSLabel] = mcpServer.Namespace
-	labels["agentregistry.dev/environment"] = env.Name
-	labels["agentregistry.dev/cluster"] = env.Cluster.Name
+	labels[EnvironmentLabel] = env.Name
+	labels[ClusterLabel] = env.Cluster.Name
 
 	catalog := agentregistryv1alpha1.MCPServerCatalog{
 		ObjectMeta: metav1.ObjectMeta{
@@ -733,11 +998,8 @@ func (r *DiscoveryConfigReconciler) handleMCPServerAdd(
 				Name:      mcpServer.Name,
 				Namespace: mcpServer.Namespace,
 			},
-			Packages: []agentregistryv1alpha1.Package{{
-				RegistryType: "oci",
-				Identifier:   mcpServer.Spec.Deployment.Image,
-				Transport:    agentregistryv1alpha1.Transport{Type: transportType},
-			}},
+			Packages: packages,
+			Remotes:  remotes,
 		},
 	}
 
@@ -746,14 +1008,20 @@ func (r *DiscoveryConfigReconciler) handleMCPServerAdd(
 	err := r.Get(ctx, client.ObjectKey{Name: catalogName, Namespace: namespace}, existing)
 
 	if apierrors.IsNotFound(err) {
+		if skip, err := r.rejectDuplicate(ctx, &agentregistryv1alpha1.MCPServerCatalogList{}, &catalog, catalog.Spec.Name, catalog.Spec.Version); err != nil || skip {
+			return err
+		}
 		if err := r.Create(ctx, &catalog); err != nil {
 			return err
 		}
 		// Set external management type, published status, and deployment info
 		catalog.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		catalog.Status.Published = true
-		catalog.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		now := metav1.Now()
+		catalog.Status.PublishedAt = &now
+		catalog.Status.Status = mcpServerCatalogStatus(&catalog, false)
 		syncDeploymentStatus(&catalog, mcpServer)
+		setDiscoveryIncompleteCondition(&catalog.Status.Conditions, issues)
 		return r.Status().Update(ctx, &catalog)
 	} else if err != nil {
 		return err
@@ -769,19 +1037,147 @@ func (r *DiscoveryConfigReconciler) handleMCPServerAdd(
 	if existing.Status.ManagementType == "" {
 		existing.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		existing.Status.Published = true
-		existing.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		if existing.Status.PublishedAt == nil {
+			now := metav1.Now()
+			existing.Status.PublishedAt = &now
+		}
+		existing.Status.Status = mcpServerCatalogStatus(existing, false)
 		needsUpdate = true
 	}
 	if existing.Status.ManagementType == agentregistryv1alpha1.ManagementTypeExternal {
 		syncDeploymentStatus(existing, mcpServer)
 		needsUpdate = true
 	}
+	if setDiscoveryIncompleteCondition(&existing.Status.Conditions, issues) {
+		needsUpdate = true
+	}
 	if needsUpdate {
 		return r.Status().Update(ctx, existing)
 	}
 	return nil
 }
 
+// rejectDuplicate reports whether a discovered entry must not be created
+// because the reject duplicate policy is set and its name and version are
+// already cataloged under another object name
+func (r *DiscoveryConfigReconciler) rejectDuplicate(ctx context.Context, list client.ObjectList, catalog client.Object, name, version string) (bool, error) {
+	if config.DuplicatePolicy() != config.DuplicatePolicyReject {
+		return false, nil
+	}
+	err := CheckDuplicateEntry(ctx, r.Client, list, catalog.GetNamespace(), catalog.GetName(), name, version)
+	if errors.Is(err, ErrDuplicateEntry) {
+		r.Logger.Warn().Err(err).Str("catalog", catalog.GetName()).Msg("not cataloging discovered resource")
+		return true, nil
+	}
+	return false, err
+}
+
+// mcpServerCatalogTransports maps the deployment and transport of a kmcp
+// MCPServer to catalog packages and remotes. Specs that cannot be mapped
+// exactly (no image, unknown transport) are mapped as far as possible and the
+// returned issues describe what was lost.
+func mcpServerCatalogTransports(mcpServer *kmcpv1alpha1.MCPServer) ([]agentregistryv1alpha1.Package, []agentregistryv1alpha1.Transport, []string) {
+	var issues []string
+
+	var transportType string
+	switch mcpServer.Spec.TransportType {
+	case kmcpv1alpha1.TransportTypeStdio:
+		transportType = "stdio"
+	case kmcpv1alpha1.TransportTypeHTTP:
+		transportType = "streamable-http"
+	default:
+		// Older kmcp versions leave the type unset and only fill in the
+		// transport-specific block, so infer it from that
+		transportType = "stdio"
+		if mcpServer.Spec.HTTPTransport != nil {
+			transportType = "streamable-http"
+		}
+		if mcpServer.Spec.TransportType != "" {
+			issues = append(issues, fmt.Sprintf("unknown transport type %q, recorded as %s", mcpServer.Spec.TransportType, transportType))
+		}
+	}
+
+	annotated, annotationIssues := annotatedPackages(mcpServer, transportType)
+	issues = append(issues, annotationIssues...)
+
+	if image := mcpServer.Spec.Deployment.Image; image != "" {
+		// The image package comes first so it stays the default at deploy time
+		packages := []agentregistryv1alpha1.Package{{
+			RegistryType: "oci",
+			Identifier:   image,
+			Transport:    agentregistryv1alpha1.Transport{Type: transportType},
+		}}
+		return append(packages, annotated...), nil, issues
+	}
+
+	// Without an image the server cannot be redeployed from the catalog, but
+	// kmcp still exposes it over streamable HTTP through its Service
+	if port := mcpServer.Spec.Deployment.Port; port != 0 {
+		issues = append(issues, "no deployment image, recorded as a remote using the in-cluster service URL")
+		return annotated, []agentregistryv1alpha1.Transport{{
+			Type: "streamable-http",
+			URL:  fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", mcpServer.Name, mcpServer.Namespace, port, mcpServicePath(mcpServer)),
+		}}, issues
+	}
+
+	if len(annotated) == 0 {
+		issues = append(issues, "no deployment image or port, no package or remote recorded")
+	}
+	return annotated, nil, issues
+}
+
+// mcpServicePath returns the path mcpServer serves MCP on through its
+// Service: the configured HTTP transport path, else the /mcp endpoint kmcp's
+// transport adapter serves stdio servers on
+func mcpServicePath(mcpServer *kmcpv1alpha1.MCPServer) string {
+	if t := mcpServer.Spec.HTTPTransport; t != nil && t.TargetPath != "" {
+		return "/" + strings.TrimPrefix(t.TargetPath, "/")
+	}
+	return "/mcp"
+}
+
+// annotatedPackages returns the packages listed in the AnnotationPackagePrefix
+// annotations of mcpServer, ordered by registry type. npm and pypi packages get
+// the npx and uvx runtime hints the registry deploys them with.
+func annotatedPackages(mcpServer *kmcpv1alpha1.MCPServer, transportType string) ([]agentregistryv1alpha1.Package, []string) {
+	var packages []agentregistryv1alpha1.Package
+	var issues []string
+	for _, key := range slices.Sorted(maps.Keys(mcpServer.Annotations)) {
+		registryType, ok := strings.CutPrefix(key, agentregistryv1alpha1.AnnotationPackagePrefix)
+		if !ok {
+			continue
+		}
+		value := strings.TrimSpace(mcpServer.Annotations[key])
+		if registryType == "" || value == "" {
+			issues = append(issues, fmt.Sprintf("ignored package annotation %q without a registry type or identifier", key))
+			continue
+		}
+		pkg := agentregistryv1alpha1.Package{
+			RegistryType: registryType,
+			Identifier:   value,
+			Transport:    agentregistryv1alpha1.Transport{Type: transportType},
+		}
+		switch registryType {
+		case "oci":
+			// Already recorded from the deployment image
+			if value == mcpServer.Spec.Deployment.Image {
+				continue
+			}
+		case "npm":
+			pkg.RuntimeHint = "npx"
+		case "pypi":
+			pkg.RuntimeHint = "uvx"
+		}
+		// An image reference carries its own tag or digest. Scoped npm names
+		// start with @, so only a later @ separates a version.
+		if i := strings.LastIndex(value, "@"); i > 0 && registryType != "oci" {
+			pkg.Identifier, pkg.Version = value[:i], value[i+1:]
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, issues
+}
+
 // syncDeploymentStatus syncs deployment status from kagent MCPServer to catalog
 func syncDeploymentStatus(catalog *agentregistryv1alpha1.MCPServerCatalog, mcpServer *kmcpv1alpha1.MCPServer) {
 	ready := false
@@ -869,8 +1265,8 @@ func (r *DiscoveryConfigReconciler) handleAgentAdd(
 	labels[sourceKindLabel] = "Agent"
 	labels[sourceNameLabel] = agent.Name
 	labels[sourceNSLabel] = agent.Namespace
-	labels["agentregistry.dev/environment"] = env.Name
-	labels["agentregistry.dev/cluster"] = env.Cluster.Name
+	labels[EnvironmentLabel] = env.Name
+	labels[ClusterLabel] = env.Cluster.Name
 
 	// Build annotations
 	annotations := make(map[string]string)
@@ -956,13 +1352,18 @@ func (r *DiscoveryConfigReconciler) handleAgentAdd(
 	err := r.Get(ctx, client.ObjectKey{Name: catalogName, Namespace: namespace}, existing)
 
 	if apierrors.IsNotFound(err) {
+		if skip, err := r.rejectDuplicate(ctx, &agentregistryv1alpha1.AgentCatalogList{}, &catalog, catalog.Spec.Name, catalog.Spec.Version); err != nil || skip {
+			return err
+		}
 		if err := r.Create(ctx, &catalog); err != nil {
 			return err
 		}
 		// Set external management type, published status, and deployment info
 		catalog.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		catalog.Status.Published = true
-		catalog.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		now := metav1.Now()
+		catalog.Status.PublishedAt = &now
+		catalog.Status.Status = agentCatalogStatus(&catalog)
 		syncAgentDeploymentStatus(&catalog, agent)
 		return r.Status().Update(ctx, &catalog)
 	} else if err != nil {
@@ -980,7 +1381,11 @@ func (r *DiscoveryConfigReconciler) handleAgentAdd(
 	if existing.Status.ManagementType == "" {
 		existing.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		existing.Status.Published = true
-		existing.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		if existing.Status.PublishedAt == nil {
+			now := metav1.Now()
+			existing.Status.PublishedAt = &now
+		}
+		existing.Status.Status = agentCatalogStatus(existing)
 		needsUpdate = true
 	}
 	if existing.Status.ManagementType == agentregistryv1alpha1.ManagementTypeExternal {
@@ -1021,8 +1426,8 @@ func (r *DiscoveryConfigReconciler) handleModelConfigAdd(
 	labels[sourceKindLabel] = "ModelConfig"
 	labels[sourceNameLabel] = model.Name
 	labels[sourceNSLabel] = model.Namespace
-	labels["agentregistry.dev/environment"] = env.Name
-	labels["agentregistry.dev/cluster"] = env.Cluster.Name
+	labels[EnvironmentLabel] = env.Name
+	labels[ClusterLabel] = env.Cluster.Name
 
 	// Extract BaseURL from provider-specific config
 	baseURL := extractModelConfigBaseURL(model)
@@ -1057,7 +1462,9 @@ func (r *DiscoveryConfigReconciler) handleModelConfigAdd(
 		// Set external management type and published status
 		catalog.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		catalog.Status.Published = true
-		catalog.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		now := metav1.Now()
+		catalog.Status.PublishedAt = &now
+		catalog.Status.Status = modelCatalogStatus(&catalog)
 		catalog.Status.Ready = true
 		return r.Status().Update(ctx, &catalog)
 	} else if err != nil {
@@ -1073,7 +1480,11 @@ func (r *DiscoveryConfigReconciler) handleModelConfigAdd(
 	if existing.Status.ManagementType == "" {
 		existing.Status.ManagementType = agentregistryv1alpha1.ManagementTypeExternal
 		existing.Status.Published = true
-		existing.Status.Status = agentregistryv1alpha1.CatalogStatusActive
+		if existing.Status.PublishedAt == nil {
+			now := metav1.Now()
+			existing.Status.PublishedAt = &now
+		}
+		existing.Status.Status = modelCatalogStatus(existing)
 		existing.Status.Ready = true
 		return r.Status().Update(ctx, existing)
 	}
@@ -1112,42 +1523,53 @@ func (r *DiscoveryConfigReconciler) getRemoteClient(env *agentregistryv1alpha1.E
 	return nil, fmt.Errorf("remote client factory not configured")
 }
 
-// stopAllInformers stops all running informers
-func (r *DiscoveryConfigReconciler) stopAllInformers() {
+// initTracking creates the maps tracking informers and handler errors. Guarded
+// by the mutexes, as concurrent reconciles of different DiscoveryConfigs may
+// be the first to run.
+func (r *DiscoveryConfigReconciler) initTracking() {
+	r.informersMu.Lock()
+	if r.informers == nil {
+		r.informers = make(map[string]cache.SharedIndexInformer)
+		r.stopChans = make(map[string]chan struct{})
+	}
+	if r.informerHashes == nil {
+		r.informerHashes = make(map[string]string)
+	}
+	r.informersMu.Unlock()
+
+	r.errorTrackerMu.Lock()
+	if r.errorTracker == nil {
+		r.errorTracker = make(map[string]*informerError)
+	}
+	r.errorTrackerMu.Unlock()
+}
+
+// stopInformer stops the informer tracked under key, if any
+func (r *DiscoveryConfigReconciler) stopInformer(key string) {
 	r.informersMu.Lock()
 	defer r.informersMu.Unlock()
 
-	for key, stopCh := range r.stopChans {
+	if stopCh, ok := r.stopChans[key]; ok {
 		close(stopCh)
-		r.Logger.Info().Str("key", key).Msg("stopped informer")
 	}
-	r.informers = make(map[string]cache.SharedIndexInformer)
-	r.stopChans = make(map[string]chan struct{})
+	delete(r.stopChans, key)
+	delete(r.informers, key)
+	delete(r.informerHashes, key)
+	r.clearInformerErrors(key)
 }
 
-// shouldRetry determines if an error should be retried based on error type and retry count
-func shouldRetry(err error) bool {
-	if err == nil {
-		return false
-	}
-	// Retry on conflict errors (resource modified)
-	if apierrors.IsConflict(err) {
-		return true
-	}
-	// Retry on timeout errors
-	if apierrors.IsTimeout(err) {
-		return true
-	}
-	// Retry on server errors (500s)
-	if apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) {
-		return true
-	}
-	// Don't retry on not found, already exists, or invalid
-	if apierrors.IsNotFound(err) || apierrors.IsAlreadyExists(err) || apierrors.IsInvalid(err) {
-		return false
+// clearInformerErrors drops the handler errors recorded under key, the key of
+// a stopped informer or of a resource one of them watched, so they no longer
+// fail ReadyCheck
+func (r *DiscoveryConfigReconciler) clearInformerErrors(key string) {
+	r.errorTrackerMu.Lock()
+	defer r.errorTrackerMu.Unlock()
+
+	for resourceKey := range r.errorTracker {
+		if resourceKey == key || strings.HasPrefix(resourceKey, key+"/") {
+			delete(r.errorTracker, resourceKey)
+		}
 	}
-	// Default: retry on unknown errors
-	return true
 }
 
 // maxRetries is the maximum number of retries for informer handlers
@@ -1156,6 +1578,9 @@ const maxRetries = 3
 // retryBackoff is the base backoff duration between retries
 const retryBackoff = 500 * time.Millisecond
 
+// maxRetryBackoff caps the backoff between informer handler retries
+const maxRetryBackoff = 5 * time.Second
+
 // executeWithRetry executes a handler function with retry logic
 func (r *DiscoveryConfigReconciler) executeWithRetry(
 	ctx context.Context,
@@ -1196,7 +1621,7 @@ func (r *DiscoveryConfigReconciler) executeWithRetry(
 	}
 
 	// Calculate backoff with jitter
-	backoff := retryBackoff * time.Duration(retryCount)
+	backoff := retryBackoffFor(retryBackoff, maxRetryBackoff, retryCount)
 	logger.Warn().Err(err).Str("key", resourceKey).Int("retry", retryCount).Dur("backoff", backoff).Msg("informer handler failed, will retry")
 
 	// Retry after backoff
@@ -1213,10 +1638,115 @@ func (r *DiscoveryConfigReconciler) executeWithRetry(
 	})
 }
 
+// notifyStatusChange enqueues config for a status refresh without blocking the
+// informer. Refreshes are debounced over StatusInterval so bursts of events
+// lead to a single status write.
+func (r *DiscoveryConfigReconciler) notifyStatusChange(config *agentregistryv1alpha1.DiscoveryConfig) {
+	if r.statusDebounce == nil {
+		return
+	}
+	r.statusDebounce.notify(config)
+}
+
+// enqueueStatusRefresh hands obj to the controller's workqueue, dropping the
+// request if the channel is full since one is then already pending
+func (r *DiscoveryConfigReconciler) enqueueStatusRefresh(obj client.Object) {
+	select {
+	case r.statusEvents <- event.GenericEvent{Object: obj}:
+	default:
+	}
+}
+
+// environmentStatuses builds the per-environment status of config from its
+// informers: resource counts come from the informer stores, and an environment
+// is connected once all of its informers have synced. setupErrors holds the
+// informer setup failure, if any, for each environment name.
+func (r *DiscoveryConfigReconciler) environmentStatuses(
+	config *agentregistryv1alpha1.DiscoveryConfig,
+	setupErrors map[string]string,
+	now metav1.Time,
+) []agentregistryv1alpha1.EnvironmentStatus {
+	r.informersMu.RLock()
+	defer r.informersMu.RUnlock()
+
+	statuses := make([]agentregistryv1alpha1.EnvironmentStatus, 0, len(config.Spec.Environments))
+	for _, env := range config.Spec.Environments {
+		status := agentregistryv1alpha1.EnvironmentStatus{
+			Name:  env.Name,
+			Error: setupErrors[env.Name],
+		}
+
+		informerCount, synced := 0, 0
+		for _, ns := range env.Namespaces {
+			for _, resourceType := range discoveryResourceTypes(&env) {
+				informer, ok := r.informers[fmt.Sprintf("%s/%s/%s/%s", config.Name, env.Name, ns, resourceType)]
+				if !ok {
+					continue
+				}
+				informerCount++
+				if informer.HasSynced() {
+					synced++
+				}
+
+				count := len(informer.GetStore().ListKeys())
+				switch resourceType {
+				case "MCPServer", "RemoteMCPServer":
+					status.DiscoveredResources.MCPServers += count
+				case "Agent":
+					status.DiscoveredResources.Agents += count
+				case "ModelConfig":
+					status.DiscoveredResources.Models += count
+				}
+			}
+		}
+
+		status.Connected = status.Error == "" && informerCount > 0 && synced == informerCount
+		if status.Connected {
+			status.LastSyncTime = &now
+		}
+		status.Message = fmt.Sprintf("%d of %d informers synced", synced, informerCount)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// ReadyCheck is a healthz.Checker reporting not-ready when a discovery informer
+// has not synced with its cluster, or when an informer handler has failed more
+// than maxRetries times.
+func (r *DiscoveryConfigReconciler) ReadyCheck(_ *http.Request) error {
+	var problems []string
+
+	r.informersMu.RLock()
+	for key, informer := range r.informers {
+		if !informer.HasSynced() {
+			problems = append(problems, fmt.Sprintf("informer %s has not synced", key))
+		}
+	}
+	r.informersMu.RUnlock()
+
+	r.errorTrackerMu.RLock()
+	for key, tracker := range r.errorTracker {
+		if tracker.retryCount > maxRetries {
+			problems = append(problems, fmt.Sprintf("handler for %s failed after %d retries: %v", key, maxRetries, tracker.err))
+		}
+	}
+	r.errorTrackerMu.RUnlock()
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("discovery not ready: %s", strings.Join(problems, "; "))
+}
+
 // SetupWithManager sets up the controller
 func (r *DiscoveryConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Manager = mgr
+	r.statusEvents = make(chan event.GenericEvent, 1024)
+	r.statusDebounce = newStatusDebouncer(r.StatusInterval, r.enqueueStatusRefresh)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentregistryv1alpha1.DiscoveryConfig{}).
-		Complete(r)
+		WatchesRawSource(source.Channel(r.statusEvents, &handler.EnqueueRequestForObject{})).
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(countReconcileErrors("discoveryconfig", traceReconcile("DiscoveryConfig", r)))
 }
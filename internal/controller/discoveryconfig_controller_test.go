@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"os"
 	"sync"
 	"testing"
@@ -12,13 +13,18 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 )
 
@@ -566,3 +572,735 @@ func TestDiscoveryConfigReconciler_InformerLifecycle(t *testing.T) {
 	assert.Empty(t, reconciler.informers)
 	reconciler.informersMu.RUnlock()
 }
+
+func TestDiscoveryConfigReconciler_ReadyCheck(t *testing.T) {
+	r := &DiscoveryConfigReconciler{}
+	assert.NoError(t, r.ReadyCheck(nil), "no informers configured should be ready")
+
+	// An informer that is never run never syncs, as when the remote cluster is unreachable.
+	failing := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+			return nil, errors.New("connection refused")
+		},
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+			return nil, errors.New("connection refused")
+		},
+	}, &kmcpv1alpha1.MCPServer{}, 0, cache.Indexers{})
+	r.informers = map[string]cache.SharedIndexInformer{"discovery/prod/default/MCPServer": failing}
+
+	err := r.ReadyCheck(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "discovery/prod/default/MCPServer has not synced")
+
+	// Handler failures below maxRetries keep the controller ready; beyond it they do not.
+	r.informers = nil
+	r.errorTracker = map[string]*informerError{
+		"prod/default/server": {err: errors.New("conflict"), retryCount: maxRetries},
+	}
+	assert.NoError(t, r.ReadyCheck(nil))
+
+	r.errorTracker["prod/default/server"].retryCount = maxRetries + 1
+	err = r.ReadyCheck(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prod/default/server")
+}
+
+func TestDiscoveryConfigReconciler_EnvironmentStatuses(t *testing.T) {
+	newInformer := func(obj runtime.Object, names ...string) cache.SharedIndexInformer {
+		informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, obj, 0, cache.Indexers{})
+		for _, name := range names {
+			o := obj.DeepCopyObject().(client.Object)
+			o.SetNamespace("default")
+			o.SetName(name)
+			require.NoError(t, informer.GetStore().Add(o))
+		}
+		return informer
+	}
+
+	r := &DiscoveryConfigReconciler{
+		informers: map[string]cache.SharedIndexInformer{
+			"discovery/dev/default/MCPServer":       newInformer(&kmcpv1alpha1.MCPServer{}, "fs", "github"),
+			"discovery/dev/default/RemoteMCPServer": newInformer(&kagentv1alpha2.RemoteMCPServer{}, "remote"),
+			"discovery/dev/default/Agent":           newInformer(&kagentv1alpha2.Agent{}, "planner"),
+		},
+	}
+	config := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "dev", Namespaces: []string{"default"}, ResourceTypes: []string{"MCPServer", "RemoteMCPServer", "Agent"}},
+				{Name: "prod", Namespaces: []string{"default"}},
+			},
+		},
+	}
+
+	statuses := r.environmentStatuses(config, map[string]string{"prod": "failed to create remote client"}, metav1.Now())
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "dev", statuses[0].Name)
+	assert.Equal(t, agentregistryv1alpha1.DiscoveredResourceCounts{MCPServers: 3, Agents: 1}, statuses[0].DiscoveredResources)
+	assert.False(t, statuses[0].Connected, "informers that have not synced are not connected")
+	assert.Empty(t, statuses[0].Error)
+
+	assert.Equal(t, "prod", statuses[1].Name)
+	assert.False(t, statuses[1].Connected)
+	assert.Equal(t, "failed to create remote client", statuses[1].Error)
+	assert.Zero(t, statuses[1].DiscoveredResources)
+}
+
+func TestDiscoveryConfigReconciler_DiscoveredResourceCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	helper := SetupTestEnv(t, 60*time.Second, true)
+	defer helper.Cleanup(t)
+
+	ctx := helper.Ctx
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "counts"}}
+	require.NoError(t, helper.Client.Create(ctx, ns))
+
+	newServer := func(name string) *kmcpv1alpha1.MCPServer {
+		return &kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "counts"},
+			Spec: kmcpv1alpha1.MCPServerSpec{
+				TransportType: "stdio",
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/example/" + name + ":latest"},
+			},
+		}
+	}
+	require.NoError(t, helper.Client.Create(ctx, newServer("first-server")))
+
+	discoveryConfig := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "counts-discovery", Namespace: "default"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{
+					Name:          "dev",
+					Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "dev", Namespace: "counts"},
+					Namespaces:    []string{"counts"},
+					ResourceTypes: []string{"MCPServer", "Agent"},
+				},
+			},
+		},
+	}
+	require.NoError(t, helper.Client.Create(ctx, discoveryConfig))
+
+	reconciler := &DiscoveryConfigReconciler{
+		Client:  helper.Client,
+		Scheme:  helper.Scheme,
+		Logger:  zerolog.Nop(),
+		Manager: helper.Manager,
+	}
+
+	oldFactory := RemoteClientFactory
+	RemoteClientFactory = func(env *agentregistryv1alpha1.Environment, scheme *runtime.Scheme) (client.WithWatch, error) {
+		return &testClientWithWatch{Client: helper.Client}, nil
+	}
+	defer func() { RemoteClientFactory = oldFactory }()
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "counts-discovery", Namespace: "default"}}
+
+	// countsAfterReconcile reconciles and returns the recorded counts for "dev".
+	countsAfterReconcile := func() (agentregistryv1alpha1.EnvironmentStatus, bool) {
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			return agentregistryv1alpha1.EnvironmentStatus{}, false
+		}
+		var updated agentregistryv1alpha1.DiscoveryConfig
+		if err := helper.Client.Get(ctx, req.NamespacedName, &updated); err != nil || len(updated.Status.Environments) != 1 {
+			return agentregistryv1alpha1.EnvironmentStatus{}, false
+		}
+		return updated.Status.Environments[0], true
+	}
+
+	require.Eventually(t, func() bool {
+		status, ok := countsAfterReconcile()
+		return ok && status.Connected && status.DiscoveredResources.MCPServers == 1 && status.DiscoveredResources.Agents == 0
+	}, 10*time.Second, 200*time.Millisecond, "initial MCPServer should be counted")
+
+	// Discovering more resources increments the counts.
+	require.NoError(t, helper.Client.Create(ctx, newServer("second-server")))
+	require.NoError(t, helper.Client.Create(ctx, &kagentv1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "planner", Namespace: "counts"},
+		Spec: kagentv1alpha2.AgentSpec{
+			Type:        kagentv1alpha2.AgentType_Declarative,
+			Declarative: &kagentv1alpha2.DeclarativeAgentSpec{SystemMessage: "plan things"},
+		},
+	}))
+
+	require.Eventually(t, func() bool {
+		status, ok := countsAfterReconcile()
+		return ok && status.DiscoveredResources.MCPServers == 2 && status.DiscoveredResources.Agents == 1
+	}, 10*time.Second, 200*time.Millisecond, "newly discovered MCPServer and Agent should be counted")
+}
+
+func TestDiscoveryConfigReconciler_InvalidResourceType(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	config := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "dev", Namespaces: []string{"default"}, ResourceTypes: []string{"MCPServers"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(config).
+		WithStatusSubresource(config).
+		Build()
+	r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "discovery"}})
+	require.NoError(t, err)
+	assert.Empty(t, r.informers, "no informer is started for an unsupported type")
+
+	var updated agentregistryv1alpha1.DiscoveryConfig
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(config), &updated))
+
+	var degraded *metav1.Condition
+	for i := range updated.Status.Conditions {
+		if updated.Status.Conditions[i].Type == "Degraded" {
+			degraded = &updated.Status.Conditions[i]
+		}
+	}
+	require.NotNil(t, degraded, "expected a Degraded condition")
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, "InvalidResourceType", degraded.Reason)
+	assert.Contains(t, degraded.Message, `"MCPServers"`)
+	assert.Contains(t, degraded.Message, "accepted values are MCPServer, Agent, ModelConfig, RemoteMCPServer")
+
+	require.Len(t, updated.Status.Environments, 1)
+	assert.Contains(t, updated.Status.Environments[0].Error, "unsupported resource type")
+}
+
+func TestDiscoveryConfigReconciler_LabelSelector(t *testing.T) {
+	remoteScheme := runtime.NewScheme()
+	require.NoError(t, kmcpv1alpha1.AddToScheme(remoteScheme))
+	newServer := func(name, team string) *kmcpv1alpha1.MCPServer {
+		return &kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shared", Labels: map[string]string{"team": team}},
+			Spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/example/" + name + ":1.0.0"},
+				TransportType: "stdio",
+			},
+		}
+	}
+	remote := fake.NewClientBuilder().
+		WithScheme(remoteScheme).
+		WithObjects(newServer("search", "a"), newServer("billing", "b")).
+		Build()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	local := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	r := &DiscoveryConfigReconciler{Client: local, Scheme: scheme, Logger: zerolog.Nop(), errorTracker: map[string]*informerError{}}
+
+	env := &agentregistryv1alpha1.Environment{
+		Name:          "dev",
+		Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster"},
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+	}
+	selector, err := discoverySelector(env)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informer := r.createMCPServerInformer(ctx, "discovery/dev/shared/MCPServer", remote, "shared", selector, env, zerolog.Nop())
+	go informer.Run(ctx.Done())
+	require.True(t, cache.WaitForCacheSync(ctx.Done(), informer.HasSynced))
+
+	catalogExists := func(name string) bool {
+		err := local.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: generateCatalogName("shared", name)}, &agentregistryv1alpha1.MCPServerCatalog{})
+		return err == nil
+	}
+
+	require.Eventually(t, func() bool { return catalogExists("search") }, 5*time.Second, 20*time.Millisecond)
+	assert.False(t, catalogExists("billing"), "resources outside the selector are not catalogued")
+
+	// Relabelling the server out of the selector removes its catalog entry
+	var server kmcpv1alpha1.MCPServer
+	require.NoError(t, remote.Get(ctx, client.ObjectKey{Namespace: "shared", Name: "search"}, &server))
+	server.Labels["team"] = "c"
+	require.NoError(t, remote.Update(ctx, &server))
+
+	require.Eventually(t, func() bool { return !catalogExists("search") }, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestMCPServerCatalogTransports(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         kmcpv1alpha1.MCPServerSpec
+		annotations  map[string]string
+		wantPackages []agentregistryv1alpha1.Package
+		wantRemotes  []agentregistryv1alpha1.Transport
+		wantIssue    string
+	}{
+		{
+			name: "stdio image",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/fs:1.0.0"},
+				TransportType: kmcpv1alpha1.TransportTypeStdio,
+			},
+			wantPackages: []agentregistryv1alpha1.Package{{RegistryType: "oci", Identifier: "ghcr.io/org/fs:1.0.0", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}}},
+		},
+		{
+			name: "http image",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/search:2.0.0"},
+				TransportType: kmcpv1alpha1.TransportTypeHTTP,
+				HTTPTransport: &kmcpv1alpha1.HTTPTransport{TargetPort: 8080},
+			},
+			wantPackages: []agentregistryv1alpha1.Package{{RegistryType: "oci", Identifier: "ghcr.io/org/search:2.0.0", Transport: agentregistryv1alpha1.Transport{Type: "streamable-http"}}},
+		},
+		{
+			name: "transport type unset, inferred from http block",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/search:2.0.0"},
+				HTTPTransport: &kmcpv1alpha1.HTTPTransport{TargetPort: 8080},
+			},
+			wantPackages: []agentregistryv1alpha1.Package{{RegistryType: "oci", Identifier: "ghcr.io/org/search:2.0.0", Transport: agentregistryv1alpha1.Transport{Type: "streamable-http"}}},
+		},
+		{
+			name: "unknown transport type",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/fs:1.0.0"},
+				TransportType: "websocket",
+			},
+			wantPackages: []agentregistryv1alpha1.Package{{RegistryType: "oci", Identifier: "ghcr.io/org/fs:1.0.0", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}}},
+			wantIssue:    `unknown transport type "websocket"`,
+		},
+		{
+			name: "no image, served on a port",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Port: 3000},
+				TransportType: kmcpv1alpha1.TransportTypeHTTP,
+			},
+			wantRemotes: []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "http://search.tools.svc.cluster.local:3000/mcp"}},
+			wantIssue:   "no deployment image",
+		},
+		{
+			name: "no image, served on a configured path",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Port: 3000},
+				TransportType: kmcpv1alpha1.TransportTypeHTTP,
+				HTTPTransport: &kmcpv1alpha1.HTTPTransport{TargetPort: 3000, TargetPath: "api/mcp"},
+			},
+			wantRemotes: []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "http://search.tools.svc.cluster.local:3000/api/mcp"}},
+			wantIssue:   "no deployment image",
+		},
+		{
+			name:      "no image or port",
+			spec:      kmcpv1alpha1.MCPServerSpec{TransportType: kmcpv1alpha1.TransportTypeStdio},
+			wantIssue: "no package or remote recorded",
+		},
+		{
+			name: "image with annotated packages",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/fs:1.0.0"},
+				TransportType: kmcpv1alpha1.TransportTypeStdio,
+			},
+			annotations: map[string]string{
+				"agentregistry.dev/package.pypi": "mcp-server-fs@1.0.0",
+				"agentregistry.dev/package.npm":  "@org/server-fs@1.0.0",
+				"agentregistry.dev/package.oci":  "ghcr.io/org/fs:1.0.0",
+				"kmcp.dev/description":           "not a package",
+			},
+			wantPackages: []agentregistryv1alpha1.Package{
+				{RegistryType: "oci", Identifier: "ghcr.io/org/fs:1.0.0", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+				{RegistryType: "npm", Identifier: "@org/server-fs", Version: "1.0.0", RuntimeHint: "npx", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+				{RegistryType: "pypi", Identifier: "mcp-server-fs", Version: "1.0.0", RuntimeHint: "uvx", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+			},
+		},
+		{
+			name: "annotated packages without an image",
+			spec: kmcpv1alpha1.MCPServerSpec{TransportType: kmcpv1alpha1.TransportTypeStdio},
+			annotations: map[string]string{
+				"agentregistry.dev/package.npm": "@org/server-fs",
+				"agentregistry.dev/package.oci": "ghcr.io/org/fs@sha256:0123",
+			},
+			wantPackages: []agentregistryv1alpha1.Package{
+				{RegistryType: "npm", Identifier: "@org/server-fs", RuntimeHint: "npx", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+				{RegistryType: "oci", Identifier: "ghcr.io/org/fs@sha256:0123", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+			},
+		},
+		{
+			name: "empty package annotation",
+			spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/fs:1.0.0"},
+				TransportType: kmcpv1alpha1.TransportTypeStdio,
+			},
+			annotations:  map[string]string{"agentregistry.dev/package.npm": " "},
+			wantPackages: []agentregistryv1alpha1.Package{{RegistryType: "oci", Identifier: "ghcr.io/org/fs:1.0.0", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}}},
+			wantIssue:    `ignored package annotation "agentregistry.dev/package.npm"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &kmcpv1alpha1.MCPServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "tools", Annotations: tt.annotations},
+				Spec:       tt.spec,
+			}
+			packages, remotes, issues := mcpServerCatalogTransports(server)
+			assert.Equal(t, tt.wantPackages, packages)
+			assert.Equal(t, tt.wantRemotes, remotes)
+			if tt.wantIssue == "" {
+				assert.Empty(t, issues)
+			} else {
+				require.Len(t, issues, 1)
+				assert.Contains(t, issues[0], tt.wantIssue)
+			}
+		})
+	}
+}
+
+func TestDiscoveryConfigReconciler_MCPServerDiscoveryIncomplete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+	env := &agentregistryv1alpha1.Environment{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster"}}
+
+	// Remote-only MCPServer without a deployment image
+	server := &kmcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "tools"},
+		Spec: kmcpv1alpha1.MCPServerSpec{
+			Deployment:    kmcpv1alpha1.MCPServerDeployment{Port: 3000},
+			TransportType: kmcpv1alpha1.TransportTypeHTTP,
+		},
+	}
+	require.NoError(t, r.handleMCPServerAdd(ctx, server, env))
+
+	var catalog agentregistryv1alpha1.MCPServerCatalog
+	key := client.ObjectKey{Namespace: testNamespace, Name: generateCatalogName("tools", "search")}
+	require.NoError(t, c.Get(ctx, key, &catalog))
+	assert.Empty(t, catalog.Spec.Packages)
+	require.Len(t, catalog.Spec.Remotes, 1)
+	require.Len(t, catalog.Status.Conditions, 1)
+	assert.Equal(t, agentregistryv1alpha1.CatalogConditionDiscoveryIncomplete, catalog.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, catalog.Status.Conditions[0].Status)
+	assert.Contains(t, catalog.Status.Conditions[0].Message, "no deployment image")
+
+	// Once an image is set the mapping is complete and the condition is cleared
+	server.Spec.Deployment.Image = "ghcr.io/org/search:1.0.0"
+	require.NoError(t, r.handleMCPServerAdd(ctx, server, env))
+	require.NoError(t, c.Get(ctx, key, &catalog))
+	require.Len(t, catalog.Spec.Packages, 1)
+	assert.Empty(t, catalog.Spec.Remotes)
+	assert.Empty(t, catalog.Status.Conditions)
+}
+
+func TestDiscoveryConfigReconciler_MCPServerAnnotatedPackages(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+	env := &agentregistryv1alpha1.Environment{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster"}}
+
+	server := &kmcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "everything",
+			Namespace: "tools",
+			Annotations: map[string]string{
+				agentregistryv1alpha1.AnnotationPackagePrefix + "npm": "@modelcontextprotocol/server-everything@2025.9.25",
+			},
+		},
+		Spec: kmcpv1alpha1.MCPServerSpec{
+			Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/everything:2025.9.25"},
+			TransportType: kmcpv1alpha1.TransportTypeStdio,
+		},
+	}
+	require.NoError(t, r.handleMCPServerAdd(ctx, server, env))
+
+	var catalog agentregistryv1alpha1.MCPServerCatalog
+	key := client.ObjectKey{Namespace: testNamespace, Name: generateCatalogName("tools", "everything")}
+	require.NoError(t, c.Get(ctx, key, &catalog))
+	require.Len(t, catalog.Spec.Packages, 2)
+	assert.Equal(t, "oci", catalog.Spec.Packages[0].RegistryType, "the image stays the default package")
+	assert.Equal(t, "npm", catalog.Spec.Packages[1].RegistryType)
+	assert.Equal(t, "@modelcontextprotocol/server-everything", catalog.Spec.Packages[1].Identifier)
+	assert.Equal(t, "2025.9.25", catalog.Spec.Packages[1].Version)
+	assert.Empty(t, catalog.Status.Conditions)
+
+	// Removing the annotation degrades to the image package
+	server.Annotations = nil
+	require.NoError(t, r.handleMCPServerAdd(ctx, server, env))
+	require.NoError(t, c.Get(ctx, key, &catalog))
+	require.Len(t, catalog.Spec.Packages, 1)
+	assert.Equal(t, "oci", catalog.Spec.Packages[0].RegistryType)
+}
+
+func TestDiscoveryConfigReconciler_ResyncRestoresDeletedCatalogEntry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	remote := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "filesystem", Namespace: "tools"},
+			Spec: kmcpv1alpha1.MCPServerSpec{
+				Deployment:    kmcpv1alpha1.MCPServerDeployment{Image: "ghcr.io/org/filesystem:1.0.0"},
+				TransportType: kmcpv1alpha1.TransportTypeStdio,
+			},
+		}).
+		Build()
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	// client-go resyncs handlers at most once a second
+	r := &DiscoveryConfigReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		Logger:       zerolog.Nop(),
+		ResyncPeriod: time.Second,
+		errorTracker: make(map[string]*informerError),
+	}
+	ctx := context.Background()
+	env := &agentregistryv1alpha1.Environment{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster"}}
+
+	informer := r.createMCPServerInformer(ctx, "discovery/dev/tools/MCPServer", remote, "tools", labels.Everything(), env, zerolog.Nop())
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+	t.Cleanup(func() { deleteDiscoveredMCPServer("tools", "filesystem") })
+
+	key := client.ObjectKey{Namespace: testNamespace, Name: generateCatalogName("tools", "filesystem")}
+	catalogExists := func() bool {
+		return c.Get(ctx, key, &agentregistryv1alpha1.MCPServerCatalog{}) == nil
+	}
+	require.Eventually(t, catalogExists, 5*time.Second, 50*time.Millisecond, "the catalog entry is created on the initial list")
+
+	// Deleted out-of-band, with no watch event for the source
+	require.NoError(t, c.Delete(ctx, &agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}))
+	require.False(t, catalogExists())
+
+	assert.Eventually(t, catalogExists, 5*time.Second, 50*time.Millisecond, "a resync recreates the catalog entry")
+}
+
+// runnableRecorder is a manager that only records the runnables added to it
+type runnableRecorder struct {
+	manager.Manager
+	mu        sync.Mutex
+	runnables []manager.Runnable
+}
+
+func (m *runnableRecorder) Add(runnable manager.Runnable) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runnables = append(m.runnables, runnable)
+	return nil
+}
+
+func TestDiscoveryConfigReconciler_RestartsInformersOfChangedEnvironments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	remote := fake.NewClientBuilder().WithScheme(scheme).Build()
+	oldFactory := RemoteClientFactory
+	RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+		return remote, nil
+	}
+	defer func() { RemoteClientFactory = oldFactory }()
+
+	config := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: testNamespace},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{
+					Name:          "dev",
+					Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster", Endpoint: "https://dev.example.com"},
+					Namespaces:    []string{"tools"},
+					ResourceTypes: []string{"MCPServer"},
+				},
+				{
+					Name:          "prod",
+					Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "prod-cluster"},
+					Namespaces:    []string{"tools"},
+					ResourceTypes: []string{"MCPServer"},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(config).
+		WithStatusSubresource(&agentregistryv1alpha1.DiscoveryConfig{}).
+		Build()
+	mgr := &runnableRecorder{}
+	r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop(), Manager: mgr}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "discovery", Namespace: testNamespace}}
+	reconcileWith := func(mutate func(*agentregistryv1alpha1.DiscoveryConfig)) {
+		t.Helper()
+		if mutate != nil {
+			var current agentregistryv1alpha1.DiscoveryConfig
+			require.NoError(t, c.Get(ctx, req.NamespacedName, &current))
+			mutate(&current)
+			require.NoError(t, c.Update(ctx, &current))
+		}
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+	}
+	stopChan := func(key string) chan struct{} {
+		r.informersMu.RLock()
+		defer r.informersMu.RUnlock()
+		return r.stopChans[key]
+	}
+	stopped := func(ch chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+
+	const (
+		devTools  = "discovery/dev/tools/MCPServer"
+		devAgents = "discovery/dev/agents/MCPServer"
+		prodTools = "discovery/prod/tools/MCPServer"
+	)
+	reconcileWith(nil)
+	require.Len(t, mgr.runnables, 2)
+	devCh, prodCh := stopChan(devTools), stopChan(prodTools)
+	require.NotNil(t, devCh)
+	require.NotNil(t, prodCh)
+
+	// Reconciling an unchanged spec keeps the informers running
+	reconcileWith(nil)
+	assert.Len(t, mgr.runnables, 2)
+	assert.False(t, stopped(devCh))
+
+	// Moving the dev environment to another namespace stops its informer and
+	// starts one for the new namespace
+	reconcileWith(func(dc *agentregistryv1alpha1.DiscoveryConfig) {
+		dc.Spec.Environments[0].Namespaces = []string{"agents"}
+	})
+	assert.True(t, stopped(devCh), "the informer of the old namespace stops")
+	assert.Nil(t, stopChan(devTools))
+	require.NotNil(t, stopChan(devAgents), "an informer starts for the new namespace")
+	assert.Len(t, mgr.runnables, 3)
+
+	// Changing the cluster connection restarts the informer under the same key
+	devCh = stopChan(devAgents)
+	reconcileWith(func(dc *agentregistryv1alpha1.DiscoveryConfig) {
+		dc.Spec.Environments[0].Cluster.Endpoint = "https://dev-2.example.com"
+	})
+	assert.True(t, stopped(devCh), "the informer of the old cluster config stops")
+	restarted := stopChan(devAgents)
+	require.NotNil(t, restarted)
+	assert.False(t, stopped(restarted))
+	assert.Len(t, mgr.runnables, 4)
+
+	// Changes to fields discovery does not use restart nothing
+	reconcileWith(func(dc *agentregistryv1alpha1.DiscoveryConfig) {
+		dc.Spec.Environments[0].DeployEnabled = true
+	})
+	assert.Len(t, mgr.runnables, 4)
+
+	// The prod environment was never touched
+	assert.False(t, stopped(prodCh))
+	assert.Equal(t, prodCh, stopChan(prodTools))
+
+	r.stopStaleInformers("discovery", nil)
+}
+
+func TestDiscoveryConfigReconciler_ConcurrentConfigs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	remote := fake.NewClientBuilder().WithScheme(scheme).Build()
+	oldFactory := RemoteClientFactory
+	RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+		return remote, nil
+	}
+	defer func() { RemoteClientFactory = oldFactory }()
+
+	newConfig := func(name string) *agentregistryv1alpha1.DiscoveryConfig {
+		return &agentregistryv1alpha1.DiscoveryConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+				Environments: []agentregistryv1alpha1.Environment{{
+					Name:          "dev",
+					Cluster:       agentregistryv1alpha1.ClusterConfig{Name: name + "-cluster"},
+					Namespaces:    []string{"tools"},
+					ResourceTypes: []string{"MCPServer"},
+				}},
+			},
+		}
+	}
+	teamA, teamB := newConfig("team-a"), newConfig("team-b")
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(teamA, teamB).
+		WithStatusSubresource(&agentregistryv1alpha1.DiscoveryConfig{}).
+		Build()
+	r := &DiscoveryConfigReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop(), Manager: &runnableRecorder{}}
+	ctx := context.Background()
+	reconcileConfig := func(name string) error {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: testNamespace}})
+		return err
+	}
+	stopChan := func(key string) chan struct{} {
+		r.informersMu.RLock()
+		defer r.informersMu.RUnlock()
+		return r.stopChans[key]
+	}
+
+	// The first reconciles of a fresh reconciler run concurrently
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, name := range []string{"team-a", "team-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = reconcileConfig(name)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	teamACh, teamBCh := stopChan("team-a/dev/tools/MCPServer"), stopChan("team-b/dev/tools/MCPServer")
+	require.NotNil(t, teamACh)
+	require.NotNil(t, teamBCh)
+
+	// Deleting one config stops only its own informers
+	require.NoError(t, c.Delete(ctx, teamA))
+	require.NoError(t, reconcileConfig("team-a"))
+	assert.Nil(t, stopChan("team-a/dev/tools/MCPServer"))
+	select {
+	case <-teamACh:
+	default:
+		t.Error("the informer of the deleted config keeps running")
+	}
+	select {
+	case <-teamBCh:
+		t.Error("the informer of another config was stopped")
+	default:
+	}
+
+	r.stopStaleInformers("team-b", nil)
+}
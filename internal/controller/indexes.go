@@ -12,14 +12,16 @@ import (
 // Index field names for cache queries
 const (
 	// MCPServerCatalog indexes
-	IndexMCPServerName      = "spec.name"
-	IndexMCPServerPublished = "status.published"
-	IndexMCPServerIsLatest  = "status.isLatest"
+	IndexMCPServerName        = "spec.name"
+	IndexMCPServerNameVersion = "spec.nameVersion"
+	IndexMCPServerPublished   = "status.published"
+	IndexMCPServerIsLatest    = "status.isLatest"
 
 	// AgentCatalog indexes
-	IndexAgentName      = "spec.name"
-	IndexAgentPublished = "status.published"
-	IndexAgentIsLatest  = "status.isLatest"
+	IndexAgentName        = "spec.name"
+	IndexAgentNameVersion = "spec.nameVersion"
+	IndexAgentPublished   = "status.published"
+	IndexAgentIsLatest    = "status.isLatest"
 
 	// SkillCatalog indexes
 	IndexSkillName      = "spec.name"
@@ -31,11 +33,45 @@ const (
 	IndexModelPublished = "status.published"
 
 	// RegistryDeployment indexes
-	IndexDeploymentResourceName = "spec.resourceName"
-	IndexDeploymentResourceType = "spec.resourceType"
-	IndexDeploymentRuntime      = "spec.runtime"
+	IndexDeploymentResourceName    = "spec.resourceName"
+	IndexDeploymentResourceVersion = "spec.resourceNameVersion"
+	IndexDeploymentResourceType    = "spec.resourceType"
+	IndexDeploymentRuntime         = "spec.runtime"
 )
 
+// NameVersionKey is the value IndexMCPServerNameVersion, IndexAgentNameVersion
+// and IndexDeploymentResourceVersion index a name and version under
+func NameVersionKey(name, version string) string {
+	return name + "@" + version
+}
+
+// nameVersionIndexValues indexes name and version as a single key, leaving
+// objects missing either out of the index
+func nameVersionIndexValues(name, version string) []string {
+	if name == "" || version == "" {
+		return nil
+	}
+	return []string{NameVersionKey(name, version)}
+}
+
+// MCPServerNameVersionIndex is the IndexMCPServerNameVersion index function
+func MCPServerNameVersionIndex(obj client.Object) []string {
+	server := obj.(*agentregistryv1alpha1.MCPServerCatalog)
+	return nameVersionIndexValues(server.Spec.Name, server.Spec.Version)
+}
+
+// AgentNameVersionIndex is the IndexAgentNameVersion index function
+func AgentNameVersionIndex(obj client.Object) []string {
+	agent := obj.(*agentregistryv1alpha1.AgentCatalog)
+	return nameVersionIndexValues(agent.Spec.Name, agent.Spec.Version)
+}
+
+// DeploymentResourceVersionIndex is the IndexDeploymentResourceVersion index function
+func DeploymentResourceVersionIndex(obj client.Object) []string {
+	deploy := obj.(*agentregistryv1alpha1.RegistryDeployment)
+	return nameVersionIndexValues(deploy.Spec.ResourceName, deploy.Spec.Version)
+}
+
 // SetupIndexes configures cache indexes for efficient queries
 func SetupIndexes(mgr ctrl.Manager) error {
 	// MCPServerCatalog indexes
@@ -51,6 +87,15 @@ func SetupIndexes(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&agentregistryv1alpha1.MCPServerCatalog{},
+		IndexMCPServerNameVersion,
+		MCPServerNameVersionIndex,
+	); err != nil {
+		return err
+	}
+
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&agentregistryv1alpha1.MCPServerCatalog{},
@@ -94,6 +139,15 @@ func SetupIndexes(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&agentregistryv1alpha1.AgentCatalog{},
+		IndexAgentNameVersion,
+		AgentNameVersionIndex,
+	); err != nil {
+		return err
+	}
+
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&agentregistryv1alpha1.AgentCatalog{},
@@ -208,6 +262,15 @@ func SetupIndexes(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&agentregistryv1alpha1.RegistryDeployment{},
+		IndexDeploymentResourceVersion,
+		DeploymentResourceVersionIndex,
+	); err != nil {
+		return err
+	}
+
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&agentregistryv1alpha1.RegistryDeployment{},
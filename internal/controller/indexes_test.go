@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestNameVersionIndexes_EmptyFields(t *testing.T) {
+	assert.Equal(t, []string{"org/search@1.0.0"}, MCPServerNameVersionIndex(&agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "1.0.0"},
+	}))
+	assert.Nil(t, MCPServerNameVersionIndex(&agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search"},
+	}))
+	assert.Nil(t, AgentNameVersionIndex(&agentregistryv1alpha1.AgentCatalog{
+		Spec: agentregistryv1alpha1.AgentCatalogSpec{Version: "1.0.0"},
+	}))
+	assert.Nil(t, DeploymentResourceVersionIndex(&agentregistryv1alpha1.RegistryDeployment{}))
+}
+
+func TestDeploymentResourceVersionIndex(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	deployment := func(name, resourceName, version string) client.Object {
+		return &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: resourceName,
+				Version:      version,
+				ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.RegistryDeployment{}, IndexDeploymentResourceVersion, DeploymentResourceVersionIndex).
+		WithObjects(
+			deployment("search-dev", "org/search", "1.0.0"),
+			deployment("search-prod", "org/search", "1.0.0"),
+			deployment("search-next", "org/search", "2.0.0"),
+			deployment("other", "org/other", "1.0.0"),
+			deployment("unversioned", "org/search", ""),
+		).
+		Build()
+	ctx := context.Background()
+
+	names := func(key string) []string {
+		var list agentregistryv1alpha1.RegistryDeploymentList
+		require.NoError(t, c.List(ctx, &list, client.MatchingFields{IndexDeploymentResourceVersion: key}))
+		var names []string
+		for _, d := range list.Items {
+			names = append(names, d.Name)
+		}
+		return names
+	}
+
+	assert.ElementsMatch(t, []string{"search-dev", "search-prod"}, names(NameVersionKey("org/search", "1.0.0")))
+	assert.Equal(t, []string{"search-next"}, names(NameVersionKey("org/search", "2.0.0")))
+	assert.Empty(t, names(NameVersionKey("org/search", "3.0.0")))
+	assert.Empty(t, names(NameVersionKey("org/search", "")))
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restartPullFailedPods deletes the pods of the MCP servers in objs that fail
+// to pull their image and were admitted without the pull secrets of the
+// server's ServiceAccount. Pull secrets are added to a pod from its
+// ServiceAccount only when the pod is created, and kmcp replaces the
+// ServiceAccount on its own reconciles, so a pod created while the secrets
+// were missing is left in ImagePullBackOff. Its ReplicaSet recreates it with
+// the secrets applied this reconcile. Resources applied through an MCP tool
+// server are skipped as their pods cannot be listed.
+func (r *RegistryDeploymentReconciler) restartPullFailedPods(ctx context.Context, targets deploymentTargets, objs []managedObject) {
+	for _, m := range objs {
+		sa, ok := m.obj.(*corev1.ServiceAccount)
+		if !ok || len(sa.ImagePullSecrets) == 0 {
+			continue
+		}
+		target, ok := targets.find(m.ref.Cluster)
+		if !ok || target.mcpURL != "" {
+			continue
+		}
+
+		var pods corev1.PodList
+		if err := target.client.List(ctx, &pods, client.InNamespace(sa.Namespace), client.MatchingLabels{
+			"app.kubernetes.io/instance":   sa.Name,
+			"app.kubernetes.io/managed-by": "kmcp",
+		}); err != nil {
+			r.Logger.Warn().Err(err).Str("namespace", sa.Namespace).Str("server", sa.Name).
+				Msg("failed to list MCP server pods for image pull secrets")
+			continue
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.DeletionTimestamp != nil || hasPullSecrets(pod, sa.ImagePullSecrets) || !imagePullFailing(pod) {
+				continue
+			}
+			if err := target.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+				r.Logger.Warn().Err(err).Str("namespace", pod.Namespace).Str("pod", pod.Name).
+					Msg("failed to restart MCP server pod without image pull secrets")
+				continue
+			}
+			r.Logger.Info().Str("namespace", pod.Namespace).Str("pod", pod.Name).
+				Msg("restarted MCP server pod to apply image pull secrets")
+		}
+	}
+}
+
+// hasPullSecrets reports whether pod was admitted with every secret in secrets
+func hasPullSecrets(pod *corev1.Pod, secrets []corev1.LocalObjectReference) bool {
+	for _, secret := range secrets {
+		if !slices.Contains(pod.Spec.ImagePullSecrets, secret) {
+			return false
+		}
+	}
+	return true
+}
+
+// imagePullFailing reports whether a container of pod is waiting on an image
+// it failed to pull
+func imagePullFailing(pod *corev1.Pod) bool {
+	statuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// mcpSessionIdleTimeout is how long an unused MCP tool server session is
+	// kept open for reuse
+	mcpSessionIdleTimeout = 2 * time.Minute
+	// mcpConnectTimeout bounds connecting to an MCP tool server, which is
+	// shared by every call waiting on it and so outlives any one's context
+	mcpConnectTimeout = 30 * time.Second
+)
+
+// pooledMCPSession is an open MCP tool server session and when it was last
+// used
+type pooledMCPSession struct {
+	session  *mcp.ClientSession
+	lastUsed time.Time
+}
+
+// mcpSessionPool keeps one session per MCP tool server URL, so the applies and
+// deletes of a reconcile share a connection instead of opening one per
+// resource. Sessions idle for longer than idleTimeout are closed. The zero
+// value is ready to use.
+type mcpSessionPool struct {
+	mu       sync.Mutex
+	sessions map[string]*pooledMCPSession
+	// dials connects to each URL once for all the calls needing a session,
+	// outside mu so a slow server does not block the others
+	dials singleflight.Group
+	// idleTimeout overrides mcpSessionIdleTimeout when non-zero
+	idleTimeout time.Duration
+	// now overrides time.Now in tests
+	now func() time.Time
+}
+
+// callTool calls the named tool on the MCP tool server at mcpURL over its
+// pooled session. A call failing on a session opened by an earlier call is
+// retried once on a new session, since the server may have dropped it.
+func (p *mcpSessionPool) callTool(ctx context.Context, mcpURL string, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	session, reused, err := p.session(ctx, mcpURL)
+	if err != nil {
+		return nil, err
+	}
+	result, err := session.CallTool(ctx, params)
+	if err == nil || ctx.Err() != nil {
+		return result, err
+	}
+	p.invalidate(mcpURL, session)
+	if !reused {
+		return nil, err
+	}
+	if session, _, err = p.session(ctx, mcpURL); err != nil {
+		return nil, err
+	}
+	result, err = session.CallTool(ctx, params)
+	if err != nil {
+		p.invalidate(mcpURL, session)
+	}
+	return result, err
+}
+
+// session returns the open session to mcpURL, connecting when there is none,
+// and whether it was opened by an earlier call. Sessions past their idle
+// timeout are closed first.
+func (p *mcpSessionPool) session(ctx context.Context, mcpURL string) (*mcp.ClientSession, bool, error) {
+	if session, ok := p.pooled(mcpURL); ok {
+		return session, true, nil
+	}
+
+	ch := p.dials.DoChan(mcpURL, func() (any, error) {
+		// Another dial may have finished since the lookup above
+		if session, ok := p.pooled(mcpURL); ok {
+			return session, nil
+		}
+		dialCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), mcpConnectTimeout)
+		defer cancel()
+		mcpClient := mcp.NewClient(&mcp.Implementation{
+			Name:    "agentregistry",
+			Version: "1.0.0",
+		}, nil)
+		session, err := mcpClient.Connect(dialCtx, &mcp.StreamableClientTransport{
+			Endpoint:   mcpURL,
+			HTTPClient: tracedHTTPClient,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to MCP tool server at %s: %w", mcpURL, err)
+		}
+		p.mu.Lock()
+		if p.sessions == nil {
+			p.sessions = make(map[string]*pooledMCPSession)
+		}
+		p.sessions[mcpURL] = &pooledMCPSession{session: session, lastUsed: p.timeNow()}
+		p.mu.Unlock()
+		return session, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, false, res.Err
+		}
+		return res.Val.(*mcp.ClientSession), false, nil
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+}
+
+// pooled returns the open session to mcpURL, if any, after closing the
+// sessions past their idle timeout
+func (p *mcpSessionPool) pooled(mcpURL string) (*mcp.ClientSession, bool) {
+	p.mu.Lock()
+	idle := p.takeIdleLocked()
+	pooled, ok := p.sessions[mcpURL]
+	if ok {
+		pooled.lastUsed = p.timeNow()
+	}
+	p.mu.Unlock()
+
+	for _, session := range idle {
+		_ = session.Close()
+	}
+	if !ok {
+		return nil, false
+	}
+	return pooled.session, true
+}
+
+// invalidate closes session and drops it from the pool if it is still the
+// session to mcpURL
+func (p *mcpSessionPool) invalidate(mcpURL string, session *mcp.ClientSession) {
+	p.mu.Lock()
+	if pooled, ok := p.sessions[mcpURL]; ok && pooled.session == session {
+		delete(p.sessions, mcpURL)
+	}
+	p.mu.Unlock()
+	_ = session.Close()
+}
+
+// takeIdleLocked drops the sessions unused for longer than the idle timeout
+// from the pool and returns them for the caller to close once p.mu is
+// released. p.mu must be held.
+func (p *mcpSessionPool) takeIdleLocked() []*mcp.ClientSession {
+	idleTimeout := p.idleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = mcpSessionIdleTimeout
+	}
+	now := p.timeNow()
+	var idle []*mcp.ClientSession
+	for mcpURL, pooled := range p.sessions {
+		if now.Sub(pooled.lastUsed) > idleTimeout {
+			idle = append(idle, pooled.session)
+			delete(p.sessions, mcpURL)
+		}
+	}
+	return idle
+}
+
+// close closes every pooled session
+func (p *mcpSessionPool) close() {
+	p.mu.Lock()
+	sessions := make([]*mcp.ClientSession, 0, len(p.sessions))
+	for mcpURL, pooled := range p.sessions {
+		sessions = append(sessions, pooled.session)
+		delete(p.sessions, mcpURL)
+	}
+	p.mu.Unlock()
+
+	for _, session := range sessions {
+		_ = session.Close()
+	}
+}
+
+func (p *mcpSessionPool) timeNow() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// mockToolServer is an MCP tool server recording the sessions opened to it and
+// the tools called
+type mockToolServer struct {
+	*httptest.Server
+	sessions atomic.Int32
+	// expired rejects requests of the session with this ID, as a server that
+	// restarted would
+	expired atomic.Value
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func newMockToolServer(t *testing.T) *mockToolServer {
+	t.Helper()
+	m := &mockToolServer{}
+	server := mcp.NewServer(&mcp.Implementation{Name: "tool-server", Version: "1.0.0"}, nil)
+	for _, name := range []string{"k8s_apply_manifest", "k8s_delete_resource"} {
+		server.AddTool(&mcp.Tool{Name: name, InputSchema: map[string]any{"type": "object"}},
+			func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				m.mu.Lock()
+				m.calls = append(m.calls, name)
+				m.mu.Unlock()
+				return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+			})
+	}
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		m.sessions.Add(1)
+		return server
+	}, nil)
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if id := req.Header.Get("Mcp-Session-Id"); id != "" && id == m.expired.Load() {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockToolServer) toolCalls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+func testConfigMaps(names ...string) []managedObject {
+	var objs []managedObject
+	for _, name := range names {
+		objs = append(objs, managedObject{
+			obj: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "target-ns"},
+			},
+			ref: agentregistryv1alpha1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Name: name, Namespace: "target-ns"},
+		})
+	}
+	return objs
+}
+
+func TestRegistryDeploymentReconciler_ApplyManagedObjects_ReusesMCPSession(t *testing.T) {
+	toolServer := newMockToolServer(t)
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	t.Cleanup(r.mcpSessions.close)
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "pooled", Namespace: "default"},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{
+			ManagedResources: []agentregistryv1alpha1.ManagedResource{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "cm-stale", Namespace: "target-ns"},
+			},
+		},
+	}
+
+	// Three applies and the prune of the stale resource share one session
+	require.NoError(t, r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{mcpURL: toolServer.URL}}, testConfigMaps("cm-a", "cm-b", "cm-c")))
+	assert.Equal(t, int32(1), toolServer.sessions.Load())
+	assert.Equal(t, []string{"k8s_apply_manifest", "k8s_apply_manifest", "k8s_apply_manifest", "k8s_delete_resource"}, toolServer.toolCalls())
+
+	// A later reconcile reuses it too
+	require.NoError(t, r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{mcpURL: toolServer.URL}}, testConfigMaps("cm-a")))
+	assert.Equal(t, int32(1), toolServer.sessions.Load())
+}
+
+func TestMCPSessionPool_ReconnectsDroppedSession(t *testing.T) {
+	toolServer := newMockToolServer(t)
+	var pool mcpSessionPool
+	t.Cleanup(pool.close)
+	ctx := context.Background()
+	params := &mcp.CallToolParams{Name: "k8s_apply_manifest", Arguments: map[string]any{}}
+
+	_, err := pool.callTool(ctx, toolServer.URL, params)
+	require.NoError(t, err)
+	session, _, err := pool.session(ctx, toolServer.URL)
+	require.NoError(t, err)
+
+	// The server forgets the session; the call is retried on a new one
+	toolServer.expired.Store(session.ID())
+	result, err := pool.callTool(ctx, toolServer.URL, params)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, int32(2), toolServer.sessions.Load())
+	assert.Len(t, toolServer.toolCalls(), 2)
+
+	replaced, reused, err := pool.session(ctx, toolServer.URL)
+	require.NoError(t, err)
+	assert.True(t, reused)
+	assert.NotEqual(t, session.ID(), replaced.ID())
+}
+
+func TestMCPSessionPool_ClosesIdleSessions(t *testing.T) {
+	toolServer := newMockToolServer(t)
+	now := time.Now()
+	pool := &mcpSessionPool{idleTimeout: time.Minute, now: func() time.Time { return now }}
+	t.Cleanup(pool.close)
+	ctx := context.Background()
+	params := &mcp.CallToolParams{Name: "k8s_apply_manifest", Arguments: map[string]any{}}
+
+	_, err := pool.callTool(ctx, toolServer.URL, params)
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = pool.callTool(ctx, toolServer.URL, params)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), toolServer.sessions.Load())
+
+	// Idle for longer than the timeout, the session is replaced
+	now = now.Add(2 * time.Minute)
+	_, err = pool.callTool(ctx, toolServer.URL, params)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), toolServer.sessions.Load())
+}
+
+func TestMCPSessionPool_SlowDialDoesNotBlockOthers(t *testing.T) {
+	toolServer := newMockToolServer(t)
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+	var pool mcpSessionPool
+	t.Cleanup(pool.close)
+	params := &mcp.CallToolParams{Name: "k8s_apply_manifest", Arguments: map[string]any{}}
+
+	// A caller waiting on the slow server gives up when its context ends
+	ctx, cancel := context.WithCancel(context.Background())
+	dialed := make(chan error)
+	go func() {
+		_, _, err := pool.session(ctx, slow.URL)
+		dialed <- err
+	}()
+
+	// Meanwhile another server is reached
+	_, err := pool.callTool(context.Background(), toolServer.URL, params)
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case err := <-dialed:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("session did not return after its context was canceled")
+	}
+}
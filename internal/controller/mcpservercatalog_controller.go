@@ -5,20 +5,28 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
 )
 
 // MCPServerCatalogReconciler reconciles a MCPServerCatalog object
 type MCPServerCatalogReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Logger zerolog.Logger
+	Scheme        *runtime.Scheme
+	Logger        zerolog.Logger
+	StartupJitter *StartupJitter
 }
 
 // +kubebuilder:rbac:groups=agentregistry.dev,resources=mcpservercatalogs,verbs=get;list;watch;create;update;patch;delete
@@ -30,6 +38,12 @@ type MCPServerCatalogReconciler struct {
 func (r *MCPServerCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Logger.With().Str("name", req.Name).Str("namespace", req.Namespace).Logger()
 
+	// Stagger the first reconcile of each object after controller startup
+	if delay := r.StartupJitter.Delay(req.NamespacedName); delay > 0 {
+		logger.Debug().Dur("delay", delay).Msg("delaying initial reconcile")
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
 	// Fetch the MCPServerCatalog
 	var server agentregistryv1alpha1.MCPServerCatalog
 	if err := r.Get(ctx, req.NamespacedName, &server); err != nil {
@@ -41,41 +55,68 @@ func (r *MCPServerCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		Str("specName", server.Spec.Name).
 		Str("version", server.Spec.Version).
 		Msg("reconciling MCPServerCatalog")
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.KeyResourceName.String(server.Spec.Name),
+		tracing.KeyVersion.String(server.Spec.Version),
+	)
 
 	statusChanged := false
+	sourceGone := false
 
 	// Sync from sourceRef only for external resources (discovered)
 	// Managed resources get their status from RegistryDeployment
 	if server.Spec.SourceRef != nil && server.Status.ManagementType == agentregistryv1alpha1.ManagementTypeExternal {
 		if err := r.syncFromSource(ctx, &server, &statusChanged); err != nil {
 			if apierrors.IsNotFound(err) {
-				// Source was deleted — mark as deprecated so users know the source is gone
-				if server.Status.Status != agentregistryv1alpha1.CatalogStatusDeprecated {
-					server.Status.Status = agentregistryv1alpha1.CatalogStatusDeprecated
-					statusChanged = true
-					logger.Info().
-						Str("sourceKind", server.Spec.SourceRef.Kind).
-						Str("sourceName", server.Spec.SourceRef.Name).
-						Str("sourceNamespace", server.Spec.SourceRef.Namespace).
-						Msg("source resource not found, marking catalog entry as deprecated")
-				}
+				// Source was deleted — deprecate the entry so users know the source is gone
+				sourceGone = true
 			} else {
 				logger.Warn().Err(err).Msg("failed to sync from source")
+				// The source state is unknown; keep the current deprecation
+				sourceGone = server.Status.Status == agentregistryv1alpha1.CatalogStatusDeprecated
 			}
 			// Don't fail reconciliation
-		} else if server.Status.Status == agentregistryv1alpha1.CatalogStatusDeprecated {
-			// Source reappeared — restore to active
-			server.Status.Status = agentregistryv1alpha1.CatalogStatusActive
-			statusChanged = true
-			logger.Info().Msg("source resource reappeared, restoring catalog entry to active")
 		}
 	}
 
+	// Stamp the publish time the first time the entry is published, whether
+	// by discovery, the API or a status patch
+	if server.Status.Published && server.Status.PublishedAt == nil {
+		now := metav1.Now()
+		server.Status.PublishedAt = &now
+		statusChanged = true
+	}
+
+	// Derive the lifecycle status from published, deprecation and soft-delete state
+	if status := mcpServerCatalogStatus(&server, sourceGone); status != server.Status.Status {
+		logger.Info().
+			Str("from", string(server.Status.Status)).
+			Str("to", string(status)).
+			Bool("sourceGone", sourceGone).
+			Msg("catalog entry status changed")
+		server.Status.Status = status
+		statusChanged = true
+	}
+
 	// Update isLatest status for all versions of this server
+	wasLatest := server.Status.IsLatest
 	if err := r.updateLatestVersion(ctx, &server); err != nil {
 		logger.Error().Err(err).Msg("failed to update latest version")
 		return ctrl.Result{}, err
 	}
+	if server.Status.IsLatest != wasLatest {
+		statusChanged = true
+	}
+
+	// Flag an entry duplicating the name and version of an older entry
+	if changed, err := syncDuplicateCondition(ctx, r.Client, &agentregistryv1alpha1.MCPServerCatalogList{}, &server, server.Spec.Name, server.Spec.Version, &server.Status.Conditions); err != nil {
+		logger.Warn().Err(err).Msg("failed to check for duplicate entries")
+	} else if changed {
+		if hasCatalogCondition(server.Status.Conditions, agentregistryv1alpha1.CatalogConditionDuplicate) {
+			logger.Warn().Str("specName", server.Spec.Name).Str("version", server.Spec.Version).Msg("duplicate catalog entry")
+		}
+		statusChanged = true
+	}
 
 	// Update observed generation
 	if server.Status.ObservedGeneration != server.Generation || statusChanged {
@@ -169,14 +210,48 @@ func deploymentRefEqual(a, b *agentregistryv1alpha1.DeploymentRef) bool {
 		a.Message == b.Message
 }
 
-// updateLatestVersion determines and updates the latest version flag for all versions of a server
+// updateLatestVersion determines and updates the latest version flag for all
+// versions of a server. The flag of server is set in memory, to be written
+// with the rest of its status.
 func (r *MCPServerCatalogReconciler) updateLatestVersion(ctx context.Context, server *agentregistryv1alpha1.MCPServerCatalog) error {
-	return updateLatestVersionForMCPServers(ctx, r.Client, server.Spec.Name)
+	return updateLatestVersionForMCPServers(ctx, r.Client, server)
+}
+
+// enqueueOtherVersions requeues the remaining versions of a deleted server,
+// so that the latest of them takes over IsLatest
+func (r *MCPServerCatalogReconciler) enqueueOtherVersions(ctx context.Context, obj client.Object) []reconcile.Request {
+	server, ok := obj.(*agentregistryv1alpha1.MCPServerCatalog)
+	if !ok {
+		return nil
+	}
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	if err := r.List(ctx, &list, client.MatchingFields{IndexMCPServerName: server.Spec.Name}); err != nil {
+		r.Logger.Warn().Err(err).Str("specName", server.Spec.Name).Msg("failed to list remaining versions of deleted server")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Name == server.Name && item.Namespace == server.Namespace {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MCPServerCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentregistryv1alpha1.MCPServerCatalog{}).
-		Complete(r)
+		// Deleting the latest version promotes another one
+		Watches(
+			&agentregistryv1alpha1.MCPServerCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueOtherVersions),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		).
+		Complete(countReconcileErrors("mcpservercatalog", traceReconcile("MCPServerCatalog", r)))
 }
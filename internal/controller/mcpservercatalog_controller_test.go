@@ -9,8 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 )
@@ -392,3 +395,81 @@ func TestMCPServerCatalogReconciler_UpdateLatestVersion_SingleVersion(t *testing
 	require.NoError(t, err)
 	assert.True(t, updated.Status.IsLatest)
 }
+
+func TestMCPServerCatalogReconciler_APICreatedVersions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	r := &MCPServerCatalogReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	// Entries as createServer writes them: unpublished, with an empty status
+	create := func(name, version string) types.NamespacedName {
+		t.Helper()
+		server := &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: version},
+		}
+		require.NoError(t, c.Create(ctx, server))
+		return client.ObjectKeyFromObject(server)
+	}
+	reconcile := func(key types.NamespacedName) {
+		t.Helper()
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		assert.False(t, result.Requeue, "status update should not conflict")
+	}
+	get := func(key types.NamespacedName) agentregistryv1alpha1.MCPServerCatalogStatus {
+		t.Helper()
+		var server agentregistryv1alpha1.MCPServerCatalog
+		require.NoError(t, c.Get(ctx, key, &server))
+		return server.Status
+	}
+
+	v1 := create("search-1-0-0", "1.0.0")
+	reconcile(v1)
+	assert.Nil(t, get(v1).PublishedAt, "unpublished entries have no publish time")
+	assert.True(t, get(v1).IsLatest)
+
+	// Publishing stamps PublishedAt once
+	var server agentregistryv1alpha1.MCPServerCatalog
+	require.NoError(t, c.Get(ctx, v1, &server))
+	server.Status.Published = true
+	require.NoError(t, c.Status().Update(ctx, &server))
+	reconcile(v1)
+	status := get(v1)
+	require.NotNil(t, status.PublishedAt)
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusActive, status.Status)
+	publishedAt := status.PublishedAt
+	reconcile(v1)
+	assert.True(t, publishedAt.Equal(get(v1).PublishedAt))
+
+	// A newer version takes over IsLatest
+	v2 := create("search-2-0-0", "2.0.0")
+	reconcile(v2)
+	assert.True(t, get(v2).IsLatest)
+	assert.False(t, get(v1).IsLatest)
+
+	// An older version created later does not
+	v0 := create("search-0-9-0", "0.9.0")
+	reconcile(v0)
+	assert.False(t, get(v0).IsLatest)
+	assert.True(t, get(v2).IsLatest)
+
+	// Deleting the latest version promotes the next one
+	require.NoError(t, c.Get(ctx, v2, &server))
+	require.NoError(t, c.Delete(ctx, &server))
+	requests := r.enqueueOtherVersions(ctx, &server)
+	assert.ElementsMatch(t, []ctrl.Request{{NamespacedName: v1}, {NamespacedName: v0}}, requests)
+	for _, req := range requests {
+		reconcile(req.NamespacedName)
+	}
+	assert.True(t, get(v1).IsLatest)
+	assert.False(t, get(v0).IsLatest)
+}
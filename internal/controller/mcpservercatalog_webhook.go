@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
+)
+
+// MCPServerCatalogValidator enforces the catalog size limits on
+// MCPServerCatalogs written directly to the apiserver, e.g. with kubectl or
+// GitOps, which bypass the checks of the HTTP API and MCP tools.
+type MCPServerCatalogValidator struct {
+	Limits config.CatalogLimits
+}
+
+var _ admission.CustomValidator = &MCPServerCatalogValidator{}
+
+// SetupMCPServerCatalogWebhook registers the validating webhook for
+// MCPServerCatalogs with the manager's webhook server
+func SetupMCPServerCatalogWebhook(mgr ctrl.Manager, limits config.CatalogLimits) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&agentregistryv1alpha1.MCPServerCatalog{}).
+		WithValidator(&MCPServerCatalogValidator{Limits: limits}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *MCPServerCatalogValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	server, ok := obj.(*agentregistryv1alpha1.MCPServerCatalog)
+	if !ok {
+		return nil, fmt.Errorf("expected an MCPServerCatalog, got %T", obj)
+	}
+	return nil, v.validate(server)
+}
+
+// ValidateUpdate implements admission.CustomValidator. Updates that leave the
+// spec unchanged are allowed, so entries created before the limits were
+// lowered can still have their metadata updated.
+func (v *MCPServerCatalogValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldServer, ok := oldObj.(*agentregistryv1alpha1.MCPServerCatalog)
+	if !ok {
+		return nil, fmt.Errorf("expected an MCPServerCatalog, got %T", oldObj)
+	}
+	server, ok := newObj.(*agentregistryv1alpha1.MCPServerCatalog)
+	if !ok {
+		return nil, fmt.Errorf("expected an MCPServerCatalog, got %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(oldServer.Spec, server.Spec) {
+		return nil, nil
+	}
+	return nil, v.validate(server)
+}
+
+// ValidateDelete implements admission.CustomValidator; deletes are always allowed
+func (v *MCPServerCatalogValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *MCPServerCatalogValidator) validate(server *agentregistryv1alpha1.MCPServerCatalog) error {
+	errs := validation.ValidateServerLimits(&server.Spec, v.Limits)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(agentregistryv1alpha1.GroupVersion.WithKind("MCPServerCatalog").GroupKind(), server.Name, errs)
+}
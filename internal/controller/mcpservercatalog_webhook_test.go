@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+func TestMCPServerCatalogValidator(t *testing.T) {
+	v := &MCPServerCatalogValidator{Limits: config.CatalogLimits{
+		MaxDescriptionLength: 16,
+		MaxPackages:          1,
+		MaxRemotes:           1,
+		MaxEnvVars:           1,
+		MaxArguments:         1,
+	}}
+	ctx := context.Background()
+	server := func(description string, remotes int) *agentregistryv1alpha1.MCPServerCatalog {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+				Name:        "org/search",
+				Version:     "1.0.0",
+				Description: description,
+				Remotes:     make([]agentregistryv1alpha1.Transport, remotes),
+			},
+		}
+	}
+
+	_, err := v.ValidateCreate(ctx, server("Search", 1))
+	require.NoError(t, err)
+
+	_, err = v.ValidateCreate(ctx, server(strings.Repeat("x", 17), 2))
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err), "expected an Invalid error, got %v", err)
+	assert.Contains(t, err.Error(), "spec.description")
+	assert.Contains(t, err.Error(), "spec.remotes")
+
+	// An oversized entry may still be updated when its spec does not change
+	oversized := server("Search", 2)
+	relabeled := oversized.DeepCopy()
+	relabeled.Labels = map[string]string{"team": "search"}
+	_, err = v.ValidateUpdate(ctx, oversized, relabeled)
+	require.NoError(t, err)
+
+	grown := server("Search", 1)
+	_, err = v.ValidateUpdate(ctx, grown, server("Search", 2))
+	assert.True(t, apierrors.IsInvalid(err), "expected an Invalid error, got %v", err)
+
+	_, err = v.ValidateDelete(ctx, oversized)
+	require.NoError(t, err)
+}
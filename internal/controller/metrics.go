@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// metricsListTimeout bounds the cache reads done while serving a scrape
+const metricsListTimeout = 10 * time.Second
+
+// unknownPhaseLabel is reported for deployments that have not been reconciled yet
+const unknownPhaseLabel = "Unknown"
+
+var reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "agentregistry_reconcile_errors_total",
+	Help: "Reconciliations that returned an error, by controller.",
+}, []string{"controller"})
+
+var (
+	catalogEntriesDesc = prometheus.NewDesc(
+		"agentregistry_catalog_entries",
+		"Number of catalog entries, by catalog type.",
+		[]string{"type"}, nil,
+	)
+	deploymentsDesc = prometheus.NewDesc(
+		"agentregistry_deployments",
+		"Number of RegistryDeployments, by phase.",
+		[]string{"phase"}, nil,
+	)
+	discoveryConnectedDesc = prometheus.NewDesc(
+		"agentregistry_discovery_environment_connected",
+		"Whether discovery is connected to an environment (1) or not (0).",
+		[]string{"config", "environment"}, nil,
+	)
+)
+
+func init() {
+	// Registered with the controller-runtime registry so it is served on
+	// the manager's metrics endpoint.
+	metrics.Registry.MustRegister(reconcileErrors)
+}
+
+// countReconcileErrors wraps a reconciler so every error it returns is
+// counted under the controller's name
+func countReconcileErrors(controllerName string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		result, err := r.Reconcile(ctx, req)
+		if err != nil {
+			reconcileErrors.WithLabelValues(controllerName).Inc()
+		}
+		return result, err
+	})
+}
+
+// CatalogMetricsCollector reports the size of the catalog, the phases of
+// deployments and discovery connectivity as gauges. The values are read from
+// reader, normally the manager cache, on every scrape so they never go stale.
+type CatalogMetricsCollector struct {
+	reader client.Reader
+}
+
+// NewCatalogMetricsCollector returns a collector reading from reader
+func NewCatalogMetricsCollector(reader client.Reader) *CatalogMetricsCollector {
+	return &CatalogMetricsCollector{reader: reader}
+}
+
+// Describe implements prometheus.Collector
+func (c *CatalogMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- catalogEntriesDesc
+	ch <- deploymentsDesc
+	ch <- discoveryConnectedDesc
+}
+
+// Collect implements prometheus.Collector. A kind that cannot be listed is
+// left out of the scrape rather than reported as zero.
+func (c *CatalogMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsListTimeout)
+	defer cancel()
+
+	catalogs := []struct {
+		catalogType string
+		list        client.ObjectList
+		count       func(client.ObjectList) int
+	}{
+		{"servers", &agentregistryv1alpha1.MCPServerCatalogList{}, func(l client.ObjectList) int {
+			return len(l.(*agentregistryv1alpha1.MCPServerCatalogList).Items)
+		}},
+		{"agents", &agentregistryv1alpha1.AgentCatalogList{}, func(l client.ObjectList) int {
+			return len(l.(*agentregistryv1alpha1.AgentCatalogList).Items)
+		}},
+		{"skills", &agentregistryv1alpha1.SkillCatalogList{}, func(l client.ObjectList) int {
+			return len(l.(*agentregistryv1alpha1.SkillCatalogList).Items)
+		}},
+		{"models", &agentregistryv1alpha1.ModelCatalogList{}, func(l client.ObjectList) int {
+			return len(l.(*agentregistryv1alpha1.ModelCatalogList).Items)
+		}},
+	}
+	for _, catalog := range catalogs {
+		if err := c.reader.List(ctx, catalog.list); err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(catalogEntriesDesc, prometheus.GaugeValue,
+			float64(catalog.count(catalog.list)), catalog.catalogType)
+	}
+
+	var deployments agentregistryv1alpha1.RegistryDeploymentList
+	if err := c.reader.List(ctx, &deployments); err == nil {
+		// Known phases are always reported so alerts on them see zeros
+		phases := map[string]int{
+			string(agentregistryv1alpha1.DeploymentPhasePending):           0,
+			string(agentregistryv1alpha1.DeploymentPhaseRunning):           0,
+			string(agentregistryv1alpha1.DeploymentPhaseFailed):            0,
+			string(agentregistryv1alpha1.DeploymentPhasePartiallyDeployed): 0,
+			string(agentregistryv1alpha1.DeploymentPhasePaused):            0,
+		}
+		for _, d := range deployments.Items {
+			phase := string(d.Status.Phase)
+			if phase == "" {
+				phase = unknownPhaseLabel
+			}
+			phases[phase]++
+		}
+		for phase, count := range phases {
+			ch <- prometheus.MustNewConstMetric(deploymentsDesc, prometheus.GaugeValue, float64(count), phase)
+		}
+	}
+
+	var configs agentregistryv1alpha1.DiscoveryConfigList
+	if err := c.reader.List(ctx, &configs); err == nil {
+		for _, dc := range configs.Items {
+			for _, env := range dc.Status.Environments {
+				connected := 0.0
+				if env.Connected {
+					connected = 1
+				}
+				ch <- prometheus.MustNewConstMetric(discoveryConnectedDesc, prometheus.GaugeValue, connected, dc.Name, env.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestCatalogMetricsCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "agentregistry"}
+	}
+	deployment := func(name string, phase agentregistryv1alpha1.DeploymentPhase) *agentregistryv1alpha1.RegistryDeployment {
+		return &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: meta(name),
+			Status:     agentregistryv1alpha1.RegistryDeploymentStatus{Phase: phase},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: meta("search-1-0-0")},
+			&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: meta("search-2-0-0")},
+			&agentregistryv1alpha1.AgentCatalog{ObjectMeta: meta("helper-1-0-0")},
+			deployment("running", agentregistryv1alpha1.DeploymentPhaseRunning),
+			deployment("failed", agentregistryv1alpha1.DeploymentPhaseFailed),
+			deployment("new", ""),
+			&agentregistryv1alpha1.DiscoveryConfig{
+				ObjectMeta: meta("default"),
+				Status: agentregistryv1alpha1.DiscoveryConfigStatus{
+					Environments: []agentregistryv1alpha1.EnvironmentStatus{
+						{Name: "dev", Connected: true},
+						{Name: "prod", Connected: false},
+					},
+				},
+			},
+		).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(NewCatalogMetricsCollector(c)))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	values := make(map[string]map[string]float64)
+	for _, family := range families {
+		values[family.GetName()] = make(map[string]float64)
+		for _, m := range family.GetMetric() {
+			key := ""
+			for _, label := range m.GetLabel() {
+				key += label.GetName() + "=" + label.GetValue() + ","
+			}
+			values[family.GetName()][key] = m.GetGauge().GetValue()
+		}
+	}
+
+	assert.Equal(t, map[string]float64{
+		"type=servers,": 2,
+		"type=agents,":  1,
+		"type=skills,":  0,
+		"type=models,":  0,
+	}, values["agentregistry_catalog_entries"])
+	assert.Equal(t, map[string]float64{
+		"phase=Pending,":           0,
+		"phase=Running,":           1,
+		"phase=Failed,":            1,
+		"phase=PartiallyDeployed,": 0,
+		"phase=Paused,":            0,
+		"phase=Unknown,":           1,
+	}, values["agentregistry_deployments"])
+	assert.Equal(t, map[string]float64{
+		"config=default,environment=dev,":  1,
+		"config=default,environment=prod,": 0,
+	}, values["agentregistry_discovery_environment_connected"])
+}
+
+func TestCountReconcileErrors(t *testing.T) {
+	failing := true
+	r := countReconcileErrors("test-controller", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		if failing {
+			return reconcile.Result{}, errors.New("boom")
+		}
+		return reconcile.Result{}, nil
+	}))
+	counter := reconcileErrors.WithLabelValues("test-controller")
+	before := testutil.ToFloat64(counter)
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{})
+	require.Error(t, err)
+	failing = false
+	_, err = r.Reconcile(context.Background(), reconcile.Request{})
+	require.NoError(t, err)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+}
@@ -3,32 +3,49 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
+	"math"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	sigyaml "sigs.k8s.io/yaml"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/helm"
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
 )
 
 // RegistryDeploymentReconciler reconciles a RegistryDeployment object
@@ -37,16 +54,89 @@ type RegistryDeploymentReconciler struct {
 	Scheme              *runtime.Scheme
 	Logger              zerolog.Logger
 	RemoteClientFactory func(env *agentregistryv1alpha1.Environment, scheme *runtime.Scheme) (client.WithWatch, error)
+	StartupJitter       *StartupJitter
+	// MaxManagedResources caps the resources a single deployment may apply;
+	// zero uses DefaultMaxManagedResources
+	MaxManagedResources int
+	// PruneOnCatalogMissing deletes the managed resources of a deployment
+	// whose catalog entry was deleted instead of leaving them running
+	PruneOnCatalogMissing bool
+	// NotifyWebhookURL receives the phase notifications of deployments that
+	// set no Spec.NotifyWebhookURL; empty sends none
+	NotifyWebhookURL string
+	// MaxConcurrentReconciles is how many deployments are reconciled at once;
+	// 0 uses the controller-runtime default of 1
+	MaxConcurrentReconciles int
+
+	// transientRetries counts the consecutive transient failures of each
+	// deployment, keyed by types.NamespacedName
+	transientRetries sync.Map
+	// notifiedPhases holds the phase last notified for each deployment, keyed
+	// by types.NamespacedName
+	notifiedPhases sync.Map
+	// mcpSessions reuses MCP tool server sessions across the applies and
+	// deletes of environments with an MCPToolServerURL
+	mcpSessions mcpSessionPool
 }
 
 const (
 	finalizerName       = "agentregistry.dev/finalizer"
-	defaultNamespace    = "kagent"
 	managedByLabel      = "agentregistry.dev/managed-by"
 	deploymentNameLabel = "agentregistry.dev/deployment-name"
 	deploymentNSLabel   = "agentregistry.dev/deployment-namespace"
+
+	// pendingRequeueInterval is how often a Pending deployment re-checks the
+	// readiness of its managed resources without waiting for a watch event.
+	pendingRequeueInterval = 30 * time.Second
+
+	// transientRetryBaseBackoff and transientRetryMaxBackoff bound the delay
+	// before a deployment that failed with a transient error is retried.
+	transientRetryBaseBackoff = 5 * time.Second
+	transientRetryMaxBackoff  = 5 * time.Minute
+)
+
+// DefaultMaxManagedResources is the default cap on the resources one
+// deployment may apply. Real deployments render a handful; the cap guards the
+// target cluster against a malformed catalog entry or translator bug.
+const DefaultMaxManagedResources = 100
+
+// ReconcileTriggerAnnotation is stamped with the current time to force a
+// RegistryDeployment or catalog entry to be reconciled again (e.g. by the bulk
+// refresh endpoint).
+const ReconcileTriggerAnnotation = "agentregistry.dev/reconcile-trigger"
+
+// TriggerReconcile stamps ReconcileTriggerAnnotation on obj, a deployment or
+// catalog entry, with the current time, which makes its controller reconcile
+// it again right away
+func TriggerReconcile(ctx context.Context, c client.Client, obj client.Object) error {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ReconcileTriggerAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	obj.SetAnnotations(annotations)
+	return c.Patch(ctx, obj, patch)
+}
+
+// deploymentChangePredicate admits spec, label and annotation changes of a
+// RegistryDeployment, so ReconcileTriggerAnnotation forces a reconcile, while
+// status-only updates such as the reconciler's own writes are ignored
+var deploymentChangePredicate = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
 )
 
+// IsManagedByDeployment reports whether obj is a resource created for the
+// RegistryDeployment with the given name and namespace
+func IsManagedByDeployment(obj client.Object, deploymentName, deploymentNamespace string) bool {
+	labels := obj.GetLabels()
+	return labels[managedByLabel] == "agentregistry" &&
+		labels[deploymentNameLabel] == deploymentName &&
+		labels[deploymentNSLabel] == deploymentNamespace
+}
+
 // +kubebuilder:rbac:groups=agentregistry.dev,resources=registrydeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=agentregistry.dev,resources=registrydeployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=agentregistry.dev,resources=registrydeployments/finalizers,verbs=update
@@ -54,11 +144,21 @@ const (
 // +kubebuilder:rbac:groups=kagent.dev,resources=remotemcpservers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kmcp.io,resources=mcpservers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;delete
+// +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=helmrepositories,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile handles RegistryDeployment reconciliation
 func (r *RegistryDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Logger.With().Str("name", req.Name).Str("namespace", req.Namespace).Logger()
 
+	// Stagger the first reconcile of each object after controller startup
+	if delay := r.StartupJitter.Delay(req.NamespacedName); delay > 0 {
+		logger.Debug().Dur("delay", delay).Msg("delaying initial reconcile")
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
 	// Skip resources with empty namespace (invalid legacy cluster-scoped resources)
 	if req.Namespace == "" {
 		logger.Warn().Msg("skipping RegistryDeployment with empty namespace (invalid resource)")
@@ -77,6 +177,12 @@ func (r *RegistryDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.R
 		Str("resourceType", string(deployment.Spec.ResourceType)).
 		Str("runtime", string(deployment.Spec.Runtime)).
 		Msg("reconciling RegistryDeployment")
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.KeyResourceName.String(deployment.Spec.ResourceName),
+		tracing.KeyVersion.String(deployment.Spec.Version),
+		tracing.KeyResourceType.String(string(deployment.Spec.ResourceType)),
+		tracing.KeyEnvironment.String(strings.Join(deploymentEnvironments(&deployment), ",")),
+	)
 
 	// Handle deletion
 	if !deployment.DeletionTimestamp.IsZero() {
@@ -96,22 +202,61 @@ func (r *RegistryDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	}
 
-	// Reconcile based on resource type
+	previousPhase := deployment.Status.Phase
+	switch {
+	case deployment.Status.DeployedAt == nil:
+		recordTimeline(&deployment.Status, "created")
+	case deployment.Generation != deployment.Status.ObservedGeneration:
+		recordTimeline(&deployment.Status, fmt.Sprintf("spec updated (generation %d)", deployment.Generation))
+	}
+
+	// A paused deployment only removes its resources, otherwise reconcile
+	// based on resource type
 	var err error
-	switch deployment.Spec.ResourceType {
-	case agentregistryv1alpha1.ResourceTypeMCP:
-		err = r.reconcileMCPDeployment(ctx, &deployment)
-	case agentregistryv1alpha1.ResourceTypeAgent:
-		err = r.reconcileAgentDeployment(ctx, &deployment)
-	default:
-		err = fmt.Errorf("unknown resource type: %s", deployment.Spec.ResourceType)
+	if deployment.Spec.Paused {
+		err = r.pauseDeployment(ctx, &deployment)
+	} else if err = validateResourceMetadata(&deployment); err == nil {
+		switch deployment.Spec.ResourceType {
+		case agentregistryv1alpha1.ResourceTypeMCP:
+			err = r.reconcileMCPDeployment(ctx, &deployment)
+		case agentregistryv1alpha1.ResourceTypeAgent:
+			err = r.reconcileAgentDeployment(ctx, &deployment)
+		default:
+			err = fmt.Errorf("unknown resource type: %s", deployment.Spec.ResourceType)
+		}
 	}
 
-	if err != nil {
+	// Transient errors, such as an unreachable remote cluster or a write
+	// conflict, keep the deployment Pending and retry with backoff instead of
+	// failing it
+	var retryAfter time.Duration
+	var missing *catalogMissingError
+	if !errors.As(err, &missing) {
+		removeCatalogCondition(&deployment.Status.Conditions, agentregistryv1alpha1.CatalogConditionCatalogMissing)
+	}
+	switch {
+	case missing != nil:
+		r.transientRetries.Delete(req.NamespacedName)
+		logger.Warn().Err(err).Msg("catalog entry of deployed resources is missing")
+		r.handleCatalogMissing(ctx, &deployment, missing)
+		retryAfter = catalogMissingRequeueInterval
+	case isTransientError(err):
+		attempt := r.recordTransientRetry(req.NamespacedName)
+		retryAfter = retryBackoffFor(transientRetryBaseBackoff, transientRetryMaxBackoff, attempt)
+		logger.Warn().Err(err).Int("attempt", attempt).Dur("backoff", retryAfter).Msg("transient error reconciling deployment, will retry")
+		deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhasePending
+		deployment.Status.Message = fmt.Sprintf("retrying in %s after transient error (attempt %d): %v", retryAfter.Round(time.Second), attempt, err)
+	case err != nil:
+		r.transientRetries.Delete(req.NamespacedName)
 		logger.Error().Err(err).Msg("failed to reconcile deployment")
-		deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhaseFailed
+		deployment.Status.Phase = failedPhase(err)
 		deployment.Status.Message = err.Error()
-	} else {
+	case deployment.Spec.Paused:
+		r.transientRetries.Delete(req.NamespacedName)
+		deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhasePaused
+		deployment.Status.Message = ""
+	default:
+		r.transientRetries.Delete(req.NamespacedName)
 		// Check if managed resources are actually ready
 		ready, message := r.checkManagedResourcesReady(ctx, &deployment)
 		if ready {
@@ -121,50 +266,117 @@ func (r *RegistryDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.R
 			deployment.Status.Phase = agentregistryv1alpha1.DeploymentPhasePending
 			deployment.Status.Message = message
 		}
+		if hasCatalogCondition(deployment.Status.Conditions, agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers) {
+			retryAfter = unresolvedMCPServersRequeueInterval
+		}
 	}
 
+	recordPhaseTimeline(&deployment.Status, previousPhase)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.KeyPhase.String(string(deployment.Status.Phase)))
+
 	// Update status
 	now := metav1.Now()
 	deployment.Status.UpdatedAt = &now
 	if deployment.Status.DeployedAt == nil {
 		deployment.Status.DeployedAt = &now
 	}
+	// Only deployments that go live count towards their entry's popularity
+	count := !deployment.Status.Counted && (deployment.Status.Phase == agentregistryv1alpha1.DeploymentPhaseRunning ||
+		deployment.Status.Phase == agentregistryv1alpha1.DeploymentPhasePending)
+	if count {
+		deployment.Status.Counted = true
+	}
 	deployment.Status.ObservedGeneration = deployment.Generation
 
 	if err := r.Status().Update(ctx, &deployment); err != nil {
 		logger.Error().Err(err).Msg("failed to update status")
 		return ctrl.Result{}, err
 	}
+	r.notifyPhaseTransition(&deployment, previousPhase)
+
+	// The deployment is counted once Counted is persisted, so a reconcile
+	// retried after a failed status write does not count it again
+	if count {
+		if err := r.countDeployment(ctx, &deployment); err != nil {
+			logger.Error().Err(err).Msg("failed to count deployment on its catalog entry")
+		}
+	}
+
+	// Remember the successfully applied spec so it can be rolled back to.
+	if err == nil && !deployment.Spec.Paused {
+		if err := r.recordAppliedSpec(ctx, &deployment); err != nil {
+			logger.Error().Err(err).Msg("failed to record applied spec")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if retryAfter > 0 {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+	if err == nil && deployment.Status.Phase == agentregistryv1alpha1.DeploymentPhasePending {
+		return ctrl.Result{RequeueAfter: pendingRequeueInterval}, nil
+	}
 
 	return ctrl.Result{}, err
 }
 
+// countDeployment increments the DeploymentCount of the catalog entry
+// deployment was created from, if it exists, once the deployment first runs
+// or progresses
+func (r *RegistryDeploymentReconciler) countDeployment(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	key := NameVersionKey(deployment.Spec.ResourceName, deployment.Spec.Version)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		switch deployment.Spec.ResourceType {
+		case agentregistryv1alpha1.ResourceTypeMCP:
+			var list agentregistryv1alpha1.MCPServerCatalogList
+			if err := r.List(ctx, &list, client.MatchingFields{IndexMCPServerNameVersion: key}); err != nil || len(list.Items) == 0 {
+				return err
+			}
+			list.Items[0].Status.DeploymentCount++
+			return r.Status().Update(ctx, &list.Items[0])
+		case agentregistryv1alpha1.ResourceTypeAgent:
+			var list agentregistryv1alpha1.AgentCatalogList
+			if err := r.List(ctx, &list, client.MatchingFields{IndexAgentNameVersion: key}); err != nil || len(list.Items) == 0 {
+				return err
+			}
+			list.Items[0].Status.DeploymentCount++
+			return r.Status().Update(ctx, &list.Items[0])
+		}
+		return nil
+	})
+}
+
+// recordTransientRetry counts another consecutive transient failure of the
+// deployment with key and returns the attempt number
+func (r *RegistryDeploymentReconciler) recordTransientRetry(key types.NamespacedName) int {
+	attempt := 1
+	if previous, ok := r.transientRetries.Load(key); ok {
+		attempt = previous.(int) + 1
+	}
+	r.transientRetries.Store(key, attempt)
+	return attempt
+}
+
 // reconcileMCPDeployment reconciles an MCP server deployment
 func (r *RegistryDeploymentReconciler) reconcileMCPDeployment(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) error {
-	// Look up the MCPServerCatalog
+	// Look up the MCPServerCatalog version
 	var serverList agentregistryv1alpha1.MCPServerCatalogList
 	if err := r.List(ctx, &serverList, client.MatchingFields{
-		IndexMCPServerName: deployment.Spec.ResourceName,
+		IndexMCPServerNameVersion: NameVersionKey(deployment.Spec.ResourceName, deployment.Spec.Version),
 	}); err != nil {
 		return fmt.Errorf("failed to list MCP servers: %w", err)
 	}
 
-	// Find the specific version
-	var catalogEntry *agentregistryv1alpha1.MCPServerCatalog
-	for i := range serverList.Items {
-		s := &serverList.Items[i]
-		if s.Spec.Version == deployment.Spec.Version {
-			catalogEntry = s
-			break
-		}
-	}
-
-	if catalogEntry == nil {
-		return fmt.Errorf("MCP server %s version %s not found", deployment.Spec.ResourceName, deployment.Spec.Version)
+	if len(serverList.Items) == 0 {
+		return catalogEntryNotFound(deployment, "MCP server")
 	}
+	catalogEntry := &serverList.Items[0]
 
 	// Validate publisher identity before deploying
-	if err := validatePublisherIdentity(catalogEntry.Spec.Metadata); err != nil {
+	if err := r.checkPublisherIdentity(deployment, catalogEntry.Spec.Metadata); err != nil {
+		return fmt.Errorf("deployment blocked for %s %s: %w", deployment.Spec.ResourceName, deployment.Spec.Version, err)
+	}
+	if err := r.checkSBOMAttestation(ctx, catalogEntry); err != nil {
 		return fmt.Errorf("deployment blocked for %s %s: %w", deployment.Spec.ResourceName, deployment.Spec.Version, err)
 	}
 
@@ -172,186 +384,534 @@ func (r *RegistryDeploymentReconciler) reconcileMCPDeployment(ctx context.Contex
 	if catalogEntry.Status.ManagementType != agentregistryv1alpha1.ManagementTypeManaged {
 		catalogEntry.Status.ManagementType = agentregistryv1alpha1.ManagementTypeManaged
 		if err := r.Status().Update(ctx, catalogEntry); err != nil {
-			return fmt.Errorf("failed to update catalog management type: %w", err)
+			return fmt.Errorf("failed to update catalog status: %w", err)
 		}
 	}
 
-	// Resolve the target client and environment
-	env, targetClient, clusterName, err := r.getTargetClientAndEnv(ctx, deployment)
+	// Resolve the target clients and environments. One that cannot be
+	// resolved or deployed into is skipped, and the others still deployed.
+	targets, err := r.resolveTargets(ctx, deployment)
+	var skipped []error
 	if err != nil {
-		return fmt.Errorf("failed to resolve target: %w", err)
-	}
-	mcpURL := ""
-	if env != nil {
-		mcpURL = env.MCPToolServerURL
+		skipped = append(skipped, err)
+	}
+
+	// Render the resources once per environment and target namespace,
+	// translating against each in turn. Only the in-memory copy is changed:
+	// the status write below does not persist the spec.
+	specNamespace := deployment.Spec.Namespace
+	pullSecrets := deployment.Spec.ImagePullSecrets
+	var objs []managedObject
+	var rendered []string
+	var fluxMissing []error
+	for _, target := range targets {
+		if target.retired {
+			continue
+		}
+		if err := target.checkDeployEnabled(); err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		if deployment.Spec.Runtime == agentregistryv1alpha1.RuntimeTypeHelm {
+			if err := checkFluxInstalled(target); err != nil {
+				if errors.Is(err, errFluxNotInstalled) {
+					fluxMissing = append(fluxMissing, err)
+				}
+				skipped = append(skipped, err)
+				continue
+			}
+		}
+		deployment.Spec.Namespace = specNamespace
+		namespaces, err := resolveTargetNamespaces(deployment, target.env)
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		// Only the package container is pulled from the environment's
+		// registry: remote servers and Helm charts run no image of ours
+		deployment.Spec.ImagePullSecrets = pullSecrets
+		if len(pullSecrets) == 0 && target.env != nil && deployment.Spec.Runtime != agentregistryv1alpha1.RuntimeTypeHelm && !usesRemote(catalogEntry, deployment) {
+			deployment.Spec.ImagePullSecrets = target.env.Registry.ImagePullSecrets
+		}
+		for _, namespace := range namespaces {
+			deployment.Spec.Namespace = namespace
+			runtimeConfig, err := r.translateMCPServer(ctx, catalogEntry, deployment)
+			if err != nil {
+				return err
+			}
+			deployment.Status.EffectiveCommand = effectiveCommand(runtimeConfig.Kubernetes.MCPServers)
+			objs = append(objs, mcpManagedObjects(runtimeConfig, target.cluster)...)
+		}
+		rendered = append(rendered, target.cluster)
 	}
+	setFluxUnavailableCondition(&deployment.Status.Conditions, fluxMissing)
 
-	// Convert catalog to runtime format
-	mcpServer, err := r.convertCatalogToMCPServer(catalogEntry, deployment)
-	if err != nil {
-		return fmt.Errorf("failed to convert catalog to MCP server: %w", err)
+	if err := r.applyRendered(ctx, deployment, targets.active(rendered), rendered, objs, skipped); err != nil {
+		return err
 	}
+	r.restartPullFailedPods(ctx, targets, objs)
+	return nil
+}
 
-	// Use KAgent translator to create Kubernetes resources
-	translator := kagent.NewTranslator()
-	desiredState := &api.DesiredState{
-		MCPServers: []*api.MCPServer{mcpServer},
-	}
+// mcpManagedObjects pairs the resources rendered for an MCP server with their
+// ManagedResource entries
+func mcpManagedObjects(runtimeConfig *api.AIRuntimeConfig, clusterName string) []managedObject {
+	var objs []managedObject
 
-	runtimeConfig, err := translator.TranslateRuntimeConfig(ctx, desiredState)
-	if err != nil {
-		return fmt.Errorf("failed to translate runtime config: %w", err)
+	// ServiceAccounts, applied before the MCPServers whose pods use them
+	for _, sa := range runtimeConfig.Kubernetes.ServiceAccounts {
+		objs = append(objs, managedObject{obj: sa, ref: agentregistryv1alpha1.ManagedResource{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+			Name:       sa.Name,
+			Namespace:  sa.Namespace,
+			Cluster:    clusterName,
+		}})
 	}
 
-	// Apply Kubernetes resources
-	managedResources := []agentregistryv1alpha1.ManagedResource{}
-
-	// Apply MCPServers (local)
+	// MCPServers (local)
 	for _, mcpServer := range runtimeConfig.Kubernetes.MCPServers {
-		r.setOwnerLabels(mcpServer, deployment)
-		if err := r.applyObj(ctx, mcpURL, targetClient, mcpServer); err != nil {
-			return fmt.Errorf("failed to apply MCPServer: %w", err)
-		}
-		managedResources = append(managedResources, agentregistryv1alpha1.ManagedResource{
+		objs = append(objs, managedObject{obj: mcpServer, ref: agentregistryv1alpha1.ManagedResource{
 			APIVersion: mcpServer.APIVersion,
 			Kind:       mcpServer.Kind,
 			Name:       mcpServer.Name,
 			Namespace:  mcpServer.Namespace,
 			Cluster:    clusterName,
-		})
+			Digest:     imageDigest(mcpServer.Spec.Deployment.Image),
+		}})
 	}
 
-	// Apply RemoteMCPServers
+	// RemoteMCPServers
 	for _, remoteMCP := range runtimeConfig.Kubernetes.RemoteMCPServers {
-		r.setOwnerLabels(remoteMCP, deployment)
-		if err := r.applyObj(ctx, mcpURL, targetClient, remoteMCP); err != nil {
-			return fmt.Errorf("failed to apply RemoteMCPServer: %w", err)
-		}
-		managedResources = append(managedResources, agentregistryv1alpha1.ManagedResource{
+		objs = append(objs, managedObject{obj: remoteMCP, ref: agentregistryv1alpha1.ManagedResource{
 			APIVersion: remoteMCP.APIVersion,
 			Kind:       remoteMCP.Kind,
 			Name:       remoteMCP.Name,
 			Namespace:  remoteMCP.Namespace,
 			Cluster:    clusterName,
-		})
+		}})
 	}
 
-	deployment.Status.ManagedResources = managedResources
-	return nil
+	// Helm chart sources and releases
+	objs = append(objs, unstructuredManagedObjects(runtimeConfig.Kubernetes.HelmRepositories, clusterName)...)
+	objs = append(objs, unstructuredManagedObjects(runtimeConfig.Kubernetes.HelmReleases, clusterName)...)
+	return objs
+}
+
+// unstructuredManagedObjects pairs unstructured resources with their
+// ManagedResource entries
+func unstructuredManagedObjects(objs []*unstructured.Unstructured, clusterName string) []managedObject {
+	managed := make([]managedObject, 0, len(objs))
+	for _, obj := range objs {
+		managed = append(managed, managedObject{obj: obj, ref: agentregistryv1alpha1.ManagedResource{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+			Cluster:    clusterName,
+		}})
+	}
+	return managed
 }
 
 // reconcileAgentDeployment reconciles an Agent deployment
 func (r *RegistryDeploymentReconciler) reconcileAgentDeployment(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) error {
-	// Look up the AgentCatalog
+	// Look up the AgentCatalog version
 	var agentList agentregistryv1alpha1.AgentCatalogList
 	if err := r.List(ctx, &agentList, client.MatchingFields{
-		IndexAgentName: deployment.Spec.ResourceName,
+		IndexAgentNameVersion: NameVersionKey(deployment.Spec.ResourceName, deployment.Spec.Version),
 	}); err != nil {
 		return fmt.Errorf("failed to list agents: %w", err)
 	}
 
-	// Find the specific version
-	var catalogEntry *agentregistryv1alpha1.AgentCatalog
-	for i := range agentList.Items {
-		a := &agentList.Items[i]
-		if a.Spec.Version == deployment.Spec.Version {
-			catalogEntry = a
-			break
-		}
-	}
-
-	if catalogEntry == nil {
-		return fmt.Errorf("agent %s version %s not found", deployment.Spec.ResourceName, deployment.Spec.Version)
+	if len(agentList.Items) == 0 {
+		return catalogEntryNotFound(deployment, "agent")
 	}
+	catalogEntry := &agentList.Items[0]
 
 	// Validate publisher identity before deploying
-	if err := validatePublisherIdentity(catalogEntry.Spec.Metadata); err != nil {
+	if err := r.checkPublisherIdentity(deployment, catalogEntry.Spec.Metadata); err != nil {
 		return fmt.Errorf("deployment blocked for %s %s: %w", deployment.Spec.ResourceName, deployment.Spec.Version, err)
 	}
 
+	// Every required skill must be published before the agent is deployed
+	if err := r.checkAgentSkills(ctx, catalogEntry); err != nil {
+		return err
+	}
+
 	// Mark as managed if not already set
 	if catalogEntry.Status.ManagementType != agentregistryv1alpha1.ManagementTypeManaged {
 		catalogEntry.Status.ManagementType = agentregistryv1alpha1.ManagementTypeManaged
 		if err := r.Status().Update(ctx, catalogEntry); err != nil {
-			return fmt.Errorf("failed to update catalog management type: %w", err)
+			return fmt.Errorf("failed to update catalog status: %w", err)
 		}
 	}
 
-	// Resolve the target client and environment
-	env, targetClient, clusterName, err := r.getTargetClientAndEnv(ctx, deployment)
-	if err != nil {
-		return fmt.Errorf("failed to resolve target: %w", err)
-	}
-	mcpURL := ""
-	if env != nil {
-		mcpURL = env.MCPToolServerURL
-	}
-
-	// Convert catalog to runtime format
-	agent, err := r.convertCatalogToAgent(catalogEntry, deployment)
+	// Resolve the target clients and environments. One that cannot be
+	// resolved or deployed into is skipped, and the others still deployed.
+	targets, err := r.resolveTargets(ctx, deployment)
+	var skipped []error
 	if err != nil {
-		return fmt.Errorf("failed to convert catalog to agent: %w", err)
-	}
-
-	// Use KAgent translator to create Kubernetes resources
-	translator := kagent.NewTranslator()
-	desiredState := &api.DesiredState{
-		Agents: []*api.Agent{agent},
+		skipped = append(skipped, err)
+	}
+
+	// Render the resources once per environment and target namespace,
+	// translating against each in turn, with the environment's pull secrets
+	// unless the deployment lists its own. Only the in-memory copy is
+	// changed: the status write below does not persist the spec.
+	specNamespace := deployment.Spec.Namespace
+	pullSecrets := deployment.Spec.ImagePullSecrets
+	var objs []managedObject
+	var rendered []string
+	for _, target := range targets {
+		if target.retired {
+			continue
+		}
+		if err := target.checkDeployEnabled(); err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		deployment.Spec.Namespace = specNamespace
+		namespaces, err := resolveTargetNamespaces(deployment, target.env)
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		deployment.Spec.ImagePullSecrets = pullSecrets
+		if len(pullSecrets) == 0 && target.env != nil {
+			deployment.Spec.ImagePullSecrets = target.env.Registry.ImagePullSecrets
+		}
+		// The MCPServers of the agent's package servers cannot be looked up
+		// through an MCP tool server
+		var serverReader client.Reader
+		if target.mcpURL == "" {
+			serverReader = target.client
+		}
+		for _, namespace := range namespaces {
+			deployment.Spec.Namespace = namespace
+			runtimeConfig, err := r.translateAgent(ctx, catalogEntry, deployment, serverReader)
+			if err != nil {
+				return err
+			}
+			objs = append(objs, agentManagedObjects(runtimeConfig, target.cluster)...)
+		}
+		rendered = append(rendered, target.cluster)
 	}
 
-	runtimeConfig, err := translator.TranslateRuntimeConfig(ctx, desiredState)
-	if err != nil {
-		return fmt.Errorf("failed to translate runtime config: %w", err)
-	}
+	return r.applyRendered(ctx, deployment, targets.active(rendered), rendered, objs, skipped)
+}
 
-	// Apply Kubernetes resources
-	managedResources := []agentregistryv1alpha1.ManagedResource{}
+// agentManagedObjects pairs the resources rendered for an agent with their
+// ManagedResource entries
+func agentManagedObjects(runtimeConfig *api.AIRuntimeConfig, clusterName string) []managedObject {
+	var objs []managedObject
 
-	// Apply ConfigMaps
+	// ConfigMaps
 	for _, cm := range runtimeConfig.Kubernetes.ConfigMaps {
-		r.setOwnerLabels(cm, deployment)
-		if err := r.applyObj(ctx, mcpURL, targetClient, cm); err != nil {
-			return fmt.Errorf("failed to apply ConfigMap: %w", err)
-		}
-		managedResources = append(managedResources, agentregistryv1alpha1.ManagedResource{
+		objs = append(objs, managedObject{obj: cm, ref: agentregistryv1alpha1.ManagedResource{
 			APIVersion: "v1",
 			Kind:       "ConfigMap",
 			Name:       cm.Name,
 			Namespace:  cm.Namespace,
 			Cluster:    clusterName,
-		})
+		}})
 	}
 
-	// Apply Agents
+	// Agents
 	for _, agent := range runtimeConfig.Kubernetes.Agents {
-		r.setOwnerLabels(agent, deployment)
-		if err := r.applyObj(ctx, mcpURL, targetClient, agent); err != nil {
-			return fmt.Errorf("failed to apply Agent: %w", err)
-		}
-		managedResources = append(managedResources, agentregistryv1alpha1.ManagedResource{
+		objs = append(objs, managedObject{obj: agent, ref: agentregistryv1alpha1.ManagedResource{
 			APIVersion: agent.APIVersion,
 			Kind:       agent.Kind,
 			Name:       agent.Name,
 			Namespace:  agent.Namespace,
 			Cluster:    clusterName,
-		})
+		}})
+	}
+	return objs
+}
+
+// managedObject pairs a rendered resource with the entry recorded for it in
+// Status.ManagedResources once it has been applied
+type managedObject struct {
+	obj client.Object
+	ref agentregistryv1alpha1.ManagedResource
+}
+
+// partialApplyError reports that some of a deployment's resources failed to
+// apply. The resources that did apply are still tracked in the status.
+type partialApplyError struct {
+	applied int
+	total   int
+	errs    []error
+}
+
+func (e *partialApplyError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("applied %d of %d resources: %s", e.applied, e.total, strings.Join(msgs, "; "))
+}
+
+func (e *partialApplyError) Unwrap() []error {
+	return e.errs
+}
+
+// applyRendered applies objs, rendered for the environments of the clusters
+// in rendered, to targets, and reports the environments skipped with their
+// errors in skipped. With none rendered nothing is applied or pruned, and the
+// skipped errors are returned. Otherwise a skipped environment makes the
+// deployment partial, like a resource that failed to apply.
+func (r *RegistryDeploymentReconciler) applyRendered(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment, targets deploymentTargets, rendered []string, objs []managedObject, skipped []error) error {
+	if len(rendered) == 0 {
+		return errors.Join(skipped...)
+	}
+	err := r.applyManagedObjects(ctx, deployment, targets, objs)
+	if len(skipped) == 0 {
+		return err
+	}
+
+	partial := &partialApplyError{applied: len(objs), total: len(objs), errs: skipped}
+	var applyErr *partialApplyError
+	switch {
+	case errors.As(err, &applyErr):
+		partial.applied = applyErr.applied
+		partial.errs = append(partial.errs, applyErr.errs...)
+	case err != nil:
+		partial.applied = 0
+		partial.errs = append(partial.errs, err)
+	}
+	return partial
+}
+
+// applyManagedObjects applies every object, continuing past failures so one
+// bad resource does not block the others, and records the applied resources
+// in Status.ManagedResources. A resource that fails to apply but was applied
+// by an earlier reconcile stays tracked so it is still cleaned up on deletion.
+// Previously applied resources that are no longer rendered are deleted. Each
+// object is applied to the target of the cluster its entry records.
+func (r *RegistryDeploymentReconciler) applyManagedObjects(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment, targets deploymentTargets, objs []managedObject) error {
+	// Refuse to apply anything rather than flood the target cluster
+	if limit := r.maxManagedResources(); len(objs) > limit {
+		return fmt.Errorf("deployment renders %d resources, more than the limit of %d; check the catalog entry for %s %s",
+			len(objs), limit, deployment.Spec.ResourceName, deployment.Spec.Version)
 	}
 
+	previous := deployment.Status.ManagedResources
+	managedResources := []agentregistryv1alpha1.ManagedResource{}
+	var errs []error
+
+	for _, m := range objs {
+		r.setOwnerLabels(m.obj, deployment)
+		target, _ := targets.find(m.ref.Cluster)
+		if err := r.applyObj(ctx, target.mcpURL, target.client, m.obj); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply %s %s: %w", m.ref.Kind, m.ref.Name, err))
+			if prev, ok := findManagedResource(previous, m.ref); ok {
+				managedResources = append(managedResources, prev)
+			}
+			continue
+		}
+		managedResources = append(managedResources, m.ref)
+	}
+
+	applied := len(objs) - len(errs)
+	managedResources = append(managedResources, r.pruneStaleResources(ctx, deployment, targets, previous, objs, &errs)...)
+
+	recordAppliedTimeline(&deployment.Status, previous, managedResources)
 	deployment.Status.ManagedResources = managedResources
+	if len(errs) > 0 {
+		return &partialApplyError{applied: applied, total: len(objs), errs: errs}
+	}
 	return nil
 }
 
+func (r *RegistryDeploymentReconciler) maxManagedResources() int {
+	if r.MaxManagedResources > 0 {
+		return r.MaxManagedResources
+	}
+	return DefaultMaxManagedResources
+}
+
+// pruneStaleResources deletes the previously managed resources that objs no
+// longer render, e.g. a ConfigMap dropped from an agent's config. It returns
+// the stale resources that must stay tracked: those whose deletion failed,
+// recorded in errs so the next reconcile retries, and those on a cluster
+// none of the targets reaches, which cannot be reached from here.
+func (r *RegistryDeploymentReconciler) pruneStaleResources(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment, targets deploymentTargets, previous []agentregistryv1alpha1.ManagedResource, objs []managedObject, errs *[]error) []agentregistryv1alpha1.ManagedResource {
+	if len(objs) == 0 {
+		return previous
+	}
+	rendered := make([]agentregistryv1alpha1.ManagedResource, len(objs))
+	for i, m := range objs {
+		rendered[i] = m.ref
+	}
+
+	var kept []agentregistryv1alpha1.ManagedResource
+	for _, res := range previous {
+		if _, ok := findManagedResource(rendered, res); ok {
+			continue
+		}
+		// The ServiceAccount of an MCPServer that is still deployed belongs
+		// to kmcp, which drops the pull secrets set here on its next
+		// reconcile; deleting it would fail the server's new pods
+		if res.Kind == "ServiceAccount" {
+			server := res
+			server.Kind = "MCPServer"
+			if _, ok := findManagedResource(rendered, server); ok {
+				continue
+			}
+		}
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			kept = append(kept, res)
+			continue
+		}
+		if err := r.deleteObj(ctx, target.mcpURL, target.client, res); err != nil {
+			*errs = append(*errs, fmt.Errorf("failed to delete stale %s %s: %w", res.Kind, res.Name, err))
+			kept = append(kept, res)
+			continue
+		}
+		r.Logger.Info().Str("deployment", deployment.Name).Str("kind", res.Kind).Str("name", res.Name).
+			Str("namespace", res.Namespace).Msg("deleted resource no longer managed by deployment")
+	}
+	return kept
+}
+
+// findManagedResource returns the entry in resources that refers to the same
+// object as ref
+func findManagedResource(resources []agentregistryv1alpha1.ManagedResource, ref agentregistryv1alpha1.ManagedResource) (agentregistryv1alpha1.ManagedResource, bool) {
+	for _, res := range resources {
+		if res.Kind == ref.Kind && res.Name == ref.Name && res.Namespace == ref.Namespace && res.Cluster == ref.Cluster {
+			return res, true
+		}
+	}
+	return agentregistryv1alpha1.ManagedResource{}, false
+}
+
+// failedPhase returns the phase for a deployment whose reconcile returned err:
+// PartiallyDeployed when some resources were applied, Failed otherwise
+func failedPhase(err error) agentregistryv1alpha1.DeploymentPhase {
+	var partial *partialApplyError
+	if errors.As(err, &partial) && partial.applied > 0 {
+		return agentregistryv1alpha1.DeploymentPhasePartiallyDeployed
+	}
+	return agentregistryv1alpha1.DeploymentPhaseFailed
+}
+
+// translateMCPServer converts an MCPServerCatalog to the runtime API format and
+// translates it into the Kubernetes resources that deploy it
+func (r *RegistryDeploymentReconciler) translateMCPServer(ctx context.Context, catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (*api.AIRuntimeConfig, error) {
+	if deployment.Spec.Runtime == agentregistryv1alpha1.RuntimeTypeHelm {
+		return r.translateHelmChart(ctx, catalog, deployment)
+	}
+
+	mcpServer, err := r.convertCatalogToMCPServer(catalog, deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert catalog to MCP server: %w", err)
+	}
+
+	runtimeConfig, err := kagent.NewTranslator().TranslateRuntimeConfig(ctx, &api.DesiredState{
+		MCPServers: []*api.MCPServer{mcpServer},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate runtime config: %w", err)
+	}
+	return runtimeConfig, nil
+}
+
+// translateHelmChart converts the catalog entry's Helm chart package to the
+// Flux resources that install it
+func (r *RegistryDeploymentReconciler) translateHelmChart(ctx context.Context, catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (*api.AIRuntimeConfig, error) {
+	chart, err := r.convertCatalogToHelmChart(catalog, deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert catalog to Helm chart: %w", err)
+	}
+
+	runtimeConfig, err := helm.NewTranslator().TranslateRuntimeConfig(ctx, &api.DesiredState{
+		HelmCharts: []*api.HelmChart{chart},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate runtime config: %w", err)
+	}
+	return runtimeConfig, nil
+}
+
+// translateAgent converts an AgentCatalog to the runtime API format and
+// translates it into the Kubernetes resources that deploy it
+func (r *RegistryDeploymentReconciler) translateAgent(ctx context.Context, catalog *agentregistryv1alpha1.AgentCatalog, deployment *agentregistryv1alpha1.RegistryDeployment, serverReader client.Reader) (*api.AIRuntimeConfig, error) {
+	if deployment.Spec.Runtime == agentregistryv1alpha1.RuntimeTypeHelm {
+		return nil, fmt.Errorf("helm runtime is only supported for MCP servers")
+	}
+
+	agent, err := r.convertCatalogToAgent(catalog, deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert catalog to agent: %w", err)
+	}
+	resolved, issues, err := r.resolveAgentMCPServers(ctx, catalog, deployment, serverReader)
+	if err != nil {
+		return nil, err
+	}
+	agent.ResolvedMCPServers = resolved
+	setUnresolvedMCPServersCondition(&deployment.Status.Conditions, issues)
+
+	runtimeConfig, err := kagent.NewTranslator().TranslateRuntimeConfig(ctx, &api.DesiredState{
+		Agents: []*api.Agent{agent},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate runtime config: %w", err)
+	}
+	return runtimeConfig, nil
+}
+
+// errCommandOverrideUnsupported is returned when a command or args override is
+// set on a deployment that does not run a catalog package container
+var errCommandOverrideUnsupported = errors.New("commandOverride and argsOverride are only supported for package-based MCP server deployments")
+
+// errImagePullSecretsUnsupported is returned when image pull secrets are set
+// on a deployment that does not run a container image of its own
+var errImagePullSecretsUnsupported = errors.New("imagePullSecrets are only supported for agent and package-based MCP server deployments")
+
+// hasCommandOverride reports whether the deployment overrides the container
+// command or arguments
+func hasCommandOverride(deployment *agentregistryv1alpha1.RegistryDeployment) bool {
+	return deployment.Spec.CommandOverride != "" || len(deployment.Spec.ArgsOverride) > 0
+}
+
+// effectiveCommand returns the command and arguments the translated MCP
+// server runs with, or nil when it uses its image's entrypoint
+func effectiveCommand(mcpServers []*kmcpv1alpha1.MCPServer) []string {
+	if len(mcpServers) == 0 {
+		return nil
+	}
+	deployment := mcpServers[0].Spec.Deployment
+	var command []string
+	if deployment.Cmd != "" {
+		command = append(command, deployment.Cmd)
+	}
+	return append(command, deployment.Args...)
+}
+
 // convertCatalogToMCPServer converts an MCPServerCatalog to the runtime API format
 func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (*api.MCPServer, error) {
+	if err := CheckRegistryTypeAllowed(catalog, deployment); err != nil {
+		return nil, err
+	}
+
 	// Determine if we should use remote or local
-	useRemote := len(catalog.Spec.Remotes) > 0 && (deployment.Spec.PreferRemote || len(catalog.Spec.Packages) == 0)
+	useRemote := usesRemote(catalog, deployment)
 
 	targetNamespace := deployment.Spec.Namespace
 	if targetNamespace == "" {
-		targetNamespace = defaultNamespace
+		targetNamespace = config.DefaultDeployNamespace()
 	}
 
 	if useRemote {
+		if hasCommandOverride(deployment) {
+			return nil, errCommandOverrideUnsupported
+		}
+		if len(deployment.Spec.ImagePullSecrets) > 0 {
+			return nil, errImagePullSecretsUnsupported
+		}
 		// Use remote transport
 		remote := catalog.Spec.Remotes[0]
 		headers := make([]api.HeaderValue, 0, len(remote.Headers))
@@ -367,16 +927,22 @@ func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentr
 			})
 		}
 
+		transportType := api.TransportTypeHTTP
+		if remote.Type == "sse" {
+			transportType = api.TransportTypeSSE
+		}
+
 		host, port, path := parseURLComponents(remote.URL)
 		return &api.MCPServer{
 			Name:          generateInternalName(catalog.Spec.Name),
 			MCPServerType: api.MCPServerTypeRemote,
 			Namespace:     targetNamespace,
 			Remote: &api.RemoteMCPServer{
-				Host:    host,
-				Port:    port,
-				Path:    path,
-				Headers: headers,
+				Host:          host,
+				Port:          port,
+				Path:          path,
+				Headers:       headers,
+				TransportType: transportType,
 			},
 		}, nil
 	}
@@ -386,13 +952,29 @@ func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentr
 		return nil, fmt.Errorf("no packages available for server %s", catalog.Spec.Name)
 	}
 
-	pkg := catalog.Spec.Packages[0]
+	pkg, err := DeploymentPackage(catalog, deployment)
+	if err != nil {
+		return nil, err
+	}
 
-	// Build environment variables from package spec and deployment config
+	// Build environment variables from package spec and deployment config.
+	// Secret references are passed through as references so their values
+	// never end up in the generated manifests.
 	env := make(map[string]string)
+	var secretEnv []api.SecretEnvVar
 	for _, envVar := range pkg.EnvironmentVariables {
 		if v, ok := deployment.Spec.Config[envVar.Name]; ok {
 			env[envVar.Name] = v
+		} else if envVar.SecretRef != nil {
+			key := envVar.SecretRef.Key
+			if key == "" {
+				key = envVar.Name
+			}
+			secretEnv = append(secretEnv, api.SecretEnvVar{
+				Name:       envVar.Name,
+				SecretName: envVar.SecretRef.SecretName,
+				Key:        key,
+			})
 		} else if envVar.Value != "" {
 			env[envVar.Name] = envVar.Value
 		}
@@ -422,11 +1004,24 @@ func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentr
 
 	// For OCI registry, use identifier as the image
 	if pkg.RegistryType == "oci" {
-		image = pkg.Identifier
+		pinned, err := pinnedImage(pkg)
+		if err != nil {
+			return nil, err
+		}
+		image = pinned
 		cmd = ""   // OCI images have their own entrypoint
 		args = nil // OCI images use their own CMD/ARGS
 	}
 
+	// Deployment overrides replace the derived entrypoint, e.g. to wrap it in a
+	// debug shim
+	if deployment.Spec.CommandOverride != "" {
+		cmd = deployment.Spec.CommandOverride
+	}
+	if len(deployment.Spec.ArgsOverride) > 0 {
+		args = slices.Clone(deployment.Spec.ArgsOverride)
+	}
+
 	var transportType api.TransportType
 	var httpTransport *api.HTTPTransport
 
@@ -443,6 +1038,18 @@ func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentr
 			Port: port,
 			Path: path,
 		}
+	case "sse":
+		transportType = api.TransportTypeSSE
+		// SSE servers listen on HTTP as well; default to the conventional endpoint
+		port := uint32(8080)
+		path := "/sse"
+		if pkg.Transport.URL != "" {
+			_, port, path = parseURLComponents(pkg.Transport.URL)
+		}
+		httpTransport = &api.HTTPTransport{
+			Port: port,
+			Path: path,
+		}
 	default:
 		// Default to stdio for local packages (npm, pypi)
 		transportType = api.TransportTypeStdio
@@ -454,10 +1061,12 @@ func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentr
 		Namespace:     targetNamespace,
 		Local: &api.LocalMCPServer{
 			Deployment: api.MCPServerDeployment{
-				Image: image,
-				Cmd:   cmd,
-				Args:  args,
-				Env:   env,
+				Image:            image,
+				Cmd:              cmd,
+				Args:             args,
+				Env:              env,
+				SecretEnv:        secretEnv,
+				ImagePullSecrets: slices.Clone(deployment.Spec.ImagePullSecrets),
 			},
 			TransportType: transportType,
 			HTTP:          httpTransport,
@@ -465,11 +1074,121 @@ func (r *RegistryDeploymentReconciler) convertCatalogToMCPServer(catalog *agentr
 	}, nil
 }
 
+// convertCatalogToHelmChart builds the Helm release for the catalog entry's
+// helm package. Deployment config keys are chart values; dotted keys such as
+// "image.tag" set nested values.
+func (r *RegistryDeploymentReconciler) convertCatalogToHelmChart(catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (*api.HelmChart, error) {
+	if err := CheckRegistryTypeAllowed(catalog, deployment); err != nil {
+		return nil, err
+	}
+
+	if hasCommandOverride(deployment) {
+		return nil, errCommandOverrideUnsupported
+	}
+	if len(deployment.Spec.ImagePullSecrets) > 0 {
+		return nil, errImagePullSecretsUnsupported
+	}
+
+	pkg, ok := helmPackage(catalog)
+	if !ok {
+		return nil, fmt.Errorf("no helm package available for server %s", catalog.Spec.Name)
+	}
+
+	repository, chartName, err := helm.ParseChartReference(pkg.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	targetNamespace := deployment.Spec.Namespace
+	if targetNamespace == "" {
+		targetNamespace = config.DefaultDeployNamespace()
+	}
+
+	values := map[string]any{}
+	for key, value := range deployment.Spec.Config {
+		setHelmValue(values, key, value)
+	}
+
+	return &api.HelmChart{
+		Name:       generateInternalName(catalog.Spec.Name),
+		Namespace:  targetNamespace,
+		Repository: repository,
+		Chart:      chartName,
+		Version:    pkg.Version,
+		Values:     values,
+	}, nil
+}
+
+// helmPackage returns the first package of the catalog entry with the helm registry type
+func helmPackage(catalog *agentregistryv1alpha1.MCPServerCatalog) (agentregistryv1alpha1.Package, bool) {
+	for _, pkg := range catalog.Spec.Packages {
+		if pkg.RegistryType == registryTypeHelm {
+			return pkg, true
+		}
+	}
+	return agentregistryv1alpha1.Package{}, false
+}
+
+// setHelmValue sets value, parsed by helmValue, at the dotted path key in
+// values, creating intermediate maps as needed
+func setHelmValue(values map[string]any, key, value string) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := values[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			values[part] = next
+		}
+		values = next
+	}
+	values[parts[len(parts)-1]] = helmValue(value)
+}
+
+// helmValue returns the chart value a deployment config value stands for.
+// "true" and "false" are booleans and numbers are numbers, except for ones
+// with leading zeros, as with helm --set. JSON objects, arrays and null are
+// decoded, and a JSON string is unquoted, so that "\"1.10\"" keeps an image
+// tag from becoming a number. Anything else is a string.
+func helmValue(value string) any {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil && !hasLeadingZero(value) {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !hasLeadingZero(value) &&
+		!math.IsInf(f, 0) && !math.IsNaN(f) && strings.ContainsAny(value, ".eE") && !strings.ContainsAny(value, "xX_") {
+		return f
+	}
+	switch trimmed := strings.TrimSpace(value); {
+	case trimmed == "null", strings.HasPrefix(trimmed, "{"), strings.HasPrefix(trimmed, "["), strings.HasPrefix(trimmed, `"`):
+		var decoded any
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded
+		}
+	}
+	return value
+}
+
+// hasLeadingZero reports whether the number in value starts with a zero
+// followed by another digit, e.g. "0123", which is kept as a string
+func hasLeadingZero(value string) bool {
+	digits := strings.TrimLeft(value, "+-")
+	return len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9'
+}
+
 // convertCatalogToAgent converts an AgentCatalog to the runtime API format
 func (r *RegistryDeploymentReconciler) convertCatalogToAgent(catalog *agentregistryv1alpha1.AgentCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (*api.Agent, error) {
+	if hasCommandOverride(deployment) {
+		return nil, errCommandOverrideUnsupported
+	}
+
 	targetNamespace := deployment.Spec.Namespace
 	if targetNamespace == "" {
-		targetNamespace = defaultNamespace
+		targetNamespace = config.DefaultDeployNamespace()
 	}
 
 	// Build environment variables
@@ -494,8 +1213,9 @@ func (r *RegistryDeploymentReconciler) convertCatalogToAgent(catalog *agentregis
 		Name:    catalog.Spec.Name,
 		Version: catalog.Spec.Version,
 		Deployment: api.AgentDeployment{
-			Image: catalog.Spec.Image,
-			Env:   env,
+			Image:            catalog.Spec.Image,
+			Env:              env,
+			ImagePullSecrets: slices.Clone(deployment.Spec.ImagePullSecrets),
 		},
 	}, nil
 }
@@ -506,28 +1226,48 @@ func (r *RegistryDeploymentReconciler) handleDeletion(ctx context.Context, deplo
 		return ctrl.Result{}, nil
 	}
 
-	// Resolve the target client and environment for deletion
-	env, targetClient, _, err := r.getTargetClientAndEnv(ctx, deployment)
+	// Resolve the target clients and environments for deletion
+	targets, err := r.resolveTargets(ctx, deployment)
 	if err != nil {
-		r.Logger.Error().Err(err).Msg("failed to resolve target for deletion, falling back to local client")
-		targetClient = r.Client
-	}
-	mcpURL := ""
-	if env != nil {
-		mcpURL = env.MCPToolServerURL
+		r.Logger.Warn().Err(err).Str("deployment", deployment.Name).
+			Msg("failed to resolve some targets for deletion, keeping their resources")
 	}
 
-	// Delete managed resources
+	// Delete managed resources. One on a cluster that cannot be reached, or
+	// that fails to delete, stays tracked and holds the finalizer.
+	var remaining []agentregistryv1alpha1.ManagedResource
 	for _, res := range deployment.Status.ManagedResources {
-		if err := r.deleteObj(ctx, mcpURL, targetClient, res); err != nil {
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			remaining = append(remaining, res)
+			continue
+		}
+		if err := r.deleteObj(ctx, target.mcpURL, target.client, res); err != nil {
 			r.Logger.Error().Err(err).
 				Str("kind", res.Kind).
 				Str("name", res.Name).
 				Str("namespace", res.Namespace).
 				Msg("failed to delete managed resource")
+			remaining = append(remaining, res)
+		}
+	}
+
+	key := types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}
+	if len(remaining) > 0 {
+		// Retry until every cluster is cleaned up, or the deployment is force
+		// deleted to abandon what is left
+		deployment.Status.ManagedResources = remaining
+		deployment.Status.Message = fmt.Sprintf("Waiting to delete %d managed resource(s); force delete the deployment to abandon them", len(remaining))
+		if err := r.Status().Update(ctx, deployment); err != nil {
+			return ctrl.Result{}, err
 		}
+		attempt := r.recordTransientRetry(key)
+		return ctrl.Result{RequeueAfter: retryBackoffFor(transientRetryBaseBackoff, transientRetryMaxBackoff, attempt)}, nil
 	}
 
+	r.notifiedPhases.Delete(key)
+	r.transientRetries.Delete(key)
+
 	// Remove finalizer
 	controllerutil.RemoveFinalizer(deployment, finalizerName)
 	if err := r.Update(ctx, deployment); err != nil {
@@ -537,52 +1277,48 @@ func (r *RegistryDeploymentReconciler) handleDeletion(ctx context.Context, deplo
 	return ctrl.Result{}, nil
 }
 
-// getTargetClientAndEnv resolves the target client and environment for a deployment.
-// If the deployment specifies an environment, it looks up the environment from DiscoveryConfig
-// resources and returns a remote client along with the environment. Otherwise, it returns
-// the local client and nil environment.
-func (r *RegistryDeploymentReconciler) getTargetClientAndEnv(ctx context.Context, deployment *agentregistryv1alpha1.RegistryDeployment) (*agentregistryv1alpha1.Environment, client.Client, string, error) {
-	envName := deployment.Spec.Environment
-	if envName == "" {
-		return nil, r.Client, "", nil
+var (
+	// ErrEnvironmentNotFound is returned when no DiscoveryConfig declares the
+	// environment a deployment targets
+	ErrEnvironmentNotFound = errors.New("environment not found")
+	// ErrEnvironmentDeployDisabled is returned when a deployment targets an
+	// environment whose DeployEnabled is false
+	ErrEnvironmentDeployDisabled = errors.New("deployment to environment not allowed")
+)
+
+// FindDeployEnvironment returns the environment named name declared by a
+// DiscoveryConfig in namespace, the namespace of the RegistryDeployments
+// targeting it. It returns an error wrapping ErrEnvironmentNotFound when no
+// DiscoveryConfig declares it, or ErrEnvironmentDeployDisabled when it does
+// not allow deployments.
+func FindDeployEnvironment(ctx context.Context, reader client.Reader, namespace, name string) (*agentregistryv1alpha1.Environment, error) {
+	env, discoveryConfig, err := findEnvironment(ctx, reader, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !env.DeployEnabled {
+		return nil, fmt.Errorf("%w: %q has deployEnabled false in DiscoveryConfig %s", ErrEnvironmentDeployDisabled, name, discoveryConfig)
 	}
+	return env, nil
+}
 
-	// List DiscoveryConfigs in the same namespace to find the environment
+// findEnvironment returns the environment named name declared by a
+// DiscoveryConfig in namespace, whether or not it allows deployments, and the
+// name of that DiscoveryConfig
+func findEnvironment(ctx context.Context, reader client.Reader, namespace, name string) (*agentregistryv1alpha1.Environment, string, error) {
 	var dcList agentregistryv1alpha1.DiscoveryConfigList
-	if err := r.List(ctx, &dcList, client.InNamespace(deployment.Namespace)); err != nil {
-		return nil, nil, "", fmt.Errorf("failed to list DiscoveryConfigs: %w", err)
+	if err := reader.List(ctx, &dcList, client.InNamespace(namespace)); err != nil {
+		return nil, "", fmt.Errorf("failed to list DiscoveryConfigs: %w", err)
 	}
 
 	for i := range dcList.Items {
 		for j := range dcList.Items[i].Spec.Environments {
-			env := &dcList.Items[i].Spec.Environments[j]
-			if env.Name == envName {
-				if !env.DeployEnabled {
-					return nil, nil, "", fmt.Errorf("deployment to environment %q is not allowed (deployEnabled is false)", envName)
-				}
-
-				// If MCP tool server is available, we don't need a K8s client
-				if env.MCPToolServerURL != "" {
-					return env, nil, env.Cluster.Name, nil
-				}
-
-				factory := r.RemoteClientFactory
-				if factory == nil {
-					factory = RemoteClientFactory
-				}
-				if factory == nil {
-					return nil, nil, "", fmt.Errorf("remote client factory not configured, cannot deploy to environment %q", envName)
-				}
-				remoteClient, err := factory(env, r.Scheme)
-				if err != nil {
-					return nil, nil, "", fmt.Errorf("failed to create remote client for environment %q: %w", envName, err)
-				}
-				return env, remoteClient, env.Cluster.Name, nil
+			if env := &dcList.Items[i].Spec.Environments[j]; env.Name == name {
+				return env, dcList.Items[i].Name, nil
 			}
 		}
 	}
-
-	return nil, nil, "", fmt.Errorf("environment %q not found in any DiscoveryConfig in namespace %q", envName, deployment.Namespace)
+	return nil, "", fmt.Errorf("%w: %q is not declared by any DiscoveryConfig in namespace %q", ErrEnvironmentNotFound, name, namespace)
 }
 
 // applyObj dispatches to MCP or direct K8s apply based on the mcpURL.
@@ -602,26 +1338,21 @@ func (r *RegistryDeploymentReconciler) deleteObj(ctx context.Context, mcpURL str
 }
 
 // applyViaMCP applies a Kubernetes resource via the MCP tool server's k8s_apply_manifest tool.
-func (r *RegistryDeploymentReconciler) applyViaMCP(ctx context.Context, mcpURL string, obj client.Object) error {
+func (r *RegistryDeploymentReconciler) applyViaMCP(ctx context.Context, mcpURL string, obj client.Object) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RegistryDeployment.applyViaMCP", trace.WithAttributes(
+		attribute.String("agentregistry.mcp.url", mcpURL),
+		attribute.String("k8s.object.kind", obj.GetObjectKind().GroupVersionKind().Kind),
+		attribute.String("k8s.object.name", obj.GetName()),
+		tracing.KeyNamespace.String(obj.GetNamespace()),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	yamlBytes, err := sigyaml.Marshal(obj)
 	if err != nil {
 		return fmt.Errorf("failed to marshal object to YAML: %w", err)
 	}
 
-	mcpClient := mcp.NewClient(&mcp.Implementation{
-		Name:    "agentregistry",
-		Version: "1.0.0",
-	}, nil)
-
-	session, err := mcpClient.Connect(ctx, &mcp.StreamableClientTransport{
-		Endpoint: mcpURL,
-	}, nil)
-	if err != nil {
-		return fmt.Errorf("failed to connect to MCP tool server at %s: %w", mcpURL, err)
-	}
-	defer session.Close()
-
-	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+	result, err := r.mcpSessions.callTool(ctx, mcpURL, &mcp.CallToolParams{
 		Name: "k8s_apply_manifest",
 		Arguments: map[string]any{
 			"manifest": string(yamlBytes),
@@ -645,21 +1376,16 @@ func (r *RegistryDeploymentReconciler) applyViaMCP(ctx context.Context, mcpURL s
 }
 
 // deleteViaMCP deletes a Kubernetes resource via the MCP tool server's k8s_delete_resource tool.
-func (r *RegistryDeploymentReconciler) deleteViaMCP(ctx context.Context, mcpURL string, res agentregistryv1alpha1.ManagedResource) error {
-	mcpClient := mcp.NewClient(&mcp.Implementation{
-		Name:    "agentregistry",
-		Version: "1.0.0",
-	}, nil)
-
-	session, err := mcpClient.Connect(ctx, &mcp.StreamableClientTransport{
-		Endpoint: mcpURL,
-	}, nil)
-	if err != nil {
-		return fmt.Errorf("failed to connect to MCP tool server at %s: %w", mcpURL, err)
-	}
-	defer session.Close()
-
-	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+func (r *RegistryDeploymentReconciler) deleteViaMCP(ctx context.Context, mcpURL string, res agentregistryv1alpha1.ManagedResource) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RegistryDeployment.deleteViaMCP", trace.WithAttributes(
+		attribute.String("agentregistry.mcp.url", mcpURL),
+		attribute.String("k8s.object.kind", res.Kind),
+		attribute.String("k8s.object.name", res.Name),
+		tracing.KeyNamespace.String(res.Namespace),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	result, err := r.mcpSessions.callTool(ctx, mcpURL, &mcp.CallToolParams{
 		Name: "k8s_delete_resource",
 		Arguments: map[string]any{
 			"apiVersion": res.APIVersion,
@@ -690,8 +1416,9 @@ func (r *RegistryDeploymentReconciler) applyResource(ctx context.Context, target
 	return targetClient.Patch(ctx, obj, client.Apply, client.FieldOwner("agentregistry"), client.ForceOwnership)
 }
 
-// deleteResource deletes a managed resource using the provided client
-func (r *RegistryDeploymentReconciler) deleteResource(ctx context.Context, targetClient client.Client, res agentregistryv1alpha1.ManagedResource) error {
+// managedResourceObject returns an empty object of the managed resource's
+// kind, named after it
+func managedResourceObject(res agentregistryv1alpha1.ManagedResource) (client.Object, error) {
 	var obj client.Object
 	switch res.Kind {
 	case "Agent":
@@ -702,12 +1429,28 @@ func (r *RegistryDeploymentReconciler) deleteResource(ctx context.Context, targe
 		obj = &kmcpv1alpha1.MCPServer{}
 	case "ConfigMap":
 		obj = &corev1.ConfigMap{}
+	case "ServiceAccount":
+		obj = &corev1.ServiceAccount{}
+	case helm.HelmReleaseGVK.Kind, helm.HelmRepositoryGVK.Kind:
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(res.APIVersion)
+		u.SetKind(res.Kind)
+		obj = u
 	default:
-		return fmt.Errorf("unknown resource kind: %s", res.Kind)
+		return nil, fmt.Errorf("unknown resource kind: %s", res.Kind)
 	}
 
 	obj.SetName(res.Name)
 	obj.SetNamespace(res.Namespace)
+	return obj, nil
+}
+
+// deleteResource deletes a managed resource using the provided client
+func (r *RegistryDeploymentReconciler) deleteResource(ctx context.Context, targetClient client.Client, res agentregistryv1alpha1.ManagedResource) error {
+	obj, err := managedResourceObject(res)
+	if err != nil {
+		return err
+	}
 
 	if err := targetClient.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
 		return err
@@ -715,12 +1458,35 @@ func (r *RegistryDeploymentReconciler) deleteResource(ctx context.Context, targe
 	return nil
 }
 
-// setOwnerLabels sets labels to track ownership
+// validateResourceMetadata checks the user-supplied labels and annotations
+// before they are applied to managed resources.
+func validateResourceMetadata(deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	if err := validation.ValidateLabels(deployment.Spec.ResourceLabels); err != nil {
+		return fmt.Errorf("invalid resourceLabels: %w", err)
+	}
+	if err := validation.ValidateAnnotations(deployment.Spec.ResourceAnnotations); err != nil {
+		return fmt.Errorf("invalid resourceAnnotations: %w", err)
+	}
+	return nil
+}
+
+// setOwnerLabels sets labels to track ownership, along with the
+// deployment's user-supplied resource labels and annotations
 func (r *RegistryDeploymentReconciler) setOwnerLabels(obj client.Object, deployment *agentregistryv1alpha1.RegistryDeployment) {
 	labels := obj.GetLabels()
 	if labels == nil {
 		labels = make(map[string]string)
 	}
+	// User-supplied labels first so the tracking labels below always win
+	maps.Copy(labels, deployment.Spec.ResourceLabels)
+	if len(deployment.Spec.ResourceAnnotations) > 0 {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		maps.Copy(annotations, deployment.Spec.ResourceAnnotations)
+		obj.SetAnnotations(annotations)
+	}
 	labels[managedByLabel] = "agentregistry"
 	labels[deploymentNameLabel] = deployment.Name
 	labels[deploymentNSLabel] = deployment.Namespace
@@ -753,8 +1519,17 @@ func (r *RegistryDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error
 		}}
 	}
 
+	// Close the pooled MCP tool server sessions on shutdown
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		r.mcpSessions.close()
+		return nil
+	})); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&agentregistryv1alpha1.RegistryDeployment{}).
+		For(&agentregistryv1alpha1.RegistryDeployment{}, builder.WithPredicates(deploymentChangePredicate)).
 		// Watch Agents managed by this controller
 		Watches(
 			&kagentv1alpha2.Agent{},
@@ -775,7 +1550,8 @@ func (r *RegistryDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(enqueueFromManagedResource),
 		).
-		Complete(r)
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(countReconcileErrors("registrydeployment", traceReconcile("RegistryDeployment", r)))
 }
 
 // Helper functions
@@ -824,6 +1600,83 @@ func parseURLComponents(rawURL string) (host string, port uint32, path string) {
 	return host, port, path
 }
 
+// registryTypeHelm is the package registry type of Helm charts. The package
+// identifier is <repository>/<chart> and its version is the chart version.
+const registryTypeHelm = "helm"
+
+// ErrRegistryTypeNotAllowed is returned when a deployment would run a package
+// whose registry type is not in config.AllowedRegistryTypes
+var ErrRegistryTypeNotAllowed = errors.New("registry type not allowed")
+
+// usesRemote reports whether deployment runs catalog through its remote
+// transport rather than a local package
+func usesRemote(catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) bool {
+	return len(catalog.Spec.Remotes) > 0 && (deployment.Spec.PreferRemote || len(catalog.Spec.Packages) == 0)
+}
+
+// ErrPackageIndexOutOfRange is returned when a deployment selects a package the
+// catalog entry does not have
+var ErrPackageIndexOutOfRange = errors.New("packageIndex out of range")
+
+// DeploymentPackage returns the catalog package selected by the deployment's
+// PackageIndex
+func DeploymentPackage(catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) (agentregistryv1alpha1.Package, error) {
+	index := int(deployment.Spec.PackageIndex)
+	if index < 0 || index >= len(catalog.Spec.Packages) {
+		return agentregistryv1alpha1.Package{}, fmt.Errorf("%w: package %d selected, %s %s has %d",
+			ErrPackageIndexOutOfRange, index, catalog.Spec.Name, catalog.Spec.Version, len(catalog.Spec.Packages))
+	}
+	return catalog.Spec.Packages[index], nil
+}
+
+// CheckRegistryTypeAllowed returns an error wrapping ErrRegistryTypeNotAllowed
+// when deployment would run catalog from a package whose registry type the
+// operator has not allowed, or wrapping ErrPackageIndexOutOfRange when the
+// selected package does not exist. Remote deployments pull no package and
+// always pass.
+func CheckRegistryTypeAllowed(catalog *agentregistryv1alpha1.MCPServerCatalog, deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	var registryType string
+	switch {
+	case deployment.Spec.Runtime == agentregistryv1alpha1.RuntimeTypeHelm:
+		registryType = registryTypeHelm
+	case usesRemote(catalog, deployment) || len(catalog.Spec.Packages) == 0:
+		return nil
+	default:
+		pkg, err := DeploymentPackage(catalog, deployment)
+		if err != nil {
+			return err
+		}
+		registryType = pkg.RegistryType
+	}
+	if config.IsRegistryTypeAllowed(registryType) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s packages cannot be deployed (allowed: %s)",
+		ErrRegistryTypeNotAllowed, registryType, strings.Join(slices.Sorted(maps.Keys(config.AllowedRegistryTypes())), ", "))
+}
+
+// pinnedImage returns the image reference for an OCI package, pinned to its
+// digest when one is set.
+func pinnedImage(pkg agentregistryv1alpha1.Package) (string, error) {
+	if pkg.Digest == "" {
+		return pkg.Identifier, nil
+	}
+	if err := validation.ValidateDigest(pkg.Digest); err != nil {
+		return "", fmt.Errorf("package %s: %w", pkg.Identifier, err)
+	}
+	// A digest supersedes any digest already present in the identifier
+	repo, _, _ := strings.Cut(pkg.Identifier, "@")
+	return repo + "@" + pkg.Digest, nil
+}
+
+// imageDigest returns the digest part of a digest-pinned image reference
+func imageDigest(image string) string {
+	if _, digest, ok := strings.Cut(image, "@"); ok {
+		return digest
+	}
+	return ""
+}
+
 func getImageAndCommand(registryType, runtimeHint string) (image, cmd string) {
 	switch registryType {
 	case "npm":
@@ -843,6 +1696,40 @@ func getImageAndCommand(registryType, runtimeHint string) (image, cmd string) {
 	}
 }
 
+// checkPublisherIdentity enforces validatePublisherIdentity unless verified
+// publishers are not required (config.RequireVerifiedPublisher), in which case
+// a failed check is logged as a warning and the deployment proceeds.
+func (r *RegistryDeploymentReconciler) checkPublisherIdentity(deployment *agentregistryv1alpha1.RegistryDeployment, metadata *apiextensionsv1.JSON) error {
+	err := validatePublisherIdentity(metadata)
+	if err == nil || config.RequireVerifiedPublisher() {
+		return err
+	}
+	r.Logger.Warn().
+		Err(err).
+		Str("deployment", deployment.Namespace+"/"+deployment.Name).
+		Str("resourceName", deployment.Spec.ResourceName).
+		Str("version", deployment.Spec.Version).
+		Msg("SKIPPING publisher identity verification: AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER=false, deploying unverified resource")
+	return nil
+}
+
+// checkSBOMAttestation blocks the deployment when SBOM attestations are
+// required (config.RequireSBOMAttestation) and the catalog version has no
+// valid one attached.
+func (r *RegistryDeploymentReconciler) checkSBOMAttestation(ctx context.Context, catalogEntry *agentregistryv1alpha1.MCPServerCatalog) error {
+	if !config.RequireSBOMAttestation() {
+		return nil
+	}
+	attestations, err := ListAttestations(ctx, r.Client, catalogEntry)
+	if err != nil {
+		return fmt.Errorf("failed to read attestations: %w", err)
+	}
+	if !HasValidSBOM(attestations) {
+		return fmt.Errorf("no valid SBOM attestation attached: AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION=true requires an inline CycloneDX/SPDX SBOM or a digest-pinned reference")
+	}
+	return nil
+}
+
 // validatePublisherIdentity checks that the catalog entry has both verified organization
 // and verified publisher identity. Deployments are blocked if either validation is missing.
 func validatePublisherIdentity(metadata *apiextensionsv1.JSON) error {
@@ -900,20 +1787,24 @@ func (r *RegistryDeploymentReconciler) checkManagedResourcesReady(ctx context.Co
 		return false, "Pending"
 	}
 
-	// Resolve the target client and environment for status checks
-	env, targetClient, _, err := r.getTargetClientAndEnv(ctx, deployment)
-	if err != nil {
-		return false, fmt.Sprintf("Failed to resolve target: %v", err)
-	}
-
-	// When MCP tool server is used, we can't query resource status directly.
-	// If apply succeeded, consider resources ready.
-	if env != nil && env.MCPToolServerURL != "" {
-		return true, ""
-	}
+	// Resolve the target clients and environments for status checks
+	targets, err := r.resolveTargets(ctx, deployment)
 
 	// Check each managed resource status
 	for _, res := range deployment.Status.ManagedResources {
+		target, ok := targets.find(res.Cluster)
+		if !ok {
+			if err != nil {
+				return false, fmt.Sprintf("Failed to resolve target: %v", err)
+			}
+			return false, fmt.Sprintf("Cluster %q of %s %s/%s cannot be reached", res.Cluster, res.Kind, res.Namespace, res.Name)
+		}
+		// When MCP tool server is used, we can't query resource status
+		// directly. If apply succeeded, consider the resource ready.
+		if target.mcpURL != "" {
+			continue
+		}
+		targetClient := target.client
 		switch res.Kind {
 		case "MCPServer":
 			var mcp kmcpv1alpha1.MCPServer
@@ -1004,6 +1895,49 @@ func (r *RegistryDeploymentReconciler) checkManagedResourcesReady(ctx context.Co
 			}
 			// ConfigMaps don't have conditions, just existence check
 			continue
+
+		case "ServiceAccount":
+			var sa corev1.ServiceAccount
+			key := client.ObjectKey{Namespace: res.Namespace, Name: res.Name}
+			if err := targetClient.Get(ctx, key, &sa); err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, fmt.Sprintf("Managed %s %s/%s not found - will recreate", res.Kind, res.Namespace, res.Name)
+				}
+				return false, fmt.Sprintf("Error checking %s %s/%s: %v", res.Kind, res.Namespace, res.Name, err)
+			}
+			// The MCPServer it belongs to reports whether its pods could pull
+			// their image
+			continue
+
+		case helm.HelmReleaseGVK.Kind, helm.HelmRepositoryGVK.Kind:
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(res.APIVersion)
+			obj.SetKind(res.Kind)
+			key := client.ObjectKey{Namespace: res.Namespace, Name: res.Name}
+			if err := targetClient.Get(ctx, key, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, fmt.Sprintf("Managed %s %s/%s not found - will recreate", res.Kind, res.Namespace, res.Name)
+				}
+				return false, fmt.Sprintf("Error checking %s %s/%s: %v", res.Kind, res.Namespace, res.Name, err)
+			}
+
+			// Flux reports a Ready condition on both sources and releases
+			conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+			ready := false
+			for _, c := range conditions {
+				cond, _ := c.(map[string]any)
+				if cond["type"] == "Ready" {
+					if cond["status"] == string(metav1.ConditionTrue) {
+						ready = true
+						break
+					}
+					message, _ := cond["message"].(string)
+					return false, message
+				}
+			}
+			if !ready {
+				return false, "Pending"
+			}
 		}
 	}
 
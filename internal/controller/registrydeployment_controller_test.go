@@ -2,18 +2,34 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"syscall"
 	"testing"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/helm"
+	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/kagent"
 	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 )
@@ -185,6 +201,13 @@ func TestParseURLComponents(t *testing.T) {
 			wantPort: 3000,
 			wantPath: "/",
 		},
+		{
+			name:     "sse endpoint without scheme defaults to 80",
+			url:      "localhost/sse",
+			wantHost: "localhost",
+			wantPort: 80,
+			wantPath: "/sse",
+		},
 	}
 
 	for _, tt := range tests {
@@ -294,6 +317,81 @@ func TestSetOwnerLabels(t *testing.T) {
 	assert.Equal(t, "default", labels[deploymentNSLabel])
 }
 
+func TestSetOwnerLabels_ResourceLabelsAndAnnotations(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-deployment",
+			Namespace: "agentregistry",
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceLabels: map[string]string{
+				"cost-center": "team-a",
+				// Attempts to override tracking labels are ignored
+				managedByLabel:      "someone-else",
+				deploymentNameLabel: "other",
+			},
+			ResourceAnnotations: map[string]string{
+				"sidecar.istio.io/inject": "true",
+			},
+		},
+	}
+
+	obj := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-server",
+			Namespace:   "default",
+			Labels:      map[string]string{"existing": "kept"},
+			Annotations: map[string]string{"existing": "kept"},
+		},
+	}
+
+	r.setOwnerLabels(obj, deployment)
+
+	labels := obj.GetLabels()
+	assert.Equal(t, "team-a", labels["cost-center"])
+	assert.Equal(t, "kept", labels["existing"])
+	assert.Equal(t, "agentregistry", labels[managedByLabel])
+	assert.Equal(t, "my-deployment", labels[deploymentNameLabel])
+	assert.Equal(t, "agentregistry", labels[deploymentNSLabel])
+
+	annotations := obj.GetAnnotations()
+	assert.Equal(t, "true", annotations["sidecar.istio.io/inject"])
+	assert.Equal(t, "kept", annotations["existing"])
+}
+
+func TestValidateResourceMetadata(t *testing.T) {
+	valid := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceLabels:      map[string]string{"app.kubernetes.io/part-of": "payments"},
+			ResourceAnnotations: map[string]string{"example.com/owner": "Team A <team-a@example.com>"},
+		},
+	}
+	assert.NoError(t, validateResourceMetadata(valid))
+
+	badLabelKey := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceLabels: map[string]string{"not a key": "x"},
+		},
+	}
+	assert.ErrorContains(t, validateResourceMetadata(badLabelKey), "resourceLabels")
+
+	badLabelValue := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceLabels: map[string]string{"team": "has spaces"},
+		},
+	}
+	assert.ErrorContains(t, validateResourceMetadata(badLabelValue), "resourceLabels")
+
+	badAnnotation := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceAnnotations: map[string]string{"bad/key/path": "x"},
+		},
+	}
+	assert.ErrorContains(t, validateResourceMetadata(badAnnotation), "resourceAnnotations")
+}
+
 // ---------------------------------------------------------------------------
 // Conversion method tests
 // ---------------------------------------------------------------------------
@@ -379,6 +477,118 @@ func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_Local(t *testing
 	require.NotNil(t, server.Local)
 }
 
+func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_SecretEnv(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.New(nil)}
+
+	catalog := &agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "npm-server",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{
+				{
+					RegistryType: "npm",
+					Identifier:   "@test/package",
+					Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+					EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+						{Name: "API_KEY", Value: "placeholder", SecretRef: &agentregistryv1alpha1.SecretKeyRef{SecretName: "search-creds"}},
+						{Name: "TOKEN", SecretRef: &agentregistryv1alpha1.SecretKeyRef{SecretName: "search-creds", Key: "TOKEN"}},
+						{Name: "OVERRIDDEN", SecretRef: &agentregistryv1alpha1.SecretKeyRef{SecretName: "other"}},
+						{Name: "LOG_LEVEL", Value: "info"},
+					},
+				},
+			},
+		},
+	}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			Config: map[string]string{"OVERRIDDEN": "explicit"},
+		},
+	}
+
+	server, err := r.convertCatalogToMCPServer(catalog, deployment)
+	require.NoError(t, err)
+	require.NotNil(t, server.Local)
+
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info", "OVERRIDDEN": "explicit"}, server.Local.Deployment.Env)
+	assert.Equal(t, []api.SecretEnvVar{
+		{Name: "API_KEY", SecretName: "search-creds", Key: "API_KEY"},
+		{Name: "TOKEN", SecretName: "search-creds", Key: "TOKEN"},
+	}, server.Local.Deployment.SecretEnv)
+}
+
+func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_SSE(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+
+	r := &RegistryDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+		Logger: zerolog.New(nil),
+	}
+
+	t.Run("remote", func(t *testing.T) {
+		catalog := &agentregistryv1alpha1.MCPServerCatalog{
+			Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+				Name:    "sse-remote",
+				Version: "1.0.0",
+				Remotes: []agentregistryv1alpha1.Transport{
+					{Type: "sse", URL: "https://api.example.com/sse"},
+				},
+			},
+		}
+		deployment := &agentregistryv1alpha1.RegistryDeployment{
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{PreferRemote: true},
+		}
+
+		server, err := r.convertCatalogToMCPServer(catalog, deployment)
+		require.NoError(t, err)
+		require.NotNil(t, server.Remote)
+		assert.Equal(t, api.TransportTypeSSE, server.Remote.TransportType)
+		assert.Equal(t, "api.example.com", server.Remote.Host)
+		assert.Equal(t, uint32(443), server.Remote.Port)
+		assert.Equal(t, "/sse", server.Remote.Path)
+	})
+
+	ssePackage := func(url string) *agentregistryv1alpha1.MCPServerCatalog {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+				Name:    "sse-package",
+				Version: "1.0.0",
+				Packages: []agentregistryv1alpha1.Package{
+					{
+						RegistryType: "npm",
+						Identifier:   "@test/sse",
+						Version:      "1.0.0",
+						Transport:    agentregistryv1alpha1.Transport{Type: "sse", URL: url},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		wantPort uint32
+		wantPath string
+	}{
+		{name: "package with port", url: "http://localhost:3001/events", wantPort: 3001, wantPath: "/events"},
+		{name: "package without port", url: "http://localhost/sse", wantPort: 80, wantPath: "/sse"},
+		{name: "package without url", url: "", wantPort: 8080, wantPath: "/sse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := r.convertCatalogToMCPServer(ssePackage(tt.url), &agentregistryv1alpha1.RegistryDeployment{})
+			require.NoError(t, err)
+			require.NotNil(t, server.Local)
+			assert.Equal(t, api.TransportTypeSSE, server.Local.TransportType)
+			require.NotNil(t, server.Local.HTTP)
+			assert.Equal(t, tt.wantPort, server.Local.HTTP.Port)
+			assert.Equal(t, tt.wantPath, server.Local.HTTP.Path)
+		})
+	}
+}
+
 func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_NoPackagesOrRemotes(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = agentregistryv1alpha1.AddToScheme(scheme)
@@ -478,3 +688,1127 @@ func TestRegistryDeploymentReconciler_ConvertCatalogToAgent_WithPackages(t *test
 	// It gets translated to k8s resources through the runtime layer
 	assert.NotEmpty(t, agent.Name)
 }
+
+func TestRegistryDeploymentReconciler_Reconcile_PendingRequeues(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "pending-server",
+			Namespace:  "default",
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "pending-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "target-ns",
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newRemoteServerCatalog("pending-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		// The fake client clears TypeMeta on typed objects after a patch; keep
+		// it so managed resources are recorded with their kind.
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				gvk := obj.GetObjectKind().GroupVersionKind()
+				err := c.Patch(ctx, obj, patch, opts...)
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+				return err
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "pending-server", Namespace: "default"},
+	}
+
+	// The applied RemoteMCPServer has no Ready condition yet, so the
+	// deployment stays Pending and is requeued to re-check readiness.
+	result, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, pendingRequeueInterval, result.RequeueAfter)
+
+	var updated agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &updated))
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, updated.Status.Phase)
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_TransientErrorRequeues(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "flaky-server",
+			Namespace:  "default",
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "flaky-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "target-ns",
+		},
+	}
+
+	// Applies fail as if the target cluster were unreachable until reachable
+	// is set
+	reachable := false
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newRemoteServerCatalog("flaky-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if !reachable {
+					return &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+				}
+				gvk := obj.GetObjectKind().GroupVersionKind()
+				err := c.Patch(ctx, obj, patch, opts...)
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+				return err
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+	}
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "flaky-server", Namespace: "default"},
+	}
+	ctx := context.Background()
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		result, err := r.Reconcile(ctx, req)
+		require.NoError(t, err, "transient errors are retried via RequeueAfter, not returned")
+		assert.GreaterOrEqual(t, result.RequeueAfter, transientRetryBaseBackoff/2)
+		assert.LessOrEqual(t, result.RequeueAfter, transientRetryBaseBackoff<<(attempt-1))
+
+		var updated agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, req.NamespacedName, &updated))
+		assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePending, updated.Status.Phase)
+		assert.Contains(t, updated.Status.Message, fmt.Sprintf("attempt %d", attempt))
+		assert.Contains(t, updated.Status.Message, "connection refused")
+	}
+
+	// Once the cluster is reachable the retry count is reset
+	reachable = true
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	_, tracked := r.transientRetries.Load(req.NamespacedName)
+	assert.False(t, tracked)
+
+	// Permanent errors still fail the deployment, e.g. a version that is not
+	// in the catalog
+	var current agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, req.NamespacedName, &current))
+	current.Spec.Version = "9.9.9"
+	require.NoError(t, c.Update(ctx, &current))
+	_, err = r.Reconcile(ctx, req)
+	require.Error(t, err)
+	var failed agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, req.NamespacedName, &failed))
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, failed.Status.Phase)
+}
+
+const testImageDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newOCIServerCatalog(name, version, digest string) *agentregistryv1alpha1.MCPServerCatalog {
+	catalog := newRemoteServerCatalog(name, version)
+	catalog.Spec.Remotes = nil
+	catalog.Spec.Packages = []agentregistryv1alpha1.Package{
+		{
+			RegistryType: "oci",
+			Identifier:   "ghcr.io/org/" + name + ":" + version,
+			Digest:       digest,
+			Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+		},
+	}
+	return catalog
+}
+
+func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_OCIDigest(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Namespace: "default"},
+	}
+
+	// Without a digest the tag reference is used as-is
+	server, err := r.convertCatalogToMCPServer(newOCIServerCatalog("oci-server", "1.0.0", ""), deployment)
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/oci-server:1.0.0", server.Local.Deployment.Image)
+
+	// With a digest the image is pinned
+	server, err = r.convertCatalogToMCPServer(newOCIServerCatalog("oci-server", "1.0.0", testImageDigest), deployment)
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/oci-server:1.0.0@"+testImageDigest, server.Local.Deployment.Image)
+
+	// An invalid digest is rejected rather than silently ignored
+	_, err = r.convertCatalogToMCPServer(newOCIServerCatalog("oci-server", "1.0.0", "sha256:abc"), deployment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid digest")
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_RecordsPinnedDigest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "pinned-server",
+			Namespace:  "default",
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "pinned-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "target-ns",
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newOCIServerCatalog("pinned-server", "1.0.0", testImageDigest)).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "pinned-server", Namespace: "default"},
+	}
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	var updated agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &updated))
+	require.Len(t, updated.Status.ManagedResources, 1)
+	assert.Equal(t, testImageDigest, updated.Status.ManagedResources[0].Digest)
+
+	var applied kmcpv1alpha1.MCPServer
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{
+		Name:      updated.Status.ManagedResources[0].Name,
+		Namespace: "target-ns",
+	}, &applied))
+	assert.Equal(t, "ghcr.io/org/pinned-server:1.0.0@"+testImageDigest, applied.Spec.Deployment.Image)
+}
+
+func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_CommandOverride(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	catalog := &agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "npm-server",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{
+				{RegistryType: "npm", Identifier: "@test/package", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+			},
+			Remotes: []agentregistryv1alpha1.Transport{
+				{Type: "streamable-http", URL: "https://example.com/mcp"},
+			},
+		},
+	}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			Namespace:       "default",
+			CommandOverride: "/debug/shim",
+			ArgsOverride:    []string{"--", "npx", "@test/package"},
+		},
+	}
+
+	server, err := r.convertCatalogToMCPServer(catalog, deployment)
+	require.NoError(t, err)
+	require.NotNil(t, server.Local)
+	assert.Equal(t, "/debug/shim", server.Local.Deployment.Cmd)
+	assert.Equal(t, []string{"--", "npx", "@test/package"}, server.Local.Deployment.Args)
+
+	// Only args overridden keeps the derived command
+	deployment.Spec.CommandOverride = ""
+	deployment.Spec.ArgsOverride = []string{"--verbose"}
+	server, err = r.convertCatalogToMCPServer(catalog, deployment)
+	require.NoError(t, err)
+	_, derivedCmd := getImageAndCommand("npm", "")
+	assert.Equal(t, derivedCmd, server.Local.Deployment.Cmd)
+	assert.Equal(t, []string{"--verbose"}, server.Local.Deployment.Args)
+
+	// Remote deployments run no container to override
+	deployment.Spec.PreferRemote = true
+	_, err = r.convertCatalogToMCPServer(catalog, deployment)
+	assert.ErrorIs(t, err, errCommandOverrideUnsupported)
+
+	_, err = r.convertCatalogToAgent(&agentregistryv1alpha1.AgentCatalog{}, deployment)
+	assert.ErrorIs(t, err, errCommandOverrideUnsupported)
+}
+
+func TestRegistryDeploymentReconciler_ImagePullSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	newDeployment := func(name, resourceName string, pullSecrets []string) *agentregistryv1alpha1.RegistryDeployment {
+		return &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry", Finalizers: []string{finalizerName}},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName:     resourceName,
+				Version:          "1.0.0",
+				ResourceType:     agentregistryv1alpha1.ResourceTypeAgent,
+				Runtime:          agentregistryv1alpha1.RuntimeTypeKubernetes,
+				Environment:      "prod",
+				ImagePullSecrets: pullSecrets,
+			},
+		}
+	}
+	newAgentCatalog := func(name string) *agentregistryv1alpha1.AgentCatalog {
+		return &agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-1-0-0", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.AgentCatalogSpec{
+				Name:     name,
+				Version:  "1.0.0",
+				Image:    "registry.example.com/private/" + name + ":1.0.0",
+				Metadata: &apiextensionsv1.JSON{Raw: []byte(verifiedPublisherMetadata)},
+			},
+		}
+	}
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{{
+				Name:          "prod",
+				Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "prod-gke", Namespace: "ai-prod"},
+				Registry:      agentregistryv1alpha1.RegistryConfig{URL: "registry.example.com", ImagePullSecrets: []string{"prod-registry"}},
+				DeployEnabled: true,
+			}},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, IndexAgentNameVersion, AgentNameVersionIndex).
+		WithObjects(
+			newDeployment("defaulted", "planner", nil),
+			newDeployment("explicit", "reviewer", []string{"team-registry"}),
+			newAgentCatalog("planner"),
+			newAgentCatalog("reviewer"),
+			discovery,
+		).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.AgentCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+		RemoteClientFactory: func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+			return c, nil
+		},
+	}
+	ctx := context.Background()
+	pullSecrets := func(deploymentName, agentName string) []corev1.LocalObjectReference {
+		t.Helper()
+		key := types.NamespacedName{Name: deploymentName, Namespace: "agentregistry"}
+		_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		var agent kagentv1alpha2.Agent
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: kagent.AgentResourceName(agentName, "1.0.0"), Namespace: "ai-prod"}, &agent))
+		require.NotNil(t, agent.Spec.BYO)
+		return agent.Spec.BYO.Deployment.ImagePullSecrets
+	}
+
+	// Without its own secrets a deployment uses the environment's registry
+	// secrets, without rewriting the stored spec
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "prod-registry"}}, pullSecrets("defaulted", "planner"))
+	var stored agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "defaulted", Namespace: "agentregistry"}, &stored))
+	assert.Empty(t, stored.Spec.ImagePullSecrets)
+
+	// Secrets set on the deployment replace the environment's
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "team-registry"}}, pullSecrets("explicit", "reviewer"))
+}
+
+func TestRegistryDeploymentReconciler_MCPServerImagePullSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	serverName := kagent.MCPServerResourceName(generateInternalName("private-server"))
+	newPod := func(name, instance string, waitingReason string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ai-prod", Labels: map[string]string{
+				"app.kubernetes.io/instance":   instance,
+				"app.kubernetes.io/managed-by": "kmcp",
+			}},
+		}
+		status := corev1.ContainerStatus{Name: "mcp"}
+		if waitingReason != "" {
+			status.State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+		} else {
+			status.State.Running = &corev1.ContainerStateRunning{}
+		}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+		return pod
+	}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-server", Namespace: "agentregistry", Finalizers: []string{finalizerName}},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "private-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Environment:  "prod",
+		},
+	}
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{{
+				Name:          "prod",
+				Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "prod-gke", Namespace: "ai-prod"},
+				Registry:      agentregistryv1alpha1.RegistryConfig{URL: "registry.example.com", ImagePullSecrets: []string{"prod-registry"}},
+				DeployEnabled: true,
+			}},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(
+			deployment,
+			newOCIServerCatalog("private-server", "1.0.0", ""),
+			discovery,
+			// Admitted before the pull secrets were set on the ServiceAccount
+			newPod("stuck", serverName, "ImagePullBackOff"),
+			newPod("running", serverName, ""),
+			newPod("other-server", "other-server", "ErrImagePull"),
+		).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+		RemoteClientFactory: func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+			return c, nil
+		},
+	}
+	ctx := context.Background()
+	_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "private-server", Namespace: "agentregistry"}})
+
+	// kmcp runs the server's pods under a ServiceAccount named after it,
+	// which carries the environment's registry secrets
+	var sa corev1.ServiceAccount
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: serverName, Namespace: "ai-prod"}, &sa))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "prod-registry"}}, sa.ImagePullSecrets)
+	var stored agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "private-server", Namespace: "agentregistry"}, &stored))
+	assert.Empty(t, stored.Spec.ImagePullSecrets)
+	_, tracked := findManagedResource(stored.Status.ManagedResources, agentregistryv1alpha1.ManagedResource{
+		Kind: "ServiceAccount", Name: serverName, Namespace: "ai-prod", Cluster: "prod-gke",
+	})
+	assert.True(t, tracked)
+
+	// Only the server's pod stuck pulling its image is restarted
+	var pod corev1.Pod
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, types.NamespacedName{Name: "stuck", Namespace: "ai-prod"}, &pod)))
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "running", Namespace: "ai-prod"}, &pod))
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "other-server", Namespace: "ai-prod"}, &pod))
+}
+
+func TestRegistryDeploymentReconciler_ImagePullSecretsUnsupported(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{ImagePullSecrets: []string{"team-registry"}},
+	}
+
+	server, err := r.convertCatalogToMCPServer(newOCIServerCatalog("oci-server", "1.0.0", ""), deployment)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-registry"}, server.Local.Deployment.ImagePullSecrets)
+
+	// Remote servers and Helm charts run no image of their own
+	_, err = r.convertCatalogToMCPServer(newRemoteServerCatalog("remote-server", "1.0.0"), deployment)
+	assert.ErrorIs(t, err, errImagePullSecretsUnsupported)
+	_, err = r.convertCatalogToHelmChart(newHelmServerCatalog("helm-server", "1.0.0"), deployment)
+	assert.ErrorIs(t, err, errImagePullSecretsUnsupported)
+}
+
+func TestRegistryDeploymentReconciler_ConvertCatalogToMCPServer_PackageIndex(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	catalog := &agentregistryv1alpha1.MCPServerCatalog{
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "everything",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{
+				{RegistryType: "oci", Identifier: "ghcr.io/org/everything:1.0.0", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+				{RegistryType: "npm", Identifier: "@org/everything", Version: "1.0.0", RuntimeHint: "npx", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+			},
+		},
+	}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{Namespace: "default"},
+	}
+
+	// The first package is the default
+	server, err := r.convertCatalogToMCPServer(catalog, deployment)
+	require.NoError(t, err)
+	require.NotNil(t, server.Local)
+	assert.Equal(t, "ghcr.io/org/everything:1.0.0", server.Local.Deployment.Image)
+
+	deployment.Spec.PackageIndex = 1
+	server, err = r.convertCatalogToMCPServer(catalog, deployment)
+	require.NoError(t, err)
+	require.NotNil(t, server.Local)
+	assert.Equal(t, "node:20-alpine", server.Local.Deployment.Image)
+	assert.Equal(t, "npx", server.Local.Deployment.Cmd)
+	assert.Contains(t, server.Local.Deployment.Args, "@org/everything")
+
+	deployment.Spec.PackageIndex = 2
+	_, err = r.convertCatalogToMCPServer(catalog, deployment)
+	assert.ErrorIs(t, err, ErrPackageIndexOutOfRange)
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_EffectiveCommand(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "shimmed-server",
+			Namespace:  "default",
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName:    "shimmed-server",
+			Version:         "1.0.0",
+			ResourceType:    agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:         agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:       "target-ns",
+			CommandOverride: "/debug/shim",
+			ArgsOverride:    []string{"/app/server"},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newOCIServerCatalog("shimmed-server", "1.0.0", testImageDigest)).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "shimmed-server", Namespace: "default"},
+	}
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	var updated agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &updated))
+	assert.Equal(t, []string{"/debug/shim", "/app/server"}, updated.Status.EffectiveCommand)
+	require.Len(t, updated.Status.ManagedResources, 1)
+
+	var applied kmcpv1alpha1.MCPServer
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{
+		Name:      updated.Status.ManagedResources[0].Name,
+		Namespace: "target-ns",
+	}, &applied))
+	assert.Equal(t, "/debug/shim", applied.Spec.Deployment.Cmd)
+	assert.Equal(t, []string{"/app/server"}, applied.Spec.Deployment.Args)
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_CountsDeployments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	newDeployment := func(name, namespace string) *agentregistryv1alpha1.RegistryDeployment {
+		return &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Finalizers: []string{finalizerName}},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: "popular-server",
+				Version:      "1.0.0",
+				ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+				Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+				Namespace:    namespace,
+			},
+		}
+	}
+	catalog := newOCIServerCatalog("popular-server", "1.0.0", testImageDigest)
+	// Selects a package the entry does not have, so it never goes live
+	broken := newDeployment("popular-broken", "dev")
+	broken.Spec.PackageIndex = 5
+
+	var conflicted bool
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(newDeployment("popular-dev", "dev"), newDeployment("popular-prod", "prod"), broken, catalog).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// The first status write of popular-dev conflicts
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if obj.GetName() == "popular-dev" && !conflicted {
+					conflicted = true
+					return apierrors.NewConflict(agentregistryv1alpha1.GroupVersion.WithResource("registrydeployments").GroupResource(), obj.GetName(), errors.New("modified"))
+				}
+				return c.SubResource(subResource).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	// Each deployment is counted once, however often it is reconciled, even
+	// when its first status write is retried
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "popular-dev", Namespace: "default"}})
+	require.True(t, apierrors.IsConflict(err), err)
+	for _, name := range []string{"popular-dev", "popular-prod", "popular-dev"} {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		require.NoError(t, err)
+	}
+	// Failed deployments are not counted
+	_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "popular-broken", Namespace: "default"}})
+	var failed agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(broken), &failed))
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, failed.Status.Phase)
+	assert.False(t, failed.Status.Counted)
+
+	var updated agentregistryv1alpha1.MCPServerCatalog
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(catalog), &updated))
+	assert.Equal(t, int64(2), updated.Status.DeploymentCount)
+	assert.Equal(t, agentregistryv1alpha1.ManagementTypeManaged, updated.Status.ManagementType)
+}
+
+func TestRegistryDeploymentReconciler_ApplyManagedObjects_PartialSuccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if obj.GetName() == "cm-b" {
+					return errors.New("admission webhook denied the request")
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "partial", Namespace: "default"},
+	}
+
+	var objs []managedObject
+	for _, name := range []string{"cm-a", "cm-b", "cm-c"} {
+		objs = append(objs, managedObject{
+			obj: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "target-ns"},
+			},
+			ref: agentregistryv1alpha1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Name: name, Namespace: "target-ns"},
+		})
+	}
+
+	err := r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{client: c}}, objs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "applied 2 of 3 resources")
+	assert.Contains(t, err.Error(), "ConfigMap cm-b")
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhasePartiallyDeployed, failedPhase(err))
+
+	// The resources after the failing one are still attempted
+	for _, name := range []string{"cm-a", "cm-c"} {
+		var cm corev1.ConfigMap
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "target-ns"}, &cm))
+	}
+
+	var tracked []string
+	for _, res := range deployment.Status.ManagedResources {
+		tracked = append(tracked, res.Name)
+	}
+	assert.Equal(t, []string{"cm-a", "cm-c"}, tracked)
+
+	// A resource applied by an earlier reconcile stays tracked when a later
+	// apply of it fails, so deletion still cleans it up.
+	deployment.Status.ManagedResources = append(deployment.Status.ManagedResources, objs[1].ref)
+	require.Error(t, r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{client: c}}, objs))
+	assert.Len(t, deployment.Status.ManagedResources, 3)
+
+	// Nothing applied at all is a plain failure
+	err = r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{client: c}}, objs[1:2])
+	require.Error(t, err)
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, failedPhase(err))
+}
+
+func TestRegistryDeploymentReconciler_ApplyManagedObjects_TooManyResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop(), MaxManagedResources: 3}
+	previous := []agentregistryv1alpha1.ManagedResource{{APIVersion: "v1", Kind: "ConfigMap", Name: "cm-old", Namespace: "target-ns"}}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exploding", Namespace: "default"},
+		Spec:       agentregistryv1alpha1.RegistryDeploymentSpec{ResourceName: "org/exploding", Version: "1.0.0"},
+		Status:     agentregistryv1alpha1.RegistryDeploymentStatus{ManagedResources: previous},
+	}
+
+	// A translator gone wrong renders far more resources than allowed
+	var objs []managedObject
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("cm-%d", i)
+		objs = append(objs, managedObject{
+			obj: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "target-ns"},
+			},
+			ref: agentregistryv1alpha1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Name: name, Namespace: "target-ns"},
+		})
+	}
+
+	err := r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{client: c}}, objs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "renders 4 resources, more than the limit of 3")
+	assert.Contains(t, err.Error(), "org/exploding 1.0.0")
+	assert.False(t, isTransientError(err))
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, failedPhase(err))
+
+	// Nothing is applied and the previously managed resources stay tracked
+	var cms corev1.ConfigMapList
+	require.NoError(t, c.List(context.Background(), &cms))
+	assert.Empty(t, cms.Items)
+	assert.Equal(t, previous, deployment.Status.ManagedResources)
+
+	// Up to the limit applies normally
+	require.NoError(t, r.applyManagedObjects(context.Background(), deployment, deploymentTargets{{client: c}}, objs[:3]))
+	assert.Len(t, deployment.Status.ManagedResources, 3)
+
+	// The default is generous
+	assert.Equal(t, DefaultMaxManagedResources, (&RegistryDeploymentReconciler{}).maxManagedResources())
+}
+
+func TestRegistryDeploymentReconciler_ApplyManagedObjects_PrunesStale(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	configMap := func(name string) managedObject {
+		return managedObject{
+			obj: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "target-ns"},
+			},
+			ref: agentregistryv1alpha1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Name: name, Namespace: "target-ns"},
+		}
+	}
+	otherCluster := agentregistryv1alpha1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Name: "cm-remote", Namespace: "target-ns", Cluster: "prod"}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &RegistryDeploymentReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+	}
+	ctx := context.Background()
+
+	require.NoError(t, r.applyManagedObjects(ctx, deployment, deploymentTargets{{client: c}}, []managedObject{configMap("cm-a"), configMap("cm-b")}))
+	deployment.Status.ManagedResources = append(deployment.Status.ManagedResources, otherCluster)
+
+	// cm-b is no longer rendered, so it is deleted and untracked
+	require.NoError(t, r.applyManagedObjects(ctx, deployment, deploymentTargets{{client: c}}, []managedObject{configMap("cm-a")}))
+
+	var cm corev1.ConfigMap
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "cm-a", Namespace: "target-ns"}, &cm))
+	err := c.Get(ctx, types.NamespacedName{Name: "cm-b", Namespace: "target-ns"}, &cm)
+	assert.True(t, apierrors.IsNotFound(err), "expected stale ConfigMap to be deleted, got %v", err)
+
+	// Resources on another cluster cannot be reached through this client and stay tracked
+	assert.Equal(t, []agentregistryv1alpha1.ManagedResource{configMap("cm-a").ref, otherCluster}, deployment.Status.ManagedResources)
+}
+
+func TestRegistryDeploymentReconciler_CheckPublisherIdentity(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "unverified", Namespace: "default"},
+	}
+	unverified := &apiextensionsv1.JSON{Raw: []byte(`{"io.modelcontextprotocol.registry/publisher-provided":{"aregistry.ai/metadata":{"identity":{"org_is_verified":false,"publisher_identity_verified_by_jwt":true}}}}`)}
+
+	t.Run("strict by default", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER", "")
+		err := r.checkPublisherIdentity(deployment, unverified)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "organization is not verified")
+
+		require.Error(t, r.checkPublisherIdentity(deployment, nil))
+	})
+
+	t.Run("relaxed", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_REQUIRE_VERIFIED_PUBLISHER", "false")
+		require.NoError(t, r.checkPublisherIdentity(deployment, unverified))
+		require.NoError(t, r.checkPublisherIdentity(deployment, nil))
+	})
+}
+
+func TestCheckRegistryTypeAllowed(t *testing.T) {
+	npmServer := newRemoteServerCatalog("npm-server", "1.0.0")
+	npmServer.Spec.Packages = []agentregistryv1alpha1.Package{{RegistryType: "npm", Identifier: "@org/npm-server"}}
+	ociServer := newOCIServerCatalog("oci-server", "1.0.0", "")
+	deployment := &agentregistryv1alpha1.RegistryDeployment{}
+	remoteDeployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{PreferRemote: true},
+	}
+
+	t.Run("all types allowed by default", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", "")
+		assert.NoError(t, CheckRegistryTypeAllowed(npmServer, deployment))
+		assert.NoError(t, CheckRegistryTypeAllowed(ociServer, deployment))
+	})
+
+	t.Run("restricted to oci", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", "OCI")
+		assert.NoError(t, CheckRegistryTypeAllowed(ociServer, deployment))
+
+		err := CheckRegistryTypeAllowed(npmServer, deployment)
+		require.ErrorIs(t, err, ErrRegistryTypeNotAllowed)
+		assert.Contains(t, err.Error(), "npm packages cannot be deployed")
+
+		// Deploying through the remote transport pulls no package
+		assert.NoError(t, CheckRegistryTypeAllowed(npmServer, remoteDeployment))
+
+		// Enforced at reconcile time when translating the catalog entry
+		r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+		_, err = r.convertCatalogToMCPServer(npmServer, deployment)
+		require.ErrorIs(t, err, ErrRegistryTypeNotAllowed)
+	})
+}
+
+func newHelmServerCatalog(name, version string) *agentregistryv1alpha1.MCPServerCatalog {
+	catalog := newOCIServerCatalog(name, version, "")
+	catalog.Spec.Packages = append(catalog.Spec.Packages, agentregistryv1alpha1.Package{
+		RegistryType: "helm",
+		Identifier:   "oci://ghcr.io/org/charts/" + name,
+		Version:      "0.4.0",
+	})
+	return catalog
+}
+
+func TestRegistryDeploymentReconciler_TranslateMCPServer_DispatchesByRuntime(t *testing.T) {
+	r := &RegistryDeploymentReconciler{Logger: zerolog.Nop()}
+	catalog := newHelmServerCatalog("charted-server", "1.0.0")
+
+	// The kubernetes runtime runs the OCI package through the kagent translator
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			Runtime:   agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace: "target-ns",
+		},
+	}
+	runtimeConfig, err := r.translateMCPServer(context.Background(), catalog, deployment)
+	require.NoError(t, err)
+	assert.Len(t, runtimeConfig.Kubernetes.MCPServers, 1)
+	assert.Empty(t, runtimeConfig.Kubernetes.HelmReleases)
+
+	// The helm runtime installs the chart package through the helm translator
+	deployment.Spec.Runtime = agentregistryv1alpha1.RuntimeTypeHelm
+	deployment.Spec.Config = map[string]string{"image.tag": "v2", "replicaCount": "3"}
+	runtimeConfig, err = r.translateMCPServer(context.Background(), catalog, deployment)
+	require.NoError(t, err)
+	assert.Empty(t, runtimeConfig.Kubernetes.MCPServers)
+	require.Len(t, runtimeConfig.Kubernetes.HelmRepositories, 1)
+	require.Len(t, runtimeConfig.Kubernetes.HelmReleases, 1)
+
+	release := runtimeConfig.Kubernetes.HelmReleases[0]
+	assert.Equal(t, "HelmRelease", release.GetKind())
+	assert.Equal(t, "target-ns", release.GetNamespace())
+	chart, _, _ := unstructured.NestedString(release.Object, "spec", "chart", "spec", "chart")
+	assert.Equal(t, "charted-server", chart)
+	version, _, _ := unstructured.NestedString(release.Object, "spec", "chart", "spec", "version")
+	assert.Equal(t, "0.4.0", version)
+	tag, _, _ := unstructured.NestedString(release.Object, "spec", "values", "image", "tag")
+	assert.Equal(t, "v2", tag)
+	replicas, _, _ := unstructured.NestedInt64(release.Object, "spec", "values", "replicaCount")
+	assert.Equal(t, int64(3), replicas)
+	url, _, _ := unstructured.NestedString(runtimeConfig.Kubernetes.HelmRepositories[0].Object, "spec", "url")
+	assert.Equal(t, "oci://ghcr.io/org/charts", url)
+
+	// Entries without a chart cannot use the helm runtime
+	_, err = r.translateMCPServer(context.Background(), newOCIServerCatalog("plain-server", "1.0.0", ""), deployment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no helm package")
+}
+
+func TestHelmValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  any
+	}{
+		{"true", true},
+		{"false", false},
+		{"3", int64(3)},
+		{"-2", int64(-2)},
+		{"0", int64(0)},
+		{"0.5", 0.5},
+		{"1e3", 1000.0},
+		{"v2", "v2"},
+		{"0123", "0123"},
+		{"0x1F", "0x1F"},
+		{"1_000", "1_000"},
+		{"Inf", "Inf"},
+		{"NaN", "NaN"},
+		{"1e999", "1e999"},
+		{"True", "True"},
+		{`"1.10"`, "1.10"},
+		{`"true"`, "true"},
+		{"null", nil},
+		{`{"cpu": "100m", "replicas": 2}`, map[string]any{"cpu": "100m", "replicas": 2.0}},
+		{`["a", "b"]`, []any{"a", "b"}},
+		{"{not json", "{not json"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, helmValue(tt.value), "helmValue(%q)", tt.value)
+	}
+
+	values := map[string]any{}
+	setHelmValue(values, "autoscaling.enabled", "false")
+	setHelmValue(values, "autoscaling.maxReplicas", "5")
+	assert.Equal(t, map[string]any{"autoscaling": map[string]any{"enabled": false, "maxReplicas": int64(5)}}, values)
+}
+
+func TestRegistryDeploymentReconciler_Reconcile_HelmRuntime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "charted-server",
+			Namespace:  "default",
+			Finalizers: []string{finalizerName},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "charted-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeHelm,
+			Namespace:    "target-ns",
+		},
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(deployment, newHelmServerCatalog("charted-server", "1.0.0")).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		WithRESTMapper(mapper).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client: c,
+		Scheme: scheme,
+		Logger: zerolog.Nop(),
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "charted-server", Namespace: "default"},
+	}
+
+	// Without the Flux CRDs nothing is applied and the deployment says why
+	_, err := r.Reconcile(context.Background(), req)
+	require.ErrorIs(t, err, errFluxNotInstalled)
+	var updated agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &updated))
+	assert.Equal(t, agentregistryv1alpha1.DeploymentPhaseFailed, updated.Status.Phase)
+	assert.Empty(t, updated.Status.ManagedResources)
+	require.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, agentregistryv1alpha1.CatalogConditionFluxUnavailable, updated.Status.Conditions[0].Type)
+	assert.Contains(t, updated.Status.Conditions[0].Message, "the local cluster does not serve source.toolkit.fluxcd.io/v1 HelmRepository and helm.toolkit.fluxcd.io/v2 HelmRelease")
+
+	mapper.Add(helm.HelmRepositoryGVK, meta.RESTScopeNamespace)
+	mapper.Add(helm.HelmReleaseGVK, meta.RESTScopeNamespace)
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &updated))
+	assert.Empty(t, updated.Status.Conditions)
+	var kinds []string
+	for _, res := range updated.Status.ManagedResources {
+		kinds = append(kinds, res.Kind)
+	}
+	assert.Equal(t, []string{"HelmRepository", "HelmRelease"}, kinds)
+
+	release := &unstructured.Unstructured{}
+	release.SetGroupVersionKind(helm.HelmReleaseGVK)
+	releaseKey := types.NamespacedName{Name: updated.Status.ManagedResources[1].Name, Namespace: "target-ns"}
+	require.NoError(t, c.Get(context.Background(), releaseKey, release))
+
+	// Managed helm resources are removed when the deployment is deleted
+	require.NoError(t, c.Delete(context.Background(), &updated))
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	err = c.Get(context.Background(), releaseKey, release)
+	assert.True(t, apierrors.IsNotFound(err), "expected HelmRelease to be deleted, got %v", err)
+}
+
+func TestTriggerReconcile_EnqueuesReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "agentregistry", Generation: 1},
+		Status:     agentregistryv1alpha1.RegistryDeploymentStatus{Phase: agentregistryv1alpha1.DeploymentPhasePending},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}).
+		Build()
+	ctx := context.Background()
+
+	var before agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(deployment), &before))
+	triggered := before.DeepCopy()
+	require.NoError(t, TriggerReconcile(ctx, c, triggered))
+
+	var after agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(deployment), &after))
+	assert.NotEmpty(t, after.Annotations[ReconcileTriggerAnnotation])
+	assert.Equal(t, before.Generation, after.Generation, "the trigger is an annotation-only change")
+	assert.True(t, deploymentChangePredicate.Update(event.UpdateEvent{ObjectOld: &before, ObjectNew: &after}),
+		"annotation-only changes must enqueue a reconcile")
+
+	statusOnly := after.DeepCopy()
+	statusOnly.Status.Phase = agentregistryv1alpha1.DeploymentPhaseRunning
+	assert.False(t, deploymentChangePredicate.Update(event.UpdateEvent{ObjectOld: &after, ObjectNew: statusOnly}),
+		"status-only updates do not enqueue a reconcile")
+
+	specChange := after.DeepCopy()
+	specChange.Generation++
+	assert.True(t, deploymentChangePredicate.Update(event.UpdateEvent{ObjectOld: &after, ObjectNew: specChange}))
+}
+
+// TestRegistryDeploymentReconciler_Reconcile_Concurrent reconciles many
+// deployments at once, as MaxConcurrentReconciles allows; run with -race to
+// check the reconciler's shared state.
+func TestRegistryDeploymentReconciler_Reconcile_Concurrent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = agentregistryv1alpha1.AddToScheme(scheme)
+	_ = kagentv1alpha2.AddToScheme(scheme)
+	_ = kmcpv1alpha1.AddToScheme(scheme)
+
+	const deployments = 20
+	hook, received := newWebhookReceiver(t)
+	objects := []client.Object{newOCIServerCatalog("popular-server", "1.0.0", testImageDigest)}
+	for i := range deployments {
+		objects = append(objects, &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("popular-%d", i), Namespace: "default", Finalizers: []string{finalizerName}},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: "popular-server",
+				Version:      "1.0.0",
+				ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+				Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+				Namespace:    fmt.Sprintf("team-%d", i),
+			},
+		})
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerNameVersion, MCPServerNameVersionIndex).
+		WithObjects(objects...).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}, &agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+
+	r := &RegistryDeploymentReconciler{
+		Client:                  c,
+		Scheme:                  scheme,
+		Logger:                  zerolog.Nop(),
+		NotifyWebhookURL:        hook.URL,
+		MaxConcurrentReconciles: deployments,
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make([]error, deployments)
+	for i := range deployments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("popular-%d", i), Namespace: "default"}})
+		}()
+	}
+	wg.Wait()
+
+	for i := range deployments {
+		require.NoError(t, errs[i], "popular-%d", i)
+		var deployment agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("popular-%d", i), Namespace: "default"}, &deployment))
+		assert.NotEmpty(t, deployment.Status.Phase, "popular-%d", i)
+	}
+	notified := map[string]bool{}
+	for range deployments {
+		notified[receiveNotification(t, received).Deployment] = true
+	}
+	assert.Len(t, notified, deployments)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// isTransientError reports whether err is likely to clear up without any
+// change to the resources involved: API conflicts, timeouts, throttling and
+// server errors, and network failures reaching a cluster. An error joining
+// several errors is transient only if all of them are.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		errs := joined.Unwrap()
+		for _, e := range errs {
+			if !isTransientError(e) {
+				return false
+			}
+		}
+		return len(errs) > 0
+	}
+	if apierrors.IsConflict(err) || apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// shouldRetry determines if an informer handler error should be retried.
+// Unlike isTransientError it retries unknown errors, giving up only on those
+// a second attempt cannot fix.
+func shouldRetry(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isTransientError(err) {
+		return true
+	}
+	// Don't retry on not found, already exists, or invalid
+	if apierrors.IsNotFound(err) || apierrors.IsAlreadyExists(err) || apierrors.IsInvalid(err) {
+		return false
+	}
+	// Default: retry on unknown errors
+	return true
+}
+
+// retryBackoffFor returns the delay before retry attempt (counting from 1):
+// base doubled for every earlier attempt and capped at max. The upper half of
+// the delay is randomized so objects failing together do not retry in
+// lockstep.
+func retryBackoffFor(base, max time.Duration, attempt int) time.Duration {
+	backoff := base
+	for i := 1; i < attempt && backoff < max; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, max)
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+	return half + rand.N(backoff-half+1)
+}
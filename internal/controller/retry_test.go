@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransientError(t *testing.T) {
+	gr := schema.GroupResource{Group: "kagent.dev", Resource: "agents"}
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"conflict", apierrors.NewConflict(gr, "a", errors.New("modified")), true},
+		{"server timeout", apierrors.NewServerTimeout(gr, "get", 1), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("down"), true},
+		{"connection refused", fmt.Errorf("failed to apply: %w", refused), true},
+		{"not found", apierrors.NewNotFound(gr, "a"), false},
+		{"catalog not found", errors.New("MCP server org/search version 1.0.0 not found"), false},
+		{"all joined transient", &partialApplyError{errs: []error{refused, apierrors.NewServiceUnavailable("down")}}, true},
+		{"some joined permanent", fmt.Errorf("wrapped: %w", &partialApplyError{errs: []error{refused, apierrors.NewBadRequest("bad")}}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransientError(tt.err))
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	gr := schema.GroupResource{Resource: "configmaps"}
+	assert.False(t, shouldRetry(nil))
+	assert.True(t, shouldRetry(apierrors.NewConflict(gr, "a", errors.New("modified"))))
+	assert.True(t, shouldRetry(errors.New("unknown")))
+	assert.False(t, shouldRetry(apierrors.NewAlreadyExists(gr, "a")))
+}
+
+func TestRetryBackoffFor(t *testing.T) {
+	base, max := time.Second, 10*time.Second
+	for attempt, upper := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: max, 50: max} {
+		for range 20 {
+			backoff := retryBackoffFor(base, max, attempt)
+			assert.GreaterOrEqual(t, backoff, upper/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, backoff, upper, "attempt %d", attempt)
+		}
+	}
+}
@@ -4,19 +4,27 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
 )
 
 // SkillCatalogReconciler reconciles a SkillCatalog object
 type SkillCatalogReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Logger zerolog.Logger
+	Scheme        *runtime.Scheme
+	Logger        zerolog.Logger
+	StartupJitter *StartupJitter
 }
 
 // +kubebuilder:rbac:groups=agentregistry.dev,resources=skillcatalogs,verbs=get;list;watch;create;update;patch;delete
@@ -27,6 +35,12 @@ type SkillCatalogReconciler struct {
 func (r *SkillCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Logger.With().Str("name", req.Name).Str("namespace", req.Namespace).Logger()
 
+	// Stagger the first reconcile of each object after controller startup
+	if delay := r.StartupJitter.Delay(req.NamespacedName); delay > 0 {
+		logger.Debug().Dur("delay", delay).Msg("delaying initial reconcile")
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
 	// Fetch the SkillCatalog
 	var skill agentregistryv1alpha1.SkillCatalog
 	if err := r.Get(ctx, req.NamespacedName, &skill); err != nil {
@@ -37,15 +51,44 @@ func (r *SkillCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		Str("specName", skill.Spec.Name).
 		Str("version", skill.Spec.Version).
 		Msg("reconciling SkillCatalog")
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.KeyResourceName.String(skill.Spec.Name),
+		tracing.KeyVersion.String(skill.Spec.Version),
+	)
 
 	// Update isLatest status for all versions of this skill
+	statusChanged := false
+	wasLatest := skill.Status.IsLatest
 	if err := r.updateLatestVersion(ctx, &skill); err != nil {
 		logger.Error().Err(err).Msg("failed to update latest version")
 		return ctrl.Result{}, err
 	}
+	if skill.Status.IsLatest != wasLatest {
+		statusChanged = true
+	}
+
+	// Derive the lifecycle status from published, deprecation and soft-delete state
+	if status := skillCatalogStatus(&skill); status != skill.Status.Status {
+		logger.Info().
+			Str("from", string(skill.Status.Status)).
+			Str("to", string(status)).
+			Msg("catalog entry status changed")
+		skill.Status.Status = status
+		statusChanged = true
+	}
+
+	// Flag an entry duplicating the name and version of an older entry
+	if changed, err := syncDuplicateCondition(ctx, r.Client, &agentregistryv1alpha1.SkillCatalogList{}, &skill, skill.Spec.Name, skill.Spec.Version, &skill.Status.Conditions); err != nil {
+		logger.Warn().Err(err).Msg("failed to check for duplicate entries")
+	} else if changed {
+		if hasCatalogCondition(skill.Status.Conditions, agentregistryv1alpha1.CatalogConditionDuplicate) {
+			logger.Warn().Str("specName", skill.Spec.Name).Str("version", skill.Spec.Version).Msg("duplicate catalog entry")
+		}
+		statusChanged = true
+	}
 
 	// Update observed generation
-	if skill.Status.ObservedGeneration != skill.Generation {
+	if skill.Status.ObservedGeneration != skill.Generation || statusChanged {
 		skill.Status.ObservedGeneration = skill.Generation
 		if err := r.Status().Update(ctx, &skill); err != nil {
 			if apierrors.IsConflict(err) {
@@ -61,14 +104,48 @@ func (r *SkillCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
-// updateLatestVersion determines and updates the latest version flag for all versions of a skill
+// updateLatestVersion determines and updates the latest version flag for all
+// versions of a skill. The flag of skill is set in memory, to be written with
+// the rest of its status.
 func (r *SkillCatalogReconciler) updateLatestVersion(ctx context.Context, skill *agentregistryv1alpha1.SkillCatalog) error {
-	return updateLatestVersionForSkills(ctx, r.Client, skill.Spec.Name)
+	return updateLatestVersionForSkills(ctx, r.Client, skill)
+}
+
+// enqueueOtherVersions requeues the remaining versions of a deleted skill, so
+// that the latest of them takes over IsLatest
+func (r *SkillCatalogReconciler) enqueueOtherVersions(ctx context.Context, obj client.Object) []reconcile.Request {
+	skill, ok := obj.(*agentregistryv1alpha1.SkillCatalog)
+	if !ok {
+		return nil
+	}
+	var list agentregistryv1alpha1.SkillCatalogList
+	if err := r.List(ctx, &list, client.MatchingFields{IndexSkillName: skill.Spec.Name}); err != nil {
+		r.Logger.Warn().Err(err).Str("specName", skill.Spec.Name).Msg("failed to list remaining versions of deleted skill")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Name == skill.Name && item.Namespace == skill.Namespace {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SkillCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentregistryv1alpha1.SkillCatalog{}).
-		Complete(r)
+		// Deleting the latest version promotes another one
+		Watches(
+			&agentregistryv1alpha1.SkillCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueOtherVersions),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		).
+		Complete(countReconcileErrors("skillcatalog", traceReconcile("SkillCatalog", r)))
 }
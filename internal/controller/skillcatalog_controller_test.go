@@ -366,3 +366,61 @@ func TestSkillCatalogReconciler_Reconcile_UpdateGenerationTracking(t *testing.T)
 
 	// Note: Proper generation tracking requires envtest with status subresource
 }
+
+func TestSkillCatalogReconciler_LatestVersionLifecycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := newTestClientWithSkillIndexes(scheme)
+	r := &SkillCatalogReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	create := func(name, version string) types.NamespacedName {
+		t.Helper()
+		skill := &agentregistryv1alpha1.SkillCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: "terraform", Version: version},
+		}
+		require.NoError(t, c.Create(ctx, skill))
+		return client.ObjectKeyFromObject(skill)
+	}
+	reconcileSkill := func(key types.NamespacedName) {
+		t.Helper()
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+		assert.False(t, result.Requeue, "status update should not conflict")
+	}
+	isLatest := func(key types.NamespacedName) bool {
+		t.Helper()
+		var skill agentregistryv1alpha1.SkillCatalog
+		require.NoError(t, c.Get(ctx, key, &skill))
+		return skill.Status.IsLatest
+	}
+
+	v1 := create("terraform-1-0-0", "1.0.0")
+	reconcileSkill(v1)
+	assert.True(t, isLatest(v1))
+
+	// A newer version takes over IsLatest
+	v2 := create("terraform-2-0-0", "2.0.0")
+	reconcileSkill(v2)
+	assert.True(t, isLatest(v2))
+	assert.False(t, isLatest(v1))
+
+	// An older version created later does not
+	v0 := create("terraform-0-9-0", "0.9.0")
+	reconcileSkill(v0)
+	assert.False(t, isLatest(v0))
+	assert.True(t, isLatest(v2))
+
+	// Deleting the latest version promotes the next one
+	var skill agentregistryv1alpha1.SkillCatalog
+	require.NoError(t, c.Get(ctx, v2, &skill))
+	require.NoError(t, c.Delete(ctx, &skill))
+	requests := r.enqueueOtherVersions(ctx, &skill)
+	assert.ElementsMatch(t, []reconcile.Request{{NamespacedName: v1}, {NamespacedName: v0}}, requests)
+	for _, req := range requests {
+		reconcileSkill(req.NamespacedName)
+	}
+	assert.True(t, isLatest(v1))
+	assert.False(t, isLatest(v0))
+}
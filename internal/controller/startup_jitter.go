@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultStartupJitterWindow is the default window over which initial
+// reconciles are spread after the controller starts.
+const DefaultStartupJitterWindow = 5 * time.Second
+
+// StartupJitter staggers the first reconcile of each object after controller
+// startup. When the manager starts, every existing object is enqueued at once;
+// delaying each object's first reconcile by a random amount within Window
+// smooths the resulting burst of apiserver requests. Objects first seen after
+// the window has elapsed are reconciled immediately.
+//
+// A nil *StartupJitter disables jitter.
+type StartupJitter struct {
+	window  time.Duration
+	started time.Time
+	seen    sync.Map
+	expire  sync.Once
+	// randDuration returns a random duration in [0, n). Overridable in tests.
+	randDuration func(n time.Duration) time.Duration
+}
+
+// NewStartupJitter returns a StartupJitter spreading initial reconciles over
+// window, starting now. A zero or negative window disables jitter.
+func NewStartupJitter(window time.Duration) *StartupJitter {
+	return &StartupJitter{
+		window:  window,
+		started: time.Now(),
+		randDuration: func(n time.Duration) time.Duration {
+			return rand.N(n)
+		},
+	}
+}
+
+// Delay returns how long the first reconcile of key should be postponed.
+// It returns zero for every subsequent call for the same key, once the startup
+// window has elapsed, or when jitter is disabled.
+func (j *StartupJitter) Delay(key types.NamespacedName) time.Duration {
+	if j == nil || j.window <= 0 {
+		return 0
+	}
+	// Keys are only recorded within the window, and dropped once it has
+	// passed, so the set does not grow for the life of the process
+	remaining := j.window - time.Since(j.started)
+	if remaining <= 0 {
+		j.expire.Do(j.seen.Clear)
+		return 0
+	}
+	if _, loaded := j.seen.LoadOrStore(key, struct{}{}); loaded {
+		return 0
+	}
+	return j.randDuration(remaining)
+}
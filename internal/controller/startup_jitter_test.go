@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestStartupJitter_SpreadsInitialReconciles(t *testing.T) {
+	window := time.Minute
+	j := NewStartupJitter(window)
+
+	delays := map[time.Duration]bool{}
+	for i := range 50 {
+		d := j.Delay(types.NamespacedName{Namespace: "agentregistry", Name: fmt.Sprintf("item-%d", i)})
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.Less(t, d, window)
+		delays[d] = true
+	}
+	assert.Greater(t, len(delays), 1, "initial reconciles should not all be scheduled at the same time")
+
+	// Only the first reconcile of a key is delayed.
+	assert.Zero(t, j.Delay(types.NamespacedName{Namespace: "agentregistry", Name: "item-0"}))
+}
+
+func TestStartupJitter_Disabled(t *testing.T) {
+	key := types.NamespacedName{Namespace: "agentregistry", Name: "item"}
+
+	var nilJitter *StartupJitter
+	assert.Zero(t, nilJitter.Delay(key))
+	assert.Zero(t, NewStartupJitter(0).Delay(key))
+
+	// Objects first seen after the window has elapsed are not delayed.
+	expired := NewStartupJitter(time.Second)
+	expired.started = time.Now().Add(-time.Minute)
+	assert.Zero(t, expired.Delay(key))
+}
+
+func TestStartupJitter_ForgetsKeysAfterWindow(t *testing.T) {
+	j := NewStartupJitter(time.Minute)
+	j.Delay(types.NamespacedName{Namespace: "agentregistry", Name: "early"})
+
+	// Once the window has passed, keys seen during it are dropped and new ones
+	// are not recorded
+	j.started = time.Now().Add(-time.Hour)
+	assert.Zero(t, j.Delay(types.NamespacedName{Namespace: "agentregistry", Name: "late"}))
+	j.seen.Range(func(key, _ any) bool {
+		t.Errorf("key %v still recorded", key)
+		return true
+	})
+}
+
+func TestSkillCatalogReconciler_StartupJitterRequeues(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	j := NewStartupJitter(time.Minute)
+	j.randDuration = func(time.Duration) time.Duration { return 10 * time.Second }
+
+	r := &SkillCatalogReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:        scheme,
+		Logger:        zerolog.Nop(),
+		StartupJitter: j,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "agentregistry", Name: "skill"}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, result.RequeueAfter)
+
+	// The requeued reconcile proceeds normally.
+	result, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
+)
+
+// tracedHTTPClient propagates the trace context of outbound requests, so calls
+// to an environment's MCP tool server join the reconcile's trace
+var tracedHTTPClient = newTracedHTTPClient(nil)
+
+// SetMCPTLSConfig sets the TLS settings, such as a custom CA bundle, of the
+// client calling environments' MCP tool servers. It must be called at
+// startup, before the first reconcile.
+func SetMCPTLSConfig(cfg *tls.Config) {
+	tracedHTTPClient = newTracedHTTPClient(cfg)
+}
+
+// newTracedHTTPClient returns a tracing HTTP client using cfg, or Go's
+// defaults when it is nil
+func newTracedHTTPClient(cfg *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	return &http.Client{Transport: otelhttp.NewTransport(transport)}
+}
+
+// traceReconcile wraps a reconciler so every reconcile runs in its own span,
+// named after the kind it reconciles. Reconcilers add attributes such as the
+// version or phase to the span carried by their context.
+func traceReconcile(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ctx, span := tracing.Tracer().Start(ctx, kind+".Reconcile", trace.WithAttributes(
+			tracing.KeyNamespace.String(req.Namespace),
+			tracing.KeyObject.String(req.Name),
+		))
+		result, err := r.Reconcile(ctx, req)
+		tracing.End(span, err)
+		return result, err
+	})
+}
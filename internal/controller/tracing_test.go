@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// recordSpans installs a tracer provider recording every ended span for the
+// duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTraceReconcile_MCPServerCatalog(t *testing.T) {
+	recorder := recordSpans(t)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "1.0.0"},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(server).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithStatusSubresource(&agentregistryv1alpha1.MCPServerCatalog{}).
+		Build()
+	r := traceReconcile("MCPServerCatalog", &MCPServerCatalogReconciler{Client: c, Scheme: scheme, Logger: zerolog.Nop()})
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "agentregistry", Name: "search-1-0-0"}})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "MCPServerCatalog.Reconcile", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "agentregistry", attrs["k8s.namespace.name"].AsString())
+	assert.Equal(t, "search-1-0-0", attrs["agentregistry.object"].AsString())
+	assert.Equal(t, "org/search", attrs["agentregistry.resource.name"].AsString())
+	assert.Equal(t, "1.0.0", attrs["agentregistry.version"].AsString())
+}
+
+func TestTraceReconcile_RecordsError(t *testing.T) {
+	recorder := recordSpans(t)
+
+	r := traceReconcile("RegistryDeployment", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, errors.New("boom")
+	}))
+	_, err := r.Reconcile(context.Background(), reconcile.Request{})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "RegistryDeployment.Reconcile", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "boom", spans[0].Status().Description)
+}
+
+func TestNewTracedHTTPClient_UsesTLSConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer srv.Close()
+
+	// The test server's certificate is not trusted by default
+	_, err := newTracedHTTPClient(nil).Get(srv.URL)
+	require.Error(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	resp, err := newTracedHTTPClient(&tls.Config{RootCAs: pool}).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
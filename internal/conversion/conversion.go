@@ -17,6 +17,14 @@ type KeyValueJSON struct {
 	Description string `json:"description,omitempty"`
 	Value       string `json:"value,omitempty"`
 	Required    bool   `json:"required,omitempty"`
+	// SecretRef points the value at a key of a Kubernetes Secret
+	SecretRef *SecretKeyRefJSON `json:"secretRef,omitempty"`
+}
+
+// SecretKeyRefJSON represents a Secret key reference in JSON format
+type SecretKeyRefJSON struct {
+	SecretName string `json:"secretName"`
+	Key        string `json:"key,omitempty"`
 }
 
 // ArgumentJSON represents an argument in JSON format
@@ -36,6 +44,7 @@ type PackageJSON struct {
 	Identifier           string         `json:"identifier"`
 	Version              string         `json:"version,omitempty"`
 	FileSHA256           string         `json:"fileSha256,omitempty"`
+	Digest               string         `json:"digest,omitempty"`
 	RuntimeHint          string         `json:"runtimeHint,omitempty"`
 	Transport            TransportJSON  `json:"transport"`
 	RuntimeArguments     []ArgumentJSON `json:"runtimeArguments,omitempty"`
@@ -70,12 +79,16 @@ func TransportFromCRD(t agentregistryv1alpha1.Transport) TransportJSON {
 
 // KeyValueFromCRD converts a CRD KeyValueInput to KeyValueJSON
 func KeyValueFromCRD(kv agentregistryv1alpha1.KeyValueInput) KeyValueJSON {
-	return KeyValueJSON{
+	result := KeyValueJSON{
 		Name:        kv.Name,
 		Description: kv.Description,
 		Value:       kv.Value,
 		Required:    kv.Required,
 	}
+	if kv.SecretRef != nil {
+		result.SecretRef = &SecretKeyRefJSON{SecretName: kv.SecretRef.SecretName, Key: kv.SecretRef.Key}
+	}
+	return result
 }
 
 // ArgumentFromCRD converts a CRD Argument to ArgumentJSON
@@ -98,6 +111,7 @@ func PackageFromCRD(p agentregistryv1alpha1.Package) PackageJSON {
 		Identifier:      p.Identifier,
 		Version:         p.Version,
 		FileSHA256:      p.FileSHA256,
+		Digest:          p.Digest,
 		RuntimeHint:     p.RuntimeHint,
 		Transport:       TransportFromCRD(p.Transport),
 	}
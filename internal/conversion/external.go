@@ -0,0 +1,149 @@
+package conversion
+
+import (
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// ExternalServerJSON represents a server from external registries (MCP Registry format)
+type ExternalServerJSON struct {
+	Name        string                  `json:"name"`
+	Version     string                  `json:"version"`
+	Title       string                  `json:"title,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	WebsiteURL  string                  `json:"websiteUrl,omitempty"`
+	Icons       []ExternalIconJSON      `json:"icons,omitempty"`
+	Repository  *ExternalRepositoryJSON `json:"repository,omitempty"`
+	Packages    []ExternalPackageJSON   `json:"packages,omitempty"`
+	Remotes     []ExternalTransportJSON `json:"remotes,omitempty"`
+}
+
+// ExternalIconJSON is an icon of a server in the MCP Registry format
+type ExternalIconJSON struct {
+	Src      string   `json:"src"`
+	MimeType string   `json:"mimeType,omitempty"`
+	Sizes    []string `json:"sizes,omitempty"`
+}
+
+type ExternalRepositoryJSON struct {
+	URL       string `json:"url,omitempty"`
+	Source    string `json:"source,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Subfolder string `json:"subfolder,omitempty"`
+}
+
+type ExternalTransportJSON struct {
+	Type    string                 `json:"type"`
+	URL     string                 `json:"url,omitempty"`
+	Headers []ExternalKeyValueJSON `json:"headers,omitempty"`
+}
+
+type ExternalKeyValueJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type ExternalPackageJSON struct {
+	RegistryType         string                 `json:"registryType"`
+	RegistryBaseURL      string                 `json:"registryBaseUrl,omitempty"`
+	Identifier           string                 `json:"identifier"`
+	Version              string                 `json:"version,omitempty"`
+	FileSHA256           string                 `json:"fileSha256,omitempty"`
+	Digest               string                 `json:"digest,omitempty"`
+	RuntimeHint          string                 `json:"runtimeHint,omitempty"`
+	Transport            ExternalTransportJSON  `json:"transport"`
+	RuntimeArguments     []ExternalArgumentJSON `json:"runtimeArguments,omitempty"`
+	PackageArguments     []ExternalArgumentJSON `json:"packageArguments,omitempty"`
+	EnvironmentVariables []ExternalKeyValueJSON `json:"environmentVariables,omitempty"`
+}
+
+type ExternalArgumentJSON struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Multiple    bool   `json:"multiple,omitempty"`
+}
+
+// ExternalServerFromCRD converts a CRD MCPServerCatalogSpec to the MCP Registry
+// format, the inverse of the conversion applied on import
+func ExternalServerFromCRD(spec agentregistryv1alpha1.MCPServerCatalogSpec) ExternalServerJSON {
+	ext := ExternalServerJSON{
+		Name:        spec.Name,
+		Version:     spec.Version,
+		Title:       spec.Title,
+		Description: spec.Description,
+		WebsiteURL:  spec.WebsiteURL,
+	}
+	if spec.IconURL != "" {
+		ext.Icons = []ExternalIconJSON{{Src: spec.IconURL}}
+	}
+
+	if spec.Repository != nil {
+		ext.Repository = &ExternalRepositoryJSON{
+			URL:       spec.Repository.URL,
+			Source:    spec.Repository.Source,
+			ID:        spec.Repository.ID,
+			Subfolder: spec.Repository.Subfolder,
+		}
+	}
+
+	for _, p := range spec.Packages {
+		pkg := ExternalPackageJSON{
+			RegistryType:    p.RegistryType,
+			RegistryBaseURL: p.RegistryBaseURL,
+			Identifier:      p.Identifier,
+			Version:         p.Version,
+			FileSHA256:      p.FileSHA256,
+			Digest:          p.Digest,
+			RuntimeHint:     p.RuntimeHint,
+			Transport:       externalTransportFromCRD(p.Transport),
+		}
+		for _, a := range p.RuntimeArguments {
+			pkg.RuntimeArguments = append(pkg.RuntimeArguments, externalArgumentFromCRD(a))
+		}
+		for _, a := range p.PackageArguments {
+			pkg.PackageArguments = append(pkg.PackageArguments, externalArgumentFromCRD(a))
+		}
+		for _, e := range p.EnvironmentVariables {
+			pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, externalKeyValueFromCRD(e))
+		}
+		ext.Packages = append(ext.Packages, pkg)
+	}
+
+	for _, r := range spec.Remotes {
+		ext.Remotes = append(ext.Remotes, externalTransportFromCRD(r))
+	}
+
+	return ext
+}
+
+func externalTransportFromCRD(t agentregistryv1alpha1.Transport) ExternalTransportJSON {
+	ext := ExternalTransportJSON{Type: t.Type, URL: t.URL}
+	for _, h := range t.Headers {
+		ext.Headers = append(ext.Headers, externalKeyValueFromCRD(h))
+	}
+	return ext
+}
+
+func externalKeyValueFromCRD(kv agentregistryv1alpha1.KeyValueInput) ExternalKeyValueJSON {
+	return ExternalKeyValueJSON{
+		Name:        kv.Name,
+		Description: kv.Description,
+		Value:       kv.Value,
+		Required:    kv.Required,
+	}
+}
+
+func externalArgumentFromCRD(a agentregistryv1alpha1.Argument) ExternalArgumentJSON {
+	return ExternalArgumentJSON{
+		Name:        a.Name,
+		Type:        a.Type,
+		Description: a.Description,
+		Value:       a.Value,
+		Required:    a.Required,
+		Multiple:    a.Multiple,
+	}
+}
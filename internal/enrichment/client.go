@@ -0,0 +1,292 @@
+// Package enrichment provides a shared client for calls to external package
+// registries (npm, PyPI, OCI registries, ...) made while enriching catalog
+// entries. It rate limits requests per host, caches successful responses for a
+// TTL, and collapses concurrent requests for the same URL into a single
+// upstream call, so that many reconciles touching the same package do not
+// stampede the registry.
+package enrichment
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultCacheTTL is how long successful responses are cached by default
+	DefaultCacheTTL = 10 * time.Minute
+	// DefaultRequestsPerSecond is the default per-host upstream request rate
+	DefaultRequestsPerSecond = 2
+	// DefaultBurst is the default per-host request burst
+	DefaultBurst = 5
+
+	// maxResponseBytes caps the size of a response body read from a registry
+	maxResponseBytes = 10 << 20
+)
+
+// Options configures a Client
+type Options struct {
+	// HTTPClient performs the upstream requests. Defaults to a client with a
+	// 30s timeout.
+	HTTPClient *http.Client
+	// TLSConfig, e.g. a custom CA bundle, is used by the default HTTPClient
+	TLSConfig *tls.Config
+	// CacheTTL is how long a successful response is served from cache.
+	// Zero or negative disables caching.
+	CacheTTL time.Duration
+	// RequestsPerSecond and Burst bound the upstream request rate per host
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// OptionsFromEnv returns Options configured from the environment:
+//
+//	AGENTREGISTRY_ENRICHMENT_CACHE_TTL  response cache TTL      (default 10m)
+//	AGENTREGISTRY_ENRICHMENT_RPS        requests/sec per host   (default 2)
+//	AGENTREGISTRY_ENRICHMENT_BURST      request burst per host  (default 5)
+func OptionsFromEnv() Options {
+	opts := Options{
+		CacheTTL:          DefaultCacheTTL,
+		RequestsPerSecond: DefaultRequestsPerSecond,
+		Burst:             DefaultBurst,
+	}
+	if v := os.Getenv("AGENTREGISTRY_ENRICHMENT_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			opts.CacheTTL = d
+		}
+	}
+	if v := os.Getenv("AGENTREGISTRY_ENRICHMENT_RPS"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 {
+			opts.RequestsPerSecond = f
+		}
+	}
+	if v := os.Getenv("AGENTREGISTRY_ENRICHMENT_BURST"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			opts.Burst = n
+		}
+	}
+	return opts
+}
+
+// StatusError is returned when a registry responds with a non-2xx status
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: unexpected status %d", e.URL, e.StatusCode)
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// Client performs cached, rate-limited GET requests against external registries.
+// It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	ttl        time.Duration
+	rps        rate.Limit
+	burst      int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	cache    map[string]cacheEntry
+	// nextSweep is when store next evicts every expired cache entry
+	nextSweep time.Time
+
+	group singleflight.Group
+	now   func() time.Time
+}
+
+// NewClient returns a Client configured by opts
+func NewClient(opts Options) *Client {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+		if opts.TLSConfig != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = opts.TLSConfig
+			httpClient.Transport = transport
+		}
+	}
+	rps := opts.RequestsPerSecond
+	if rps <= 0 {
+		rps = DefaultRequestsPerSecond
+	}
+	burst := opts.Burst
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+	return &Client{
+		httpClient: httpClient,
+		ttl:        opts.CacheTTL,
+		rps:        rate.Limit(rps),
+		burst:      burst,
+		limiters:   make(map[string]*rate.Limiter),
+		cache:      make(map[string]cacheEntry),
+		now:        time.Now,
+	}
+}
+
+var (
+	sharedTLSConfig *tls.Config
+	shared          = sync.OnceValue(func() *Client {
+		opts := OptionsFromEnv()
+		opts.TLSConfig = sharedTLSConfig
+		return NewClient(opts)
+	})
+)
+
+// SetSharedTLSConfig sets the TLS settings of the Shared client. It must be
+// called at startup, before the first call to Shared.
+func SetSharedTLSConfig(cfg *tls.Config) {
+	sharedTLSConfig = cfg
+}
+
+// Shared returns the process-wide Client configured from the environment.
+// Callers should use it rather than creating their own so that rate limits
+// and the cache apply across the whole controller.
+func Shared() *Client {
+	return shared()
+}
+
+// Get fetches rawURL and returns the response body. Fresh cached responses are
+// returned without contacting the registry, and concurrent calls for the same
+// URL share one upstream request.
+func (c *Client) Get(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	host := u.Host
+
+	if body, ok := c.cached(rawURL); ok {
+		cacheHits.WithLabelValues(host).Inc()
+		return body, nil
+	}
+	cacheMisses.WithLabelValues(host).Inc()
+
+	v, err, _ := c.group.Do(rawURL, func() (any, error) {
+		// Another caller may have populated the cache while we waited to
+		// become the leader for this key.
+		if body, ok := c.cached(rawURL); ok {
+			return body, nil
+		}
+		body, err := c.fetch(ctx, host, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		c.store(rawURL, body)
+		return body, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// GetJSON fetches rawURL like Get and decodes the JSON body into v
+func (c *Client) GetJSON(ctx context.Context, rawURL string, v any) error {
+	body, err := c.Get(ctx, rawURL)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", rawURL, err)
+	}
+	return nil
+}
+
+func (c *Client) fetch(ctx context.Context, host, rawURL string) ([]byte, error) {
+	limiter := c.limiter(host)
+	if !limiter.Allow() {
+		rateLimited.WithLabelValues(host).Inc()
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit wait for %s: %w", host, err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		upstreamRequests.WithLabelValues(host, "error").Inc()
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	upstreamRequests.WithLabelValues(host, strconv.Itoa(resp.StatusCode)).Inc()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{URL: rawURL, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", rawURL, err)
+	}
+	return body, nil
+}
+
+func (c *Client) limiter(host string) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(c.rps, c.burst)
+		c.limiters[host] = l
+	}
+	return l
+}
+
+func (c *Client) cached(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.cache, key)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// store caches body under key for the TTL. Expired entries are only dropped
+// by cached when their key is requested again, so once per TTL store also
+// evicts every expired entry to keep the cache from holding on to URLs that
+// are never fetched again.
+func (c *Client) store(key string, body []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if !now.Before(c.nextSweep) {
+		for k, entry := range c.cache {
+			if !now.Before(entry.expires) {
+				delete(c.cache, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+	c.cache[key] = cacheEntry{body: body, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,156 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ConcurrentRequestsShareOneUpstreamCall(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"name":"left-pad","version":"1.3.0"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(Options{CacheTTL: time.Minute})
+
+	const n = 20
+	var wg sync.WaitGroup
+	results := make([]map[string]string, n)
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.GetJSON(context.Background(), srv.URL+"/left-pad", &results[i])
+		}()
+	}
+
+	// Give the goroutines time to pile up behind the in-flight request
+	// before letting the server answer.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for i := range n {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "1.3.0", results[i]["version"])
+	}
+
+	// Subsequent requests are served from cache.
+	_, err := c.Get(context.Background(), srv.URL+"/left-pad")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_CacheExpiresAfterTTL(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := NewClient(Options{CacheTTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	_, err := c.Get(context.Background(), srv.URL)
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(2 * time.Minute)
+	_, err = c.Get(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_EvictsExpiredEntries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := NewClient(Options{CacheTTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	for _, path := range []string{"/a", "/b"} {
+		_, err := c.Get(context.Background(), srv.URL+path)
+		require.NoError(t, err)
+	}
+	assert.Len(t, c.cache, 2)
+
+	// Entries that are never requested again are evicted once they expire
+	now = now.Add(2 * time.Minute)
+	_, err := c.Get(context.Background(), srv.URL+"/c")
+	require.NoError(t, err)
+	assert.Len(t, c.cache, 1)
+	assert.Contains(t, c.cache, srv.URL+"/c")
+}
+
+func TestClient_ErrorsAreNotCached(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(Options{CacheTTL: time.Minute})
+
+	_, err := c.Get(context.Background(), srv.URL+"/missing")
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+
+	_, err = c.Get(context.Background(), srv.URL+"/missing")
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_RateLimitsPerHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// Caching disabled so every call goes upstream; one token, no refill
+	// within the test's deadline.
+	c := NewClient(Options{RequestsPerSecond: 0.01, Burst: 1})
+
+	_, err := c.Get(context.Background(), srv.URL+"/a")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Get(ctx, srv.URL+"/b")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate limit")
+}
+
+func TestOptionsFromEnv(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_ENRICHMENT_CACHE_TTL", "30s")
+	t.Setenv("AGENTREGISTRY_ENRICHMENT_RPS", "0.5")
+	t.Setenv("AGENTREGISTRY_ENRICHMENT_BURST", "invalid")
+
+	opts := OptionsFromEnv()
+	assert.Equal(t, 30*time.Second, opts.CacheTTL)
+	assert.Equal(t, 0.5, opts.RequestsPerSecond)
+	assert.Equal(t, DefaultBurst, opts.Burst)
+}
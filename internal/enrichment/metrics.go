@@ -0,0 +1,34 @@
+package enrichment
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "agentregistry_enrichment_cache_hits_total",
+		Help: "Enrichment requests served from the response cache, by registry host.",
+	}, []string{"host"})
+
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "agentregistry_enrichment_cache_misses_total",
+		Help: "Enrichment requests not found in the response cache, by registry host.",
+	}, []string{"host"})
+
+	rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "agentregistry_enrichment_rate_limited_total",
+		Help: "Upstream enrichment requests delayed by the per-host rate limit.",
+	}, []string{"host"})
+
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "agentregistry_enrichment_upstream_requests_total",
+		Help: "Requests sent to external package registries, by host and HTTP status code.",
+	}, []string{"host", "code"})
+)
+
+func init() {
+	// Registered with the controller-runtime registry so they are served on
+	// the manager's metrics endpoint.
+	metrics.Registry.MustRegister(cacheHits, cacheMisses, rateLimited, upstreamRequests)
+}
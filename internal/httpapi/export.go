@@ -0,0 +1,26 @@
+package httpapi
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/internal/catalogexport"
+)
+
+type ExportInput struct {
+	Type        string `query:"type" json:"type,omitempty" enum:"servers,agents,skills,models" doc:"Export a single catalog type; defaults to all"`
+	Environment string `query:"environment" json:"environment,omitempty" doc:"Only export entries discovered in this environment"`
+}
+
+type ExportResponse struct {
+	Body catalogexport.Bundle
+}
+
+func (s *Server) exportCatalog(ctx context.Context, input *ExportInput) (*ExportResponse, error) {
+	bundle, err := catalogexport.Export(ctx, s.cache, input.Type, input.Environment)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to export catalog", err)
+	}
+	return &ExportResponse{Body: *bundle}, nil
+}
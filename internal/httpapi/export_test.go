@@ -0,0 +1,117 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/catalogexport"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestExportCatalog_RoundTrip(t *testing.T) {
+	source, sourceClient := setupTestServer(t)
+	ctx := context.Background()
+
+	search := agentregistryv1alpha1.MCPServerCatalogSpec{
+		Name:        "io.example/search",
+		Version:     "1.2.0",
+		Title:       "Search",
+		Description: "Full-text search",
+		WebsiteURL:  "https://example.com/search",
+		IconURL:     "https://example.com/search.png",
+		Repository:  &agentregistryv1alpha1.Repository{URL: "https://github.com/example/search", Source: "github", Subfolder: "server"},
+		Packages: []agentregistryv1alpha1.Package{{
+			RegistryType: "npm",
+			Identifier:   "@example/search",
+			Version:      "1.2.0",
+			RuntimeHint:  "npx",
+			Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+			RuntimeArguments: []agentregistryv1alpha1.Argument{
+				{Name: "--yes", Type: "named"},
+			},
+			PackageArguments: []agentregistryv1alpha1.Argument{
+				{Name: "index", Type: "positional", Value: "docs", Required: true},
+			},
+			EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+				{Name: "SEARCH_API_KEY", Description: "API key", Required: true},
+			},
+		}},
+		Remotes: []agentregistryv1alpha1.Transport{{
+			Type: "streamable-http",
+			URL:  "https://search.example.com/mcp",
+			Headers: []agentregistryv1alpha1.KeyValueInput{
+				{Name: "Authorization", Value: "Bearer {token}", Required: true},
+			},
+		}},
+	}
+	weather := agentregistryv1alpha1.MCPServerCatalogSpec{
+		Name:    "io.example/weather",
+		Version: "0.1.0",
+		Remotes: []agentregistryv1alpha1.Transport{{Type: "sse", URL: "https://weather.example.com/sse"}},
+	}
+	for name, spec := range map[string]agentregistryv1alpha1.MCPServerCatalogSpec{"search": search, "weather": weather} {
+		obj := &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       spec,
+		}
+		if name == "weather" {
+			obj.Labels = map[string]string{controller.EnvironmentLabel: "prod"}
+		}
+		require.NoError(t, sourceClient.Create(ctx, obj))
+	}
+	require.NoError(t, sourceClient.Create(ctx, &agentregistryv1alpha1.AgentCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "helper", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "helper", Version: "1.0.0"},
+	}))
+
+	resp, err := source.exportCatalog(ctx, &ExportInput{})
+	require.NoError(t, err)
+	bundle := resp.Body
+	assert.Equal(t, 3, bundle.Metadata.Count)
+	require.Len(t, bundle.Servers, 2)
+	assert.Equal(t, "io.example/search", bundle.Servers[0].Name)
+	require.Len(t, bundle.Agents, 1)
+
+	// Filters
+	resp, err = source.exportCatalog(ctx, &ExportInput{Type: catalogexport.TypeServers, Environment: "prod"})
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Servers, 1)
+	assert.Equal(t, "io.example/weather", resp.Body.Servers[0].Name)
+	assert.Empty(t, resp.Body.Agents)
+	resp, err = source.exportCatalog(ctx, &ExportInput{Type: catalogexport.TypeAgents})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Body.Servers)
+	assert.Len(t, resp.Body.Agents, 1)
+
+	// Re-import the full bundle into an empty registry
+	raw, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(raw)
+	}))
+	defer ts.Close()
+
+	target, targetClient := setupTestServer(t)
+	target.importClient = ts.Client()
+	importResp, err := target.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL + "/export"}})
+	require.NoError(t, err)
+	require.True(t, importResp.Body.Success, importResp.Body.Errors)
+	assert.Equal(t, "Imported 2, updated 0, skipped 0 servers", importResp.Body.Message)
+
+	var imported agentregistryv1alpha1.MCPServerCatalogList
+	require.NoError(t, targetClient.List(ctx, &imported))
+	specs := map[string]agentregistryv1alpha1.MCPServerCatalogSpec{}
+	for _, item := range imported.Items {
+		specs[item.Spec.Name] = item.Spec
+	}
+	assert.Equal(t, search, specs["io.example/search"])
+	assert.Equal(t, weather, specs["io.example/weather"])
+}
@@ -0,0 +1,250 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/catalogexport"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
+)
+
+// featuredTypes are the catalog types that can be featured, in the order
+// they are listed
+var featuredTypes = []string{catalogexport.TypeServers, catalogexport.TypeAgents, catalogexport.TypeSkills, catalogexport.TypeModels}
+
+// FeaturedEntryJSON is a catalog entry recommended by the registry curators
+type FeaturedEntryJSON struct {
+	Type        string `json:"type" enum:"servers,agents,skills,models"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type FeaturedListResponse struct {
+	Entries  []FeaturedEntryJSON   `json:"entries"`
+	Metadata handlers.ListMetadata `json:"metadata"`
+}
+
+type ListFeaturedInput struct {
+	Type string `query:"type" json:"type,omitempty" enum:"servers,agents,skills,models" doc:"Only list featured entries of this type"`
+}
+
+type ListFeaturedResponse struct {
+	Body FeaturedListResponse
+}
+
+type SetFeaturedInput struct {
+	Type string `path:"type" enum:"servers,agents,skills,models"`
+	Name string `path:"name" doc:"URL-encoded entry name"`
+	Body struct {
+		Featured bool `json:"featured"`
+	}
+}
+
+type SetFeaturedResult struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Featured bool   `json:"featured"`
+	// Updated is the number of versions whose flag changed
+	Updated int `json:"updated"`
+}
+
+type SetFeaturedResponse struct {
+	Body SetFeaturedResult
+}
+
+// catalogEntry is a catalog object along with the fields listed for it
+type catalogEntry struct {
+	obj     client.Object
+	brief   FeaturedEntryJSON
+	deleted bool
+}
+
+// registerFeaturedRoutes registers the public list of featured entries and
+// the admin endpoint curating it
+func (s *Server) registerFeaturedRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "list-featured",
+		Method:      http.MethodGet,
+		Path:        "/v0/featured",
+		Summary:     "List the catalog entries featured by the registry curators",
+		Tags:        []string{"featured"},
+	}, func(ctx context.Context, input *ListFeaturedInput) (*ListFeaturedResponse, error) {
+		return s.listFeatured(ctx, input)
+	})
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "admin-set-featured",
+		Method:      http.MethodPut,
+		Path:        "/admin/v0/featured/{type}/{name}",
+		Summary:     "Feature or unfeature every version of a catalog entry",
+		Tags:        []string{"featured", "admin"},
+	}, func(ctx context.Context, input *SetFeaturedInput) (*SetFeaturedResponse, error) {
+		return s.setFeatured(ctx, input)
+	})
+}
+
+// listFeatured lists the featured entries, each at its latest featured
+// version, skipping soft-deleted ones and those not visible to the caller
+func (s *Server) listFeatured(ctx context.Context, input *ListFeaturedInput) (*ListFeaturedResponse, error) {
+	entries := []FeaturedEntryJSON{}
+	for _, catalogType := range featuredTypes {
+		if input.Type != "" && input.Type != catalogType {
+			continue
+		}
+		items, err := s.listCatalogEntries(ctx, catalogType, "")
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list featured entries", err)
+		}
+
+		latest := make(map[string]FeaturedEntryJSON)
+		for _, item := range items {
+			if item.deleted || !handlers.IsFeatured(item.obj) || !handlers.VisibleTo(ctx, item.obj) {
+				continue
+			}
+			if cur, ok := latest[item.brief.Name]; !ok || semver.Compare(item.brief.Version, cur.Version) > 0 {
+				latest[item.brief.Name] = item.brief
+			}
+		}
+		featured := make([]FeaturedEntryJSON, 0, len(latest))
+		for _, entry := range latest {
+			featured = append(featured, entry)
+		}
+		sort.Slice(featured, func(i, j int) bool { return featured[i].Name < featured[j].Name })
+		entries = append(entries, featured...)
+	}
+
+	return &ListFeaturedResponse{Body: FeaturedListResponse{
+		Entries:  entries,
+		Metadata: handlers.ListMetadata{Count: len(entries)},
+	}}, nil
+}
+
+// setFeatured sets or clears the featured annotation on every version of an
+// entry
+func (s *Server) setFeatured(ctx context.Context, input *SetFeaturedInput) (*SetFeaturedResponse, error) {
+	name, err := url.PathUnescape(input.Name)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid name encoding", err)
+	}
+
+	items, err := s.listCatalogEntries(ctx, input.Type, name)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get catalog entry", err)
+	}
+	if len(items) == 0 {
+		return nil, huma.Error404NotFound("Catalog entry not found")
+	}
+
+	updated := 0
+	for _, item := range items {
+		if handlers.IsFeatured(item.obj) == input.Body.Featured {
+			continue
+		}
+		obj := item.obj
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		annotations := obj.GetAnnotations()
+		if input.Body.Featured {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[agentregistryv1alpha1.AnnotationFeatured] = "true"
+		} else {
+			delete(annotations, agentregistryv1alpha1.AnnotationFeatured)
+		}
+		obj.SetAnnotations(annotations)
+		if err := s.client.Patch(ctx, obj, patch); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to update catalog entry", err)
+		}
+		updated++
+	}
+
+	s.logger.Info().
+		Str("type", input.Type).
+		Str("name", name).
+		Bool("featured", input.Body.Featured).
+		Int("updated", updated).
+		Msg("catalog entry curation changed")
+	return &SetFeaturedResponse{Body: SetFeaturedResult{
+		Type:     input.Type,
+		Name:     name,
+		Featured: input.Body.Featured,
+		Updated:  updated,
+	}}, nil
+}
+
+// listCatalogEntries lists the entries of a catalog type, every version of
+// name when it is set
+func (s *Server) listCatalogEntries(ctx context.Context, catalogType, name string) ([]catalogEntry, error) {
+	byName := func(index string) []client.ListOption {
+		if name == "" {
+			return nil
+		}
+		return []client.ListOption{client.MatchingFields{index: name}}
+	}
+	var entries []catalogEntry
+	switch catalogType {
+	case catalogexport.TypeServers:
+		var list agentregistryv1alpha1.MCPServerCatalogList
+		if err := s.cache.List(ctx, &list, byName(controller.IndexMCPServerName)...); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			entries = append(entries, catalogEntry{
+				obj:     item,
+				brief:   FeaturedEntryJSON{Type: catalogType, Name: item.Spec.Name, Version: item.Spec.Version, Title: item.Spec.Title, Description: item.Spec.Description},
+				deleted: item.Status.Status == agentregistryv1alpha1.CatalogStatusDeleted,
+			})
+		}
+	case catalogexport.TypeAgents:
+		var list agentregistryv1alpha1.AgentCatalogList
+		if err := s.cache.List(ctx, &list, byName(controller.IndexAgentName)...); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			entries = append(entries, catalogEntry{
+				obj:     item,
+				brief:   FeaturedEntryJSON{Type: catalogType, Name: item.Spec.Name, Version: item.Spec.Version, Title: item.Spec.Title, Description: item.Spec.Description},
+				deleted: item.Status.Status == agentregistryv1alpha1.CatalogStatusDeleted,
+			})
+		}
+	case catalogexport.TypeSkills:
+		var list agentregistryv1alpha1.SkillCatalogList
+		if err := s.cache.List(ctx, &list, byName(controller.IndexSkillName)...); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			entries = append(entries, catalogEntry{
+				obj:     item,
+				brief:   FeaturedEntryJSON{Type: catalogType, Name: item.Spec.Name, Version: item.Spec.Version, Title: item.Spec.Title, Description: item.Spec.Description},
+				deleted: item.Status.Status == agentregistryv1alpha1.CatalogStatusDeleted,
+			})
+		}
+	case catalogexport.TypeModels:
+		var list agentregistryv1alpha1.ModelCatalogList
+		if err := s.cache.List(ctx, &list, byName(controller.IndexModelName)...); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			entries = append(entries, catalogEntry{
+				obj:     item,
+				brief:   FeaturedEntryJSON{Type: catalogType, Name: item.Spec.Name, Description: item.Spec.Description},
+				deleted: item.Status.Status == agentregistryv1alpha1.CatalogStatusDeleted,
+			})
+		}
+	}
+	return entries, nil
+}
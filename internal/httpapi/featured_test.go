@@ -0,0 +1,205 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func setupFeaturedTestServer(t *testing.T, objs ...client.Object) (*Server, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.AgentCatalog).Spec.Name}
+		}).
+		Build()
+	server := NewServer(c, &mockCache{client: c}, zerolog.Nop())
+	server.allowedTokens["curator-token"] = true
+	return server, c
+}
+
+func TestFeatured_AdminSetsAndPublicLists(t *testing.T) {
+	server := func(name, version string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/search", Version: version, Title: "Search"},
+		}
+	}
+	s, c := setupFeaturedTestServer(t,
+		server("search-1-0-0", "1.0.0"),
+		server("search-1-1-0", "1.1.0"),
+		&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-1-0-0", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/weather", Version: "1.0.0"},
+		},
+		&agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "helper-1-0-0", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "helper", Version: "1.0.0"},
+		},
+	)
+
+	setFeatured := func(path string, featured bool, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(map[string]bool{"featured": featured})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		return rec
+	}
+	listFeatured := func() []FeaturedEntryJSON {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/featured", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp FeaturedListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp.Entries
+	}
+
+	// Only admins can curate
+	rec := setFeatured("/admin/v0/featured/servers/io.example%2Fsearch", true, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, listFeatured())
+
+	rec = setFeatured("/admin/v0/featured/servers/io.example%2Fsearch", true, "curator-token")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var result SetFeaturedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, 2, result.Updated)
+
+	var stored agentregistryv1alpha1.MCPServerCatalog
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "agentregistry", Name: "search-1-0-0"}, &stored))
+	assert.Equal(t, "true", stored.Annotations[agentregistryv1alpha1.AnnotationFeatured])
+
+	rec = setFeatured("/admin/v0/featured/agents/helper", true, "curator-token")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = setFeatured("/admin/v0/featured/agents/missing", true, "curator-token")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Each featured entry is listed once, at its latest version
+	assert.Equal(t, []FeaturedEntryJSON{
+		{Type: "servers", Name: "io.example/search", Version: "1.1.0", Title: "Search"},
+		{Type: "agents", Name: "helper", Version: "1.0.0"},
+	}, listFeatured())
+
+	rec = setFeatured("/admin/v0/featured/agents/helper", false, "curator-token")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Len(t, listFeatured(), 1)
+}
+
+func TestFeatured_ListFilterAndBadge(t *testing.T) {
+	s, _ := setupFeaturedTestServer(t,
+		&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "search-1-0-0",
+				Namespace:   "agentregistry",
+				Annotations: map[string]string{agentregistryv1alpha1.AnnotationFeatured: "true"},
+			},
+			Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/search", Version: "1.0.0"},
+		},
+		&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-1-0-0", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/weather", Version: "1.0.0"},
+		},
+	)
+
+	list := func(path string) map[string]bool {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp struct {
+			Servers []struct {
+				Server struct {
+					Name string `json:"name"`
+				} `json:"server"`
+				Meta struct {
+					Featured bool `json:"featured"`
+				} `json:"_meta"`
+			} `json:"servers"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		featured := make(map[string]bool)
+		for _, item := range resp.Servers {
+			featured[item.Server.Name] = item.Meta.Featured
+		}
+		return featured
+	}
+
+	assert.Equal(t, map[string]bool{"io.example/search": true, "io.example/weather": false}, list("/v0/servers"))
+	assert.Equal(t, map[string]bool{"io.example/search": true}, list("/v0/servers?featured=true"))
+}
+
+func TestFeatured_StrictPublicVisibility(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_STRICT_PUBLIC_VISIBILITY", "true")
+	now := metav1.Now()
+	featured := map[string]string{agentregistryv1alpha1.AnnotationFeatured: "true"}
+	s, _ := setupFeaturedTestServer(t,
+		&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry", Annotations: featured},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/search", Version: "1.0.0"},
+			Status:     agentregistryv1alpha1.MCPServerCatalogStatus{Published: true, PublishedAt: &now},
+		},
+		&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-1-0-0", Namespace: "agentregistry", Annotations: featured},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/weather", Version: "1.0.0"},
+		},
+	)
+
+	get := func(path, token string) []byte {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		return rec.Body.Bytes()
+	}
+	countServers := func(path, token string) int {
+		t.Helper()
+		var resp struct {
+			Servers []json.RawMessage `json:"servers"`
+		}
+		require.NoError(t, json.Unmarshal(get(path, token), &resp))
+		return len(resp.Servers)
+	}
+
+	var resp FeaturedListResponse
+	require.NoError(t, json.Unmarshal(get("/v0/featured", ""), &resp))
+	assert.Equal(t, []FeaturedEntryJSON{{Type: "servers", Name: "io.example/search", Version: "1.0.0"}}, resp.Entries)
+
+	assert.Equal(t, 1, countServers("/v0/servers", ""))
+	assert.Equal(t, 2, countServers("/admin/v0/servers", "curator-token"), "admins still see unpublished entries")
+
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/servers/io.example%2Fweather/versions/1.0.0", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
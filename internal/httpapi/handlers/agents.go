@@ -16,6 +16,8 @@ import (
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/search"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
 )
 
 // AgentHandler handles agent catalog operations
@@ -65,6 +67,7 @@ type AgentJSON struct {
 	ModelConfigRef    string                `json:"modelConfigRef,omitempty"`
 	Tools             []AgentToolRefJSON    `json:"tools,omitempty"`
 	Skills            []string              `json:"skills,omitempty"`
+	RequiredSkills    []string              `json:"requiredSkills,omitempty" doc:"Skill catalog entries the agent depends on, as name or name@version"`
 	TelemetryEndpoint string                `json:"telemetryEndpoint,omitempty"`
 	WebsiteURL        string                `json:"websiteUrl,omitempty"`
 	Repository        *RepositoryJSON       `json:"repository,omitempty"`
@@ -107,6 +110,11 @@ type AgentMeta struct {
 	Source            string                 `json:"source,omitempty"` // discovery, manual, deployment
 	IsDiscovered      bool                   `json:"isDiscovered,omitempty"`
 	Publisher         *PublisherInfoJSON     `json:"publisher,omitempty"`
+	DeploymentCount   int64                  `json:"deploymentCount,omitempty"`
+	Featured          bool                   `json:"featured,omitempty"`
+	// Deployments summarizes the RegistryDeployments of this version; set
+	// with ?includeDeployments=true
+	Deployments *DeploymentSummary `json:"deployments,omitempty"`
 }
 
 type AgentResponse struct {
@@ -121,10 +129,14 @@ type AgentListResponse struct {
 
 // Input types
 type ListAgentsInput struct {
-	Cursor  string `query:"cursor" json:"cursor,omitempty"`
-	Limit   int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
-	Search  string `query:"search" json:"search,omitempty"`
-	Version string `query:"version" json:"version,omitempty"`
+	Cursor             string `query:"cursor" json:"cursor,omitempty"`
+	Limit              int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
+	Search             string `query:"search" json:"search,omitempty" doc:"Match name, title and description, ignoring case; exact and prefix name matches rank first"`
+	SearchFields       string `query:"searchFields" json:"searchFields,omitempty" doc:"Comma-separated fields to search: name, title, description. Defaults to all."`
+	Version            string `query:"version" json:"version,omitempty"`
+	Sort               string `query:"sort" json:"sort,omitempty" enum:"popularity" doc:"popularity lists the most deployed versions first"`
+	Featured           bool   `query:"featured" json:"featured,omitempty" doc:"List only entries featured by the registry curators"`
+	IncludeDeployments bool   `query:"includeDeployments" json:"includeDeployments,omitempty" doc:"Summarize the deployments of each version in _meta.deployments"`
 }
 
 type AgentDetailInput struct {
@@ -217,6 +229,11 @@ func (h *AgentHandler) RegisterRoutes(api huma.API, pathPrefix string, isAdmin b
 }
 
 func (h *AgentHandler) listAgents(ctx context.Context, input *ListAgentsInput, isAdmin bool) (*Response[AgentListResponse], error) {
+	scope, err := search.ParseScope(input.SearchFields)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid searchFields", err)
+	}
+
 	var agentList agentregistryv1alpha1.AgentCatalogList
 
 	listOpts := []client.ListOption{}
@@ -238,13 +255,17 @@ func (h *AgentHandler) listAgents(ctx context.Context, input *ListAgentsInput, i
 		deploymentMap = make(map[string]*agentregistryv1alpha1.RegistryDeployment)
 	}
 
+	ranked := search.RankWithin(agentList.Items, input.Search, scope, search.AgentFields)
+	if input.Sort == SortPopularity {
+		sortByPopularity(ranked, func(a *agentregistryv1alpha1.AgentCatalog) int64 { return a.Status.DeploymentCount })
+	}
+
 	agents := make([]AgentResponse, 0, len(agentList.Items))
-	for _, a := range agentList.Items {
-		if input.Search != "" && !strings.Contains(strings.ToLower(a.Spec.Name), strings.ToLower(input.Search)) {
+	for _, a := range ranked {
+		if input.Version != "" && input.Version != "latest" && a.Spec.Version != input.Version {
 			continue
 		}
-
-		if input.Version != "" && input.Version != "latest" && a.Spec.Version != input.Version {
+		if input.Featured && !IsFeatured(&a) {
 			continue
 		}
 
@@ -262,6 +283,18 @@ func (h *AgentHandler) listAgents(ctx context.Context, input *ListAgentsInput, i
 		agents = agents[:limit]
 	}
 
+	// Summarize only the page returned, each through the deployment index
+	if input.IncludeDeployments {
+		for i := range agents {
+			entry := &agents[i].Agent
+			summary, err := summarizeDeployments(ctx, h.listFromCacheOrClient, agentregistryv1alpha1.ResourceTypeAgent, entry.Name, entry.Version)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to summarize deployments", err)
+			}
+			agents[i].Meta.Deployments = summary
+		}
+	}
+
 	return &Response[AgentListResponse]{
 		Body: AgentListResponse{
 			Agents: agents,
@@ -294,16 +327,15 @@ func (h *AgentHandler) buildDeploymentMap(ctx context.Context) (map[string]*agen
 // getDeploymentForAgent looks up a RegistryDeployment for a specific agent by name and version
 func (h *AgentHandler) getDeploymentForAgent(ctx context.Context, resourceName, version string) (*agentregistryv1alpha1.RegistryDeployment, error) {
 	var deploymentList agentregistryv1alpha1.RegistryDeploymentList
-	if err := h.listFromCacheOrClient(ctx, &deploymentList); err != nil {
+	if err := h.listFromCacheOrClient(ctx, &deploymentList, client.MatchingFields{
+		controller.IndexDeploymentResourceVersion: controller.NameVersionKey(resourceName, version),
+	}); err != nil {
 		return nil, err
 	}
 
 	for i := range deploymentList.Items {
-		deployment := &deploymentList.Items[i]
-		if deployment.Spec.ResourceType == agentregistryv1alpha1.ResourceTypeAgent &&
-			deployment.Spec.ResourceName == resourceName &&
-			deployment.Spec.Version == version {
-			return deployment, nil
+		if deploymentList.Items[i].Spec.ResourceType == agentregistryv1alpha1.ResourceTypeAgent {
+			return &deploymentList.Items[i], nil
 		}
 	}
 
@@ -313,7 +345,7 @@ func (h *AgentHandler) getDeploymentForAgent(ctx context.Context, resourceName,
 func (h *AgentHandler) getAgent(ctx context.Context, input *AgentDetailInput, isAdmin bool) (*Response[AgentResponse], error) {
 	agentName, err := url.PathUnescape(input.AgentName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		return nil, invalidName("Invalid agent name encoding", err)
 	}
 
 	var agentList agentregistryv1alpha1.AgentCatalogList
@@ -328,11 +360,22 @@ func (h *AgentHandler) getAgent(ctx context.Context, input *AgentDetailInput, is
 		return nil, huma.Error500InternalServerError("Failed to get agent", err)
 	}
 
+	// Fall back to computing latest on the fly when the IsLatest flag has not
+	// been set yet.
 	if len(agentList.Items) == 0 {
-		return nil, huma.Error404NotFound("Agent not found")
+		if err := h.listFromCacheOrClient(ctx, &agentList, client.MatchingFields{
+			controller.IndexAgentName: agentName,
+		}); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get agent", err)
+		}
+	}
+
+	latest := semver.LatestIndex(agentList.Items, func(a agentregistryv1alpha1.AgentCatalog) string { return a.Spec.Version })
+	if latest < 0 {
+		return nil, catalogNotFound("Agent not found")
 	}
 
-	agent := &agentList.Items[0]
+	agent := &agentList.Items[latest]
 
 	// Fetch deployment for this agent
 	deployment, err := h.getDeploymentForAgent(ctx, agent.Spec.Name, agent.Spec.Version)
@@ -348,11 +391,11 @@ func (h *AgentHandler) getAgent(ctx context.Context, input *AgentDetailInput, is
 func (h *AgentHandler) getAgentVersion(ctx context.Context, input *AgentVersionDetailInput, isAdmin bool) (*Response[AgentResponse], error) {
 	agentName, err := url.PathUnescape(input.AgentName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		return nil, invalidName("Invalid agent name encoding", err)
 	}
 	version, err := url.PathUnescape(input.Version)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		return nil, invalidVersion("Invalid version encoding", err)
 	}
 
 	var agentList agentregistryv1alpha1.AgentCatalogList
@@ -376,7 +419,7 @@ func (h *AgentHandler) getAgentVersion(ctx context.Context, input *AgentVersionD
 		}
 	}
 
-	return nil, huma.Error404NotFound("Agent version not found")
+	return nil, catalogNotFound("Agent version not found")
 }
 
 func (h *AgentHandler) createAgent(ctx context.Context, input *CreateAgentInput) (*Response[AgentResponse], error) {
@@ -404,6 +447,7 @@ func (h *AgentHandler) createAgent(ctx context.Context, input *CreateAgentInput)
 			SystemMessage:     input.Body.SystemMessage,
 			ModelConfigRef:    input.Body.ModelConfigRef,
 			Skills:            input.Body.Skills,
+			RequiredSkills:    input.Body.RequiredSkills,
 			TelemetryEndpoint: input.Body.TelemetryEndpoint,
 			WebsiteURL:        input.Body.WebsiteURL,
 		},
@@ -475,6 +519,10 @@ func (h *AgentHandler) createAgent(ctx context.Context, input *CreateAgentInput)
 		})
 	}
 
+	if err := rejectDuplicateEntry(ctx, h.client, &agentregistryv1alpha1.AgentCatalogList{}, agent, agent.Spec.Name, agent.Spec.Version); err != nil {
+		return nil, err
+	}
+
 	if err := h.client.Create(ctx, agent); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to create agent", err)
 	}
@@ -487,7 +535,7 @@ func (h *AgentHandler) createAgent(ctx context.Context, input *CreateAgentInput)
 func (h *AgentHandler) listAgentVersions(ctx context.Context, input *AgentDetailInput) (*Response[AgentListResponse], error) {
 	agentName, err := url.PathUnescape(input.AgentName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid agent name encoding", err)
+		return nil, invalidName("Invalid agent name encoding", err)
 	}
 
 	var agentList agentregistryv1alpha1.AgentCatalogList
@@ -496,6 +544,7 @@ func (h *AgentHandler) listAgentVersions(ctx context.Context, input *AgentDetail
 	}); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to list agent versions", err)
 	}
+	semver.SortDescendingFunc(agentList.Items, func(a agentregistryv1alpha1.AgentCatalog) string { return a.Spec.Version })
 
 	// Fetch all RegistryDeployments to build a lookup map
 	deploymentMap, err := h.buildDeploymentMap(ctx)
@@ -537,6 +586,7 @@ func (h *AgentHandler) convertToAgentResponse(a *agentregistryv1alpha1.AgentCata
 		SystemMessage:     a.Spec.SystemMessage,
 		ModelConfigRef:    a.Spec.ModelConfigRef,
 		Skills:            a.Spec.Skills,
+		RequiredSkills:    a.Spec.RequiredSkills,
 		TelemetryEndpoint: a.Spec.TelemetryEndpoint,
 		WebsiteURL:        a.Spec.WebsiteURL,
 	}
@@ -626,14 +676,8 @@ func (h *AgentHandler) convertToAgentResponse(a *agentregistryv1alpha1.AgentCata
 	}
 
 	// Check for discovery labels to determine source
-	if a.Labels != nil {
-		if a.Labels["agentregistry.dev/discovered"] == "true" {
-			resp.Meta.IsDiscovered = true
-			resp.Meta.Source = "discovery"
-		} else if source := a.Labels["agentregistry.dev/resource-source"]; source != "" {
-			resp.Meta.Source = source
-		}
-	}
+	resp.Meta.IsDiscovered = a.Labels["agentregistry.dev/discovered"] == "true"
+	resp.Meta.Source = agentregistryv1alpha1.ResourceSourceOf(a.Labels)
 
 	// Include publisher-provided metadata if available
 	if a.Spec.Metadata != nil && len(a.Spec.Metadata.Raw) > 0 {
@@ -664,6 +708,8 @@ func (h *AgentHandler) convertToAgentResponse(a *agentregistryv1alpha1.AgentCata
 
 	// Map governance/publisher verification from status
 	resp.Meta.Publisher = convertPublisherVerification(a.Status.Publisher)
+	resp.Meta.DeploymentCount = a.Status.DeploymentCount
+	resp.Meta.Featured = IsFeatured(a)
 
 	return resp
 }
@@ -671,7 +717,8 @@ func (h *AgentHandler) convertToAgentResponse(a *agentregistryv1alpha1.AgentCata
 // convertRegistryDeploymentToDeploymentInfo converts a RegistryDeployment to DeploymentInfo
 func (h *AgentHandler) convertRegistryDeploymentToDeploymentInfo(d *agentregistryv1alpha1.RegistryDeployment) *DeploymentInfo {
 	info := &DeploymentInfo{
-		Namespace: d.Spec.Namespace,
+		Namespace:  d.Spec.Namespace,
+		Namespaces: d.Spec.Namespaces,
 	}
 
 	// Determine ready status based on phase
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -13,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
 )
 
 func setupAgentTestClient(t *testing.T) client.Client {
@@ -139,6 +142,41 @@ func TestAgentHandler_CreateAgent_WithPackagesAndRemotes(t *testing.T) {
 	assert.Equal(t, []string{"server.js"}, resp.Body.Agent.McpServers[0].Args)
 }
 
+func TestAgentHandler_CreateAgent_DuplicatePolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	discovered := &agentregistryv1alpha1.AgentCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-default-my-agent"},
+		Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "my-agent", Version: "1.0.0"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(discovered).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.AgentCatalog).Spec.Name}
+		}).Build()
+	handler := NewAgentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+	input := func(version string) *CreateAgentInput {
+		return &CreateAgentInput{Body: AgentJSON{Name: "my-agent", Version: version, Image: "img:latest"}}
+	}
+
+	t.Setenv("AGENTREGISTRY_DUPLICATE_POLICY", "reject")
+	_, err := handler.createAgent(ctx, input("1.0.0"))
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusConflict, resp.GetStatus())
+	require.NotEmpty(t, resp.Details)
+	assert.Contains(t, resp.Details[0], "dev-default-my-agent")
+
+	_, err = handler.createAgent(ctx, input("2.0.0"))
+	require.NoError(t, err)
+
+	// The default report policy keeps the duplicate and leaves flagging it to
+	// the reconciler
+	t.Setenv("AGENTREGISTRY_DUPLICATE_POLICY", "")
+	_, err = handler.createAgent(ctx, input("1.0.0"))
+	require.NoError(t, err)
+}
+
 // ---------------------------------------------------------------------------
 // convertToAgentResponse
 // ---------------------------------------------------------------------------
@@ -186,6 +224,11 @@ func TestAgentHandler_ConvertToAgentResponse_ResourceSourceLabel(t *testing.T) {
 	resp := handler.convertToAgentResponse(agent, nil)
 	assert.False(t, resp.Meta.IsDiscovered)
 	assert.Equal(t, "import", resp.Meta.Source)
+
+	// Entries without a source label were created by hand
+	agent.Labels = nil
+	resp = handler.convertToAgentResponse(agent, nil)
+	assert.Equal(t, "manual", resp.Meta.Source)
 }
 
 func TestAgentHandler_ConvertToAgentResponse_WithDeploymentStatus(t *testing.T) {
@@ -352,3 +395,33 @@ func TestAgentHandler_ConvertToAgentResponse_WithMcpServers(t *testing.T) {
 	assert.Equal(t, []string{"server.js"}, resp.Agent.McpServers[0].Args)
 	assert.Equal(t, []string{"PORT=3000", "DEBUG=true"}, resp.Agent.McpServers[0].Env)
 }
+
+func TestAgentHandler_ListAgents_IncludeDeployments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.RegistryDeployment{}, controller.IndexDeploymentResourceVersion, controller.DeploymentResourceVersionIndex).
+		WithObjects(
+			&agentregistryv1alpha1.AgentCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("planner", "1.0.0")},
+				Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "planner", Version: "1.0.0"},
+			},
+			&agentregistryv1alpha1.RegistryDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "planner", Namespace: "agentregistry"},
+				Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+					ResourceName: "planner",
+					Version:      "1.0.0",
+					ResourceType: agentregistryv1alpha1.ResourceTypeAgent,
+					Namespace:    "agents",
+				},
+				Status: agentregistryv1alpha1.RegistryDeploymentStatus{Phase: agentregistryv1alpha1.DeploymentPhasePending},
+			},
+		).
+		Build()
+	handler := NewAgentHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.listAgents(context.Background(), &ListAgentsInput{IncludeDeployments: true}, false)
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Agents, 1)
+	assert.Equal(t, &DeploymentSummary{Total: 1, Pending: 1, Namespaces: []string{"agents"}}, resp.Body.Agents[0].Meta.Deployments)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// Caller identifies who reads the catalog, to apply the VisibilityGroups of
+// catalog entries
+type Caller struct {
+	// Admin callers present an admin token and see every entry
+	Admin bool
+	// Groups are the groups in the validated OIDC token of the caller
+	Groups []string
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx carrying caller
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller recorded in ctx, or an anonymous caller
+// without groups when there is none
+func CallerFromContext(ctx context.Context) Caller {
+	caller, _ := ctx.Value(callerKey{}).(Caller)
+	return caller
+}
+
+// CanSee reports whether the caller may see an entry restricted to groups.
+// Entries without groups are visible to everyone.
+func (c Caller) CanSee(groups []string) bool {
+	if c.Admin || len(groups) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(c.Groups, func(g string) bool { return slices.Contains(groups, g) })
+}
+
+// visibilityGroups returns the VisibilityGroups of a catalog entry, or nil for
+// other objects
+func visibilityGroups(obj client.Object) []string {
+	switch o := obj.(type) {
+	case *agentregistryv1alpha1.MCPServerCatalog:
+		return o.Spec.VisibilityGroups
+	case *agentregistryv1alpha1.AgentCatalog:
+		return o.Spec.VisibilityGroups
+	case *agentregistryv1alpha1.SkillCatalog:
+		return o.Spec.VisibilityGroups
+	case *agentregistryv1alpha1.ModelCatalog:
+		return o.Spec.VisibilityGroups
+	}
+	return nil
+}
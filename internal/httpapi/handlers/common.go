@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"sort"
 	"time"
 
+	"github.com/danielgtaylor/huma/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
 	"github.com/agentregistry-dev/agentregistry/internal/validation"
 )
 
@@ -26,6 +33,7 @@ type PublisherInfoJSON struct {
 // DeploymentInfo contains runtime deployment information from the source resource
 type DeploymentInfo struct {
 	Namespace   string     `json:"namespace,omitempty"`
+	Namespaces  []string   `json:"namespaces,omitempty"`
 	ServiceName string     `json:"serviceName,omitempty"`
 	URL         string     `json:"url,omitempty"`
 	Ready       bool       `json:"ready"`
@@ -33,6 +41,53 @@ type DeploymentInfo struct {
 	LastChecked *time.Time `json:"lastChecked,omitempty"`
 }
 
+// DeploymentSummary counts the RegistryDeployments of a catalog entry version
+// by phase. Total also counts deployments in other phases, such as Paused.
+type DeploymentSummary struct {
+	Total      int      `json:"total"`
+	Running    int      `json:"running"`
+	Pending    int      `json:"pending"`
+	Failed     int      `json:"failed"`
+	Namespaces []string `json:"namespaces,omitempty" doc:"Target namespaces of the deployments"`
+}
+
+// summarizeDeployments summarizes the RegistryDeployments of resourceType
+// created for name at version, looked up with list through the
+// IndexDeploymentResourceVersion index
+func summarizeDeployments(ctx context.Context, list func(context.Context, client.ObjectList, ...client.ListOption) error, resourceType agentregistryv1alpha1.ResourceType, name, version string) (*DeploymentSummary, error) {
+	var deployments agentregistryv1alpha1.RegistryDeploymentList
+	if err := list(ctx, &deployments, client.MatchingFields{
+		controller.IndexDeploymentResourceVersion: controller.NameVersionKey(name, version),
+	}); err != nil {
+		return nil, err
+	}
+
+	summary := &DeploymentSummary{}
+	namespaces := map[string]bool{}
+	for _, d := range deployments.Items {
+		if d.Spec.ResourceType != resourceType {
+			continue
+		}
+		summary.Total++
+		switch d.Status.Phase {
+		case agentregistryv1alpha1.DeploymentPhaseRunning:
+			summary.Running++
+		case agentregistryv1alpha1.DeploymentPhasePending:
+			summary.Pending++
+		case agentregistryv1alpha1.DeploymentPhaseFailed:
+			summary.Failed++
+		}
+		for _, ns := range append([]string{d.Spec.Namespace}, d.Spec.Namespaces...) {
+			if ns != "" && !namespaces[ns] {
+				namespaces[ns] = true
+				summary.Namespaces = append(summary.Namespaces, ns)
+			}
+		}
+	}
+	sort.Strings(summary.Namespaces)
+	return summary, nil
+}
+
 // EmptyResponse represents an empty response
 type EmptyResponse struct {
 	Message string `json:"message,omitempty"`
@@ -44,6 +99,23 @@ type ListMetadata struct {
 	Count      int    `json:"count"`
 }
 
+// SortPopularity is the list sort order putting the most deployed entries first
+const SortPopularity = "popularity"
+
+// sortByPopularity orders items by deployment count, most deployed first,
+// keeping the existing order, e.g. search relevance, among equal counts
+func sortByPopularity[T any](items []T, deploymentCount func(*T) int64) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return deploymentCount(&items[i]) > deploymentCount(&items[j])
+	})
+}
+
+// IsFeatured reports whether a catalog entry is featured by the registry
+// curators
+func IsFeatured(obj metav1.Object) bool {
+	return obj.GetAnnotations()[agentregistryv1alpha1.AnnotationFeatured] == "true"
+}
+
 // SanitizeK8sName converts a name to a valid Kubernetes resource name
 func SanitizeK8sName(name string) string {
 	return validation.SanitizeName(name)
@@ -97,3 +169,20 @@ func SetCatalogCondition(conditions []agentregistryv1alpha1.CatalogCondition, co
 		Message:            message,
 	})
 }
+
+// rejectDuplicateEntry returns a 409 when the duplicate policy is reject and
+// an object other than obj already catalogs name and version. list selects
+// the catalog kind.
+func rejectDuplicateEntry(ctx context.Context, reader client.Reader, list client.ObjectList, obj client.Object, name, version string) error {
+	if config.DuplicatePolicy() != config.DuplicatePolicyReject {
+		return nil
+	}
+	err := controller.CheckDuplicateEntry(ctx, reader, list, obj.GetNamespace(), obj.GetName(), name, version)
+	switch {
+	case errors.Is(err, controller.ErrDuplicateEntry):
+		return huma.Error409Conflict("Catalog entry already exists under another name", err)
+	case err != nil:
+		return huma.Error500InternalServerError("Failed to check for duplicate entries", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// Batch item results
+const (
+	BatchItemCreated = "created"
+	BatchItemSkipped = "skipped"
+	BatchItemFailed  = "failed"
+)
+
+// BatchDeploymentItem describes a single deployment in a batch request
+type BatchDeploymentItem struct {
+	ResourceName string            `json:"resourceName"`
+	Version      string            `json:"version"`
+	ResourceType string            `json:"resourceType"`
+	PreferRemote bool              `json:"preferRemote,omitempty"`
+	Config       map[string]string `json:"config,omitempty"`
+	Namespace    string            `json:"namespace,omitempty"`
+	Environment  string            `json:"environment,omitempty"`
+}
+
+type BatchDeploymentsInput struct {
+	Body struct {
+		Items []BatchDeploymentItem `json:"items" minItems:"1" maxItems:"100"`
+	}
+}
+
+// BatchDeploymentResult is the outcome of one item in a batch request
+type BatchDeploymentResult struct {
+	Name         string          `json:"name"`
+	ResourceName string          `json:"resourceName"`
+	Version      string          `json:"version"`
+	Result       string          `json:"result" enum:"created,skipped,failed"`
+	Message      string          `json:"message,omitempty"`
+	Deployment   *DeploymentJSON `json:"deployment,omitempty"`
+}
+
+type BatchDeploymentsResponse struct {
+	Results []BatchDeploymentResult `json:"results"`
+	Created int                     `json:"created"`
+	Skipped int                     `json:"skipped"`
+	Failed  int                     `json:"failed"`
+}
+
+// createDeploymentsBatch creates a RegistryDeployment per item. Items are
+// processed independently: a failure is reported on that item only, and items
+// whose deployment already exists are reported as skipped so that the request
+// can be safely retried.
+func (h *DeploymentHandler) createDeploymentsBatch(ctx context.Context, input *BatchDeploymentsInput) (*Response[BatchDeploymentsResponse], error) {
+	resp := BatchDeploymentsResponse{
+		Results: make([]BatchDeploymentResult, 0, len(input.Body.Items)),
+	}
+
+	for _, item := range input.Body.Items {
+		result := h.createBatchItem(ctx, item)
+		switch result.Result {
+		case BatchItemCreated:
+			resp.Created++
+		case BatchItemSkipped:
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	return &Response[BatchDeploymentsResponse]{Body: resp}, nil
+}
+
+func (h *DeploymentHandler) createBatchItem(ctx context.Context, item BatchDeploymentItem) BatchDeploymentResult {
+	result := BatchDeploymentResult{
+		ResourceName: item.ResourceName,
+		Version:      item.Version,
+		Result:       BatchItemFailed,
+	}
+
+	if item.ResourceName == "" || item.Version == "" {
+		result.Message = "resourceName and version are required"
+		return result
+	}
+	switch agentregistryv1alpha1.ResourceType(item.ResourceType) {
+	case agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.ResourceTypeAgent:
+	default:
+		result.Message = "resourceType must be one of: mcp, agent"
+		return result
+	}
+
+	result.Name = GenerateCRName(item.ResourceName, item.Version)
+
+	var existing agentregistryv1alpha1.RegistryDeployment
+	err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: result.Name}, &existing)
+	if err == nil {
+		result.Result = BatchItemSkipped
+		result.Message = "deployment already exists"
+		return result
+	}
+	if !apierrors.IsNotFound(err) {
+		result.Message = err.Error()
+		return result
+	}
+
+	create := &CreateDeploymentInput{}
+	create.Body.ResourceName = item.ResourceName
+	create.Body.Version = item.Version
+	create.Body.ResourceType = item.ResourceType
+	create.Body.PreferRemote = item.PreferRemote
+	create.Body.Config = item.Config
+	create.Body.Namespace = item.Namespace
+	create.Body.Environment = item.Environment
+
+	created, err := h.createNamedDeployment(ctx, result.Name, create)
+	if err != nil {
+		// Lost a race with a concurrent create of the same name
+		var statusErr huma.StatusError
+		if errors.As(err, &statusErr) && statusErr.GetStatus() == http.StatusConflict {
+			result.Result = BatchItemSkipped
+			result.Message = "deployment already exists"
+			return result
+		}
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Result = BatchItemCreated
+	result.Deployment = &created.Body.Deployment
+	return result
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestDeploymentHandler_CreateDeploymentsBatch_Mixed(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	// Pre-existing deployment that the batch must skip rather than fail on.
+	require.NoError(t, c.Create(ctx, &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("existing-server", "1.0.0"), Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "existing-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+		},
+	}))
+
+	input := &BatchDeploymentsInput{}
+	input.Body.Items = []BatchDeploymentItem{
+		{ResourceName: "io.github.org/server", Version: "1.0.0", ResourceType: "mcp", Namespace: "default", Config: map[string]string{"LOG_LEVEL": "debug"}},
+		{ResourceName: "my-agent", Version: "2.0.0", ResourceType: "agent", Namespace: "prod"},
+		{ResourceName: "existing-server", Version: "1.0.0", ResourceType: "mcp", Namespace: "default"},
+		{ResourceName: "forbidden", Version: "1.0.0", ResourceType: "mcp", Namespace: "kube-system"},
+		{ResourceName: "bad-type", Version: "1.0.0", ResourceType: "skill"},
+		{ResourceName: "", Version: "1.0.0", ResourceType: "mcp"},
+	}
+
+	resp, err := handler.createDeploymentsBatch(ctx, input)
+	require.NoError(t, err, "partial failures must not fail the whole batch")
+	body := resp.Body
+	require.Len(t, body.Results, 6)
+	assert.Equal(t, 2, body.Created)
+	assert.Equal(t, 1, body.Skipped)
+	assert.Equal(t, 3, body.Failed)
+
+	assert.Equal(t, BatchItemCreated, body.Results[0].Result)
+	assert.Equal(t, GenerateCRName("io.github.org/server", "1.0.0"), body.Results[0].Name)
+	require.NotNil(t, body.Results[0].Deployment)
+	assert.Equal(t, "default", body.Results[0].Deployment.Namespace)
+
+	assert.Equal(t, BatchItemCreated, body.Results[1].Result)
+	assert.Equal(t, BatchItemSkipped, body.Results[2].Result)
+	assert.Equal(t, BatchItemFailed, body.Results[3].Result)
+	assert.Contains(t, body.Results[3].Message, "not allowed")
+	assert.Equal(t, BatchItemFailed, body.Results[4].Result)
+	assert.Contains(t, body.Results[4].Message, "resourceType")
+	assert.Equal(t, BatchItemFailed, body.Results[5].Result)
+
+	var created agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: body.Results[0].Name}, &created))
+	assert.Equal(t, "debug", created.Spec.Config["LOG_LEVEL"])
+
+	// Re-submitting the same batch is idempotent: created items are now skipped.
+	resp, err = handler.createDeploymentsBatch(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.Body.Created)
+	assert.Equal(t, 3, resp.Body.Skipped)
+	assert.Equal(t, 3, resp.Body.Failed)
+}
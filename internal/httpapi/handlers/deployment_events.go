@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// Deployment event types
+const (
+	DeploymentEventPhase    = "phase"
+	DeploymentEventResource = "resource"
+	DeploymentEventDeleted  = "deleted"
+)
+
+// deploymentEventsKeepAlive is how often an idle event stream sends a comment
+// so proxies do not close the connection
+const deploymentEventsKeepAlive = 15 * time.Second
+
+// DeploymentEvent is a server-sent event describing a change to a deployment
+// or to one of the resources it manages
+type DeploymentEvent struct {
+	Type          string    `json:"type"`
+	Deployment    string    `json:"deployment"`
+	Phase         string    `json:"phase,omitempty"`
+	PreviousPhase string    `json:"previousPhase,omitempty"`
+	Message       string    `json:"message,omitempty"`
+	Kind          string    `json:"kind,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	Namespace     string    `json:"namespace,omitempty"`
+	Action        string    `json:"action,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// managedResourceKinds are the kinds a RegistryDeployment creates locally
+var managedResourceKinds = []struct {
+	kind string
+	obj  client.Object
+}{
+	{"MCPServer", &kmcpv1alpha1.MCPServer{}},
+	{"RemoteMCPServer", &kagentv1alpha2.RemoteMCPServer{}},
+	{"Agent", &kagentv1alpha2.Agent{}},
+}
+
+// StreamEvents streams the phase transitions of a deployment, and changes to
+// the resources it manages, as server-sent events until the client
+// disconnects or the deployment is deleted. The current phase is sent first.
+func (h *DeploymentHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorResponse(w, NewError(http.StatusInternalServerError, "Streaming is not supported"))
+		return
+	}
+	if h.cache == nil {
+		writeErrorResponse(w, NewError(http.StatusServiceUnavailable, "Deployment events require the informer cache"))
+		return
+	}
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	if err := h.cache.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: name}, &deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeErrorResponse(w, deploymentNotFound())
+			return
+		}
+		writeErrorResponse(w, NewError(http.StatusInternalServerError, "Failed to get deployment", err))
+		return
+	}
+
+	// Informer callbacks must not block, so events are buffered and dropped
+	// when a slow client falls too far behind
+	events := make(chan DeploymentEvent, 64)
+	emit := func(event DeploymentEvent) {
+		event.Deployment = name
+		event.Timestamp = time.Now().UTC()
+		select {
+		case events <- event:
+		default:
+			h.logger.Warn().Str("deployment", name).Str("type", event.Type).Msg("event stream client is too slow, dropping event")
+		}
+	}
+
+	observeDeployment := func(obj interface{}) {
+		d, ok := obj.(*agentregistryv1alpha1.RegistryDeployment)
+		if !ok || d.Name != name || d.Namespace != deployment.Namespace {
+			return
+		}
+		emit(DeploymentEvent{Type: DeploymentEventPhase, Phase: string(d.Status.Phase), Message: d.Status.Message})
+	}
+	if err := h.watch(ctx, &agentregistryv1alpha1.RegistryDeployment{}, toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { observeDeployment(obj) },
+		UpdateFunc: func(_, obj interface{}) { observeDeployment(obj) },
+		DeleteFunc: func(obj interface{}) {
+			if d, ok := deletedObject(obj).(*agentregistryv1alpha1.RegistryDeployment); ok && d.Name == name && d.Namespace == deployment.Namespace {
+				emit(DeploymentEvent{Type: DeploymentEventDeleted})
+			}
+		},
+	}); err != nil {
+		writeErrorResponse(w, NewError(http.StatusInternalServerError, "Failed to watch deployment", err))
+		return
+	}
+
+	for _, managed := range managedResourceKinds {
+		observeResource := func(obj interface{}, action string) {
+			o, ok := obj.(client.Object)
+			if !ok || !controller.IsManagedByDeployment(o, name, deployment.Namespace) {
+				return
+			}
+			emit(DeploymentEvent{
+				Type:      DeploymentEventResource,
+				Kind:      managed.kind,
+				Name:      o.GetName(),
+				Namespace: o.GetNamespace(),
+				Action:    action,
+			})
+		}
+		err := h.watch(ctx, managed.obj, toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { observeResource(obj, "added") },
+			UpdateFunc: func(oldObj, obj interface{}) {
+				// Periodic resyncs deliver unchanged objects
+				if o, ok := oldObj.(client.Object); ok && o.GetResourceVersion() == obj.(client.Object).GetResourceVersion() {
+					return
+				}
+				observeResource(obj, "updated")
+			},
+			DeleteFunc: func(obj interface{}) { observeResource(deletedObject(obj), "deleted") },
+		})
+		if err != nil {
+			// The kind may not be installed in this cluster; phase events
+			// still reflect the state of the managed resources
+			h.logger.Debug().Err(err).Str("kind", managed.kind).Msg("not watching managed resource kind")
+		}
+	}
+
+	// The stream outlives the server's WriteTimeout, which would otherwise
+	// cut it off mid-stream
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Warn().Err(err).Str("deployment", name).Msg("failed to clear write deadline of event stream")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	lastPhase := string(deployment.Status.Phase)
+	if err := writeDeploymentEvent(w, DeploymentEvent{
+		Type:       DeploymentEventPhase,
+		Deployment: name,
+		Phase:      lastPhase,
+		Message:    deployment.Status.Message,
+		Timestamp:  time.Now().UTC(),
+	}); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(deploymentEventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event := <-events:
+			if event.Type == DeploymentEventPhase {
+				// Only transitions are reported, not every status write
+				if event.Phase == lastPhase {
+					continue
+				}
+				event.PreviousPhase = lastPhase
+				lastPhase = event.Phase
+			}
+			if err := writeDeploymentEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+			if event.Type == DeploymentEventDeleted {
+				return
+			}
+		}
+	}
+}
+
+// watch registers handler with the cache informer for obj's kind and removes
+// it again once ctx is done
+func (h *DeploymentHandler) watch(ctx context.Context, obj client.Object, handler toolscache.ResourceEventHandler) error {
+	informer, err := h.cache.GetInformer(ctx, obj)
+	if err != nil {
+		return err
+	}
+	registration, err := informer.AddEventHandler(handler)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = informer.RemoveEventHandler(registration)
+	}()
+	return nil
+}
+
+// deletedObject unwraps the tombstone informers deliver when a delete was
+// missed while disconnected
+func deletedObject(obj interface{}) interface{} {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
+
+func writeDeploymentEvent(w http.ResponseWriter, event DeploymentEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// writeErrorResponse writes err as the structured API error body for
+// handlers served outside huma
+func writeErrorResponse(w http.ResponseWriter, err error) {
+	var resp *ErrorResponse
+	if !errors.As(err, &resp) {
+		resp = newCodedError(http.StatusInternalServerError, CodeInternal, err.Error())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.GetStatus())
+	json.NewEncoder(w).Encode(resp)
+}
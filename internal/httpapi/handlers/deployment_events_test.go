@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// eventsTestCache serves informers from FakeInformers, whose events the test
+// triggers by hand, and reads from a fake client. It also backs other
+// handler tests that read from the cache.
+type eventsTestCache struct {
+	*informertest.FakeInformers
+	reader client.Reader
+}
+
+func (c *eventsTestCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *eventsTestCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func TestDeploymentHandler_StreamEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kagentv1alpha2.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-deploy", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "org/search",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+		},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{Phase: agentregistryv1alpha1.DeploymentPhasePending},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	h := NewDeploymentHandler(c, &eventsTestCache{FakeInformers: informers, reader: c}, zerolog.Nop())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v0/deployments/{name}/events", h.StreamEvents)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v0/deployments/missing/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v0/deployments/search-deploy/events", nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	next := func() DeploymentEvent {
+		t.Helper()
+		var event DeploymentEvent
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				require.NoError(t, json.Unmarshal([]byte(data), &event))
+				return event
+			}
+		}
+		t.Fatalf("stream ended: %v", scanner.Err())
+		return event
+	}
+
+	// The current phase is sent once the handlers are registered
+	event := next()
+	assert.Equal(t, DeploymentEventPhase, event.Type)
+	assert.Equal(t, "Pending", event.Phase)
+
+	deploymentInformer, err := informers.FakeInformerFor(ctx, &agentregistryv1alpha1.RegistryDeployment{})
+	require.NoError(t, err)
+	mcpInformer, err := informers.FakeInformerFor(ctx, &kmcpv1alpha1.MCPServer{})
+	require.NoError(t, err)
+
+	// Resources of other deployments are ignored
+	mcpInformer.Add(&kmcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kagent"}})
+	mcpInformer.Add(&kmcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{
+		Name:      "search",
+		Namespace: "kagent",
+		Labels: map[string]string{
+			"agentregistry.dev/managed-by":           "agentregistry",
+			"agentregistry.dev/deployment-name":      "search-deploy",
+			"agentregistry.dev/deployment-namespace": "agentregistry",
+		},
+	}})
+	event = next()
+	assert.Equal(t, DeploymentEventResource, event.Type)
+	assert.Equal(t, "MCPServer", event.Kind)
+	assert.Equal(t, "search", event.Name)
+	assert.Equal(t, "added", event.Action)
+
+	// A status write without a phase change is not reported
+	running := deployment.DeepCopy()
+	running.Status.Message = "still pending"
+	deploymentInformer.Update(deployment, running)
+	running = running.DeepCopy()
+	running.Status.Phase = agentregistryv1alpha1.DeploymentPhaseRunning
+	deploymentInformer.Update(deployment, running)
+
+	event = next()
+	assert.Equal(t, DeploymentEventPhase, event.Type)
+	assert.Equal(t, "Running", event.Phase)
+	assert.Equal(t, "Pending", event.PreviousPhase)
+	assert.Equal(t, "search-deploy", event.Deployment)
+
+	// Deleting the deployment ends the stream
+	deploymentInformer.Delete(running)
+	event = next()
+	assert.Equal(t, DeploymentEventDeleted, event.Type)
+	for scanner.Scan() {
+		assert.NotContains(t, scanner.Text(), "data:")
+	}
+}
+
+func TestDeploymentHandler_StreamEvents_OutlivesWriteTimeout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-deploy", Namespace: "agentregistry"},
+		Status:     agentregistryv1alpha1.RegistryDeploymentStatus{Phase: agentregistryv1alpha1.DeploymentPhasePending},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	h := NewDeploymentHandler(c, &eventsTestCache{FakeInformers: informers, reader: c}, zerolog.Nop())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v0/deployments/{name}/events", h.StreamEvents)
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v0/deployments/search-deploy/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	events := make(chan string)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				events <- data
+			}
+		}
+	}()
+	next := func() DeploymentEvent {
+		t.Helper()
+		var event DeploymentEvent
+		select {
+		case data, ok := <-events:
+			require.True(t, ok, "stream ended")
+			require.NoError(t, json.Unmarshal([]byte(data), &event))
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+		return event
+	}
+	assert.Equal(t, "Pending", next().Phase)
+
+	// An event sent well past the server's WriteTimeout still arrives
+	time.Sleep(300 * time.Millisecond)
+	deploymentInformer, err := informers.FakeInformerFor(ctx, &agentregistryv1alpha1.RegistryDeployment{})
+	require.NoError(t, err)
+	running := deployment.DeepCopy()
+	running.Status.Phase = agentregistryv1alpha1.DeploymentPhaseRunning
+	deploymentInformer.Update(deployment, running)
+	assert.Equal(t, "Running", next().Phase)
+}
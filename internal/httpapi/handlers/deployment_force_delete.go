@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/danielgtaylor/huma/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+type ForceDeleteDeploymentInput struct {
+	DeploymentName string `path:"deploymentName" json:"deploymentName"`
+	Confirm        bool   `query:"confirm" json:"confirm,omitempty" doc:"Must be true; managed resources that cannot be deleted are left behind"`
+}
+
+// ForceDeleteDeploymentResponse lists the managed resources a forced deletion
+// left behind in the target cluster
+type ForceDeleteDeploymentResponse struct {
+	Message  string                                  `json:"message"`
+	Orphaned []agentregistryv1alpha1.ManagedResource `json:"orphaned,omitempty"`
+}
+
+// forceDeleteDeployment removes the finalizer of a deployment that is stuck
+// deleting, after a best-effort cleanup of its managed resources
+func (h *DeploymentHandler) forceDeleteDeployment(ctx context.Context, input *ForceDeleteDeploymentInput) (*Response[ForceDeleteDeploymentResponse], error) {
+	deploymentName, err := url.PathUnescape(input.DeploymentName)
+	if err != nil {
+		return nil, invalidName("Invalid deployment name encoding", err)
+	}
+	if !input.Confirm {
+		return nil, huma.Error400BadRequest("Force delete may orphan managed resources; pass confirm=true to proceed")
+	}
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, deploymentNotFound()
+		}
+		return nil, huma.Error500InternalServerError("Failed to get deployment", err)
+	}
+
+	orphaned, err := controller.ForceDeleteDeployment(ctx, h.client, h.logger, &deployment)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to force delete deployment", err)
+	}
+
+	h.logger.Warn().Bool("audit", true).
+		Str("action", "force-delete").
+		Str("deployment", deploymentName).
+		Interface("orphaned", orphaned).
+		Msg("deployment force deleted")
+
+	return &Response[ForceDeleteDeploymentResponse]{
+		Body: ForceDeleteDeploymentResponse{
+			Message:  "Deployment force deleted",
+			Orphaned: orphaned,
+		},
+	}, nil
+}
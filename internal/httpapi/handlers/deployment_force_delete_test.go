@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestDeploymentHandler_ForceDeleteDeployment(t *testing.T) {
+	orphan := agentregistryv1alpha1.ManagedResource{APIVersion: "kagent.dev/v1alpha1", Kind: "MCPServer", Name: "my-server", Namespace: "prod", Cluster: "gone"}
+	now := metav1.Now()
+	// The deployment targets an environment that no longer exists, so its
+	// finalizer can never clean up the managed resources
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "my-server-1-0-0",
+			Namespace:         "agentregistry",
+			Finalizers:        []string{"agentregistry.dev/finalizer"},
+			DeletionTimestamp: &now,
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "my-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Environment:  "gone",
+		},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{
+			ManagedResources: []agentregistryv1alpha1.ManagedResource{orphan},
+		},
+	}
+	c := setupDeploymentTestClient(t, deployment)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	// confirm=true is required
+	_, err := handler.forceDeleteDeployment(ctx, &ForceDeleteDeploymentInput{DeploymentName: "my-server-1-0-0"})
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusBadRequest, resp.GetStatus())
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(deployment), &agentregistryv1alpha1.RegistryDeployment{}))
+
+	result, err := handler.forceDeleteDeployment(ctx, &ForceDeleteDeploymentInput{DeploymentName: "my-server-1-0-0", Confirm: true})
+	require.NoError(t, err)
+	assert.Equal(t, []agentregistryv1alpha1.ManagedResource{orphan}, result.Body.Orphaned)
+
+	err = c.Get(ctx, client.ObjectKeyFromObject(deployment), &agentregistryv1alpha1.RegistryDeployment{})
+	assert.True(t, apierrors.IsNotFound(err), "expected deployment to be gone, got %v", err)
+
+	// The record of the orphaned resources outlives the deployment
+	recorded, err := controller.ListOrphanedResources(ctx, c, "agentregistry", "my-server-1-0-0")
+	require.NoError(t, err)
+	assert.Equal(t, []agentregistryv1alpha1.ManagedResource{orphan}, recorded)
+
+	_, err = handler.forceDeleteDeployment(ctx, &ForceDeleteDeploymentInput{DeploymentName: "my-server-1-0-0", Confirm: true})
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusNotFound, resp.GetStatus())
+}
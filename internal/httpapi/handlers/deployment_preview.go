@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	sigyaml "sigs.k8s.io/yaml"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// PreviewResource identifies one rendered Kubernetes resource
+type PreviewResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// DeploymentPreviewResponse holds the manifests a deployment would apply
+type DeploymentPreviewResponse struct {
+	Resources []PreviewResource `json:"resources"`
+	// Manifests is a multi-document YAML stream of the rendered resources
+	Manifests string `json:"manifests"`
+}
+
+func (h *DeploymentHandler) previewDeployment(ctx context.Context, input *CreateDeploymentInput) (*Response[DeploymentPreviewResponse], error) {
+	if input.Body.ResourceName == "" || input.Body.Version == "" {
+		return nil, huma.Error400BadRequest("resourceName and version are required")
+	}
+
+	deployment, err := NewRegistryDeployment(GenerateCRName(input.Body.ResourceName, input.Body.Version), input)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := PreviewDeployment(ctx, h.reader(), deployment)
+	if err != nil {
+		switch {
+		case errors.Is(err, controller.ErrPreviewCatalogNotFound):
+			return nil, catalogNotFound("Catalog entry not found", err)
+		case errors.Is(err, controller.ErrPreviewInvalid):
+			return nil, huma.Error400BadRequest("Failed to render deployment", err)
+		default:
+			return nil, huma.Error500InternalServerError("Failed to render deployment", err)
+		}
+	}
+
+	return &Response[DeploymentPreviewResponse]{Body: *preview}, nil
+}
+
+// PreviewDeployment renders the Kubernetes resources deployment would create
+// and serializes them as YAML. Nothing is applied. Errors wrap
+// controller.ErrPreviewCatalogNotFound or controller.ErrPreviewInvalid where
+// applicable.
+func PreviewDeployment(ctx context.Context, reader client.Reader, deployment *agentregistryv1alpha1.RegistryDeployment) (*DeploymentPreviewResponse, error) {
+	objs, err := controller.RenderDeployment(ctx, reader, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &DeploymentPreviewResponse{Resources: make([]PreviewResource, 0, len(objs))}
+	docs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		preview.Resources = append(preview.Resources, PreviewResource{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+		})
+
+		doc, err := sigyaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(doc))
+	}
+	preview.Manifests = strings.Join(docs, "---\n")
+	return preview, nil
+}
+
+// reader returns the cache when available, otherwise the client
+func (h *DeploymentHandler) reader() client.Reader {
+	if h.cache != nil {
+		return h.cache
+	}
+	return h.client
+}
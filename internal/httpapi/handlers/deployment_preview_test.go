@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// verifiedPublisher is catalog metadata that passes the deployment publisher check.
+var verifiedPublisher = &apiextensionsv1.JSON{Raw: []byte(`{"io.modelcontextprotocol.registry/publisher-provided":{"aregistry.ai/metadata":{"identity":{"org_is_verified":true,"publisher_identity_verified_by_jwt":true}}}}`)}
+
+func newPreviewInput(resourceName string) *CreateDeploymentInput {
+	input := &CreateDeploymentInput{}
+	input.Body.ResourceName = resourceName
+	input.Body.Version = "1.0.0"
+	input.Body.ResourceType = "mcp"
+	input.Body.Namespace = "default"
+	return input
+}
+
+func TestDeploymentHandler_PreviewDeployment_RemoteServer(t *testing.T) {
+	c := setupDeploymentTestClient(t, &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/remote", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:     "org/remote",
+			Version:  "1.0.0",
+			Remotes:  []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "https://mcp.example.com/mcp"}},
+			Metadata: verifiedPublisher,
+		},
+	})
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.previewDeployment(context.Background(), newPreviewInput("org/remote"))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Body.Resources, 1)
+	assert.Equal(t, "RemoteMCPServer", resp.Body.Resources[0].Kind)
+	assert.Equal(t, "default", resp.Body.Resources[0].Namespace)
+	assert.Contains(t, resp.Body.Manifests, "kind: RemoteMCPServer")
+	assert.Contains(t, resp.Body.Manifests, "mcp.example.com")
+
+	// Nothing is created by a preview.
+	var deployments agentregistryv1alpha1.RegistryDeploymentList
+	require.NoError(t, c.List(context.Background(), &deployments))
+	assert.Empty(t, deployments.Items)
+}
+
+func TestDeploymentHandler_PreviewDeployment_LocalNpmServer(t *testing.T) {
+	c := setupDeploymentTestClient(t, &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/local", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/local",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{{
+				RegistryType: "npm",
+				Identifier:   "@org/local-server",
+				Version:      "1.0.0",
+				Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+			}},
+			Metadata: verifiedPublisher,
+		},
+	})
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	input := newPreviewInput("org/local")
+	input.Body.ResourceLabels = map[string]string{"team": "platform"}
+	resp, err := handler.previewDeployment(context.Background(), input)
+	require.NoError(t, err)
+
+	require.Len(t, resp.Body.Resources, 1)
+	assert.Equal(t, "MCPServer", resp.Body.Resources[0].Kind)
+	assert.Contains(t, resp.Body.Manifests, "kind: MCPServer")
+	assert.Contains(t, resp.Body.Manifests, "@org/local-server")
+	assert.Contains(t, resp.Body.Manifests, "team: platform")
+}
+
+func TestDeploymentHandler_PreviewDeployment_AgentWithRegistryServer(t *testing.T) {
+	c := setupDeploymentTestClient(t,
+		&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/search", "1.0.0")},
+			Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+				Name:     "org/search",
+				Version:  "1.0.0",
+				Remotes:  []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "https://search.example.com/mcp"}},
+				Metadata: verifiedPublisher,
+			},
+		},
+		&agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/researcher", "1.0.0")},
+			Spec: agentregistryv1alpha1.AgentCatalogSpec{
+				Name:    "org/researcher",
+				Version: "1.0.0",
+				Image:   "ghcr.io/org/researcher:1.0.0",
+				McpServers: []agentregistryv1alpha1.McpServerConfig{
+					{Type: "registry", Name: "search", RegistryServerName: "org/search", RegistryServerVersion: "1.0.0"},
+				},
+				Metadata: verifiedPublisher,
+			},
+		},
+	)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	input := newPreviewInput("org/researcher")
+	input.Body.ResourceType = "agent"
+	resp, err := handler.previewDeployment(context.Background(), input)
+	require.NoError(t, err)
+
+	var kinds []string
+	for _, res := range resp.Body.Resources {
+		kinds = append(kinds, res.Kind)
+	}
+	assert.Contains(t, kinds, "Agent")
+	assert.Contains(t, resp.Body.Manifests, "search.example.com", "the referenced server is resolved from the catalog")
+}
+
+func TestDeploymentHandler_PreviewDeployment_RequiresSBOM(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_REQUIRE_SBOM_ATTESTATION", "true")
+	c := setupDeploymentTestClient(t, &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/remote", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:     "org/remote",
+			Version:  "1.0.0",
+			Remotes:  []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "https://mcp.example.com/mcp"}},
+			Metadata: verifiedPublisher,
+		},
+	})
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	_, err := handler.previewDeployment(context.Background(), newPreviewInput("org/remote"))
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp), "expected an API error, got %v", err)
+	assert.Equal(t, http.StatusBadRequest, resp.GetStatus())
+	require.NotEmpty(t, resp.Details)
+	assert.Contains(t, resp.Details[0], "no valid SBOM attestation")
+}
+
+func TestDeploymentHandler_PreviewDeployment_Errors(t *testing.T) {
+	c := setupDeploymentTestClient(t, &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/empty", "1.0.0")},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/empty", Version: "1.0.0", Metadata: verifiedPublisher},
+	})
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	status := func(err error) int {
+		var statusErr huma.StatusError
+		require.True(t, errors.As(err, &statusErr))
+		return statusErr.GetStatus()
+	}
+	detail := func(err error) string {
+		var resp *ErrorResponse
+		require.True(t, errors.As(err, &resp))
+		require.NotEmpty(t, resp.Details)
+		return resp.Details[0]
+	}
+
+	_, err := handler.previewDeployment(ctx, newPreviewInput("org/missing"))
+	assert.Equal(t, http.StatusNotFound, status(err))
+
+	// A server with neither packages nor remotes cannot be translated.
+	_, err = handler.previewDeployment(ctx, newPreviewInput("org/empty"))
+	assert.Equal(t, http.StatusBadRequest, status(err))
+	assert.Contains(t, detail(err), "no packages available")
+}
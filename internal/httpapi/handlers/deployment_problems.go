@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// Deployment problem severities, most severe first
+const (
+	ProblemSeverityCritical = "critical"
+	ProblemSeverityError    = "error"
+	ProblemSeverityWarning  = "warning"
+)
+
+var problemSeverityRank = map[string]int{
+	ProblemSeverityCritical: 0,
+	ProblemSeverityError:    1,
+	ProblemSeverityWarning:  2,
+}
+
+// DeploymentProblem is a deployment that is not running cleanly
+type DeploymentProblem struct {
+	Name              string                                   `json:"name"`
+	ResourceName      string                                   `json:"resourceName"`
+	Version           string                                   `json:"version"`
+	ResourceType      string                                   `json:"resourceType"`
+	Namespace         string                                   `json:"namespace,omitempty"`
+	Namespaces        []string                                 `json:"namespaces,omitempty"`
+	Environment       string                                   `json:"environment,omitempty"`
+	Phase             string                                   `json:"phase"`
+	Severity          string                                   `json:"severity" enum:"critical,error,warning"`
+	Message           string                                   `json:"message,omitempty"`
+	Deleting          bool                                     `json:"deleting,omitempty"`
+	FailingConditions []agentregistryv1alpha1.CatalogCondition `json:"failingConditions,omitempty"`
+	Since             time.Time                                `json:"since" doc:"When the deployment last changed state"`
+}
+
+// DeploymentProblemsResponse lists the deployments needing attention, most
+// severe and oldest first
+type DeploymentProblemsResponse struct {
+	Problems []DeploymentProblem `json:"problems"`
+	Total    int                 `json:"total"`
+}
+
+// registerProblemsRoute registers the admin endpoint listing broken deployments
+func (h *DeploymentHandler) registerProblemsRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-deployment-problems" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/deployments/problems",
+		Summary:     "List deployments that are failed, pending, deleting or have failing conditions",
+		Tags:        tags,
+	}, func(ctx context.Context, input *struct{}) (*Response[DeploymentProblemsResponse], error) {
+		return h.listDeploymentProblems(ctx)
+	})
+}
+
+func (h *DeploymentHandler) listDeploymentProblems(ctx context.Context) (*Response[DeploymentProblemsResponse], error) {
+	var list agentregistryv1alpha1.RegistryDeploymentList
+	if err := h.cache.List(ctx, &list); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to list deployments", err)
+	}
+	problems := DeploymentProblems(list.Items)
+	return &Response[DeploymentProblemsResponse]{
+		Body: DeploymentProblemsResponse{Problems: problems, Total: len(problems)},
+	}, nil
+}
+
+// DeploymentProblems returns the deployments that are not cleanly Running,
+// sorted by severity and then by how long they have been in their state.
+// Failed deployments are critical, partially deployed ones errors, and
+// pending or deleting deployments, or Running ones with a failing condition,
+// warnings.
+func DeploymentProblems(deployments []agentregistryv1alpha1.RegistryDeployment) []DeploymentProblem {
+	problems := make([]DeploymentProblem, 0)
+	for _, d := range deployments {
+		var failing []agentregistryv1alpha1.CatalogCondition
+		for _, c := range d.Status.Conditions {
+			if isFailingCondition(c) {
+				failing = append(failing, c)
+			}
+		}
+		deleting := !d.DeletionTimestamp.IsZero()
+
+		var severity string
+		switch {
+		case d.Status.Phase == agentregistryv1alpha1.DeploymentPhaseFailed:
+			severity = ProblemSeverityCritical
+		case d.Status.Phase == agentregistryv1alpha1.DeploymentPhasePartiallyDeployed:
+			severity = ProblemSeverityError
+		case d.Status.Phase == agentregistryv1alpha1.DeploymentPhasePaused && !deleting && len(failing) == 0:
+			// Paused on purpose
+			continue
+		case d.Status.Phase != agentregistryv1alpha1.DeploymentPhaseRunning, deleting, len(failing) > 0:
+			severity = ProblemSeverityWarning
+		default:
+			continue
+		}
+
+		since := d.CreationTimestamp.Time
+		if d.Status.UpdatedAt != nil {
+			since = d.Status.UpdatedAt.Time
+		}
+		if deleting {
+			since = d.DeletionTimestamp.Time
+		}
+		phase := string(d.Status.Phase)
+		if phase == "" {
+			phase = string(agentregistryv1alpha1.DeploymentPhasePending)
+		}
+		problems = append(problems, DeploymentProblem{
+			Name:              d.Name,
+			ResourceName:      d.Spec.ResourceName,
+			Version:           d.Spec.Version,
+			ResourceType:      string(d.Spec.ResourceType),
+			Namespace:         d.Spec.Namespace,
+			Namespaces:        d.Spec.Namespaces,
+			Environment:       d.Spec.Environment,
+			Phase:             phase,
+			Severity:          severity,
+			Message:           d.Status.Message,
+			Deleting:          deleting,
+			FailingConditions: failing,
+			Since:             since,
+		})
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i], problems[j]
+		if ra, rb := problemSeverityRank[a.Severity], problemSeverityRank[b.Severity]; ra != rb {
+			return ra < rb
+		}
+		if !a.Since.Equal(b.Since) {
+			return a.Since.Before(b.Since)
+		}
+		return a.Name < b.Name
+	})
+	return problems
+}
+
+// isFailingCondition reports whether a deployment condition signals a
+// problem. Most conditions fail when False; the ones naming a problem, such
+// as UnresolvedMCPServers, CatalogMissing or FluxUnavailable, fail when True.
+func isFailingCondition(c agentregistryv1alpha1.CatalogCondition) bool {
+	switch c.Type {
+	case agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers, agentregistryv1alpha1.CatalogConditionCatalogMissing,
+		agentregistryv1alpha1.CatalogConditionFluxUnavailable:
+		return c.Status == metav1.ConditionTrue
+	}
+	return c.Status == metav1.ConditionFalse
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestDeploymentHandler_ListDeploymentProblems(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	at := func(ago time.Duration) *metav1.Time {
+		ts := metav1.NewTime(now.Add(-ago))
+		return &ts
+	}
+	withUpdate := func(d *agentregistryv1alpha1.RegistryDeployment, ago time.Duration, message string) *agentregistryv1alpha1.RegistryDeployment {
+		d.Status.UpdatedAt = at(ago)
+		d.Status.Message = message
+		return d
+	}
+
+	degraded := newReferencingDeployment("degraded", "org/degraded", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning)
+	degraded.Status.UpdatedAt = at(time.Minute)
+	degraded.Status.Conditions = []agentregistryv1alpha1.CatalogCondition{
+		{Type: agentregistryv1alpha1.CatalogConditionReady, Status: metav1.ConditionFalse, Reason: "Unavailable"},
+		{Type: agentregistryv1alpha1.CatalogConditionPublished, Status: metav1.ConditionTrue},
+	}
+	unresolved := newReferencingDeployment("unresolved", "org/agent", "1.0.0", agentregistryv1alpha1.ResourceTypeAgent, agentregistryv1alpha1.DeploymentPhaseRunning)
+	unresolved.Status.UpdatedAt = at(30 * time.Second)
+	unresolved.Status.Conditions = []agentregistryv1alpha1.CatalogCondition{
+		{Type: agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers, Status: metav1.ConditionTrue, Message: "org/missing: not found in catalog"},
+	}
+	failedNew := withUpdate(newReferencingDeployment("failed-new", "org/a", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseFailed), time.Minute, "image pull failed")
+	failedNew.Spec.Namespace = ""
+	failedNew.Spec.Namespaces = []string{"team-a", "team-b"}
+	deleting := newReferencingDeployment("deleting", "org/deleting", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning)
+	deleting.DeletionTimestamp = at(3 * time.Hour)
+	deleting.Finalizers = []string{"agentregistry.dev/finalizer"}
+
+	c := setupDeploymentTestClient(t,
+		withUpdate(newReferencingDeployment("healthy", "org/healthy", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning), time.Hour, ""),
+		failedNew,
+		withUpdate(newReferencingDeployment("failed-old", "org/b", "1.0.0", agentregistryv1alpha1.ResourceTypeAgent, agentregistryv1alpha1.DeploymentPhaseFailed), 2*time.Hour, "catalog entry not found"),
+		withUpdate(newReferencingDeployment("partial", "org/c", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhasePartiallyDeployed), 5*time.Minute, "applied 1 of 2 resources"),
+		withUpdate(newReferencingDeployment("pending", "org/d", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhasePending), 10*time.Minute, ""),
+		withUpdate(newReferencingDeployment("paused", "org/f", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhasePaused), time.Hour, ""),
+		newReferencingDeployment("new", "org/e", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, ""),
+		degraded,
+		unresolved,
+		deleting,
+	)
+	h := NewDeploymentHandler(c, &eventsTestCache{reader: c}, zerolog.Nop())
+
+	resp, err := h.listDeploymentProblems(context.Background())
+	require.NoError(t, err)
+	problems := resp.Body.Problems
+	require.Equal(t, 8, resp.Body.Total)
+
+	var names, severities []string
+	for _, p := range problems {
+		names = append(names, p.Name)
+		severities = append(severities, p.Severity)
+	}
+	// Most severe first, then the longest in their state; "new" has never
+	// been reconciled and falls back to its zero creation time
+	assert.Equal(t, []string{"failed-old", "failed-new", "partial", "new", "deleting", "pending", "degraded", "unresolved"}, names)
+	assert.Equal(t, []string{"critical", "critical", "error", "warning", "warning", "warning", "warning", "warning"}, severities)
+
+	assert.Equal(t, "catalog entry not found", problems[0].Message)
+	assert.Equal(t, "agent", problems[0].ResourceType)
+	assert.True(t, problems[0].Since.Equal(now.Add(-2*time.Hour)))
+	// A deployment into several namespaces reports all of them
+	assert.Empty(t, problems[1].Namespace)
+	assert.Equal(t, []string{"team-a", "team-b"}, problems[1].Namespaces)
+	assert.Equal(t, "Pending", problems[3].Phase)
+	assert.True(t, problems[4].Deleting)
+	assert.Equal(t, "Running", problems[4].Phase)
+	require.Len(t, problems[6].FailingConditions, 1)
+	assert.Equal(t, "Unavailable", problems[6].FailingConditions[0].Reason)
+	// Conditions naming a problem fail when True
+	require.Len(t, problems[7].FailingConditions, 1)
+	assert.Equal(t, agentregistryv1alpha1.CatalogConditionUnresolvedMCPServers, problems[7].FailingConditions[0].Type)
+}
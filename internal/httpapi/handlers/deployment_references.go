@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
+)
+
+type ServerDeploymentsInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+}
+
+type AgentDeploymentsInput struct {
+	AgentName string `path:"agentName" json:"agentName"`
+}
+
+type ServerVersionDeploymentsInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+	Version    string `path:"version" json:"version"`
+}
+
+type AgentVersionDeploymentsInput struct {
+	AgentName string `path:"agentName" json:"agentName"`
+	Version   string `path:"version" json:"version"`
+}
+
+// registerReferenceRoutes registers the endpoints listing the deployments
+// created from a catalog entry
+func (h *DeploymentHandler) registerReferenceRoutes(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-deployments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/deployments",
+		Summary:     "List deployments of a server across all versions",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerDeploymentsInput) (*Response[DeploymentListResponse], error) {
+		return h.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeMCP, input.ServerName, "", "server")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-version-deployments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/deployments",
+		Summary:     "List deployments of a server version",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerVersionDeploymentsInput) (*Response[DeploymentListResponse], error) {
+		return h.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeMCP, input.ServerName, input.Version, "server")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-agent-deployments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/deployments",
+		Summary:     "List deployments of an agent across all versions",
+		Tags:        tags,
+	}, func(ctx context.Context, input *AgentDeploymentsInput) (*Response[DeploymentListResponse], error) {
+		return h.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeAgent, input.AgentName, "", "agent")
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-agent-version-deployments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}/deployments",
+		Summary:     "List deployments of an agent version",
+		Tags:        tags,
+	}, func(ctx context.Context, input *AgentVersionDeploymentsInput) (*Response[DeploymentListResponse], error) {
+		return h.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeAgent, input.AgentName, input.Version, "agent")
+	})
+}
+
+// listReferencingDeployments lists the deployments of a catalog entry, of all
+// its versions when rawVersion is empty
+func (h *DeploymentHandler) listReferencingDeployments(ctx context.Context, resourceType agentregistryv1alpha1.ResourceType, rawName, rawVersion, kind string) (*Response[DeploymentListResponse], error) {
+	name, err := url.PathUnescape(rawName)
+	if err != nil {
+		return nil, invalidName("Invalid "+kind+" name encoding", err)
+	}
+	version, err := url.PathUnescape(rawVersion)
+	if err != nil {
+		return nil, invalidVersion("Invalid version encoding", err)
+	}
+
+	items, err := ListReferencingDeployments(ctx, h.reader(), resourceType, name)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to list deployments", err)
+	}
+	if version != "" {
+		items = DeploymentsOfVersion(items, version)
+	}
+
+	deployments := make([]DeploymentJSON, 0, len(items))
+	for i := range items {
+		deployments = append(deployments, h.convertToDeploymentJSON(&items[i]))
+	}
+
+	return &Response[DeploymentListResponse]{
+		Body: DeploymentListResponse{
+			Deployments: deployments,
+			Metadata:    ListMetadata{Count: len(deployments)},
+		},
+	}, nil
+}
+
+// ListReferencingDeployments returns the RegistryDeployments created from any
+// version of the named catalog entry, newest version first.
+func ListReferencingDeployments(ctx context.Context, reader client.Reader, resourceType agentregistryv1alpha1.ResourceType, name string) ([]agentregistryv1alpha1.RegistryDeployment, error) {
+	var list agentregistryv1alpha1.RegistryDeploymentList
+	if err := reader.List(ctx, &list, client.MatchingFields{
+		controller.IndexDeploymentResourceName: name,
+	}); err != nil {
+		return nil, err
+	}
+
+	deployments := make([]agentregistryv1alpha1.RegistryDeployment, 0, len(list.Items))
+	for _, d := range list.Items {
+		if d.Spec.ResourceType == resourceType {
+			deployments = append(deployments, d)
+		}
+	}
+	sort.SliceStable(deployments, func(i, j int) bool {
+		if c := semver.Compare(deployments[i].Spec.Version, deployments[j].Spec.Version); c != 0 {
+			return c > 0
+		}
+		return deployments[i].Name < deployments[j].Name
+	})
+	return deployments, nil
+}
+
+// DeploymentsOfVersion filters deployments down to those of a single catalog
+// entry version
+func DeploymentsOfVersion(deployments []agentregistryv1alpha1.RegistryDeployment, version string) []agentregistryv1alpha1.RegistryDeployment {
+	matching := make([]agentregistryv1alpha1.RegistryDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		if d.Spec.Version == version {
+			matching = append(matching, d)
+		}
+	}
+	return matching
+}
+
+// ActiveDeployments filters out deployments that are already being deleted
+func ActiveDeployments(deployments []agentregistryv1alpha1.RegistryDeployment) []agentregistryv1alpha1.RegistryDeployment {
+	active := make([]agentregistryv1alpha1.RegistryDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		if d.DeletionTimestamp.IsZero() {
+			active = append(active, d)
+		}
+	}
+	return active
+}
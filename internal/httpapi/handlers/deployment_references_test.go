@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func newReferencingDeployment(name, resourceName, version string, resourceType agentregistryv1alpha1.ResourceType, phase agentregistryv1alpha1.DeploymentPhase) *agentregistryv1alpha1.RegistryDeployment {
+	return &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: resourceName,
+			Version:      version,
+			ResourceType: resourceType,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "default",
+		},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{Phase: phase},
+	}
+}
+
+func TestDeploymentHandler_ListReferencingDeployments(t *testing.T) {
+	c := setupDeploymentTestClient(t,
+		newReferencingDeployment("search-1-0-0", "org/search", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning),
+		newReferencingDeployment("search-1-10-0", "org/search", "1.10.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseFailed),
+		newReferencingDeployment("search-agent", "org/search", "1.0.0", agentregistryv1alpha1.ResourceTypeAgent, agentregistryv1alpha1.DeploymentPhaseRunning),
+		newReferencingDeployment("other-1-0-0", "org/other", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning),
+	)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := handler.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeMCP, "org%2Fsearch", "", "server")
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Body.Metadata.Count)
+	// Newest version first, across all versions of the entry
+	assert.Equal(t, "1.10.0", resp.Body.Deployments[0].Version)
+	assert.Equal(t, "Failed", resp.Body.Deployments[0].Status)
+	assert.Equal(t, "1.0.0", resp.Body.Deployments[1].Version)
+	assert.Equal(t, "Running", resp.Body.Deployments[1].Status)
+
+	resp, err = handler.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeAgent, "org/search", "", "agent")
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Deployments, 1)
+	assert.Equal(t, "agent", resp.Body.Deployments[0].ResourceType)
+
+	// A single version
+	resp, err = handler.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeMCP, "org/search", "1.0.0", "server")
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Deployments, 1)
+	assert.Equal(t, "search-1-0-0", resp.Body.Deployments[0].Name)
+	assert.Equal(t, "default", resp.Body.Deployments[0].Namespace)
+	assert.Equal(t, "Running", resp.Body.Deployments[0].Status)
+
+	resp, err = handler.listReferencingDeployments(ctx, agentregistryv1alpha1.ResourceTypeMCP, "org/unused", "", "server")
+	require.NoError(t, err)
+	assert.Empty(t, resp.Body.Deployments)
+}
+
+func TestActiveDeployments(t *testing.T) {
+	running := newReferencingDeployment("a", "org/search", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning)
+	deleting := newReferencingDeployment("b", "org/search", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning)
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	active := ActiveDeployments([]agentregistryv1alpha1.RegistryDeployment{*running, *deleting})
+	require.Len(t, active, 1)
+	assert.Equal(t, "a", active[0].Name)
+}
+
+func TestServerHandler_DeleteServerVersion_ActiveDeployments(t *testing.T) {
+	entry := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "1.0.0"},
+	}
+	unused := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-2-0-0", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "2.0.0"},
+	}
+	c := setupDeploymentTestClient(t, entry, unused,
+		newReferencingDeployment("search-1-0-0", "org/search", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	// Blocked while a deployment of the version is active
+	_, err := handler.deleteServerVersion(ctx, &DeleteServerVersionInput{ServerName: "org%2Fsearch", Version: "1.0.0"})
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusConflict, resp.GetStatus())
+	assert.Contains(t, resp.Message, "search-1-0-0 (Running)")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(entry), &agentregistryv1alpha1.MCPServerCatalog{}))
+
+	// Other versions are not affected by the deployment
+	_, err = handler.deleteServerVersion(ctx, &DeleteServerVersionInput{ServerName: "org/search", Version: "2.0.0"})
+	require.NoError(t, err)
+
+	// force=true deletes regardless
+	_, err = handler.deleteServerVersion(ctx, &DeleteServerVersionInput{ServerName: "org/search", Version: "1.0.0", Force: true})
+	require.NoError(t, err)
+	err = c.Get(ctx, client.ObjectKeyFromObject(entry), &agentregistryv1alpha1.MCPServerCatalog{})
+	assert.True(t, apierrors.IsNotFound(err), "expected server version to be deleted, got %v", err)
+
+	_, err = handler.deleteServerVersion(ctx, &DeleteServerVersionInput{ServerName: "org/search", Version: "1.0.0"})
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusNotFound, resp.GetStatus())
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// redactedValue replaces secret values in live resources
+const redactedValue = "REDACTED"
+
+// DeploymentResourceJSON is the live state of one managed resource
+type DeploymentResourceJSON struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Name       string         `json:"name"`
+	Namespace  string         `json:"namespace"`
+	Missing    bool           `json:"missing,omitempty" doc:"The resource no longer exists in the target cluster"`
+	Error      string         `json:"error,omitempty" doc:"Why the resource could not be read"`
+	Object     map[string]any `json:"object,omitempty" doc:"The live object, with secret values redacted"`
+}
+
+// DeploymentResourcesResponse lists the live managed resources of a deployment
+type DeploymentResourcesResponse struct {
+	Deployment string                   `json:"deployment"`
+	Resources  []DeploymentResourceJSON `json:"resources"`
+}
+
+// getDeploymentResources returns the live objects a deployment manages in its
+// target cluster
+func (h *DeploymentHandler) getDeploymentResources(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResourcesResponse], error) {
+	deploymentName, err := url.PathUnescape(input.DeploymentName)
+	if err != nil {
+		return nil, invalidName("Invalid deployment name encoding", err)
+	}
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, deploymentNotFound()
+		}
+		return nil, huma.Error500InternalServerError("Failed to get deployment", err)
+	}
+
+	live, err := controller.GetManagedResources(ctx, h.client, h.logger, &deployment)
+	if err != nil {
+		if errors.Is(err, controller.ErrManagedResourcesNotReadable) {
+			return nil, huma.Error409Conflict("Deployment resources are applied through an MCP tool server and cannot be read back; inspect them in the target cluster", err)
+		}
+		return nil, newCodedError(http.StatusBadGateway, CodeRemoteClusterUnreachable, "Failed to reach the deployment target", err)
+	}
+
+	resources := make([]DeploymentResourceJSON, 0, len(live))
+	for _, res := range live {
+		item := DeploymentResourceJSON{
+			APIVersion: res.APIVersion,
+			Kind:       res.Kind,
+			Name:       res.Name,
+			Namespace:  res.Namespace,
+			Missing:    res.Missing,
+			Error:      res.Error,
+		}
+		if res.Object != nil {
+			item.Object = redactLiveObject(res.Object)
+		}
+		resources = append(resources, item)
+	}
+	return &Response[DeploymentResourcesResponse]{
+		Body: DeploymentResourcesResponse{Deployment: deploymentName, Resources: resources},
+	}, nil
+}
+
+// redactLiveObject strips server bookkeeping from a live object and redacts
+// the values that may hold secrets: env values with secret-looking names,
+// all header values, and other secret-looking fields. JSON documents in
+// ConfigMap data, such as an agent's mcp-servers.json, are redacted the same
+// way.
+func redactLiveObject(obj map[string]any) map[string]any {
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		delete(metadata, "managedFields")
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		}
+	}
+	if obj["kind"] == "ConfigMap" {
+		if data, ok := obj["data"].(map[string]any); ok {
+			for k, v := range data {
+				s, ok := v.(string)
+				if !ok {
+					continue
+				}
+				var doc any
+				if err := json.Unmarshal([]byte(s), &doc); err != nil {
+					if isSecretConfigKey(k) {
+						data[k] = redactedValue
+					}
+					continue
+				}
+				if redacted, err := json.Marshal(redactSecrets(doc, "")); err == nil {
+					data[k] = string(redacted)
+				}
+			}
+		}
+	}
+	return redactSecrets(obj, "").(map[string]any)
+}
+
+// redactSecrets redacts secret values in v in place; parentKey is the field
+// v was found under
+func redactSecrets(v any, parentKey string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		// Name/value pairs: env vars, headersFrom entries
+		name, isPair := val["name"].(string)
+		if _, ok := val["value"].(string); !ok {
+			isPair = false
+		}
+		if isPair && (isHeaderField(parentKey) || isSecretConfigKey(name)) {
+			val["value"] = redactedValue
+		}
+		for k, child := range val {
+			if _, ok := child.(string); ok {
+				if (!isPair && isHeaderField(parentKey)) || isSecretFieldKey(k) {
+					val[k] = redactedValue
+				}
+				continue
+			}
+			val[k] = redactSecrets(child, k)
+		}
+	case []any:
+		for i := range val {
+			val[i] = redactSecrets(val[i], parentKey)
+		}
+	}
+	return v
+}
+
+// isHeaderField reports whether a field holds HTTP headers, whose values are
+// redacted regardless of their names
+func isHeaderField(key string) bool {
+	return key == "headers" || key == "headersFrom"
+}
+
+// isSecretFieldKey reports whether a field looks like it holds a secret.
+// Fields naming a Secret, such as secretName, are references, not values.
+func isSecretFieldKey(key string) bool {
+	if strings.HasSuffix(strings.ToLower(key), "name") {
+		return false
+	}
+	return isSecretConfigKey(key) || strings.EqualFold(key, "authorization")
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestDeploymentHandler_GetDeploymentResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kagentv1alpha2.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "org/search",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+		},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{
+			Phase: agentregistryv1alpha1.DeploymentPhaseRunning,
+			ManagedResources: []agentregistryv1alpha1.ManagedResource{
+				{APIVersion: "kagent.dev/v1alpha1", Kind: "MCPServer", Name: "search", Namespace: "prod"},
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "search-config", Namespace: "prod"},
+				{APIVersion: "kagent.dev/v1alpha2", Kind: "RemoteMCPServer", Name: "gone", Namespace: "prod"},
+			},
+		},
+	}
+	mcpServer := &kmcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "prod"},
+		Spec: kmcpv1alpha1.MCPServerSpec{
+			Deployment: kmcpv1alpha1.MCPServerDeployment{
+				Image: "ghcr.io/org/search:1.0.0",
+				Env:   map[string]string{"API_KEY": "hunter2", "LOG_LEVEL": "debug"},
+			},
+		},
+	}
+	servers, err := json.Marshal([]map[string]any{{
+		"name":    "upstream",
+		"url":     "https://upstream.example.com/mcp",
+		"headers": map[string]string{"Authorization": "Bearer hunter2"},
+	}})
+	require.NoError(t, err)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-config", Namespace: "prod"},
+		Data:       map[string]string{"mcp-servers.json": string(servers)},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, mcpServer, configMap).Build()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	result, err := handler.getDeploymentResources(ctx, &DeploymentDetailInput{DeploymentName: "search-1-0-0"})
+	require.NoError(t, err)
+	resources := result.Body.Resources
+	require.Len(t, resources, 3)
+
+	assert.Equal(t, "MCPServer", resources[0].Kind)
+	require.NotNil(t, resources[0].Object)
+	assert.Equal(t, "MCPServer", resources[0].Object["kind"])
+	spec := resources[0].Object["spec"].(map[string]any)["deployment"].(map[string]any)
+	assert.Equal(t, "ghcr.io/org/search:1.0.0", spec["image"])
+	assert.Equal(t, map[string]any{"API_KEY": redactedValue, "LOG_LEVEL": "debug"}, spec["env"])
+
+	assert.Equal(t, "ConfigMap", resources[1].Kind)
+	data := resources[1].Object["data"].(map[string]any)["mcp-servers.json"].(string)
+	assert.NotContains(t, data, "hunter2")
+	assert.Contains(t, data, "https://upstream.example.com/mcp")
+
+	assert.Equal(t, "RemoteMCPServer", resources[2].Kind)
+	assert.True(t, resources[2].Missing)
+	assert.Nil(t, resources[2].Object)
+
+	_, err = handler.getDeploymentResources(ctx, &DeploymentDetailInput{DeploymentName: "missing"})
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusNotFound, resp.GetStatus())
+}
+
+func TestDeploymentHandler_GetDeploymentResources_MCPToolServerTarget(t *testing.T) {
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "org/search",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Environment:  "edge",
+		},
+	}
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{{
+				Name:             "edge",
+				DeployEnabled:    true,
+				MCPToolServerURL: "http://tools.edge.svc/mcp",
+			}},
+		},
+	}
+	handler := NewDeploymentHandler(setupDeploymentTestClient(t, deployment, discovery), nil, zerolog.Nop())
+
+	_, err := handler.getDeploymentResources(context.Background(), &DeploymentDetailInput{DeploymentName: "search-1-0-0"})
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusConflict, resp.GetStatus())
+	assert.Contains(t, resp.Message, "MCP tool server")
+}
+
+func TestRedactLiveObject_HeadersFrom(t *testing.T) {
+	obj := map[string]any{
+		"kind": "RemoteMCPServer",
+		"metadata": map[string]any{
+			"name":          "upstream",
+			"managedFields": []any{map[string]any{"manager": "agentregistry"}},
+		},
+		"spec": map[string]any{
+			"url": "https://upstream.example.com/mcp",
+			"headersFrom": []any{
+				map[string]any{"name": "Authorization", "value": "Bearer hunter2"},
+				map[string]any{"name": "X-Token", "valueFrom": map[string]any{"type": "Secret", "name": "upstream-token"}},
+			},
+		},
+	}
+	redacted := redactLiveObject(obj)
+
+	assert.NotContains(t, redacted["metadata"], "managedFields")
+	headers := redacted["spec"].(map[string]any)["headersFrom"].([]any)
+	assert.Equal(t, map[string]any{"name": "Authorization", "value": redactedValue}, headers[0])
+	assert.Equal(t, "upstream-token", headers[1].(map[string]any)["valueFrom"].(map[string]any)["name"])
+}
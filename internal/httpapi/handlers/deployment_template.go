@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// secretKeyHints are substrings of config keys whose values are treated as
+// secrets and replaced by placeholders when a template is exported.
+var secretKeyHints = []string{"secret", "token", "password", "passwd", "apikey", "api_key", "api-key", "credential", "private", "auth", "dsn"}
+
+// secretKeySuffixes are suffixes of lowercased config keys naming a key, such
+// as ANTHROPIC_KEY, whose values are treated as secrets like secretKeyHints.
+var secretKeySuffixes = []string{"_key", "-key", ".key"}
+
+// templatePlaceholder matches a ${NAME} placeholder in a template config value.
+var templatePlaceholder = regexp.MustCompile(`\$\{([A-Za-z0-9_.-]+)\}`)
+
+// DeploymentTemplate is a reusable, environment-independent description of a
+// deployment. Secret config values are replaced by ${KEY} placeholders that
+// must be supplied as parameters when the template is applied.
+type DeploymentTemplate struct {
+	ResourceName string            `json:"resourceName"`
+	Version      string            `json:"version"`
+	ResourceType string            `json:"resourceType"`
+	Runtime      string            `json:"runtime,omitempty"`
+	PreferRemote bool              `json:"preferRemote,omitempty"`
+	Config       map[string]string `json:"config,omitempty"`
+	Parameters   []string          `json:"parameters,omitempty"`
+}
+
+type DeploymentTemplateResponse struct {
+	Template DeploymentTemplate `json:"template"`
+}
+
+type ApplyDeploymentTemplateInput struct {
+	Body struct {
+		Template    DeploymentTemplate `json:"template"`
+		Namespace   string             `json:"namespace,omitempty"`
+		Environment string             `json:"environment,omitempty"`
+		Parameters  map[string]string  `json:"parameters,omitempty"`
+	}
+}
+
+// isSecretConfigKey reports whether a config key looks like it holds a secret
+func isSecretConfigKey(key string) bool {
+	// camelCase keys such as accessKey
+	if strings.HasSuffix(key, "Key") {
+		return true
+	}
+	k := strings.ToLower(key)
+	for _, hint := range secretKeyHints {
+		if strings.Contains(k, hint) {
+			return true
+		}
+	}
+	for _, suffix := range secretKeySuffixes {
+		if strings.HasSuffix(k, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDeploymentTemplate captures a deployment as a template, redacting
+// secret-looking config values.
+func buildDeploymentTemplate(d *agentregistryv1alpha1.RegistryDeployment) DeploymentTemplate {
+	tmpl := DeploymentTemplate{
+		ResourceName: d.Spec.ResourceName,
+		Version:      d.Spec.Version,
+		ResourceType: string(d.Spec.ResourceType),
+		Runtime:      string(d.Spec.Runtime),
+		PreferRemote: d.Spec.PreferRemote,
+	}
+	if len(d.Spec.Config) > 0 {
+		tmpl.Config = make(map[string]string, len(d.Spec.Config))
+		for k, v := range d.Spec.Config {
+			if isSecretConfigKey(k) {
+				tmpl.Config[k] = "${" + k + "}"
+				tmpl.Parameters = append(tmpl.Parameters, k)
+				continue
+			}
+			tmpl.Config[k] = v
+		}
+		sort.Strings(tmpl.Parameters)
+	}
+	return tmpl
+}
+
+// renderTemplateConfig substitutes ${NAME} placeholders in the template config
+// with the supplied parameters. It returns the names of any placeholders left
+// unresolved.
+func renderTemplateConfig(config map[string]string, params map[string]string) (map[string]string, []string) {
+	if config == nil {
+		return nil, nil
+	}
+	rendered := make(map[string]string, len(config))
+	missing := map[string]bool{}
+	for k, v := range config {
+		rendered[k] = templatePlaceholder.ReplaceAllStringFunc(v, func(m string) string {
+			name := templatePlaceholder.FindStringSubmatch(m)[1]
+			if p, ok := params[name]; ok {
+				return p
+			}
+			missing[name] = true
+			return m
+		})
+	}
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return rendered, names
+}
+
+func (h *DeploymentHandler) getDeploymentTemplate(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentTemplateResponse], error) {
+	deploymentName, err := url.PathUnescape(input.DeploymentName)
+	if err != nil {
+		return nil, invalidName("Invalid deployment name encoding", err)
+	}
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
+		return nil, deploymentNotFound()
+	}
+
+	return &Response[DeploymentTemplateResponse]{
+		Body: DeploymentTemplateResponse{
+			Template: buildDeploymentTemplate(&deployment),
+		},
+	}, nil
+}
+
+func (h *DeploymentHandler) applyDeploymentTemplate(ctx context.Context, input *ApplyDeploymentTemplateInput) (*Response[DeploymentResponse], error) {
+	tmpl := input.Body.Template
+	if tmpl.ResourceName == "" || tmpl.Version == "" || tmpl.ResourceType == "" {
+		return nil, huma.Error400BadRequest("Template must include resourceName, version and resourceType")
+	}
+
+	config, missing := renderTemplateConfig(tmpl.Config, input.Body.Parameters)
+	if len(missing) > 0 {
+		return nil, huma.Error400BadRequest("Missing template parameters: " + strings.Join(missing, ", "))
+	}
+
+	create := &CreateDeploymentInput{}
+	create.Body.ResourceName = tmpl.ResourceName
+	create.Body.Version = tmpl.Version
+	create.Body.ResourceType = tmpl.ResourceType
+	create.Body.Runtime = tmpl.Runtime
+	create.Body.PreferRemote = tmpl.PreferRemote
+	create.Body.Config = config
+	create.Body.Namespace = input.Body.Namespace
+	create.Body.Environment = input.Body.Environment
+
+	// All RegistryDeployments live in the same namespace, so qualify the name
+	// with the target to avoid colliding with the deployment the template
+	// was exported from.
+	crName := GenerateCRName(tmpl.ResourceName, tmpl.Version)
+	if target := input.Body.Environment; target != "" {
+		crName += "-" + SanitizeK8sName(target)
+	} else if target := input.Body.Namespace; target != "" {
+		crName += "-" + SanitizeK8sName(target)
+	}
+
+	return h.createNamedDeployment(ctx, crName, create)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestIsSecretConfigKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"API_KEY":        true,
+		"GITHUB_TOKEN":   true,
+		"DB_PASSWORD":    true,
+		"client_secret":  true,
+		"LOG_LEVEL":      false,
+		"ENDPOINT":       false,
+		"KEYBOARD_MODE":  false,
+		"AWS_CREDENTIAL": true,
+		"ANTHROPIC_KEY":  true,
+		"OPENAI-KEY":     true,
+		"accessKey":      true,
+		"AUTH":           true,
+		"BASIC_AUTH":     true,
+		"SENTRY_DSN":     true,
+		"DSN":            true,
+		"KEY_ID":         false,
+		"MONKEY_MODE":    false,
+	} {
+		assert.Equal(t, want, isSecretConfigKey(key), key)
+	}
+}
+
+func TestBuildDeploymentTemplate_RedactsKeyAuthAndDSNValues(t *testing.T) {
+	tmpl := buildDeploymentTemplate(&agentregistryv1alpha1.RegistryDeployment{
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			Config: map[string]string{
+				"ANTHROPIC_KEY": "sk-ant-1",
+				"AUTH":          "Bearer abc",
+				"DATABASE_DSN":  "postgres://u:p@db/app",
+				"LOG_LEVEL":     "debug",
+			},
+		},
+	})
+	assert.Equal(t, map[string]string{
+		"ANTHROPIC_KEY": "${ANTHROPIC_KEY}",
+		"AUTH":          "${AUTH}",
+		"DATABASE_DSN":  "${DATABASE_DSN}",
+		"LOG_LEVEL":     "debug",
+	}, tmpl.Config)
+	assert.Equal(t, []string{"ANTHROPIC_KEY", "AUTH", "DATABASE_DSN"}, tmpl.Parameters)
+}
+
+func TestDeploymentHandler_TemplateRoundTrip(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	require.NoError(t, c.Create(ctx, &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-server-1-0-0", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "my-server",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Namespace:    "default",
+			Config: map[string]string{
+				"API_KEY":   "dev-secret",
+				"LOG_LEVEL": "debug",
+			},
+		},
+	}))
+
+	exported, err := handler.getDeploymentTemplate(ctx, &DeploymentDetailInput{DeploymentName: "my-server-1-0-0"})
+	require.NoError(t, err)
+	tmpl := exported.Body.Template
+	assert.Equal(t, "my-server", tmpl.ResourceName)
+	assert.Equal(t, "1.0.0", tmpl.Version)
+	assert.Equal(t, "mcp", tmpl.ResourceType)
+	assert.Equal(t, "${API_KEY}", tmpl.Config["API_KEY"], "secret values must be redacted")
+	assert.Equal(t, "debug", tmpl.Config["LOG_LEVEL"])
+	assert.Equal(t, []string{"API_KEY"}, tmpl.Parameters)
+
+	// Applying without the secret parameter is rejected.
+	apply := &ApplyDeploymentTemplateInput{}
+	apply.Body.Template = tmpl
+	apply.Body.Namespace = "prod"
+	_, err = handler.applyDeploymentTemplate(ctx, apply)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API_KEY")
+
+	// Applying with parameters creates a new deployment in the target namespace.
+	apply.Body.Parameters = map[string]string{"API_KEY": "prod-secret"}
+	resp, err := handler.applyDeploymentTemplate(ctx, apply)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", resp.Body.Deployment.Namespace)
+	assert.Equal(t, "prod-secret", resp.Body.Deployment.Config["API_KEY"])
+	assert.Equal(t, "debug", resp.Body.Deployment.Config["LOG_LEVEL"])
+
+	var created agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "my-server-1-0-0-prod"}, &created))
+	assert.Equal(t, "my-server", created.Spec.ResourceName)
+	assert.Equal(t, "prod", created.Spec.Namespace)
+}
+
+func TestDeploymentHandler_ApplyTemplate_NamespaceNotAllowed(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	apply := &ApplyDeploymentTemplateInput{}
+	apply.Body.Template = DeploymentTemplate{ResourceName: "my-server", Version: "1.0.0", ResourceType: "mcp"}
+	apply.Body.Namespace = "kube-system"
+
+	_, err := handler.applyDeploymentTemplate(context.Background(), apply)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not allowed")
+}
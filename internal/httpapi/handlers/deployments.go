@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,6 +22,7 @@ import (
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/config"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
 )
 
 // DeploymentHandler handles deployment operations
@@ -39,24 +43,38 @@ func NewDeploymentHandler(c client.Client, cache cache.Cache, logger zerolog.Log
 
 // Deployment response types
 type DeploymentJSON struct {
-	ResourceName    string            `json:"resourceName"`
-	Version         string            `json:"version"`
-	ResourceType    string            `json:"resourceType"`              // "mcp" or "agent" (catalog type)
-	K8sResourceType string            `json:"k8sResourceType,omitempty"` // "MCPServer", "RemoteMCPServer", "Agent" (actual K8s resource)
-	Runtime         string            `json:"runtime"`
-	PreferRemote    bool              `json:"preferRemote,omitempty"`
-	Config          map[string]string `json:"config,omitempty"`
-	Namespace       string            `json:"namespace,omitempty"`
-	Environment     string            `json:"environment,omitempty"` // Environment label (dev, staging, prod, etc.)
-	Status          string            `json:"status,omitempty"`
-	DeployedAt      *time.Time        `json:"deployedAt,omitempty"`
-	UpdatedAt       *time.Time        `json:"updatedAt,omitempty"`
-	Message         string            `json:"message,omitempty"`
-	IsExternal      bool              `json:"isExternal,omitempty"`
+	Name                string            `json:"name,omitempty"` // RegistryDeployment name; empty for external deployments
+	ResourceName        string            `json:"resourceName"`
+	Version             string            `json:"version"`
+	ResourceType        string            `json:"resourceType"`              // "mcp" or "agent" (catalog type)
+	K8sResourceType     string            `json:"k8sResourceType,omitempty"` // "MCPServer", "RemoteMCPServer", "Agent" (actual K8s resource)
+	Runtime             string            `json:"runtime"`
+	PreferRemote        bool              `json:"preferRemote,omitempty"`
+	PackageIndex        int32             `json:"packageIndex,omitempty"`
+	Config              map[string]string `json:"config,omitempty"`
+	Namespace           string            `json:"namespace,omitempty"`
+	Namespaces          []string          `json:"namespaces,omitempty"`
+	Environment         string            `json:"environment,omitempty"` // Environment label (dev, staging, prod, etc.)
+	Environments        []string          `json:"environments,omitempty"`
+	ResourceLabels      map[string]string `json:"resourceLabels,omitempty"`
+	ResourceAnnotations map[string]string `json:"resourceAnnotations,omitempty"`
+	CommandOverride     string            `json:"commandOverride,omitempty"`
+	ArgsOverride        []string          `json:"argsOverride,omitempty"`
+	ImagePullSecrets    []string          `json:"imagePullSecrets,omitempty"`
+	EffectiveCommand    []string          `json:"effectiveCommand,omitempty"`
+	Paused              bool              `json:"paused,omitempty"`
+	Status              string            `json:"status,omitempty"`
+	DeployedAt          *time.Time        `json:"deployedAt,omitempty"`
+	UpdatedAt           *time.Time        `json:"updatedAt,omitempty"`
+	Message             string            `json:"message,omitempty"`
+	IsExternal          bool              `json:"isExternal,omitempty"`
 }
 
 type DeploymentResponse struct {
 	Deployment DeploymentJSON `json:"deployment"`
+	// ConfigIssues reports config keys the catalog entry does not declare and
+	// required ones that are missing, when there are any
+	ConfigIssues *controller.ConfigIssues `json:"configIssues,omitempty"`
 }
 
 type DeploymentListResponse struct {
@@ -77,25 +95,50 @@ type DeploymentDetailInput struct {
 }
 
 type CreateDeploymentInput struct {
-	Body struct {
+	Strict bool `query:"strict" json:"strict,omitempty" doc:"Reject config with unknown keys or missing required keys instead of reporting them"`
+	Body   struct {
 		ResourceName string            `json:"resourceName"`
 		Version      string            `json:"version"`
 		ResourceType string            `json:"resourceType"`
 		Runtime      string            `json:"runtime"`
 		PreferRemote bool              `json:"preferRemote,omitempty"`
+		PackageIndex int32             `json:"packageIndex,omitempty" minimum:"0" doc:"Which of the server's packages to deploy; defaults to the first"`
 		Config       map[string]string `json:"config,omitempty"`
 		Namespace    string            `json:"namespace,omitempty"`
+		Namespaces   []string          `json:"namespaces,omitempty" doc:"Deploy into each of these namespaces instead of a single one; mutually exclusive with namespace"`
 		Environment  string            `json:"environment,omitempty"`
+		Environments []string          `json:"environments,omitempty" doc:"Deploy into each of these environments instead of a single one; mutually exclusive with environment"`
+		// Labels and annotations added to the managed resources
+		ResourceLabels      map[string]string `json:"resourceLabels,omitempty"`
+		ResourceAnnotations map[string]string `json:"resourceAnnotations,omitempty"`
+		// Replace the container command and args derived from the catalog
+		// package (package-based MCP server deployments only)
+		CommandOverride string   `json:"commandOverride,omitempty"`
+		ArgsOverride    []string `json:"argsOverride,omitempty"`
+		// Secrets used to pull the image from a private registry; default
+		// to those of the environment's registry (agent deployments only)
+		ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 	}
 }
 
 type UpdateDeploymentConfigInput struct {
 	DeploymentName string `path:"deploymentName" json:"deploymentName"`
+	Strict         bool   `query:"strict" json:"strict,omitempty" doc:"Reject config with unknown keys or missing required keys instead of reporting them"`
 	Body           struct {
 		Config map[string]string `json:"config"`
 	}
 }
 
+type RefreshDeploymentsInput struct {
+	Phase string `query:"phase" json:"phase,omitempty" default:"Pending" enum:"Pending,Running,Failed"`
+}
+
+// DeploymentRefreshResponse reports how many deployments were requeued
+type DeploymentRefreshResponse struct {
+	Phase    string `json:"phase"`
+	Requeued int    `json:"requeued"`
+}
+
 type DeleteDeploymentVersionInput struct {
 	ServerName   string `path:"serverName" json:"serverName"`
 	Version      string `path:"version" json:"version"`
@@ -131,6 +174,9 @@ func (h *DeploymentHandler) RegisterRoutes(api huma.API, pathPrefix string, isAd
 		return h.getDeployment(ctx, input)
 	})
 
+	// List deployments created from a catalog entry
+	h.registerReferenceRoutes(api, pathPrefix, tags)
+
 	// Admin-only endpoints (mutations). Registered only under /admin/v0 so that
 	// deployment create/update/delete require authentication.
 	if isAdmin {
@@ -145,6 +191,50 @@ func (h *DeploymentHandler) RegisterRoutes(api huma.API, pathPrefix string, isAd
 			return h.createDeployment(ctx, input)
 		})
 
+		// Create several deployments at once, reporting per-item results
+		huma.Register(api, huma.Operation{
+			OperationID: "create-deployments-batch" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/batch",
+			Summary:     "Create multiple deployments",
+			Tags:        tags,
+		}, func(ctx context.Context, input *BatchDeploymentsInput) (*Response[BatchDeploymentsResponse], error) {
+			return h.createDeploymentsBatch(ctx, input)
+		})
+
+		// Render the resources a deployment would create without applying them
+		huma.Register(api, huma.Operation{
+			OperationID: "preview-deployment" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/preview",
+			Summary:     "Preview the Kubernetes manifests of a deployment",
+			Tags:        tags,
+		}, func(ctx context.Context, input *CreateDeploymentInput) (*Response[DeploymentPreviewResponse], error) {
+			return h.previewDeployment(ctx, input)
+		})
+
+		// Requeue all deployments in a phase (e.g. re-check Pending readiness)
+		huma.Register(api, huma.Operation{
+			OperationID: "refresh-deployments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/refresh",
+			Summary:     "Requeue all deployments in the given phase",
+			Tags:        tags,
+		}, func(ctx context.Context, input *RefreshDeploymentsInput) (*Response[DeploymentRefreshResponse], error) {
+			return h.refreshDeployments(ctx, input)
+		})
+
+		// Reconcile a single deployment now (e.g. one stuck Pending)
+		huma.Register(api, huma.Operation{
+			OperationID: "reconcile-deployment" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/{deploymentName}/reconcile",
+			Summary:     "Force an immediate reconcile of a deployment",
+			Tags:        tags,
+		}, func(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResponse], error) {
+			return h.reconcileDeployment(ctx, input)
+		})
+
 		// Update deployment config
 		huma.Register(api, huma.Operation{
 			OperationID: "update-deployment-config" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -156,6 +246,39 @@ func (h *DeploymentHandler) RegisterRoutes(api huma.API, pathPrefix string, isAd
 			return h.updateDeploymentConfig(ctx, input)
 		})
 
+		// Roll back to the previously applied version and config
+		huma.Register(api, huma.Operation{
+			OperationID: "rollback-deployment" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/{deploymentName}/rollback",
+			Summary:     "Roll back deployment to the previously applied spec",
+			Tags:        tags,
+		}, func(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResponse], error) {
+			return h.rollbackDeployment(ctx, input)
+		})
+
+		// Export a deployment as a reusable template (secrets redacted)
+		huma.Register(api, huma.Operation{
+			OperationID: "get-deployment-template" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodGet,
+			Path:        pathPrefix + "/deployments/{deploymentName}/template",
+			Summary:     "Export deployment as a reusable template",
+			Tags:        tags,
+		}, func(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentTemplateResponse], error) {
+			return h.getDeploymentTemplate(ctx, input)
+		})
+
+		// Instantiate a deployment template in another namespace/environment
+		huma.Register(api, huma.Operation{
+			OperationID: "apply-deployment-template" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/apply-template",
+			Summary:     "Create a deployment from a template",
+			Tags:        tags,
+		}, func(ctx context.Context, input *ApplyDeploymentTemplateInput) (*Response[DeploymentResponse], error) {
+			return h.applyDeploymentTemplate(ctx, input)
+		})
+
 		// Delete deployment by name
 		huma.Register(api, huma.Operation{
 			OperationID: "delete-deployment" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -167,6 +290,31 @@ func (h *DeploymentHandler) RegisterRoutes(api huma.API, pathPrefix string, isAd
 			return h.deleteDeployment(ctx, input)
 		})
 
+		// Live objects of the resources a deployment manages (secrets redacted)
+		huma.Register(api, huma.Operation{
+			OperationID: "get-deployment-resources" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodGet,
+			Path:        pathPrefix + "/deployments/{deploymentName}/resources",
+			Summary:     "Get the live Kubernetes resources managed by a deployment",
+			Tags:        tags,
+		}, func(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResourcesResponse], error) {
+			return h.getDeploymentResources(ctx, input)
+		})
+
+		// Deployments needing attention
+		h.registerProblemsRoute(api, pathPrefix, tags)
+
+		// Remove the finalizer of a deployment stuck deleting
+		huma.Register(api, huma.Operation{
+			OperationID: "force-delete-deployment" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/deployments/{deploymentName}/force-delete",
+			Summary:     "Force delete a deployment stuck deleting, orphaning resources that cannot be cleaned up",
+			Tags:        tags,
+		}, func(ctx context.Context, input *ForceDeleteDeploymentInput) (*Response[ForceDeleteDeploymentResponse], error) {
+			return h.forceDeleteDeployment(ctx, input)
+		})
+
 		// Delete deployment by server name and version (UI compatibility)
 		huma.Register(api, huma.Operation{
 			OperationID: "delete-deployment-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -263,12 +411,12 @@ func (h *DeploymentHandler) listDeployments(ctx context.Context, input *ListDepl
 func (h *DeploymentHandler) getDeployment(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResponse], error) {
 	deploymentName, err := url.PathUnescape(input.DeploymentName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid deployment name encoding", err)
+		return nil, invalidName("Invalid deployment name encoding", err)
 	}
 
 	var deployment agentregistryv1alpha1.RegistryDeployment
 	if err := h.cache.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
-		return nil, huma.Error404NotFound("Deployment not found")
+		return nil, deploymentNotFound()
 	}
 
 	return &Response[DeploymentResponse]{
@@ -279,22 +427,125 @@ func (h *DeploymentHandler) getDeployment(ctx context.Context, input *Deployment
 }
 
 func (h *DeploymentHandler) createDeployment(ctx context.Context, input *CreateDeploymentInput) (*Response[DeploymentResponse], error) {
-	crName := GenerateCRName(input.Body.ResourceName, input.Body.Version)
+	return h.createNamedDeployment(ctx, GenerateCRName(input.Body.ResourceName, input.Body.Version), input)
+}
+
+// createNamedDeployment creates a RegistryDeployment with an explicit CR name
+func (h *DeploymentHandler) createNamedDeployment(ctx context.Context, crName string, input *CreateDeploymentInput) (*Response[DeploymentResponse], error) {
+	deployment, err := NewRegistryDeployment(crName, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckDeploymentEnvironment(ctx, h.reader(), deployment); err != nil {
+		if errors.Is(err, controller.ErrEnvironmentNotFound) || errors.Is(err, controller.ErrEnvironmentDeployDisabled) {
+			return nil, huma.Error400BadRequest("Invalid environment", err)
+		}
+		return nil, huma.Error500InternalServerError("Failed to look up environment", err)
+	}
+	if err := CheckDeploymentRegistryType(ctx, h.reader(), deployment); err != nil {
+		if errors.Is(err, controller.ErrRegistryTypeNotAllowed) {
+			return nil, newCodedError(http.StatusForbidden, CodeRegistryTypeNotAllowed, "Deployment of this package is not allowed", err)
+		}
+		if errors.Is(err, controller.ErrPackageIndexOutOfRange) {
+			return nil, huma.Error400BadRequest("Invalid packageIndex", err)
+		}
+		return nil, huma.Error500InternalServerError("Failed to look up catalog entry", err)
+	}
+	issues, err := CheckDeploymentConfig(ctx, h.reader(), deployment)
+	if err != nil {
+		if errors.Is(err, controller.ErrPackageIndexOutOfRange) {
+			return nil, huma.Error400BadRequest("Invalid packageIndex", err)
+		}
+		return nil, huma.Error500InternalServerError("Failed to look up catalog entry", err)
+	}
+	if input.Strict && issues != nil {
+		return nil, invalidConfig(issues)
+	}
+
+	if err := h.client.Create(ctx, deployment); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, huma.Error409Conflict("Deployment "+crName+" already exists", err)
+		}
+		return nil, huma.Error500InternalServerError("Failed to create deployment", err)
+	}
+
+	// Note: Status will be set by the RegistryDeploymentReconciler.
+	// Don't update status here to avoid race conditions with the reconciler.
+
+	return &Response[DeploymentResponse]{
+		Body: DeploymentResponse{
+			Deployment:   h.convertToDeploymentJSON(deployment),
+			ConfigIssues: issues,
+		},
+	}, nil
+}
 
-	// Always use kubernetes runtime
+// NewRegistryDeployment builds the RegistryDeployment described by input,
+// enforcing the target namespace allowlist and metadata validation. The HTTP
+// API and the MCP tools both use it, so deploys and previews are built alike.
+func NewRegistryDeployment(crName string, input *CreateDeploymentInput) (*agentregistryv1alpha1.RegistryDeployment, error) {
+	// Use the kubernetes runtime unless the entry's Helm chart is requested
 	runtime := agentregistryv1alpha1.RuntimeTypeKubernetes
+	if input.Body.Runtime == string(agentregistryv1alpha1.RuntimeTypeHelm) {
+		runtime = agentregistryv1alpha1.RuntimeTypeHelm
+	}
 
 	// Target namespace for the deployed resources (MCPServer, Agent, etc.).
 	// Restrict to an allowlist so a caller cannot use the controller's
 	// cluster-wide RBAC to schedule workloads into arbitrary namespaces.
+	// Deployments into an environment leave it empty so the reconciler can
+	// derive it from the environment.
+	if len(input.Body.Environments) > 0 {
+		if input.Body.Environment != "" {
+			return nil, huma.Error400BadRequest("environment and environments are mutually exclusive")
+		}
+		if slices.Contains(input.Body.Environments, "") {
+			return nil, huma.Error400BadRequest("environments must not contain an empty name")
+		}
+	}
+	remote := input.Body.Environment != "" || len(input.Body.Environments) > 0
+
 	targetNamespace := input.Body.Namespace
-	if targetNamespace == "" {
-		targetNamespace = config.GetNamespace()
+	if len(input.Body.Namespaces) > 0 {
+		if targetNamespace != "" {
+			return nil, huma.Error400BadRequest("namespace and namespaces are mutually exclusive")
+		}
+		for _, ns := range input.Body.Namespaces {
+			if ns == "" {
+				return nil, huma.Error400BadRequest("namespaces must not contain an empty name")
+			}
+			if !config.IsDeploymentNamespaceAllowed(ns) {
+				return nil, huma.Error403Forbidden("Deployment into namespace " + ns + " is not allowed")
+			}
+		}
+	} else {
+		if targetNamespace == "" && !remote {
+			targetNamespace = config.DefaultDeployNamespace()
+		}
+		if !config.IsDeploymentNamespaceAllowed(targetNamespace) {
+			return nil, huma.Error403Forbidden(
+				"Deployment into namespace " + targetNamespace + " is not allowed",
+			)
+		}
+	}
+
+	if err := validation.ValidateLabels(input.Body.ResourceLabels); err != nil {
+		return nil, huma.Error400BadRequest("Invalid resourceLabels", err)
+	}
+	if err := validation.ValidateAnnotations(input.Body.ResourceAnnotations); err != nil {
+		return nil, huma.Error400BadRequest("Invalid resourceAnnotations", err)
+	}
+	if (input.Body.CommandOverride != "" || len(input.Body.ArgsOverride) > 0) &&
+		(input.Body.ResourceType != string(agentregistryv1alpha1.ResourceTypeMCP) || runtime == agentregistryv1alpha1.RuntimeTypeHelm) {
+		return nil, huma.Error400BadRequest("commandOverride and argsOverride are only supported for package-based MCP server deployments")
+	}
+	if len(input.Body.ImagePullSecrets) > 0 && runtime == agentregistryv1alpha1.RuntimeTypeHelm {
+		return nil, huma.Error400BadRequest("imagePullSecrets are not supported for Helm chart deployments")
 	}
-	if !config.IsDeploymentNamespaceAllowed(targetNamespace) {
-		return nil, huma.Error403Forbidden(
-			"Deployment into namespace " + targetNamespace + " is not allowed",
-		)
+	if input.Body.PackageIndex != 0 &&
+		(input.Body.ResourceType != string(agentregistryv1alpha1.ResourceTypeMCP) || runtime == agentregistryv1alpha1.RuntimeTypeHelm) {
+		return nil, huma.Error400BadRequest("packageIndex is only supported for package-based MCP server deployments")
 	}
 
 	deployment := &agentregistryv1alpha1.RegistryDeployment{
@@ -309,40 +560,100 @@ func (h *DeploymentHandler) createDeployment(ctx context.Context, input *CreateD
 			},
 		},
 		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
-			ResourceName: input.Body.ResourceName,
-			Version:      input.Body.Version,
-			ResourceType: agentregistryv1alpha1.ResourceType(input.Body.ResourceType),
-			Runtime:      runtime,
-			PreferRemote: input.Body.PreferRemote,
-			Config:       input.Body.Config,
-			Namespace:    targetNamespace, // Target namespace for deployed resources
-			Environment:  input.Body.Environment,
+			ResourceName:        input.Body.ResourceName,
+			Version:             input.Body.Version,
+			ResourceType:        agentregistryv1alpha1.ResourceType(input.Body.ResourceType),
+			Runtime:             runtime,
+			PreferRemote:        input.Body.PreferRemote,
+			PackageIndex:        input.Body.PackageIndex,
+			Config:              input.Body.Config,
+			Namespace:           targetNamespace, // Target namespace for deployed resources
+			Namespaces:          input.Body.Namespaces,
+			Environment:         input.Body.Environment,
+			Environments:        input.Body.Environments,
+			ResourceLabels:      input.Body.ResourceLabels,
+			ResourceAnnotations: input.Body.ResourceAnnotations,
+			CommandOverride:     input.Body.CommandOverride,
+			ArgsOverride:        input.Body.ArgsOverride,
+			ImagePullSecrets:    input.Body.ImagePullSecrets,
 		},
 	}
+	return deployment, nil
+}
 
-	if err := h.client.Create(ctx, deployment); err != nil {
-		return nil, huma.Error500InternalServerError("Failed to create deployment", err)
+// CheckDeploymentEnvironment rejects a deployment into an environment that no
+// DiscoveryConfig declares or that does not allow deployments, so it fails at
+// creation rather than when it is first reconciled. Each of Environments is
+// checked. Deployments into the local cluster always pass.
+func CheckDeploymentEnvironment(ctx context.Context, reader client.Reader, deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	environments := deployment.Spec.Environments
+	if deployment.Spec.Environment != "" {
+		environments = []string{deployment.Spec.Environment}
+	}
+	for _, env := range environments {
+		if _, err := controller.FindDeployEnvironment(ctx, reader, deployment.Namespace, env); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Note: Status will be set by the RegistryDeploymentReconciler.
-	// Don't update status here to avoid race conditions with the reconciler.
+// CheckDeploymentRegistryType rejects an MCP deployment whose catalog entry
+// would run from a package with a registry type the operator has not allowed.
+// It passes when the catalog entry does not exist yet; the reconciler enforces
+// the allowlist again once it does.
+func CheckDeploymentRegistryType(ctx context.Context, reader client.Reader, deployment *agentregistryv1alpha1.RegistryDeployment) error {
+	if deployment.Spec.ResourceType != agentregistryv1alpha1.ResourceTypeMCP {
+		return nil
+	}
+	entry, err := findServerEntry(ctx, reader, deployment.Spec.ResourceName, deployment.Spec.Version)
+	if err != nil || entry == nil {
+		return err
+	}
+	return controller.CheckRegistryTypeAllowed(entry, deployment)
+}
 
-	return &Response[DeploymentResponse]{
-		Body: DeploymentResponse{
-			Deployment: h.convertToDeploymentJSON(deployment),
-		},
-	}, nil
+// CheckDeploymentConfig validates the config of an MCP deployment against the
+// inputs its catalog entry declares. It returns nil when there are no issues,
+// or when the deployment is not an MCP deployment or its entry does not exist,
+// and an error wrapping controller.ErrPackageIndexOutOfRange when the
+// deployment selects a package the entry does not have.
+func CheckDeploymentConfig(ctx context.Context, reader client.Reader, deployment *agentregistryv1alpha1.RegistryDeployment) (*controller.ConfigIssues, error) {
+	if deployment.Spec.ResourceType != agentregistryv1alpha1.ResourceTypeMCP {
+		return nil, nil
+	}
+	entry, err := findServerEntry(ctx, reader, deployment.Spec.ResourceName, deployment.Spec.Version)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	issues, err := controller.ValidateDeploymentConfig(entry, deployment)
+	if err != nil {
+		return nil, err
+	}
+	if issues.Empty() {
+		return nil, nil
+	}
+	return &issues, nil
+}
+
+// invalidConfig rejects a deployment config in strict mode
+func invalidConfig(issues *controller.ConfigIssues) error {
+	var errs []error
+	for _, warning := range issues.Warnings() {
+		errs = append(errs, errors.New(warning))
+	}
+	return newCodedError(http.StatusUnprocessableEntity, CodeInvalidConfig, "Deployment config does not match the inputs declared by the catalog entry", errs...)
 }
 
 func (h *DeploymentHandler) updateDeploymentConfig(ctx context.Context, input *UpdateDeploymentConfigInput) (*Response[DeploymentResponse], error) {
 	deploymentName, err := url.PathUnescape(input.DeploymentName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid deployment name encoding", err)
+		return nil, invalidName("Invalid deployment name encoding", err)
 	}
 
 	var deployment agentregistryv1alpha1.RegistryDeployment
 	if err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
-		return nil, huma.Error404NotFound("Deployment not found")
+		return nil, deploymentNotFound()
 	}
 
 	// Merge config
@@ -351,11 +662,104 @@ func (h *DeploymentHandler) updateDeploymentConfig(ctx context.Context, input *U
 	} else {
 		maps.Copy(deployment.Spec.Config, input.Body.Config)
 	}
+	issues, err := CheckDeploymentConfig(ctx, h.reader(), &deployment)
+	if err != nil {
+		if errors.Is(err, controller.ErrPackageIndexOutOfRange) {
+			return nil, huma.Error400BadRequest("Invalid packageIndex", err)
+		}
+		return nil, huma.Error500InternalServerError("Failed to look up catalog entry", err)
+	}
+	if input.Strict && issues != nil {
+		return nil, invalidConfig(issues)
+	}
 
 	if err := h.client.Update(ctx, &deployment); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to update deployment", err)
 	}
 
+	return &Response[DeploymentResponse]{
+		Body: DeploymentResponse{
+			Deployment:   h.convertToDeploymentJSON(&deployment),
+			ConfigIssues: issues,
+		},
+	}, nil
+}
+
+func (h *DeploymentHandler) refreshDeployments(ctx context.Context, input *RefreshDeploymentsInput) (*Response[DeploymentRefreshResponse], error) {
+	phase := input.Phase
+	if phase == "" {
+		phase = string(agentregistryv1alpha1.DeploymentPhasePending)
+	}
+
+	var deploymentList agentregistryv1alpha1.RegistryDeploymentList
+	if err := h.client.List(ctx, &deploymentList, client.InNamespace("agentregistry")); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to list deployments", err)
+	}
+
+	// Stamp the reconcile-trigger annotation; the annotation change is picked
+	// up by the controller watch and each deployment is reconciled again.
+	requeued := 0
+	for i := range deploymentList.Items {
+		d := &deploymentList.Items[i]
+		if string(d.Status.Phase) != phase {
+			continue
+		}
+		if err := controller.TriggerReconcile(ctx, h.client, d); err != nil {
+			h.logger.Warn().Err(err).Str("deployment", d.Name).Msg("failed to requeue deployment")
+			continue
+		}
+		requeued++
+	}
+
+	return &Response[DeploymentRefreshResponse]{
+		Body: DeploymentRefreshResponse{
+			Phase:    phase,
+			Requeued: requeued,
+		},
+	}, nil
+}
+
+func (h *DeploymentHandler) reconcileDeployment(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResponse], error) {
+	deploymentName, err := url.PathUnescape(input.DeploymentName)
+	if err != nil {
+		return nil, invalidName("Invalid deployment name encoding", err)
+	}
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
+		return nil, deploymentNotFound()
+	}
+
+	if err := controller.TriggerReconcile(ctx, h.client, &deployment); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to trigger reconcile", err)
+	}
+
+	return &Response[DeploymentResponse]{
+		Body: DeploymentResponse{
+			Deployment: h.convertToDeploymentJSON(&deployment),
+		},
+	}, nil
+}
+
+func (h *DeploymentHandler) rollbackDeployment(ctx context.Context, input *DeploymentDetailInput) (*Response[DeploymentResponse], error) {
+	deploymentName, err := url.PathUnescape(input.DeploymentName)
+	if err != nil {
+		return nil, invalidName("Invalid deployment name encoding", err)
+	}
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: deploymentName}, &deployment); err != nil {
+		return nil, deploymentNotFound()
+	}
+
+	if _, err := controller.RollbackDeployment(&deployment); err != nil {
+		return nil, huma.Error409Conflict("Nothing to roll back to", err)
+	}
+
+	if err := h.client.Update(ctx, &deployment); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to roll back deployment", err)
+	}
+
 	return &Response[DeploymentResponse]{
 		Body: DeploymentResponse{
 			Deployment: h.convertToDeploymentJSON(&deployment),
@@ -366,7 +770,7 @@ func (h *DeploymentHandler) updateDeploymentConfig(ctx context.Context, input *U
 func (h *DeploymentHandler) deleteDeployment(ctx context.Context, input *DeploymentDetailInput) (*Response[EmptyResponse], error) {
 	deploymentName, err := url.PathUnescape(input.DeploymentName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid deployment name encoding", err)
+		return nil, invalidName("Invalid deployment name encoding", err)
 	}
 
 	deployment := &agentregistryv1alpha1.RegistryDeployment{
@@ -388,18 +792,18 @@ func (h *DeploymentHandler) deleteDeployment(ctx context.Context, input *Deploym
 func (h *DeploymentHandler) deleteDeploymentVersion(ctx context.Context, input *DeleteDeploymentVersionInput) (*Response[EmptyResponse], error) {
 	serverName, err := url.PathUnescape(input.ServerName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		return nil, invalidName("Invalid server name encoding", err)
 	}
 	version, err := url.PathUnescape(input.Version)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		return nil, invalidVersion("Invalid version encoding", err)
 	}
 
 	// Find the deployment by resource name, version, and optionally resource type
 	var deploymentList agentregistryv1alpha1.RegistryDeploymentList
 	listOpts := []client.ListOption{
 		client.MatchingFields{
-			controller.IndexDeploymentResourceName: serverName,
+			controller.IndexDeploymentResourceVersion: controller.NameVersionKey(serverName, version),
 		},
 	}
 
@@ -408,38 +812,47 @@ func (h *DeploymentHandler) deleteDeploymentVersion(ctx context.Context, input *
 	}
 
 	for _, d := range deploymentList.Items {
-		if d.Spec.Version == version {
-			// If resource type specified, match it
-			if input.ResourceType != "" && string(d.Spec.ResourceType) != input.ResourceType {
-				continue
-			}
-
-			if err := h.client.Delete(ctx, &d); err != nil {
-				return nil, huma.Error500InternalServerError("Failed to delete deployment", err)
-			}
+		// If resource type specified, match it
+		if input.ResourceType != "" && string(d.Spec.ResourceType) != input.ResourceType {
+			continue
+		}
 
-			return &Response[EmptyResponse]{
-				Body: EmptyResponse{Message: "Deployment deleted successfully"},
-			}, nil
+		if err := h.client.Delete(ctx, &d); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to delete deployment", err)
 		}
+
+		return &Response[EmptyResponse]{
+			Body: EmptyResponse{Message: "Deployment deleted successfully"},
+		}, nil
 	}
 
-	return nil, huma.Error404NotFound("Deployment not found")
+	return nil, deploymentNotFound()
 }
 
 func (h *DeploymentHandler) convertToDeploymentJSON(d *agentregistryv1alpha1.RegistryDeployment) DeploymentJSON {
 	deployment := DeploymentJSON{
-		ResourceName: d.Spec.ResourceName,
-		Version:      d.Spec.Version,
-		ResourceType: string(d.Spec.ResourceType),
-		Runtime:      string(d.Spec.Runtime),
-		PreferRemote: d.Spec.PreferRemote,
-		Config:       d.Spec.Config,
-		Namespace:    d.Spec.Namespace,
-		Environment:  d.Spec.Environment,
-		Status:       string(d.Status.Phase),
-		Message:      d.Status.Message,
-		IsExternal:   false,
+		Name:                d.Name,
+		ResourceName:        d.Spec.ResourceName,
+		Version:             d.Spec.Version,
+		ResourceType:        string(d.Spec.ResourceType),
+		Runtime:             string(d.Spec.Runtime),
+		PreferRemote:        d.Spec.PreferRemote,
+		PackageIndex:        d.Spec.PackageIndex,
+		Config:              d.Spec.Config,
+		Namespace:           d.Spec.Namespace,
+		Namespaces:          d.Spec.Namespaces,
+		Environment:         d.Spec.Environment,
+		Environments:        d.Spec.Environments,
+		ResourceLabels:      d.Spec.ResourceLabels,
+		ResourceAnnotations: d.Spec.ResourceAnnotations,
+		CommandOverride:     d.Spec.CommandOverride,
+		ArgsOverride:        d.Spec.ArgsOverride,
+		ImagePullSecrets:    d.Spec.ImagePullSecrets,
+		EffectiveCommand:    d.Status.EffectiveCommand,
+		Paused:              d.Spec.Paused,
+		Status:              string(d.Status.Phase),
+		Message:             d.Status.Message,
+		IsExternal:          false,
 	}
 
 	// Fall back to label for environment if not set in spec
@@ -2,27 +2,46 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
 )
 
-func setupDeploymentTestClient(t *testing.T) client.Client {
+func setupDeploymentTestClient(t *testing.T, objs ...client.Object) client.Client {
 	// The deployment handler enforces a namespace allowlist. These tests deploy
 	// into "default"/"prod", so widen the allowlist for the duration of the test.
 	t.Setenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES", "default,prod,agentregistry")
 
 	scheme := runtime.NewScheme()
 	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
-	return fake.NewClientBuilder().WithScheme(scheme).Build()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerNameVersion, controller.MCPServerNameVersionIndex).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.AgentCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.RegistryDeployment{}, controller.IndexDeploymentResourceName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.RegistryDeployment).Spec.ResourceName}
+		}).
+		WithIndex(&agentregistryv1alpha1.RegistryDeployment{}, controller.IndexDeploymentResourceVersion, controller.DeploymentResourceVersionIndex).
+		WithObjects(objs...).
+		Build()
 }
 
 // ---------------------------------------------------------------------------
@@ -160,6 +179,434 @@ func TestDeploymentHandler_CreateDeployment_NamespaceNotAllowed(t *testing.T) {
 	assert.Empty(t, deployments.Items)
 }
 
+func TestDeploymentHandler_CreateDeployment_DefaultNamespace(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES", "")
+	t.Setenv("POD_NAMESPACE", "")
+
+	newInput := func(namespace, environment string) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{}
+		input.Body.ResourceName = "org/agent"
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = "agent"
+		input.Body.Namespace = namespace
+		input.Body.Environment = environment
+		return input
+	}
+
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", "")
+	deployment, err := NewRegistryDeployment("agent-1-0-0", newInput("", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "kagent", deployment.Spec.Namespace)
+
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", "agents")
+	deployment, err = NewRegistryDeployment("agent-1-0-0", newInput("", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "agents", deployment.Spec.Namespace)
+
+	deployment, err = NewRegistryDeployment("agent-1-0-0", newInput("agentregistry", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "agentregistry", deployment.Spec.Namespace, "an explicit namespace wins")
+
+	// The reconciler derives the namespace of environment deployments
+	deployment, err = NewRegistryDeployment("agent-1-0-0", newInput("", "prod"))
+	require.NoError(t, err)
+	assert.Empty(t, deployment.Spec.Namespace)
+}
+
+func TestDeploymentHandler_CreateDeployment_RegistryTypeAllowlist(t *testing.T) {
+	npmServer := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/npm-server", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/npm-server",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{{
+				RegistryType: "npm",
+				Identifier:   "@org/npm-server",
+				Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+			}},
+		},
+	}
+	ociServer := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/oci-server", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/oci-server",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{{
+				RegistryType: "oci",
+				Identifier:   "ghcr.io/org/oci-server:1.0.0",
+				Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+			}},
+		},
+	}
+	c := setupDeploymentTestClient(t, npmServer, ociServer)
+	t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", "oci")
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	newInput := func(name string) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{}
+		input.Body.ResourceName = name
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = "mcp"
+		input.Body.Namespace = "default"
+		return input
+	}
+
+	_, err := handler.createDeployment(context.Background(), newInput("org/npm-server"))
+	require.Error(t, err)
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusForbidden, resp.GetStatus())
+	assert.Equal(t, CodeRegistryTypeNotAllowed, resp.Code)
+	require.NotEmpty(t, resp.Details)
+	assert.Contains(t, resp.Details[0], "npm packages cannot be deployed (allowed: oci)")
+
+	_, err = handler.createDeployment(context.Background(), newInput("org/oci-server"))
+	require.NoError(t, err)
+
+	// Unknown catalog entries are left to the reconciler
+	_, err = handler.createDeployment(context.Background(), newInput("org/not-yet-published"))
+	require.NoError(t, err)
+}
+
+func TestDeploymentHandler_CreateDeployment_PackageIndex(t *testing.T) {
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/everything", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/everything",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{
+				{RegistryType: "oci", Identifier: "ghcr.io/org/everything:1.0.0", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+				{RegistryType: "npm", Identifier: "@org/everything", Transport: agentregistryv1alpha1.Transport{Type: "stdio"}},
+			},
+		},
+	}
+	c := setupDeploymentTestClient(t, server)
+	t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", "oci")
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	newInput := func(resourceType string, index int32) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{}
+		input.Body.ResourceName = "org/everything"
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = resourceType
+		input.Body.Namespace = "default"
+		input.Body.PackageIndex = index
+		return input
+	}
+	status := func(err error) int {
+		t.Helper()
+		var resp *ErrorResponse
+		require.True(t, errors.As(err, &resp), "expected an API error, got %v", err)
+		return resp.GetStatus()
+	}
+
+	// The selected package is checked against the registry type allowlist
+	_, err := handler.createDeployment(context.Background(), newInput("mcp", 1))
+	assert.Equal(t, http.StatusForbidden, status(err))
+
+	_, err = handler.createDeployment(context.Background(), newInput("mcp", 2))
+	assert.Equal(t, http.StatusBadRequest, status(err))
+
+	_, err = handler.createDeployment(context.Background(), newInput("agent", 1))
+	assert.Equal(t, http.StatusBadRequest, status(err))
+
+	t.Setenv("AGENTREGISTRY_ALLOWED_REGISTRY_TYPES", "oci,npm")
+	resp, err := handler.createDeployment(context.Background(), newInput("mcp", 1))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Body.Deployment.PackageIndex)
+}
+
+func TestDeploymentHandler_CreateDeployment_Namespaces(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	newInput := func(namespace string, namespaces ...string) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{}
+		input.Body.ResourceName = "org/fanout"
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = "mcp"
+		input.Body.Namespace = namespace
+		input.Body.Namespaces = namespaces
+		return input
+	}
+	status := func(err error) int {
+		t.Helper()
+		var resp *ErrorResponse
+		require.True(t, errors.As(err, &resp), "expected an API error, got %v", err)
+		return resp.GetStatus()
+	}
+
+	_, err := handler.createDeployment(context.Background(), newInput("default", "prod"))
+	assert.Equal(t, http.StatusBadRequest, status(err), "namespace and namespaces are mutually exclusive")
+
+	_, err = handler.createDeployment(context.Background(), newInput("", "default", "team-a"))
+	assert.Equal(t, http.StatusForbidden, status(err), "every namespace must be allowed")
+
+	resp, err := handler.createDeployment(context.Background(), newInput("", "default", "prod"))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Body.Deployment.Namespace, "the default namespace is not filled in")
+	assert.Equal(t, []string{"default", "prod"}, resp.Body.Deployment.Namespaces)
+
+	var stored agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "agentregistry", Name: GenerateCRName("org/fanout", "1.0.0")}, &stored))
+	assert.Equal(t, []string{"default", "prod"}, stored.Spec.Namespaces)
+}
+
+func TestDeploymentHandler_CreateDeployment_Environment(t *testing.T) {
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "prod", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "prod-gke"}, DeployEnabled: true},
+				{Name: "audit", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "audit-gke"}},
+			},
+		},
+	}
+	c := setupDeploymentTestClient(t, discovery)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	newInput := func(name, environment string) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{}
+		input.Body.ResourceName = name
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = "agent"
+		input.Body.Environment = environment
+		return input
+	}
+
+	for name, environment := range map[string]string{
+		"nonexistent":     "staging",
+		"deploy disabled": "audit",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := handler.createDeployment(context.Background(), newInput("org/"+environment, environment))
+			var resp *ErrorResponse
+			require.True(t, errors.As(err, &resp), "expected an API error, got %v", err)
+			assert.Equal(t, http.StatusBadRequest, resp.GetStatus())
+			require.Len(t, resp.Details, 1)
+			assert.Contains(t, resp.Details[0], environment)
+
+			var list agentregistryv1alpha1.RegistryDeploymentList
+			require.NoError(t, c.List(context.Background(), &list))
+			assert.Empty(t, list.Items, "nothing is created")
+		})
+	}
+
+	resp, err := handler.createDeployment(context.Background(), newInput("org/prod", "prod"))
+	require.NoError(t, err)
+	assert.Equal(t, "prod", resp.Body.Deployment.Environment)
+}
+
+func TestDeploymentHandler_CreateDeployment_Environments(t *testing.T) {
+	discovery := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-kind"}, DeployEnabled: true},
+				{Name: "staging", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "staging-gke"}, DeployEnabled: true},
+				{Name: "audit", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "audit-gke"}},
+			},
+		},
+	}
+	c := setupDeploymentTestClient(t, discovery)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	newInput := func(environment string, environments ...string) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{}
+		input.Body.ResourceName = "org/rollout"
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = "agent"
+		input.Body.Environment = environment
+		input.Body.Environments = environments
+		return input
+	}
+	status := func(err error) int {
+		t.Helper()
+		var resp *ErrorResponse
+		require.True(t, errors.As(err, &resp), "expected an API error, got %v", err)
+		return resp.GetStatus()
+	}
+
+	_, err := handler.createDeployment(context.Background(), newInput("dev", "staging"))
+	assert.Equal(t, http.StatusBadRequest, status(err), "environment and environments are mutually exclusive")
+
+	_, err = handler.createDeployment(context.Background(), newInput("", "dev", "audit"))
+	assert.Equal(t, http.StatusBadRequest, status(err), "every environment must allow deployments")
+
+	resp, err := handler.createDeployment(context.Background(), newInput("", "dev", "staging"))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Body.Deployment.Environment)
+	assert.Empty(t, resp.Body.Deployment.Namespace, "the namespace is left to each environment")
+	assert.Equal(t, []string{"dev", "staging"}, resp.Body.Deployment.Environments)
+
+	var stored agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "agentregistry", Name: GenerateCRName("org/rollout", "1.0.0")}, &stored))
+	assert.Equal(t, []string{"dev", "staging"}, stored.Spec.Environments)
+}
+
+func TestDeploymentHandler_CreateDeployment_ConfigValidation(t *testing.T) {
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/github", "1.0.0")},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/github",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{{
+				RegistryType: "oci",
+				Identifier:   "ghcr.io/org/github:1.0.0",
+				Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+				EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+					{Name: "GITHUB_TOKEN", Required: true},
+					{Name: "LOG_LEVEL"},
+				},
+			}},
+		},
+	}
+	c := setupDeploymentTestClient(t, server)
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	newInput := func(strict bool, config map[string]string) *CreateDeploymentInput {
+		input := &CreateDeploymentInput{Strict: strict}
+		input.Body.ResourceName = "org/github"
+		input.Body.Version = "1.0.0"
+		input.Body.ResourceType = "mcp"
+		input.Body.Namespace = "default"
+		input.Body.Config = config
+		return input
+	}
+
+	// Strict mode rejects a typo and the missing required key it hides
+	_, err := handler.createDeployment(ctx, newInput(true, map[string]string{"GITHUB_TOKNE": "token"}))
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.GetStatus())
+	assert.Equal(t, CodeInvalidConfig, resp.Code)
+	assert.Equal(t, []string{
+		"unknown config keys are ignored: GITHUB_TOKNE",
+		"required config keys are missing: GITHUB_TOKEN",
+	}, resp.Details)
+
+	// Otherwise the deployment is created and the issues are reported
+	created, err := handler.createDeployment(ctx, newInput(false, map[string]string{"GITHUB_TOKNE": "token"}))
+	require.NoError(t, err)
+	require.NotNil(t, created.Body.ConfigIssues)
+	assert.Equal(t, []string{"GITHUB_TOKNE"}, created.Body.ConfigIssues.Unknown)
+	assert.Equal(t, []string{"GITHUB_TOKEN"}, created.Body.ConfigIssues.MissingRequired)
+
+	// Fixing the config through an update clears the issues
+	update := &UpdateDeploymentConfigInput{DeploymentName: created.Body.Deployment.Name, Strict: true}
+	update.Body.Config = map[string]string{"LOG_LEVEL": "debug"}
+	_, err = handler.updateDeploymentConfig(ctx, update)
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, CodeInvalidConfig, resp.Code)
+
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: created.Body.Deployment.Name}, &deployment))
+	deployment.Spec.Config = nil
+	require.NoError(t, c.Update(ctx, &deployment))
+	update.Body.Config = map[string]string{"GITHUB_TOKEN": "token"}
+	updated, err := handler.updateDeploymentConfig(ctx, update)
+	require.NoError(t, err)
+	assert.Nil(t, updated.Body.ConfigIssues)
+}
+
+func TestDeploymentHandler_CreateDeployment_ResourceLabels(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	input := &CreateDeploymentInput{}
+	input.Body.ResourceName = "labelled-server"
+	input.Body.Version = "1.0.0"
+	input.Body.ResourceType = "mcp"
+	input.Body.Namespace = "default"
+	input.Body.ResourceLabels = map[string]string{"cost-center": "team-a"}
+	input.Body.ResourceAnnotations = map[string]string{"sidecar.istio.io/inject": "true"}
+
+	resp, err := handler.createDeployment(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", resp.Body.Deployment.ResourceLabels["cost-center"])
+
+	var created agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "labelled-server-1-0-0"}, &created))
+	assert.Equal(t, "team-a", created.Spec.ResourceLabels["cost-center"])
+	assert.Equal(t, "true", created.Spec.ResourceAnnotations["sidecar.istio.io/inject"])
+
+	// Invalid label values are rejected up front
+	input.Body.ResourceName = "bad-labels"
+	input.Body.ResourceLabels = map[string]string{"cost-center": "not a valid value"}
+	_, err = handler.createDeployment(ctx, input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid resourceLabels")
+}
+
+func TestDeploymentHandler_CreateDeployment_CommandOverride(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	input := &CreateDeploymentInput{}
+	input.Body.ResourceName = "shimmed-server"
+	input.Body.Version = "1.0.0"
+	input.Body.ResourceType = "mcp"
+	input.Body.Namespace = "default"
+	input.Body.CommandOverride = "/debug/shim"
+	input.Body.ArgsOverride = []string{"--", "server"}
+
+	resp, err := handler.createDeployment(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "/debug/shim", resp.Body.Deployment.CommandOverride)
+
+	var created agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "shimmed-server-1-0-0"}, &created))
+	assert.Equal(t, "/debug/shim", created.Spec.CommandOverride)
+	assert.Equal(t, []string{"--", "server"}, created.Spec.ArgsOverride)
+
+	// Agents and Helm releases run no package container to override
+	input.Body.ResourceName = "shimmed-agent"
+	input.Body.ResourceType = "agent"
+	_, err = handler.createDeployment(ctx, input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only supported for package-based MCP server deployments")
+
+	input.Body.ResourceName = "shimmed-chart"
+	input.Body.ResourceType = "mcp"
+	input.Body.Runtime = "helm"
+	_, err = handler.createDeployment(ctx, input)
+	require.Error(t, err)
+}
+
+func TestDeploymentHandler_CreateDeployment_ImagePullSecrets(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	input := &CreateDeploymentInput{}
+	input.Body.ResourceName = "private-agent"
+	input.Body.Version = "1.0.0"
+	input.Body.ResourceType = "agent"
+	input.Body.Namespace = "default"
+	input.Body.ImagePullSecrets = []string{"team-registry"}
+
+	resp, err := handler.createDeployment(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-registry"}, resp.Body.Deployment.ImagePullSecrets)
+
+	var created agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "private-agent-1-0-0"}, &created))
+	assert.Equal(t, []string{"team-registry"}, created.Spec.ImagePullSecrets)
+
+	// MCP servers take pull secrets too, Helm charts run no image of ours
+	input.Body.ResourceName = "private-server"
+	input.Body.ResourceType = "mcp"
+	resp, err = handler.createDeployment(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-registry"}, resp.Body.Deployment.ImagePullSecrets)
+
+	input.Body.ResourceName = "private-chart"
+	input.Body.Runtime = "helm"
+	_, err = handler.createDeployment(ctx, input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported for Helm chart deployments")
+}
+
 func TestDeploymentHandler_CreateDeployment_InvalidRuntime(t *testing.T) {
 	c := setupDeploymentTestClient(t)
 	ctx := context.Background()
@@ -183,6 +630,146 @@ func TestDeploymentHandler_CreateDeployment_InvalidRuntime(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// refreshDeployments
+// ---------------------------------------------------------------------------
+
+func TestDeploymentHandler_RefreshDeployments_Pending(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	newDeployment := func(name string, phase agentregistryv1alpha1.DeploymentPhase) *agentregistryv1alpha1.RegistryDeployment {
+		return &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: name,
+				Version:      "1.0.0",
+				ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+				Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			},
+			Status: agentregistryv1alpha1.RegistryDeploymentStatus{Phase: phase},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newDeployment("pending-a", agentregistryv1alpha1.DeploymentPhasePending),
+			newDeployment("pending-b", agentregistryv1alpha1.DeploymentPhasePending),
+			newDeployment("running", agentregistryv1alpha1.DeploymentPhaseRunning),
+		).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}).
+		Build()
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.refreshDeployments(ctx, &RefreshDeploymentsInput{Phase: "Pending"})
+	require.NoError(t, err)
+	assert.Equal(t, "Pending", resp.Body.Phase)
+	assert.Equal(t, 2, resp.Body.Requeued)
+
+	for name, wantTriggered := range map[string]bool{"pending-a": true, "pending-b": true, "running": false} {
+		var d agentregistryv1alpha1.RegistryDeployment
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: name}, &d))
+		_, triggered := d.Annotations[controller.ReconcileTriggerAnnotation]
+		assert.Equal(t, wantTriggered, triggered, name)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// reconcileDeployment
+// ---------------------------------------------------------------------------
+
+func TestDeploymentHandler_ReconcileDeployment(t *testing.T) {
+	deployment := newReferencingDeployment("stuck", "org/search", "1.0.0",
+		agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhasePending)
+	c := setupDeploymentTestClient(t, deployment)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.reconcileDeployment(ctx, &DeploymentDetailInput{DeploymentName: "stuck"})
+	require.NoError(t, err)
+	assert.Equal(t, "stuck", resp.Body.Deployment.Name)
+
+	var d agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "stuck"}, &d))
+	first := d.Annotations[controller.ReconcileTriggerAnnotation]
+	assert.NotEmpty(t, first)
+
+	// Every call changes the annotation so it triggers another reconcile
+	_, err = handler.reconcileDeployment(ctx, &DeploymentDetailInput{DeploymentName: "stuck"})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "stuck"}, &d))
+	assert.NotEqual(t, first, d.Annotations[controller.ReconcileTriggerAnnotation])
+
+	_, err = handler.reconcileDeployment(ctx, &DeploymentDetailInput{DeploymentName: "missing"})
+	var errResp *ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, http.StatusNotFound, errResp.GetStatus())
+}
+
+// ---------------------------------------------------------------------------
+// rollbackDeployment
+// ---------------------------------------------------------------------------
+
+func TestDeploymentHandler_RollbackDeployment(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-server-2-0-0",
+			Namespace: "agentregistry",
+			Annotations: map[string]string{
+				controller.AppliedSpecAnnotation:  `{"version":"2.0.0","config":{"LOG_LEVEL":"debug"}}`,
+				controller.PreviousSpecAnnotation: `{"version":"1.0.0","config":{"LOG_LEVEL":"info"}}`,
+			},
+		},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "my-server",
+			Version:      "2.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+			Config:       map[string]string{"LOG_LEVEL": "debug"},
+		},
+	}
+	require.NoError(t, c.Create(ctx, deployment))
+
+	resp, err := handler.rollbackDeployment(ctx, &DeploymentDetailInput{DeploymentName: "my-server-2-0-0"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", resp.Body.Deployment.Version)
+	assert.Equal(t, "info", resp.Body.Deployment.Config["LOG_LEVEL"])
+
+	var updated agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "my-server-2-0-0"}, &updated))
+	assert.Equal(t, "1.0.0", updated.Spec.Version)
+	assert.JSONEq(t, `{"version":"2.0.0","config":{"LOG_LEVEL":"debug"}}`, updated.Annotations[controller.PreviousSpecAnnotation])
+}
+
+func TestDeploymentHandler_RollbackDeployment_NoPreviousSpec(t *testing.T) {
+	c := setupDeploymentTestClient(t)
+	ctx := context.Background()
+	handler := NewDeploymentHandler(c, nil, zerolog.Nop())
+
+	require.NoError(t, c.Create(ctx, &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "fresh", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "fresh",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Runtime:      agentregistryv1alpha1.RuntimeTypeKubernetes,
+		},
+	}))
+
+	resp, err := handler.rollbackDeployment(ctx, &DeploymentDetailInput{DeploymentName: "fresh"})
+	require.Error(t, err)
+	assert.Nil(t, resp)
+
+	_, err = handler.rollbackDeployment(ctx, &DeploymentDetailInput{DeploymentName: "missing"})
+	require.Error(t, err)
+}
+
 // ---------------------------------------------------------------------------
 // convertToDeploymentJSON
 // ---------------------------------------------------------------------------
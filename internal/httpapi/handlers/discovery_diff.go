@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+type DiscoveryDiffInput struct {
+	Body DiscoveryDiffRequest
+}
+
+// DiscoveryDiffRequest is a proposed DiscoveryConfig spec
+type DiscoveryDiffRequest struct {
+	Name string                                    `json:"name,omitempty" doc:"DiscoveryConfig the spec would replace; defaults to the only existing DiscoveryConfig"`
+	Spec agentregistryv1alpha1.DiscoveryConfigSpec `json:"spec"`
+}
+
+// DiscoveryScopeChange is an informer that would start or stop. Created lists
+// the resources a new informer would catalog; Orphaned lists the catalog
+// entries a stopped informer would leave behind.
+type DiscoveryScopeChange struct {
+	Key          string            `json:"key"`
+	Environment  string            `json:"environment"`
+	Namespace    string            `json:"namespace"`
+	ResourceType string            `json:"resourceType"`
+	Created      []string          `json:"created,omitempty"`
+	Orphaned     []CatalogEntryRef `json:"orphaned,omitempty"`
+	Error        string            `json:"error,omitempty" doc:"Why the affected resources could not be determined"`
+}
+
+// DiscoveryDiffResponse is the impact of applying a proposed DiscoveryConfig spec
+type DiscoveryDiffResponse struct {
+	Name             string                 `json:"name"`
+	InformersToStart []DiscoveryScopeChange `json:"informersToStart"`
+	InformersToStop  []DiscoveryScopeChange `json:"informersToStop"`
+	Unchanged        int                    `json:"unchanged" doc:"Number of informers kept running"`
+}
+
+// registerDiscoveryDiffRoute registers the admin endpoint previewing a DiscoveryConfig change
+func (h *EnvironmentHandler) registerDiscoveryDiffRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-discovery-config" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/discovery/diff",
+		Summary:     "Preview the informers and catalog entries affected by a DiscoveryConfig change",
+		Tags:        tags,
+	}, func(ctx context.Context, input *DiscoveryDiffInput) (*Response[DiscoveryDiffResponse], error) {
+		return h.diffDiscoveryConfig(ctx, input)
+	})
+}
+
+func (h *EnvironmentHandler) diffDiscoveryConfig(ctx context.Context, input *DiscoveryDiffInput) (*Response[DiscoveryDiffResponse], error) {
+	current, err := h.currentDiscoveryConfig(ctx, input.Body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	currentScopes := controller.DiscoveryScopes(current.Name, &current.Spec)
+	proposedScopes := controller.DiscoveryScopes(current.Name, &input.Body.Spec)
+	currentKeys := make(map[string]bool, len(currentScopes))
+	for _, scope := range currentScopes {
+		currentKeys[scope.Key] = true
+	}
+	proposedKeys := make(map[string]bool, len(proposedScopes))
+	for _, scope := range proposedScopes {
+		proposedKeys[scope.Key] = true
+	}
+
+	diff := DiscoveryDiffResponse{
+		Name:             current.Name,
+		InformersToStart: []DiscoveryScopeChange{},
+		InformersToStop:  []DiscoveryScopeChange{},
+	}
+
+	envs := make(map[string]*agentregistryv1alpha1.Environment, len(input.Body.Spec.Environments))
+	for i := range input.Body.Spec.Environments {
+		envs[input.Body.Spec.Environments[i].Name] = &input.Body.Spec.Environments[i]
+	}
+	for _, scope := range proposedScopes {
+		if currentKeys[scope.Key] {
+			diff.Unchanged++
+			continue
+		}
+		change := newDiscoveryScopeChange(scope)
+		created, err := controller.ListDiscoverableResources(ctx, envs[scope.Environment], scope, h.client.Scheme())
+		if err != nil {
+			change.Error = err.Error()
+		} else {
+			change.Created = created
+		}
+		diff.InformersToStart = append(diff.InformersToStart, change)
+	}
+
+	for _, scope := range currentScopes {
+		if proposedKeys[scope.Key] {
+			continue
+		}
+		change := newDiscoveryScopeChange(scope)
+		orphaned, err := h.discoveredEntries(ctx, scope)
+		if err != nil {
+			change.Error = err.Error()
+		} else {
+			change.Orphaned = orphaned
+		}
+		diff.InformersToStop = append(diff.InformersToStop, change)
+	}
+
+	return &Response[DiscoveryDiffResponse]{Body: diff}, nil
+}
+
+// currentDiscoveryConfig returns the DiscoveryConfig a proposed spec is
+// compared against. A named config that does not exist yet compares as empty.
+func (h *EnvironmentHandler) currentDiscoveryConfig(ctx context.Context, name string) (*agentregistryv1alpha1.DiscoveryConfig, error) {
+	if name != "" {
+		var dc agentregistryv1alpha1.DiscoveryConfig
+		err := h.client.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: name}, &dc)
+		switch {
+		case apierrors.IsNotFound(err):
+			dc = agentregistryv1alpha1.DiscoveryConfig{}
+			dc.Name = name
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to get DiscoveryConfig", err)
+		}
+		return &dc, nil
+	}
+
+	var list agentregistryv1alpha1.DiscoveryConfigList
+	if err := h.client.List(ctx, &list, client.InNamespace("agentregistry")); err != nil {
+		return nil, huma.Error500InternalServerError("failed to list DiscoveryConfigs", err)
+	}
+	if len(list.Items) != 1 {
+		return nil, huma.Error400BadRequest("name is required unless exactly one DiscoveryConfig exists")
+	}
+	return &list.Items[0], nil
+}
+
+// discoveredEntries lists the catalog entries discovered within scope
+func (h *EnvironmentHandler) discoveredEntries(ctx context.Context, scope controller.DiscoveryScope) ([]CatalogEntryRef, error) {
+	list, opts, err := controller.DiscoveredEntryListOptions(scope, config.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if err := h.client.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+
+	var refs []CatalogEntryRef
+	switch l := list.(type) {
+	case *agentregistryv1alpha1.MCPServerCatalogList:
+		for _, item := range l.Items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name, Version: item.Spec.Version})
+		}
+	case *agentregistryv1alpha1.AgentCatalogList:
+		for _, item := range l.Items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name, Version: item.Spec.Version})
+		}
+	case *agentregistryv1alpha1.ModelCatalogList:
+		for _, item := range l.Items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name})
+		}
+	}
+	return refs, nil
+}
+
+func newDiscoveryScopeChange(scope controller.DiscoveryScope) DiscoveryScopeChange {
+	return DiscoveryScopeChange{
+		Key:          scope.Key,
+		Environment:  scope.Environment,
+		Namespace:    scope.Namespace,
+		ResourceType: scope.ResourceType,
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	kagentv1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestEnvironmentHandler_DiffDiscoveryConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kagentv1alpha2.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	dc := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{{
+				Name:          "dev",
+				Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster"},
+				Namespaces:    []string{"team-a", "team-b"},
+				ResourceTypes: []string{"MCPServer"},
+			}},
+		},
+	}
+	// Discovered from team-b, which the proposed spec stops watching
+	orphan := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team-b-search",
+			Namespace: config.GetNamespace(),
+			Labels: map[string]string{
+				"agentregistry.dev/discovered":       "true",
+				"agentregistry.dev/source-kind":      "MCPServer",
+				"agentregistry.dev/source-namespace": "team-b",
+				controller.EnvironmentLabel:          "dev",
+			},
+		},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "team-b/search", Version: "1.0.0"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dc, orphan).Build()
+
+	remote := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&kagentv1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "planner", Namespace: "agents"},
+	}).Build()
+	oldFactory := controller.RemoteClientFactory
+	controller.RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+		return remote, nil
+	}
+	t.Cleanup(func() { controller.RemoteClientFactory = oldFactory })
+
+	_, api := humatest.New(t)
+	h := NewEnvironmentHandler(c, nil, zerolog.Nop())
+	h.RegisterRoutes(api, "/v0", false)
+	h.RegisterRoutes(api, "/admin/v0", true)
+
+	proposed := DiscoveryDiffRequest{
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{
+					Name:          "dev",
+					Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "dev-cluster"},
+					Namespaces:    []string{"team-a"},
+					ResourceTypes: []string{"MCPServer"},
+				},
+				{
+					Name:          "prod",
+					Cluster:       agentregistryv1alpha1.ClusterConfig{Name: "prod-cluster"},
+					Namespaces:    []string{"agents"},
+					ResourceTypes: []string{"Agent"},
+				},
+			},
+		},
+	}
+
+	// Previewing is admin only
+	resp := api.Post("/v0/discovery/diff", proposed)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = api.Post("/admin/v0/discovery/diff", proposed)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var diff DiscoveryDiffResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &diff))
+
+	assert.Equal(t, "discovery", diff.Name)
+	assert.Equal(t, 1, diff.Unchanged)
+
+	require.Len(t, diff.InformersToStart, 1)
+	assert.Equal(t, "discovery/prod/agents/Agent", diff.InformersToStart[0].Key)
+	assert.Equal(t, []string{"planner"}, diff.InformersToStart[0].Created)
+	assert.Empty(t, diff.InformersToStart[0].Error)
+
+	require.Len(t, diff.InformersToStop, 1)
+	assert.Equal(t, "discovery/dev/team-b/MCPServer", diff.InformersToStop[0].Key)
+	assert.Equal(t, []CatalogEntryRef{{Name: "team-b/search", Version: "1.0.0"}}, diff.InformersToStop[0].Orphaned)
+
+	// A config that does not exist yet compares as empty
+	proposed.Name = "new-discovery"
+	resp = api.Post("/admin/v0/discovery/diff", proposed)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &diff))
+	assert.Len(t, diff.InformersToStart, 2)
+	assert.Empty(t, diff.InformersToStop)
+	for _, change := range diff.InformersToStart {
+		assert.Empty(t, change.Error)
+	}
+}
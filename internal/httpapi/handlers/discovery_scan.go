@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// discoveryScanPollInterval is how often a waiting scan request checks whether
+// the controller has completed the scan
+var discoveryScanPollInterval = 500 * time.Millisecond
+
+type DiscoveryScanInput struct {
+	Name    string `path:"name" doc:"DiscoveryConfig to scan"`
+	Wait    bool   `query:"wait" doc:"Wait for the scan to complete and return its results"`
+	Timeout int    `query:"timeout" default:"30" minimum:"1" maximum:"120" doc:"Seconds to wait for the scan when wait is set"`
+}
+
+// DiscoveryScanResponse is the per-environment discovery status of a
+// DiscoveryConfig after a scan was triggered
+type DiscoveryScanResponse struct {
+	DiscoveryMapConfig
+	Completed bool `json:"completed" doc:"Every environment has synced or failed; false when not waiting or when the wait timed out"`
+}
+
+// registerDiscoveryScanRoute registers the admin endpoint triggering a discovery scan
+func (h *EnvironmentHandler) registerDiscoveryScanRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "scan-discovery-config" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/discovery/{name}/scan",
+		Summary:     "Trigger a discovery scan and optionally wait for its results",
+		Tags:        tags,
+	}, func(ctx context.Context, input *DiscoveryScanInput) (*Response[DiscoveryScanResponse], error) {
+		return h.scanDiscoveryConfig(ctx, input)
+	})
+}
+
+func (h *EnvironmentHandler) scanDiscoveryConfig(ctx context.Context, input *DiscoveryScanInput) (*Response[DiscoveryScanResponse], error) {
+	name, err := url.PathUnescape(input.Name)
+	if err != nil {
+		return nil, invalidName("Invalid DiscoveryConfig name encoding", err)
+	}
+
+	var dc agentregistryv1alpha1.DiscoveryConfig
+	key := client.ObjectKey{Namespace: "agentregistry", Name: name}
+	if err := h.client.Get(ctx, key, &dc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, huma.Error404NotFound("DiscoveryConfig not found")
+		}
+		return nil, huma.Error500InternalServerError("failed to get DiscoveryConfig", err)
+	}
+	if err := controller.TriggerDiscoveryScan(ctx, h.client, &dc); err != nil {
+		return nil, huma.Error500InternalServerError("failed to trigger discovery scan", err)
+	}
+	if !input.Wait {
+		return discoveryScanResponse(&dc, false), nil
+	}
+
+	timeout := time.Duration(input.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(discoveryScanPollInterval)
+	defer ticker.Stop()
+
+	// The controller removes the scan annotation once every environment has
+	// synced or failed, after writing the resulting status
+	for {
+		select {
+		case <-waitCtx.Done():
+			h.logger.Info().Str("discoveryconfig", name).Dur("timeout", timeout).Msg("discovery scan did not complete in time")
+			return discoveryScanResponse(&dc, false), nil
+		case <-ticker.C:
+		}
+		if err := h.client.Get(ctx, key, &dc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, huma.Error404NotFound("DiscoveryConfig not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to get DiscoveryConfig", err)
+		}
+		if !controller.DiscoveryScanPending(&dc) {
+			return discoveryScanResponse(&dc, controller.DiscoveryScanComplete(&dc)), nil
+		}
+	}
+}
+
+func discoveryScanResponse(dc *agentregistryv1alpha1.DiscoveryConfig, completed bool) *Response[DiscoveryScanResponse] {
+	SortDiscoveryConfigs([]agentregistryv1alpha1.DiscoveryConfig{*dc})
+	return &Response[DiscoveryScanResponse]{
+		Body: DiscoveryScanResponse{
+			DiscoveryMapConfig: discoveryMapConfig(dc),
+			Completed:          completed,
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestEnvironmentHandler_ScanDiscoveryConfig(t *testing.T) {
+	defer func(interval time.Duration) { discoveryScanPollInterval = interval }(discoveryScanPollInterval)
+	discoveryScanPollInterval = 10 * time.Millisecond
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	dc := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{
+				{Name: "prod", Namespaces: []string{"tools"}},
+				{Name: "dev", Namespaces: []string{"tools"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dc).WithStatusSubresource(dc).Build()
+	h := NewEnvironmentHandler(c, nil, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Stand in for the controller: write the scan results, then consume the
+	// scan annotation
+	go func() {
+		for ctx.Err() == nil {
+			time.Sleep(5 * time.Millisecond)
+			var current agentregistryv1alpha1.DiscoveryConfig
+			if err := c.Get(ctx, client.ObjectKeyFromObject(dc), &current); err != nil || !controller.DiscoveryScanPending(&current) {
+				continue
+			}
+			current.Status.Environments = []agentregistryv1alpha1.EnvironmentStatus{
+				{Name: "prod", Connected: true, DiscoveredResources: agentregistryv1alpha1.DiscoveredResourceCounts{MCPServers: 3, Agents: 1}},
+				{Name: "dev", Error: "failed to create remote client"},
+			}
+			current.Status.Conditions = []metav1.Condition{{
+				Type:               "Ready",
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: current.Generation,
+				LastTransitionTime: metav1.Now(),
+				Reason:             "InformersStarted",
+			}}
+			if err := c.Status().Update(ctx, &current); err != nil {
+				continue
+			}
+			patch := client.MergeFrom(current.DeepCopy())
+			delete(current.Annotations, controller.DiscoveryScanAnnotation)
+			_ = c.Patch(ctx, &current, patch)
+			return
+		}
+	}()
+
+	resp, err := h.scanDiscoveryConfig(ctx, &DiscoveryScanInput{Name: "default", Wait: true, Timeout: 5})
+	require.NoError(t, err)
+	assert.True(t, resp.Body.Completed)
+	assert.Equal(t, "default", resp.Body.Name)
+	require.Len(t, resp.Body.Environments, 2)
+	assert.Equal(t, "dev", resp.Body.Environments[0].Name)
+	assert.Equal(t, "failed to create remote client", resp.Body.Environments[0].Error)
+	assert.Equal(t, "prod", resp.Body.Environments[1].Name)
+	assert.True(t, resp.Body.Environments[1].Connected)
+	assert.Equal(t, DiscoveryMapResourceCounts{MCPServers: 3, Agents: 1}, resp.Body.Environments[1].DiscoveredResources)
+}
+
+func TestEnvironmentHandler_ScanDiscoveryConfig_NoWait(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	dc := &agentregistryv1alpha1.DiscoveryConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "agentregistry"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dc).Build()
+	h := NewEnvironmentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := h.scanDiscoveryConfig(ctx, &DiscoveryScanInput{Name: "default"})
+	require.NoError(t, err)
+	assert.False(t, resp.Body.Completed)
+
+	var updated agentregistryv1alpha1.DiscoveryConfig
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(dc), &updated))
+	assert.True(t, controller.DiscoveryScanPending(&updated), "the scan is requested")
+
+	_, err = h.scanDiscoveryConfig(ctx, &DiscoveryScanInput{Name: "missing"})
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.GetStatus())
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
+)
+
+type CompareEnvironmentsInput struct {
+	From string `query:"from" json:"from" required:"true" doc:"Baseline environment"`
+	To   string `query:"to" json:"to" required:"true" doc:"Environment compared against the baseline"`
+}
+
+// CatalogEntryRef identifies a catalog entry by name and version
+type CatalogEntryRef struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// CatalogVersionChange is an entry present in both environments at different versions
+type CatalogVersionChange struct {
+	Name        string `json:"name"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+}
+
+// CatalogTypeComparison holds the differences for one catalog type. Added
+// entries exist only in the "to" environment, removed entries only in "from".
+type CatalogTypeComparison struct {
+	Added          []CatalogEntryRef      `json:"added"`
+	Removed        []CatalogEntryRef      `json:"removed"`
+	VersionChanged []CatalogVersionChange `json:"versionChanged"`
+}
+
+// EnvironmentComparison is the per-type catalog diff between two environments
+type EnvironmentComparison struct {
+	From       string                `json:"from"`
+	To         string                `json:"to"`
+	MCPServers CatalogTypeComparison `json:"mcpServers"`
+	Agents     CatalogTypeComparison `json:"agents"`
+	Skills     CatalogTypeComparison `json:"skills"`
+	Models     CatalogTypeComparison `json:"models"`
+}
+
+func (h *EnvironmentHandler) compareEnvironments(ctx context.Context, input *CompareEnvironmentsInput) (*Response[EnvironmentComparison], error) {
+	if input.From == "" || input.To == "" {
+		return nil, huma.Error400BadRequest("Both from and to environments are required")
+	}
+
+	reader := client.Reader(h.client)
+	if h.cache != nil {
+		reader = h.cache
+	}
+
+	comparison, err := CompareEnvironmentCatalogs(ctx, reader, input.From, input.To)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to compare environments", err)
+	}
+
+	return &Response[EnvironmentComparison]{Body: *comparison}, nil
+}
+
+// CompareEnvironmentCatalogs diffs the catalog entries labelled with the from
+// and to environments. Entries are matched by name; when an environment holds
+// several versions of the same entry, the highest version is compared.
+func CompareEnvironmentCatalogs(ctx context.Context, reader client.Reader, from, to string) (*EnvironmentComparison, error) {
+	comparison := &EnvironmentComparison{From: from, To: to}
+
+	var err error
+	if comparison.MCPServers, err = compareCatalogType(ctx, reader, from, to, &agentregistryv1alpha1.MCPServerCatalogList{}, func(list client.ObjectList) []CatalogEntryRef {
+		items := list.(*agentregistryv1alpha1.MCPServerCatalogList).Items
+		refs := make([]CatalogEntryRef, 0, len(items))
+		for _, item := range items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name, Version: item.Spec.Version})
+		}
+		return refs
+	}); err != nil {
+		return nil, err
+	}
+
+	if comparison.Agents, err = compareCatalogType(ctx, reader, from, to, &agentregistryv1alpha1.AgentCatalogList{}, func(list client.ObjectList) []CatalogEntryRef {
+		items := list.(*agentregistryv1alpha1.AgentCatalogList).Items
+		refs := make([]CatalogEntryRef, 0, len(items))
+		for _, item := range items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name, Version: item.Spec.Version})
+		}
+		return refs
+	}); err != nil {
+		return nil, err
+	}
+
+	if comparison.Skills, err = compareCatalogType(ctx, reader, from, to, &agentregistryv1alpha1.SkillCatalogList{}, func(list client.ObjectList) []CatalogEntryRef {
+		items := list.(*agentregistryv1alpha1.SkillCatalogList).Items
+		refs := make([]CatalogEntryRef, 0, len(items))
+		for _, item := range items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name, Version: item.Spec.Version})
+		}
+		return refs
+	}); err != nil {
+		return nil, err
+	}
+
+	// Models are not versioned; they are compared by name only.
+	if comparison.Models, err = compareCatalogType(ctx, reader, from, to, &agentregistryv1alpha1.ModelCatalogList{}, func(list client.ObjectList) []CatalogEntryRef {
+		items := list.(*agentregistryv1alpha1.ModelCatalogList).Items
+		refs := make([]CatalogEntryRef, 0, len(items))
+		for _, item := range items {
+			refs = append(refs, CatalogEntryRef{Name: item.Spec.Name})
+		}
+		return refs
+	}); err != nil {
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+// compareCatalogType lists one catalog type in both environments and diffs
+// the resulting entries. list is reused for both queries.
+func compareCatalogType(
+	ctx context.Context,
+	reader client.Reader,
+	from, to string,
+	list client.ObjectList,
+	refs func(client.ObjectList) []CatalogEntryRef,
+) (CatalogTypeComparison, error) {
+	envEntries := func(env string) (map[string]string, error) {
+		if err := reader.List(ctx, list, client.MatchingLabels{controller.EnvironmentLabel: env}); err != nil {
+			return nil, err
+		}
+		latest := map[string]string{}
+		for _, ref := range refs(list) {
+			if current, ok := latest[ref.Name]; !ok || semver.Compare(ref.Version, current) > 0 {
+				latest[ref.Name] = ref.Version
+			}
+		}
+		return latest, nil
+	}
+
+	fromEntries, err := envEntries(from)
+	if err != nil {
+		return CatalogTypeComparison{}, err
+	}
+	toEntries, err := envEntries(to)
+	if err != nil {
+		return CatalogTypeComparison{}, err
+	}
+
+	result := CatalogTypeComparison{
+		Added:          []CatalogEntryRef{},
+		Removed:        []CatalogEntryRef{},
+		VersionChanged: []CatalogVersionChange{},
+	}
+	for name, toVersion := range toEntries {
+		fromVersion, ok := fromEntries[name]
+		switch {
+		case !ok:
+			result.Added = append(result.Added, CatalogEntryRef{Name: name, Version: toVersion})
+		case fromVersion != toVersion:
+			result.VersionChanged = append(result.VersionChanged, CatalogVersionChange{Name: name, FromVersion: fromVersion, ToVersion: toVersion})
+		}
+	}
+	for name, fromVersion := range fromEntries {
+		if _, ok := toEntries[name]; !ok {
+			result.Removed = append(result.Removed, CatalogEntryRef{Name: name, Version: fromVersion})
+		}
+	}
+
+	sort.Slice(result.Added, func(i, j int) bool { return result.Added[i].Name < result.Added[j].Name })
+	sort.Slice(result.Removed, func(i, j int) bool { return result.Removed[i].Name < result.Removed[j].Name })
+	sort.Slice(result.VersionChanged, func(i, j int) bool { return result.VersionChanged[i].Name < result.VersionChanged[j].Name })
+	return result, nil
+}
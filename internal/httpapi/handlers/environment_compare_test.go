@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func envServer(env, name, version string) *agentregistryv1alpha1.MCPServerCatalog {
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      env + "-" + GenerateCRName(name, version),
+			Namespace: "agentregistry",
+			Labels:    map[string]string{controller.EnvironmentLabel: env},
+		},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: version},
+	}
+}
+
+func envAgent(env, name, version string) *agentregistryv1alpha1.AgentCatalog {
+	return &agentregistryv1alpha1.AgentCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      env + "-" + GenerateCRName(name, version),
+			Namespace: "agentregistry",
+			Labels:    map[string]string{controller.EnvironmentLabel: env},
+		},
+		Spec: agentregistryv1alpha1.AgentCatalogSpec{Name: name, Version: version},
+	}
+}
+
+func TestEnvironmentHandler_CompareEnvironments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	objs := []client.Object{
+		// In both at the same version: not reported
+		envServer("staging", "shared", "1.0.0"),
+		envServer("prod", "shared", "1.0.0"),
+		// Version differs; staging holds two versions and the highest is compared
+		envServer("staging", "upgraded", "1.0.0"),
+		envServer("staging", "upgraded", "1.2.0"),
+		envServer("prod", "upgraded", "1.1.0"),
+		// Only in prod
+		envServer("prod", "prod-only", "3.0.0"),
+		// Only in staging
+		envServer("staging", "staging-only", "0.1.0"),
+		// Unrelated environment is ignored
+		envServer("dev", "dev-only", "0.0.1"),
+		envAgent("prod", "planner", "2.0.0"),
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	handler := NewEnvironmentHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.compareEnvironments(context.Background(), &CompareEnvironmentsInput{From: "staging", To: "prod"})
+	require.NoError(t, err)
+	cmp := resp.Body
+
+	assert.Equal(t, "staging", cmp.From)
+	assert.Equal(t, "prod", cmp.To)
+	assert.Equal(t, []CatalogEntryRef{{Name: "prod-only", Version: "3.0.0"}}, cmp.MCPServers.Added)
+	assert.Equal(t, []CatalogEntryRef{{Name: "staging-only", Version: "0.1.0"}}, cmp.MCPServers.Removed)
+	assert.Equal(t, []CatalogVersionChange{{Name: "upgraded", FromVersion: "1.2.0", ToVersion: "1.1.0"}}, cmp.MCPServers.VersionChanged)
+
+	assert.Equal(t, []CatalogEntryRef{{Name: "planner", Version: "2.0.0"}}, cmp.Agents.Added)
+	assert.Empty(t, cmp.Agents.Removed)
+	assert.Empty(t, cmp.Skills.Added)
+	assert.Empty(t, cmp.Models.Added)
+}
+
+func TestEnvironmentHandler_CompareEnvironments_MissingParams(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	handler := NewEnvironmentHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, zerolog.Nop())
+
+	_, err := handler.compareEnvironments(context.Background(), &CompareEnvironmentsInput{From: "staging"})
+	require.Error(t, err)
+}
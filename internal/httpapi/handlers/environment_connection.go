@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+type EnvironmentConnectionInput struct {
+	Name        string `path:"name" doc:"DiscoveryConfig declaring the environment"`
+	Environment string `path:"environment" doc:"Environment to test"`
+}
+
+// registerEnvironmentConnectionRoute registers the admin endpoint testing the
+// connection to an environment's cluster
+func (h *EnvironmentHandler) registerEnvironmentConnectionRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "test-environment-connection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/discovery/{name}/environments/{environment}/connection",
+		Summary:     "Test that discovery can reach an environment's cluster, without starting an informer",
+		Tags:        tags,
+	}, func(ctx context.Context, input *EnvironmentConnectionInput) (*Response[controller.EnvironmentConnection], error) {
+		return h.testEnvironmentConnection(ctx, input)
+	})
+}
+
+func (h *EnvironmentHandler) testEnvironmentConnection(ctx context.Context, input *EnvironmentConnectionInput) (*Response[controller.EnvironmentConnection], error) {
+	name, err := url.PathUnescape(input.Name)
+	if err != nil {
+		return nil, invalidName("Invalid DiscoveryConfig name encoding", err)
+	}
+	env, err := findDiscoveryEnvironment(ctx, h.client, name, input.Environment)
+	if err != nil {
+		return nil, err
+	}
+	result := controller.CheckEnvironmentConnection(ctx, env, h.client.Scheme())
+	if !result.Connected {
+		h.logger.Info().Str("discoveryconfig", name).Str("environment", env.Name).Str("error", result.Error).Msg("environment connection test failed")
+	}
+	return &Response[controller.EnvironmentConnection]{Body: result}, nil
+}
+
+// findDiscoveryEnvironment returns the environment named environment of the
+// DiscoveryConfig name, or a 404 error when either does not exist
+func findDiscoveryEnvironment(ctx context.Context, reader client.Reader, name, environment string) (*agentregistryv1alpha1.Environment, error) {
+	var dc agentregistryv1alpha1.DiscoveryConfig
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: name}, &dc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, huma.Error404NotFound("DiscoveryConfig not found")
+		}
+		return nil, huma.Error500InternalServerError("failed to get DiscoveryConfig", err)
+	}
+	for i := range dc.Spec.Environments {
+		if dc.Spec.Environments[i].Name == environment {
+			return &dc.Spec.Environments[i], nil
+		}
+	}
+	return nil, huma.Error404NotFound("Environment not found in DiscoveryConfig")
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestEnvironmentHandler_TestEnvironmentConnection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&agentregistryv1alpha1.DiscoveryConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+				Environments: []agentregistryv1alpha1.Environment{
+					{Name: "prod", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "prod-gke"}, Namespaces: []string{"agents"}, ResourceTypes: []string{"Agent"}},
+				},
+			},
+		}).
+		Build()
+
+	oldFactory := controller.RemoteClientFactory
+	controller.RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+		return nil, errors.New("x509: certificate signed by unknown authority")
+	}
+	t.Cleanup(func() { controller.RemoteClientFactory = oldFactory })
+
+	h := NewEnvironmentHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := h.testEnvironmentConnection(ctx, &EnvironmentConnectionInput{Name: "discovery", Environment: "prod"})
+	require.NoError(t, err)
+	assert.False(t, resp.Body.Connected)
+	assert.Equal(t, "x509: certificate signed by unknown authority", resp.Body.Error)
+	assert.Equal(t, "prod-gke", resp.Body.Cluster)
+	assert.Equal(t, "agents", resp.Body.Namespace)
+	assert.Equal(t, "Agent", resp.Body.ResourceType)
+
+	for name, input := range map[string]*EnvironmentConnectionInput{
+		"unknown config":      {Name: "missing", Environment: "prod"},
+		"unknown environment": {Name: "discovery", Environment: "staging"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := h.testEnvironmentConnection(ctx, input)
+			var statusErr interface{ GetStatus() int }
+			require.True(t, errors.As(err, &statusErr), "expected an API error, got %v", err)
+			assert.Equal(t, http.StatusNotFound, statusErr.GetStatus())
+		})
+	}
+}
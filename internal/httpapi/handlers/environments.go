@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"cmp"
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -110,6 +112,17 @@ func (h *EnvironmentHandler) RegisterRoutes(api huma.API, pathPrefix string, isA
 		return h.listEnvironments(ctx)
 	})
 
+	// Compare catalog entries between two environments
+	huma.Register(api, huma.Operation{
+		OperationID: "compare-environments" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/environments/compare",
+		Summary:     "Compare catalog entries between two environments",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CompareEnvironmentsInput) (*Response[EnvironmentComparison], error) {
+		return h.compareEnvironments(ctx, input)
+	})
+
 	// Discovery map for topology visualization
 	huma.Register(api, huma.Operation{
 		OperationID: "get-discovery-map" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -120,6 +133,12 @@ func (h *EnvironmentHandler) RegisterRoutes(api huma.API, pathPrefix string, isA
 	}, func(ctx context.Context, input *struct{}) (*Response[DiscoveryMapResponse], error) {
 		return h.getDiscoveryMap(ctx)
 	})
+
+	if isAdmin {
+		h.registerDiscoveryDiffRoute(api, pathPrefix, tags)
+		h.registerDiscoveryScanRoute(api, pathPrefix, tags)
+		h.registerEnvironmentConnectionRoute(api, pathPrefix, tags)
+	}
 }
 
 func (h *EnvironmentHandler) listEnvironments(ctx context.Context) (*Response[EnvironmentListResponse], error) {
@@ -127,6 +146,7 @@ func (h *EnvironmentHandler) listEnvironments(ctx context.Context) (*Response[En
 	if err := h.client.List(ctx, &list, client.InNamespace("agentregistry")); err != nil {
 		return nil, huma.Error500InternalServerError("failed to list DiscoveryConfigs", err)
 	}
+	SortDiscoveryConfigs(list.Items)
 
 	environments := make([]EnvironmentJSON, 0)
 	for _, dc := range list.Items {
@@ -150,6 +170,7 @@ func (h *EnvironmentHandler) listEnvironments(ctx context.Context) (*Response[En
 			})
 		}
 	}
+	slices.SortStableFunc(environments, func(a, b EnvironmentJSON) int { return cmp.Compare(a.Name, b.Name) })
 
 	return &Response[EnvironmentListResponse]{
 		Body: EnvironmentListResponse{
@@ -164,66 +185,86 @@ func (h *EnvironmentHandler) getDiscoveryMap(ctx context.Context) (*Response[Dis
 	if err := h.client.List(ctx, &list, client.InNamespace("agentregistry")); err != nil {
 		return nil, huma.Error500InternalServerError("failed to list DiscoveryConfigs", err)
 	}
+	SortDiscoveryConfigs(list.Items)
 
 	configs := make([]DiscoveryMapConfig, 0, len(list.Items))
-	for _, dc := range list.Items {
-		// Build status lookup by environment name
-		statusByEnv := make(map[string]agentregistryv1alpha1.EnvironmentStatus)
-		for _, es := range dc.Status.Environments {
-			statusByEnv[es.Name] = es
-		}
+	for i := range list.Items {
+		configs = append(configs, discoveryMapConfig(&list.Items[i]))
+	}
 
-		envs := make([]DiscoveryMapEnvironment, 0, len(dc.Spec.Environments))
-		for _, env := range dc.Spec.Environments {
-			mapEnv := DiscoveryMapEnvironment{
-				Name: env.Name,
-				Cluster: DiscoveryMapCluster{
-					Name:     env.Cluster.Name,
-					Provider: env.Provider,
-					Zone:     env.Cluster.Zone,
-					Region:   env.Cluster.Region,
-				},
-				Namespaces:       env.Namespaces,
-				ResourceTypes:    env.ResourceTypes,
-				DiscoveryEnabled: env.DiscoveryEnabled,
-				Labels:           env.Labels,
-			}
+	return &Response[DiscoveryMapResponse]{
+		Body: DiscoveryMapResponse{
+			Configs: configs,
+		},
+	}, nil
+}
 
-			// Merge status if available
-			if es, ok := statusByEnv[env.Name]; ok {
-				mapEnv.Connected = es.Connected
-				mapEnv.Error = es.Error
-				if es.LastSyncTime != nil {
-					t := es.LastSyncTime.Time
-					mapEnv.LastSyncTime = &t
-				}
-				mapEnv.DiscoveredResources = DiscoveryMapResourceCounts{
-					MCPServers: es.DiscoveredResources.MCPServers,
-					Agents:     es.DiscoveredResources.Agents,
-					Skills:     es.DiscoveredResources.Skills,
-					Models:     es.DiscoveredResources.Models,
-				}
-			}
+// discoveryMapConfig merges the spec and per-environment status of dc
+func discoveryMapConfig(dc *agentregistryv1alpha1.DiscoveryConfig) DiscoveryMapConfig {
+	// Build status lookup by environment name
+	statusByEnv := make(map[string]agentregistryv1alpha1.EnvironmentStatus)
+	for _, es := range dc.Status.Environments {
+		statusByEnv[es.Name] = es
+	}
 
-			envs = append(envs, mapEnv)
+	envs := make([]DiscoveryMapEnvironment, 0, len(dc.Spec.Environments))
+	for _, env := range dc.Spec.Environments {
+		mapEnv := DiscoveryMapEnvironment{
+			Name: env.Name,
+			Cluster: DiscoveryMapCluster{
+				Name:     env.Cluster.Name,
+				Provider: env.Provider,
+				Zone:     env.Cluster.Zone,
+				Region:   env.Cluster.Region,
+			},
+			Namespaces:       env.Namespaces,
+			ResourceTypes:    env.ResourceTypes,
+			DiscoveryEnabled: env.DiscoveryEnabled,
+			Labels:           env.Labels,
 		}
 
-		var lastSync *time.Time
-		if dc.Status.LastSyncTime != nil {
-			t := dc.Status.LastSyncTime.Time
-			lastSync = &t
+		// Merge status if available
+		if es, ok := statusByEnv[env.Name]; ok {
+			mapEnv.Connected = es.Connected
+			mapEnv.Error = es.Error
+			if es.LastSyncTime != nil {
+				t := es.LastSyncTime.Time
+				mapEnv.LastSyncTime = &t
+			}
+			mapEnv.DiscoveredResources = DiscoveryMapResourceCounts{
+				MCPServers: es.DiscoveredResources.MCPServers,
+				Agents:     es.DiscoveredResources.Agents,
+				Skills:     es.DiscoveredResources.Skills,
+				Models:     es.DiscoveredResources.Models,
+			}
 		}
 
-		configs = append(configs, DiscoveryMapConfig{
-			Name:         dc.Name,
-			Environments: envs,
-			LastSyncTime: lastSync,
-		})
+		envs = append(envs, mapEnv)
 	}
 
-	return &Response[DiscoveryMapResponse]{
-		Body: DiscoveryMapResponse{
-			Configs: configs,
-		},
-	}, nil
+	var lastSync *time.Time
+	if dc.Status.LastSyncTime != nil {
+		t := dc.Status.LastSyncTime.Time
+		lastSync = &t
+	}
+
+	return DiscoveryMapConfig{
+		Name:         dc.Name,
+		Environments: envs,
+		LastSyncTime: lastSync,
+	}
+}
+
+// SortDiscoveryConfigs orders configs, and the environments of each config, by
+// name. Listings built from them then stay put when operators reorder or edit
+// the environments of a DiscoveryConfig.
+func SortDiscoveryConfigs(configs []agentregistryv1alpha1.DiscoveryConfig) {
+	slices.SortFunc(configs, func(a, b agentregistryv1alpha1.DiscoveryConfig) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i := range configs {
+		slices.SortStableFunc(configs[i].Spec.Environments, func(a, b agentregistryv1alpha1.Environment) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+	}
 }
@@ -110,3 +110,45 @@ func TestEnvironmentHandler_ListEnvironments_FallbackToNamespaces(t *testing.T)
 	// Should fall back to first namespace
 	assert.Equal(t, "dev-ns", resp.Body.Environments[0].Namespace)
 }
+
+func TestEnvironmentHandler_StableOrdering(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	newConfig := func(name string, envs ...string) *agentregistryv1alpha1.DiscoveryConfig {
+		dc := &agentregistryv1alpha1.DiscoveryConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+		}
+		for _, env := range envs {
+			dc.Spec.Environments = append(dc.Spec.Environments, agentregistryv1alpha1.Environment{Name: env})
+		}
+		return dc
+	}
+	for _, envs := range [][]string{{"prod", "dev", "staging"}, {"staging", "prod", "dev"}} {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(newConfig("zeta", envs...), newConfig("alpha", "qa", "edge")).
+			Build()
+		handler := NewEnvironmentHandler(c, nil, zerolog.Nop())
+
+		discoveryMap, err := handler.getDiscoveryMap(context.Background())
+		require.NoError(t, err)
+		var layout [][]string
+		for _, config := range discoveryMap.Body.Configs {
+			names := []string{config.Name}
+			for _, env := range config.Environments {
+				names = append(names, env.Name)
+			}
+			layout = append(layout, names)
+		}
+		assert.Equal(t, [][]string{{"alpha", "edge", "qa"}, {"zeta", "dev", "prod", "staging"}}, layout, "spec order %v", envs)
+
+		list, err := handler.listEnvironments(context.Background())
+		require.NoError(t, err)
+		var names []string
+		for _, env := range list.Body.Environments {
+			names = append(names, env.Name)
+		}
+		assert.Equal(t, []string{"dev", "edge", "prod", "qa", "staging"}, names, "spec order %v", envs)
+	}
+}
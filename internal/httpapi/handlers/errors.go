@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ErrorCode is a stable, machine-readable identifier for an API error.
+// Clients should branch on the code rather than the message.
+type ErrorCode string
+
+const (
+	// CodeInvalidRequest is returned for malformed or incomplete requests
+	CodeInvalidRequest ErrorCode = "INVALID_REQUEST"
+	// CodeInvalidName is returned when a name path parameter cannot be decoded or is invalid
+	CodeInvalidName ErrorCode = "INVALID_NAME"
+	// CodeInvalidVersion is returned when a version cannot be decoded or is not a valid version
+	CodeInvalidVersion ErrorCode = "INVALID_VERSION"
+	// CodeValidationFailed is returned when the request body fails schema validation
+	CodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	// CodeUnauthorized is returned when the request is not authenticated
+	CodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	// CodeForbidden is returned when the caller may not perform the operation
+	CodeForbidden ErrorCode = "FORBIDDEN"
+	// CodeRegistryTypeNotAllowed is returned when a package's registry type is not deployable
+	CodeRegistryTypeNotAllowed ErrorCode = "REGISTRY_TYPE_NOT_ALLOWED"
+	// CodeInvalidConfig is returned in strict mode when a deployment config does not match the catalog entry's inputs
+	CodeInvalidConfig ErrorCode = "INVALID_CONFIG"
+	// CodeNotFound is returned for missing resources without a more specific code
+	CodeNotFound ErrorCode = "NOT_FOUND"
+	// CodeCatalogNotFound is returned when a server, agent, skill or model catalog entry does not exist
+	CodeCatalogNotFound ErrorCode = "CATALOG_NOT_FOUND"
+	// CodeDeploymentNotFound is returned when a deployment does not exist
+	CodeDeploymentNotFound ErrorCode = "DEPLOYMENT_NOT_FOUND"
+	// CodeConflict is returned when the request conflicts with existing state
+	CodeConflict ErrorCode = "CONFLICT"
+	// CodeRemoteClusterUnreachable is returned when an environment's cluster cannot be reached
+	CodeRemoteClusterUnreachable ErrorCode = "REMOTE_CLUSTER_UNREACHABLE"
+	// CodeUpstreamUnavailable is returned when an external source fails or returns an error
+	CodeUpstreamUnavailable ErrorCode = "UPSTREAM_UNAVAILABLE"
+	// CodeInternal is returned for unexpected server-side failures
+	CodeInternal ErrorCode = "INTERNAL_ERROR"
+)
+
+// ErrorResponse is the JSON body of every API error. Besides the code, it
+// keeps the RFC 7807 title, status and detail fields huma's default error body
+// has, so clients reading those keep working.
+type ErrorResponse struct {
+	Code    ErrorCode `json:"code" doc:"Stable machine-readable error code"`
+	Message string    `json:"message" doc:"Human-readable summary of the error"`
+	Details []string  `json:"details,omitempty" doc:"Underlying causes, when available"`
+
+	Title  string `json:"title" doc:"HTTP status text"`
+	Status int    `json:"status" doc:"HTTP status code"`
+	Detail string `json:"detail" doc:"Message followed by the underlying causes"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return e.Message
+}
+
+// GetStatus returns the HTTP status code of the error
+func (e *ErrorResponse) GetStatus() int {
+	return e.Status
+}
+
+// NewError builds an ErrorResponse with the default code for status. Assign
+// it to huma.NewError when building the API so that huma.ErrorXXX helpers and
+// huma's own request validation failures produce structured bodies; use
+// newCodedError when a more specific code applies.
+func NewError(status int, msg string, errs ...error) huma.StatusError {
+	return newCodedError(status, defaultErrorCode(status), msg, errs...)
+}
+
+// newCodedError builds an ErrorResponse with an explicit code
+func newCodedError(status int, code ErrorCode, msg string, errs ...error) *ErrorResponse {
+	resp := &ErrorResponse{Code: code, Message: msg, Title: http.StatusText(status), Status: status, Detail: msg}
+	for _, err := range errs {
+		if err != nil {
+			resp.Details = append(resp.Details, err.Error())
+		}
+	}
+	if len(resp.Details) > 0 {
+		resp.Detail = msg + ": " + strings.Join(resp.Details, "; ")
+	}
+	return resp
+}
+
+// defaultErrorCode maps an HTTP status to the code used when no more
+// specific code applies
+func defaultErrorCode(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return CodeUpstreamUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// catalogNotFound reports a missing server, agent, skill or model
+func catalogNotFound(msg string, errs ...error) error {
+	return newCodedError(http.StatusNotFound, CodeCatalogNotFound, msg, errs...)
+}
+
+// deploymentNotFound reports a missing deployment
+func deploymentNotFound(errs ...error) error {
+	return newCodedError(http.StatusNotFound, CodeDeploymentNotFound, "Deployment not found", errs...)
+}
+
+// invalidName reports a name that cannot be decoded or is invalid
+func invalidName(msg string, errs ...error) error {
+	return newCodedError(http.StatusBadRequest, CodeInvalidName, msg, errs...)
+}
+
+// invalidVersion reports a version that cannot be decoded or is invalid
+func invalidVersion(msg string, errs ...error) error {
+	return newCodedError(http.StatusBadRequest, CodeInvalidVersion, msg, errs...)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// As NewServer does when building the API
+	huma.NewError = NewError
+	os.Exit(m.Run())
+}
+
+func TestErrorResponse_JSONBody(t *testing.T) {
+	_, api := humatest.New(t)
+	NewServerHandler(setupServerVersionsTestClient(t), nil, zerolog.Nop()).RegisterRoutes(api, "/v0", true)
+
+	decode := func(body []byte) map[string]any {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(body, &m))
+		return m
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		resp := api.Get("/v0/servers/missing")
+		require.Equal(t, http.StatusNotFound, resp.Code)
+
+		body := decode(resp.Body.Bytes())
+		assert.Equal(t, string(CodeCatalogNotFound), body["code"])
+		assert.Equal(t, "Server not found", body["message"])
+		assert.NotContains(t, body, "details")
+		// The RFC 7807 fields are kept for clients reading them
+		assert.Equal(t, "Not Found", body["title"])
+		assert.EqualValues(t, http.StatusNotFound, body["status"])
+		assert.Equal(t, "Server not found", body["detail"])
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		resp := api.Post("/v0/servers", map[string]any{
+			"name":        "example/server",
+			"version":     "not-a-version",
+			"description": "Example server",
+		})
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+
+		body := decode(resp.Body.Bytes())
+		assert.Equal(t, string(CodeInvalidVersion), body["code"])
+		assert.Equal(t, "Invalid version", body["message"])
+		details, ok := body["details"].([]any)
+		require.True(t, ok, "details should be a list")
+		assert.NotEmpty(t, details)
+		assert.Contains(t, body["detail"], "Invalid version: ")
+	})
+}
+
+func TestNewError_DefaultCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		want   ErrorCode
+	}{
+		{http.StatusBadRequest, CodeInvalidRequest},
+		{http.StatusForbidden, CodeForbidden},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusConflict, CodeConflict},
+		{http.StatusUnprocessableEntity, CodeValidationFailed},
+		{http.StatusBadGateway, CodeUpstreamUnavailable},
+		{http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		err := NewError(tt.status, "message")
+		resp, ok := err.(*ErrorResponse)
+		require.True(t, ok)
+		assert.Equal(t, tt.status, resp.GetStatus())
+		assert.Equal(t, tt.want, resp.Code)
+	}
+}
@@ -15,6 +15,8 @@ import (
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/search"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
 )
 
 // ModelHandler handles model catalog operations
@@ -53,6 +55,7 @@ type ModelMeta struct {
 	UsedBy   []ModelUsageRefJSON `json:"usedBy,omitempty"`
 	Ready    bool                `json:"ready"`
 	Message  string              `json:"message,omitempty"`
+	Featured bool                `json:"featured,omitempty"`
 }
 
 type ModelResponse struct {
@@ -67,10 +70,12 @@ type ModelListResponse struct {
 
 // Input types
 type ListModelsInput struct {
-	Cursor   string `query:"cursor" json:"cursor,omitempty"`
-	Limit    int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
-	Search   string `query:"search" json:"search,omitempty"`
-	Provider string `query:"provider" json:"provider,omitempty"`
+	Cursor       string `query:"cursor" json:"cursor,omitempty"`
+	Limit        int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
+	Search       string `query:"search" json:"search,omitempty" doc:"Match name, title and description, ignoring case; exact and prefix name matches rank first"`
+	SearchFields string `query:"searchFields" json:"searchFields,omitempty" doc:"Comma-separated fields to search: name, title, description. Defaults to all."`
+	Provider     string `query:"provider" json:"provider,omitempty"`
+	Featured     bool   `query:"featured" json:"featured,omitempty" doc:"List only entries featured by the registry curators"`
 }
 
 type ModelDetailInput struct {
@@ -126,6 +131,11 @@ func (h *ModelHandler) RegisterRoutes(api huma.API, pathPrefix string, isAdmin b
 }
 
 func (h *ModelHandler) listModels(ctx context.Context, input *ListModelsInput, isAdmin bool) (*Response[ModelListResponse], error) {
+	scope, err := search.ParseScope(input.SearchFields)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid searchFields", err)
+	}
+
 	var modelList agentregistryv1alpha1.ModelCatalogList
 
 	listOpts := []client.ListOption{}
@@ -135,12 +145,11 @@ func (h *ModelHandler) listModels(ctx context.Context, input *ListModelsInput, i
 	}
 
 	models := make([]ModelResponse, 0, len(modelList.Items))
-	for _, m := range modelList.Items {
-		if input.Search != "" && !strings.Contains(strings.ToLower(m.Spec.Name), strings.ToLower(input.Search)) {
+	for _, m := range search.RankWithin(modelList.Items, input.Search, scope, search.ModelFields) {
+		if input.Provider != "" && !strings.EqualFold(m.Spec.Provider, input.Provider) {
 			continue
 		}
-
-		if input.Provider != "" && !strings.EqualFold(m.Spec.Provider, input.Provider) {
+		if input.Featured && !IsFeatured(&m) {
 			continue
 		}
 
@@ -168,7 +177,7 @@ func (h *ModelHandler) listModels(ctx context.Context, input *ListModelsInput, i
 func (h *ModelHandler) getModel(ctx context.Context, input *ModelDetailInput, isAdmin bool) (*Response[ModelResponse], error) {
 	modelName, err := url.PathUnescape(input.ModelName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid model name encoding", err)
+		return nil, invalidName("Invalid model name encoding", err)
 	}
 
 	var modelList agentregistryv1alpha1.ModelCatalogList
@@ -183,7 +192,7 @@ func (h *ModelHandler) getModel(ctx context.Context, input *ModelDetailInput, is
 	}
 
 	if len(modelList.Items) == 0 {
-		return nil, huma.Error404NotFound("Model not found")
+		return nil, catalogNotFound("Model not found")
 	}
 
 	return &Response[ModelResponse]{
@@ -192,6 +201,15 @@ func (h *ModelHandler) getModel(ctx context.Context, input *ModelDetailInput, is
 }
 
 func (h *ModelHandler) createModel(ctx context.Context, input *CreateModelInput) (*Response[ModelResponse], error) {
+	if err := validation.ValidateModelProvider(input.Body.Provider); err != nil {
+		return nil, huma.Error400BadRequest("Invalid model provider", err)
+	}
+	if input.Body.BaseURL != "" {
+		if err := validation.ValidateModelBaseURL(input.Body.BaseURL); err != nil {
+			return nil, huma.Error400BadRequest("Invalid model base URL", err)
+		}
+	}
+
 	crName := SanitizeK8sName(input.Body.Name)
 
 	model := &agentregistryv1alpha1.ModelCatalog{
@@ -254,9 +272,10 @@ func (h *ModelHandler) convertToModelResponse(m *agentregistryv1alpha1.ModelCata
 				IsLatest:    true, // Models don't have versions currently
 				Published:   true,
 			},
-			UsedBy:  usedBy,
-			Ready:   m.Status.Ready,
-			Message: m.Status.Message,
+			UsedBy:   usedBy,
+			Ready:    m.Status.Ready,
+			Message:  m.Status.Message,
+			Featured: IsFeatured(m),
 		},
 	}
 }
@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -110,6 +113,47 @@ func TestModelHandler_CreateModel_MultipleProviders(t *testing.T) {
 	assert.Equal(t, "openai", resp2.Body.Model.Provider)
 }
 
+func TestModelHandler_CreateModel_Validation(t *testing.T) {
+	c := setupModelTestClient(t)
+	ctx := context.Background()
+	handler := NewModelHandler(c, nil, zerolog.Nop())
+
+	tests := []struct {
+		name       string
+		body       ModelJSON
+		wantDetail string
+	}{
+		{
+			name:       "unknown provider",
+			body:       ModelJSON{Name: "typo-model", Provider: "Antropic", Model: "claude-3-opus"},
+			wantDetail: "accepted: OpenAI, Anthropic",
+		},
+		{
+			name:       "relative base URL",
+			body:       ModelJSON{Name: "bad-url-model", Provider: "Ollama", Model: "llama3", BaseURL: "ollama:11434"},
+			wantDetail: "must use http or https",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.createModel(ctx, &CreateModelInput{Body: tt.body})
+			var resp *ErrorResponse
+			require.True(t, errors.As(err, &resp))
+			assert.Equal(t, http.StatusBadRequest, resp.GetStatus())
+			require.NotEmpty(t, resp.Details)
+			assert.Contains(t, resp.Details[0], tt.wantDetail)
+
+			err = c.Get(ctx, client.ObjectKey{Name: tt.body.Name}, &agentregistryv1alpha1.ModelCatalog{})
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+
+	// Providers outside the known set go through the custom escape hatch
+	_, err := handler.createModel(ctx, &CreateModelInput{Body: ModelJSON{Name: "gateway-model", Provider: "custom:litellm", Model: "gpt-4"}})
+	require.NoError(t, err)
+}
+
 // ---------------------------------------------------------------------------
 // getModel
 // ---------------------------------------------------------------------------
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// AttestationJSON is a supply-chain attestation attached to a server version
+type AttestationJSON struct {
+	ID            string    `json:"id,omitempty" readOnly:"true"`
+	Type          string    `json:"type" enum:"provenance,sbom,other" doc:"Attestation kind"`
+	PredicateType string    `json:"predicateType,omitempty" doc:"In-toto predicate type, e.g. https://slsa.dev/provenance/v1"`
+	MediaType     string    `json:"mediaType,omitempty"`
+	URI           string    `json:"uri,omitempty" doc:"Location of the attestation when it is stored elsewhere"`
+	Digest        string    `json:"digest,omitempty" doc:"sha256:<hex> digest of the referenced attestation"`
+	Content       string    `json:"content,omitempty" doc:"Inline attestation document, at most 256KiB"`
+	CreatedAt     time.Time `json:"createdAt,omitempty" readOnly:"true"`
+}
+
+type AttestationListResponse struct {
+	Attestations []AttestationJSON `json:"attestations"`
+	Metadata     ListMetadata      `json:"metadata"`
+}
+
+type CreateAttestationInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+	Version    string `path:"version" json:"version"`
+	Body       AttestationJSON
+}
+
+// registerAttestationRoutes registers the endpoints reading and, for admins,
+// attaching the attestations of a server version
+func (h *ServerHandler) registerAttestationRoutes(api huma.API, pathPrefix string, tags []string, isAdmin bool) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-attestations" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/attestations",
+		Summary:     "List attestations of an MCP server version",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[AttestationListResponse], error) {
+		return h.listAttestations(ctx, input)
+	})
+
+	if isAdmin {
+		huma.Register(api, huma.Operation{
+			OperationID: "create-server-attestation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/servers/{serverName}/versions/{version}/attestations",
+			Summary:     "Attach an attestation to an MCP server version",
+			Tags:        tags,
+		}, func(ctx context.Context, input *CreateAttestationInput) (*Response[AttestationJSON], error) {
+			return h.createAttestation(ctx, input)
+		})
+	}
+}
+
+func (h *ServerHandler) listAttestations(ctx context.Context, input *ServerVersionDetailInput) (*Response[AttestationListResponse], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return nil, invalidVersion("Invalid version encoding", err)
+	}
+
+	reader := client.Reader(h.client)
+	if h.cache != nil {
+		reader = h.cache
+	}
+
+	server, err := findServerEntry(ctx, reader, serverName, version)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	if server == nil {
+		return nil, catalogNotFound("Server version not found")
+	}
+
+	attestations, err := controller.ListAttestations(ctx, h.client, server)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to read attestations", err)
+	}
+
+	items := make([]AttestationJSON, 0, len(attestations))
+	for _, a := range attestations {
+		items = append(items, AttestationJSON(a))
+	}
+	return &Response[AttestationListResponse]{
+		Body: AttestationListResponse{
+			Attestations: items,
+			Metadata:     ListMetadata{Count: len(items)},
+		},
+	}, nil
+}
+
+func (h *ServerHandler) createAttestation(ctx context.Context, input *CreateAttestationInput) (*Response[AttestationJSON], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return nil, invalidVersion("Invalid version encoding", err)
+	}
+
+	server, err := findServerEntry(ctx, h.client, serverName, version)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	if server == nil {
+		return nil, catalogNotFound("Server version not found")
+	}
+
+	attestation := controller.Attestation(input.Body)
+	if err := controller.ValidateAttestation(&attestation); err != nil {
+		return nil, huma.Error400BadRequest("Invalid attestation", err)
+	}
+
+	stored, err := controller.AddAttestation(ctx, h.client, server, attestation)
+	switch {
+	case errors.Is(err, controller.ErrAttestationExists):
+		return nil, huma.Error409Conflict("Attestation already attached", err)
+	case errors.Is(err, controller.ErrAttestationsFull):
+		return nil, huma.Error422UnprocessableEntity("Attestation storage for this server version is full", err)
+	case err != nil:
+		return nil, huma.Error500InternalServerError("Failed to store attestation", err)
+	}
+
+	return &Response[AttestationJSON]{Body: AttestationJSON(*stored)}, nil
+}
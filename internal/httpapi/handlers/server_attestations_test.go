@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestServerHandler_Attestations_CreateAndRetrieve(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/search", "1.0.0"), Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "1.0.0"},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(server).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		Build()
+
+	_, api := humatest.New(t)
+	h := NewServerHandler(c, nil, zerolog.Nop())
+	h.RegisterRoutes(api, "/v0", false)
+	h.RegisterRoutes(api, "/admin/v0", true)
+
+	const path = "/servers/org%2Fsearch/versions/1.0.0/attestations"
+	sbom := map[string]any{
+		"type":    "sbom",
+		"content": `{"bomFormat":"CycloneDX","specVersion":"1.5"}`,
+	}
+
+	// Public API is read-only
+	resp := api.Post("/v0"+path, sbom)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+
+	resp = api.Post("/admin/v0"+path, sbom)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var created AttestationJSON
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	resp = api.Post("/admin/v0"+path, map[string]any{
+		"type":          "provenance",
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"uri":           "oci://ghcr.io/org/search@sha256:abc",
+		"digest":        "sha256:" + strings.Repeat("d", 64),
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	// A digest must be a full sha256 hex digest
+	for _, digest := range []string{"sha256:def", "sha256:" + strings.Repeat("D", 64), "sha256:" + strings.Repeat("g", 64)} {
+		resp = api.Post("/admin/v0"+path, map[string]any{"type": "sbom", "uri": "https://example.com/sbom.json", "digest": digest})
+		assert.Equal(t, http.StatusBadRequest, resp.Code, digest)
+	}
+
+	// Attaching the same attestation again conflicts
+	resp = api.Post("/admin/v0"+path, sbom)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	// Exactly one of uri and content is required
+	resp = api.Post("/admin/v0"+path, map[string]any{"type": "sbom"})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = api.Get("/v0" + path)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var list AttestationListResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Equal(t, 2, list.Metadata.Count)
+	types := []string{list.Attestations[0].Type, list.Attestations[1].Type}
+	assert.ElementsMatch(t, []string{"sbom", "provenance"}, types)
+
+	var cm corev1.ConfigMap
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "agentregistry", Name: controller.AttestationsConfigMapName(server.Name)}, &cm))
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, server.Name, cm.OwnerReferences[0].Name)
+
+	resp = api.Get("/v0/servers/org%2Fsearch/versions/1.0.0")
+	require.Equal(t, http.StatusOK, resp.Code)
+	var detail ServerResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &detail))
+	assert.True(t, detail.Meta.HasAttestations)
+
+	resp = api.Get("/v0/servers/org%2Fsearch/versions/2.0.0/attestations")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+type DeprecateServerInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+	Version    string `path:"version" json:"version"`
+	Body       struct {
+		ReplacedBy *agentregistryv1alpha1.CatalogEntryReference `json:"replacedBy,omitempty" doc:"Server that supersedes this version; an empty version means its latest"`
+	} `required:"false"`
+}
+
+// registerDeprecationRoute registers the admin endpoint deprecating a server
+// version
+func (h *ServerHandler) registerDeprecationRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "deprecate-server-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/deprecate",
+		Summary:     "Deprecate MCP server version, optionally naming its successor",
+		Tags:        tags,
+	}, func(ctx context.Context, input *DeprecateServerInput) (*Response[ServerResponse], error) {
+		return h.deprecateServerVersion(ctx, input)
+	})
+}
+
+// deprecateServerVersion marks a server version deprecated and records the
+// server replacing it. The catalog reconciler derives the deprecated status
+// from the annotation and replacedBy; deprecating again replaces the
+// successor, or clears it when none is given.
+func (h *ServerHandler) deprecateServerVersion(ctx context.Context, input *DeprecateServerInput) (*Response[ServerResponse], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return nil, invalidVersion("Invalid version encoding", err)
+	}
+
+	server, err := findServerEntry(ctx, h.client, serverName, version)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	if server == nil {
+		return nil, catalogNotFound("Server version not found")
+	}
+
+	successor := input.Body.ReplacedBy
+	if successor != nil {
+		if successor.Name == "" {
+			return nil, huma.Error400BadRequest("replacedBy.name is required")
+		}
+		if successor.Name == serverName && (successor.Version == "" || successor.Version == version) {
+			return nil, huma.Error400BadRequest("A server version cannot replace itself")
+		}
+		found, err := findServerEntry(ctx, h.client, successor.Name, successor.Version)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get successor", err)
+		}
+		if found == nil {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("Successor %s not found", formatEntryRef(successor)))
+		}
+	}
+
+	if server.Annotations == nil {
+		server.Annotations = map[string]string{}
+	}
+	server.Annotations[agentregistryv1alpha1.AnnotationDeprecated] = "true"
+	server.Spec.ReplacedBy = successor
+	if err := h.client.Update(ctx, server); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to deprecate server", err)
+	}
+
+	deployment, err := h.getDeploymentForServer(ctx, server.Spec.Name, server.Spec.Version)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("server", server.Spec.Name).Str("version", server.Spec.Version).Msg("Failed to get deployment for server")
+	}
+	return &Response[ServerResponse]{
+		Body: h.convertToServerResponse(server, deployment),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func newPublishedTestServer(name, version string) *agentregistryv1alpha1.MCPServerCatalog {
+	now := metav1.Now()
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, version)},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: version},
+		Status: agentregistryv1alpha1.MCPServerCatalogStatus{
+			Status:      agentregistryv1alpha1.CatalogStatusActive,
+			Published:   true,
+			PublishedAt: &now,
+		},
+	}
+}
+
+func deprecateInput(name, version string, replacedBy *agentregistryv1alpha1.CatalogEntryReference) *DeprecateServerInput {
+	input := &DeprecateServerInput{ServerName: name, Version: version}
+	input.Body.ReplacedBy = replacedBy
+	return input
+}
+
+func TestServerHandler_DeprecateServerVersion(t *testing.T) {
+	c := setupServerDiffTestClient(t,
+		newPublishedTestServer("org/legacy", "1.0.0"),
+		newPublishedTestServer("org/legacy", "1.1.0"),
+		newPublishedTestServer("org/current", "2.0.0"),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+	get := func(name, version string) *agentregistryv1alpha1.MCPServerCatalog {
+		t.Helper()
+		var server agentregistryv1alpha1.MCPServerCatalog
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: GenerateCRName(name, version)}, &server))
+		return &server
+	}
+
+	t.Run("with successor", func(t *testing.T) {
+		successor := &agentregistryv1alpha1.CatalogEntryReference{Name: "org/current", Version: "2.0.0"}
+		resp, err := handler.deprecateServerVersion(ctx, deprecateInput("org%2Flegacy", "1.0.0", successor))
+		require.NoError(t, err)
+		assert.Equal(t, successor, resp.Body.Meta.ReplacedBy)
+
+		server := get("org/legacy", "1.0.0")
+		assert.Equal(t, "true", server.Annotations[agentregistryv1alpha1.AnnotationDeprecated])
+		assert.Equal(t, successor, server.Spec.ReplacedBy)
+	})
+
+	t.Run("without successor", func(t *testing.T) {
+		resp, err := handler.deprecateServerVersion(ctx, deprecateInput("org%2Flegacy", "1.1.0", nil))
+		require.NoError(t, err)
+		assert.Nil(t, resp.Body.Meta.ReplacedBy)
+
+		server := get("org/legacy", "1.1.0")
+		assert.Equal(t, "true", server.Annotations[agentregistryv1alpha1.AnnotationDeprecated])
+		assert.Nil(t, server.Spec.ReplacedBy)
+	})
+
+	for name, tc := range map[string]struct {
+		input  *DeprecateServerInput
+		status int
+	}{
+		"unknown version":   {deprecateInput("org%2Flegacy", "9.9.9", nil), http.StatusNotFound},
+		"unknown successor": {deprecateInput("org%2Flegacy", "1.0.0", &agentregistryv1alpha1.CatalogEntryReference{Name: "org/missing"}), http.StatusBadRequest},
+		"self successor":    {deprecateInput("org%2Flegacy", "1.0.0", &agentregistryv1alpha1.CatalogEntryReference{Name: "org/legacy", Version: "1.0.0"}), http.StatusBadRequest},
+		"unnamed successor": {deprecateInput("org%2Flegacy", "1.0.0", &agentregistryv1alpha1.CatalogEntryReference{Version: "2.0.0"}), http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := handler.deprecateServerVersion(ctx, tc.input)
+			var apiErr *ErrorResponse
+			require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+			assert.Equal(t, tc.status, apiErr.GetStatus())
+		})
+	}
+}
+
+func TestServerHandler_DeprecateServerVersion_HiddenFromPublicListing(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_STRICT_PUBLIC_VISIBILITY", "true")
+	c := setupServerDiffTestClient(t,
+		newPublishedTestServer("org/legacy", "1.0.0"),
+		newPublishedTestServer("org/current", "2.0.0"),
+	)
+	ctx := context.Background()
+	admin := NewServerHandler(c, nil, zerolog.Nop())
+	public := NewServerHandler(PublicClient(c), PublicCache(&readerCache{reader: c}), zerolog.Nop())
+	listNames := func(h *ServerHandler, isAdmin bool) []string {
+		t.Helper()
+		list, err := h.listServers(ctx, &ListServersInput{Limit: 30}, isAdmin)
+		require.NoError(t, err)
+		var names []string
+		for _, s := range list.Body.Servers {
+			names = append(names, s.Server.Name)
+		}
+		return names
+	}
+	require.ElementsMatch(t, []string{"org/legacy", "org/current"}, listNames(public, false))
+
+	_, err := admin.deprecateServerVersion(ctx, deprecateInput("org%2Flegacy", "1.0.0", &agentregistryv1alpha1.CatalogEntryReference{Name: "org/current"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"org/current"}, listNames(public, false), "deprecated entries are hidden from non-admins")
+	assert.ElementsMatch(t, []string{"org/legacy", "org/current"}, listNames(admin, true))
+}
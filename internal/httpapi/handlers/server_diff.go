@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// ServerDiffInput selects the two versions of a server to compare
+type ServerDiffInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+	From       string `query:"from" json:"from" required:"true"`
+	To         string `query:"to" json:"to" required:"true"`
+}
+
+// FieldChange describes a single difference between two server versions.
+// Field is a dotted path such as "packages[npm:@org/pkg].transport.type".
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// ServerDiff lists the fields added, removed, and changed between two versions
+type ServerDiff struct {
+	Name    string        `json:"name"`
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Added   []FieldChange `json:"added"`
+	Removed []FieldChange `json:"removed"`
+	Changed []FieldChange `json:"changed"`
+}
+
+func (h *ServerHandler) diffServerVersions(ctx context.Context, input *ServerDiffInput) (*Response[ServerDiff], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+
+	var serverList agentregistryv1alpha1.MCPServerCatalogList
+	if err := h.listFromCacheOrClient(ctx, &serverList, client.MatchingFields{
+		controller.IndexMCPServerName: serverName,
+	}); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to list server versions", err)
+	}
+
+	var from, to *agentregistryv1alpha1.MCPServerCatalog
+	for i := range serverList.Items {
+		// From and To may be the same version
+		if serverList.Items[i].Spec.Version == input.From {
+			from = &serverList.Items[i]
+		}
+		if serverList.Items[i].Spec.Version == input.To {
+			to = &serverList.Items[i]
+		}
+	}
+	if from == nil {
+		return nil, catalogNotFound("Server version " + input.From + " not found")
+	}
+	if to == nil {
+		return nil, catalogNotFound("Server version " + input.To + " not found")
+	}
+
+	return &Response[ServerDiff]{
+		Body: diffServerSpecs(serverName, &from.Spec, &to.Spec),
+	}, nil
+}
+
+// diffServerSpecs compares the deployable parts of two server specs: packages,
+// remotes, transports, environment variables, and arguments. Packages are
+// matched by registry type and identifier, remotes by URL, and variables and
+// arguments by name.
+func diffServerSpecs(name string, from, to *agentregistryv1alpha1.MCPServerCatalogSpec) ServerDiff {
+	d := &ServerDiff{
+		Name:    name,
+		From:    from.Version,
+		To:      to.Version,
+		Added:   []FieldChange{},
+		Removed: []FieldChange{},
+		Changed: []FieldChange{},
+	}
+
+	fromPkgs := make(map[string]agentregistryv1alpha1.Package, len(from.Packages))
+	for _, p := range from.Packages {
+		fromPkgs[packageKey(p)] = p
+	}
+	toPkgs := make(map[string]agentregistryv1alpha1.Package, len(to.Packages))
+	for _, p := range to.Packages {
+		key := packageKey(p)
+		toPkgs[key] = p
+		prefix := "packages[" + key + "]"
+		old, ok := fromPkgs[key]
+		if !ok {
+			d.Added = append(d.Added, FieldChange{Field: prefix, To: p.Version})
+			continue
+		}
+		d.compare(prefix+".version", old.Version, p.Version)
+		d.compare(prefix+".runtimeHint", old.RuntimeHint, p.RuntimeHint)
+		d.diffTransport(prefix+".transport", old.Transport, p.Transport)
+		d.diffKeyValues(prefix+".environmentVariables", old.EnvironmentVariables, p.EnvironmentVariables)
+		d.diffArguments(prefix+".runtimeArguments", old.RuntimeArguments, p.RuntimeArguments)
+		d.diffArguments(prefix+".packageArguments", old.PackageArguments, p.PackageArguments)
+	}
+	for _, p := range from.Packages {
+		if _, ok := toPkgs[packageKey(p)]; !ok {
+			d.Removed = append(d.Removed, FieldChange{Field: "packages[" + packageKey(p) + "]", From: p.Version})
+		}
+	}
+
+	fromRemotes := make(map[string]agentregistryv1alpha1.Transport, len(from.Remotes))
+	for _, r := range from.Remotes {
+		fromRemotes[r.URL] = r
+	}
+	toRemotes := make(map[string]bool, len(to.Remotes))
+	for _, r := range to.Remotes {
+		toRemotes[r.URL] = true
+		prefix := "remotes[" + r.URL + "]"
+		old, ok := fromRemotes[r.URL]
+		if !ok {
+			d.Added = append(d.Added, FieldChange{Field: prefix, To: r.Type})
+			continue
+		}
+		d.diffTransport(prefix, old, r)
+	}
+	for _, r := range from.Remotes {
+		if !toRemotes[r.URL] {
+			d.Removed = append(d.Removed, FieldChange{Field: "remotes[" + r.URL + "]", From: r.Type})
+		}
+	}
+
+	return *d
+}
+
+func packageKey(p agentregistryv1alpha1.Package) string {
+	return p.RegistryType + ":" + p.Identifier
+}
+
+func (d *ServerDiff) compare(field, from, to string) {
+	if from != to {
+		d.Changed = append(d.Changed, FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func (d *ServerDiff) diffTransport(prefix string, from, to agentregistryv1alpha1.Transport) {
+	d.compare(prefix+".type", from.Type, to.Type)
+	d.compare(prefix+".url", from.URL, to.URL)
+	d.diffKeyValues(prefix+".headers", from.Headers, to.Headers)
+}
+
+func (d *ServerDiff) diffKeyValues(prefix string, from, to []agentregistryv1alpha1.KeyValueInput) {
+	old := make(map[string]agentregistryv1alpha1.KeyValueInput, len(from))
+	for _, kv := range from {
+		old[kv.Name] = kv
+	}
+	seen := make(map[string]bool, len(to))
+	for _, kv := range to {
+		seen[kv.Name] = true
+		field := fmt.Sprintf("%s[%s]", prefix, kv.Name)
+		prev, ok := old[kv.Name]
+		if !ok {
+			d.Added = append(d.Added, FieldChange{Field: field, To: kv.Value})
+			continue
+		}
+		d.compare(field+".value", prev.Value, kv.Value)
+		d.compare(field+".required", strconv.FormatBool(prev.Required), strconv.FormatBool(kv.Required))
+		d.compare(field+".secretRef", secretRefString(prev.SecretRef), secretRefString(kv.SecretRef))
+	}
+	for _, kv := range from {
+		if !seen[kv.Name] {
+			d.Removed = append(d.Removed, FieldChange{Field: fmt.Sprintf("%s[%s]", prefix, kv.Name), From: kv.Value})
+		}
+	}
+}
+
+// secretRefString renders a secret reference as secretName/key for diffs
+func secretRefString(ref *agentregistryv1alpha1.SecretKeyRef) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.SecretName + "/" + ref.Key
+}
+
+func (d *ServerDiff) diffArguments(prefix string, from, to []agentregistryv1alpha1.Argument) {
+	old := make(map[string]agentregistryv1alpha1.Argument, len(from))
+	for _, a := range from {
+		old[a.Name] = a
+	}
+	seen := make(map[string]bool, len(to))
+	for _, a := range to {
+		seen[a.Name] = true
+		field := fmt.Sprintf("%s[%s]", prefix, a.Name)
+		prev, ok := old[a.Name]
+		if !ok {
+			d.Added = append(d.Added, FieldChange{Field: field, To: a.Value})
+			continue
+		}
+		d.compare(field+".type", prev.Type, a.Type)
+		d.compare(field+".value", prev.Value, a.Value)
+		d.compare(field+".required", strconv.FormatBool(prev.Required), strconv.FormatBool(a.Required))
+	}
+	for _, a := range from {
+		if !seen[a.Name] {
+			d.Removed = append(d.Removed, FieldChange{Field: fmt.Sprintf("%s[%s]", prefix, a.Name), From: a.Value})
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func setupServerDiffTestClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithObjects(objs...).
+		Build()
+}
+
+func newDiffTestServer(version string, packages ...agentregistryv1alpha1.Package) *agentregistryv1alpha1.MCPServerCatalog {
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("diff-server", version)},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:     "diff-server",
+			Version:  version,
+			Packages: packages,
+		},
+	}
+}
+
+func findChange(changes []FieldChange, field string) *FieldChange {
+	for i := range changes {
+		if changes[i].Field == field {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestServerHandler_DiffServerVersions(t *testing.T) {
+	v1 := newDiffTestServer("1.0.0", agentregistryv1alpha1.Package{
+		RegistryType: "npm",
+		Identifier:   "@org/server",
+		Version:      "1.0.0",
+		Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+		EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+			{Name: "API_KEY", Required: true},
+			{Name: "LEGACY_MODE", Value: "true"},
+		},
+	})
+	v2 := newDiffTestServer("2.0.0",
+		agentregistryv1alpha1.Package{
+			RegistryType: "npm",
+			Identifier:   "@org/server",
+			Version:      "2.0.0",
+			Transport:    agentregistryv1alpha1.Transport{Type: "streamable-http", URL: "http://localhost:8080/mcp"},
+			EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+				{Name: "API_KEY", Required: true},
+			},
+		},
+		agentregistryv1alpha1.Package{
+			RegistryType: "oci",
+			Identifier:   "ghcr.io/org/server",
+			Version:      "2.0.0",
+			Transport:    agentregistryv1alpha1.Transport{Type: "streamable-http"},
+		},
+	)
+
+	handler := NewServerHandler(setupServerDiffTestClient(t, v1, v2), nil, zerolog.Nop())
+
+	resp, err := handler.diffServerVersions(context.Background(), &ServerDiffInput{
+		ServerName: "diff-server",
+		From:       "1.0.0",
+		To:         "2.0.0",
+	})
+	require.NoError(t, err)
+	diff := resp.Body
+	assert.Equal(t, "1.0.0", diff.From)
+	assert.Equal(t, "2.0.0", diff.To)
+
+	// Added package
+	added := findChange(diff.Added, "packages[oci:ghcr.io/org/server]")
+	require.NotNil(t, added)
+	assert.Equal(t, "2.0.0", added.To)
+
+	// Changed transport type
+	changed := findChange(diff.Changed, "packages[npm:@org/server].transport.type")
+	require.NotNil(t, changed)
+	assert.Equal(t, "stdio", changed.From)
+	assert.Equal(t, "streamable-http", changed.To)
+	assert.NotNil(t, findChange(diff.Changed, "packages[npm:@org/server].version"))
+
+	// Removed env var
+	removed := findChange(diff.Removed, "packages[npm:@org/server].environmentVariables[LEGACY_MODE]")
+	require.NotNil(t, removed)
+	assert.Equal(t, "true", removed.From)
+
+	// Unchanged env var is not reported
+	assert.Nil(t, findChange(diff.Changed, "packages[npm:@org/server].environmentVariables[API_KEY].required"))
+
+	// A version compared with itself has no differences
+	resp, err = handler.diffServerVersions(context.Background(), &ServerDiffInput{
+		ServerName: "diff-server",
+		From:       "2.0.0",
+		To:         "2.0.0",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Body.Added)
+	assert.Empty(t, resp.Body.Removed)
+	assert.Empty(t, resp.Body.Changed)
+}
+
+func TestServerHandler_DiffServerVersions_NotFound(t *testing.T) {
+	handler := NewServerHandler(setupServerDiffTestClient(t, newDiffTestServer("1.0.0")), nil, zerolog.Nop())
+
+	_, err := handler.diffServerVersions(context.Background(), &ServerDiffInput{
+		ServerName: "diff-server",
+		From:       "1.0.0",
+		To:         "9.9.9",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "9.9.9")
+}
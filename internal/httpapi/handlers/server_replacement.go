@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
+)
+
+// ServerReplacement is the result of following a server's replacedBy chain
+type ServerReplacement struct {
+	Name string `json:"name"`
+	// Chain lists every entry visited, starting with the requested server
+	Chain []CatalogEntryRef `json:"chain"`
+	// Replacement is the final non-deprecated entry, if one was found
+	Replacement *CatalogEntryRef `json:"replacement,omitempty"`
+	// Cycle is set when the chain loops back on an entry already visited
+	Cycle   bool   `json:"cycle"`
+	Message string `json:"message"`
+}
+
+func (h *ServerHandler) getServerReplacement(ctx context.Context, input *ServerDetailInput) (*Response[ServerReplacement], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+
+	reader := client.Reader(h.client)
+	if h.cache != nil {
+		reader = h.cache
+	}
+
+	replacement, err := ResolveServerReplacement(ctx, reader, serverName)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to resolve server replacement", err)
+	}
+	if replacement == nil {
+		return nil, catalogNotFound("Server not found")
+	}
+
+	return &Response[ServerReplacement]{Body: *replacement}, nil
+}
+
+// ResolveServerReplacement follows the replacedBy references starting at the
+// latest version of name until it reaches an entry that is not deprecated. It
+// returns nil when no server with that name exists. A chain that revisits an
+// entry is reported as a cycle instead of being followed.
+func ResolveServerReplacement(ctx context.Context, reader client.Reader, name string) (*ServerReplacement, error) {
+	current, err := findServerEntry(ctx, reader, name, "")
+	if err != nil || current == nil {
+		return nil, err
+	}
+
+	result := &ServerReplacement{Name: name, Chain: []CatalogEntryRef{}}
+	visited := map[CatalogEntryRef]bool{}
+	for {
+		ref := CatalogEntryRef{Name: current.Spec.Name, Version: current.Spec.Version}
+		if visited[ref] {
+			result.Cycle = true
+			result.Message = fmt.Sprintf("replacement chain loops back to %s@%s", ref.Name, ref.Version)
+			return result, nil
+		}
+		visited[ref] = true
+		result.Chain = append(result.Chain, ref)
+
+		if current.Status.Status != agentregistryv1alpha1.CatalogStatusDeprecated {
+			result.Replacement = &ref
+			if len(result.Chain) == 1 {
+				result.Message = "server is not deprecated"
+			} else {
+				result.Message = fmt.Sprintf("use %s@%s instead", ref.Name, ref.Version)
+			}
+			return result, nil
+		}
+
+		next := current.Spec.ReplacedBy
+		if next == nil || next.Name == "" {
+			result.Message = fmt.Sprintf("%s@%s is deprecated with no replacement", ref.Name, ref.Version)
+			return result, nil
+		}
+
+		if current, err = findServerEntry(ctx, reader, next.Name, next.Version); err != nil {
+			return nil, err
+		}
+		if current == nil {
+			result.Message = fmt.Sprintf("%s@%s is replaced by %s, which is not in the catalog", ref.Name, ref.Version, formatEntryRef(next))
+			return result, nil
+		}
+	}
+}
+
+// findServerEntry returns the server with the given name and version, or its
+// latest version when version is empty. It returns nil when none matches.
+func findServerEntry(ctx context.Context, reader client.Reader, name, version string) (*agentregistryv1alpha1.MCPServerCatalog, error) {
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	if err := reader.List(ctx, &list, client.MatchingFields{controller.IndexMCPServerName: name}); err != nil {
+		return nil, err
+	}
+
+	if version != "" {
+		for i := range list.Items {
+			if list.Items[i].Spec.Version == version {
+				return &list.Items[i], nil
+			}
+		}
+		return nil, nil
+	}
+
+	for i := range list.Items {
+		if list.Items[i].Status.IsLatest {
+			return &list.Items[i], nil
+		}
+	}
+	latest := semver.LatestIndex(list.Items, func(s agentregistryv1alpha1.MCPServerCatalog) string { return s.Spec.Version })
+	if latest < 0 {
+		return nil, nil
+	}
+	return &list.Items[latest], nil
+}
+
+func formatEntryRef(ref *agentregistryv1alpha1.CatalogEntryReference) string {
+	if ref.Version == "" {
+		return ref.Name
+	}
+	return ref.Name + "@" + ref.Version
+}
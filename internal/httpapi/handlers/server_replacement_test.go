@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func newReplacementTestServer(name, version string, deprecated bool, replacedBy *agentregistryv1alpha1.CatalogEntryReference) *agentregistryv1alpha1.MCPServerCatalog {
+	status := agentregistryv1alpha1.CatalogStatusActive
+	if deprecated {
+		status = agentregistryv1alpha1.CatalogStatusDeprecated
+	}
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, version)},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:       name,
+			Version:    version,
+			ReplacedBy: replacedBy,
+		},
+		Status: agentregistryv1alpha1.MCPServerCatalogStatus{Status: status},
+	}
+}
+
+func TestServerHandler_GetServerReplacement_Chain(t *testing.T) {
+	c := setupServerDiffTestClient(t,
+		newReplacementTestServer("org/legacy", "1.0.0", true, &agentregistryv1alpha1.CatalogEntryReference{Name: "org/interim"}),
+		newReplacementTestServer("org/interim", "1.0.0", true, nil),
+		newReplacementTestServer("org/interim", "2.0.0", true, &agentregistryv1alpha1.CatalogEntryReference{Name: "org/current", Version: "3.0.0"}),
+		newReplacementTestServer("org/current", "3.0.0", false, nil),
+		newReplacementTestServer("org/current", "4.0.0", false, nil),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.getServerReplacement(context.Background(), &ServerDetailInput{ServerName: "org%2Flegacy"})
+	require.NoError(t, err)
+	body := resp.Body
+
+	assert.False(t, body.Cycle)
+	assert.Equal(t, []CatalogEntryRef{
+		{Name: "org/legacy", Version: "1.0.0"},
+		{Name: "org/interim", Version: "2.0.0"},
+		{Name: "org/current", Version: "3.0.0"},
+	}, body.Chain, "unversioned references resolve to the latest version; versioned ones are pinned")
+	require.NotNil(t, body.Replacement)
+	assert.Equal(t, CatalogEntryRef{Name: "org/current", Version: "3.0.0"}, *body.Replacement)
+}
+
+func TestServerHandler_GetServerReplacement_NoReplacement(t *testing.T) {
+	c := setupServerDiffTestClient(t,
+		newReplacementTestServer("org/active", "1.0.0", false, nil),
+		newReplacementTestServer("org/orphan", "1.0.0", true, nil),
+		newReplacementTestServer("org/dangling", "1.0.0", true, &agentregistryv1alpha1.CatalogEntryReference{Name: "org/missing"}),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := handler.getServerReplacement(ctx, &ServerDetailInput{ServerName: "org/active"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Body.Replacement)
+	assert.Equal(t, "org/active", resp.Body.Replacement.Name)
+
+	resp, err = handler.getServerReplacement(ctx, &ServerDetailInput{ServerName: "org/orphan"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Body.Replacement)
+	assert.Contains(t, resp.Body.Message, "no replacement")
+
+	resp, err = handler.getServerReplacement(ctx, &ServerDetailInput{ServerName: "org/dangling"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Body.Replacement)
+	assert.Contains(t, resp.Body.Message, "org/missing")
+
+	_, err = handler.getServerReplacement(ctx, &ServerDetailInput{ServerName: "org/unknown"})
+	var statusErr huma.StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.GetStatus())
+}
+
+func TestServerHandler_GetServerReplacement_Cycle(t *testing.T) {
+	c := setupServerDiffTestClient(t,
+		newReplacementTestServer("org/a", "1.0.0", true, &agentregistryv1alpha1.CatalogEntryReference{Name: "org/b"}),
+		newReplacementTestServer("org/b", "1.0.0", true, &agentregistryv1alpha1.CatalogEntryReference{Name: "org/c"}),
+		newReplacementTestServer("org/c", "1.0.0", true, &agentregistryv1alpha1.CatalogEntryReference{Name: "org/a", Version: "1.0.0"}),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.getServerReplacement(context.Background(), &ServerDetailInput{ServerName: "org/a"})
+	require.NoError(t, err)
+	assert.True(t, resp.Body.Cycle)
+	assert.Nil(t, resp.Body.Replacement)
+	assert.Len(t, resp.Body.Chain, 3)
+	assert.Contains(t, resp.Body.Message, "org/a@1.0.0")
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+type ServerSourceInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+	Version    string `query:"version" json:"version,omitempty" doc:"Server version; defaults to the latest"`
+}
+
+// ServerSourceResponse is the live discovered resource a catalog entry was
+// created from
+type ServerSourceResponse struct {
+	Name      string                                `json:"name"`
+	Version   string                                `json:"version"`
+	SourceRef agentregistryv1alpha1.SourceReference `json:"sourceRef"`
+	Object    map[string]any                        `json:"object" doc:"The live source object including its status, with secret values redacted"`
+}
+
+// registerSourceRoute registers the endpoint resolving a server's SourceRef
+func (h *ServerHandler) registerSourceRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-source" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/source",
+		Summary:     "Get the live discovered resource behind an MCP server",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerSourceInput) (*Response[ServerSourceResponse], error) {
+		return h.getServerSource(ctx, input)
+	})
+}
+
+func (h *ServerHandler) getServerSource(ctx context.Context, input *ServerSourceInput) (*Response[ServerSourceResponse], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+
+	reader := client.Reader(h.client)
+	if h.cache != nil {
+		reader = h.cache
+	}
+	server, err := findServerEntry(ctx, reader, serverName, input.Version)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	if server == nil {
+		return nil, catalogNotFound("Server not found")
+	}
+	ref := server.Spec.SourceRef
+	if ref == nil {
+		return nil, huma.Error404NotFound("Server was not discovered and has no source resource")
+	}
+
+	source, err := controller.GetDiscoveredSource(ctx, *ref)
+	if err != nil {
+		return nil, huma.Error404NotFound("Source resource is not in the discovery cache", err)
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(source)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to convert source resource", err)
+	}
+	// Typed objects from the informers carry no type metadata
+	if gvk, err := apiutil.GVKForObject(source, h.client.Scheme()); err == nil {
+		obj["apiVersion"], obj["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	}
+
+	return &Response[ServerSourceResponse]{
+		Body: ServerSourceResponse{
+			Name:      server.Spec.Name,
+			Version:   server.Spec.Version,
+			SourceRef: *ref,
+			Object:    redactLiveObject(obj),
+		},
+	}, nil
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestServerHandler_GetServerSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+
+	source := &kmcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "filesystem", Namespace: "tools"},
+		Spec: kmcpv1alpha1.MCPServerSpec{
+			TransportType: "stdio",
+			Deployment: kmcpv1alpha1.MCPServerDeployment{
+				Image: "ghcr.io/modelcontextprotocol/servers/filesystem:latest",
+				Env:   map[string]string{"API_TOKEN": "s3cret", "ROOT": "/data"},
+			},
+		},
+		Status: kmcpv1alpha1.MCPServerStatus{
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Message: "Server is ready"}},
+		},
+	}
+	controller.SetDiscoveredSource(source)
+	t.Cleanup(func() { controller.DeleteDiscoveredSource(source) })
+
+	entry := func(name string, ref *agentregistryv1alpha1.SourceReference) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, "1.0.0"), Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0", SourceRef: ref},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			entry("tools/filesystem", &agentregistryv1alpha1.SourceReference{Kind: "MCPServer", Name: "filesystem", Namespace: "tools"}),
+			entry("tools/gone", &agentregistryv1alpha1.SourceReference{Kind: "MCPServer", Name: "gone", Namespace: "tools"}),
+			entry("manual", nil),
+		).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		Build()
+	h := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	// The live spec may carry inline env values, so only admins can read it
+	_, api := humatest.New(t)
+	h.RegisterRoutes(api, "/v0", false)
+	h.RegisterRoutes(api, "/admin/v0", true)
+	assert.Equal(t, http.StatusNotFound, api.Get("/v0/servers/tools%2Ffilesystem/source").Code)
+	assert.Equal(t, http.StatusOK, api.Get("/admin/v0/servers/tools%2Ffilesystem/source").Code)
+
+	resp, err := h.getServerSource(ctx, &ServerSourceInput{ServerName: "tools%2Ffilesystem"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", resp.Body.Version)
+	assert.Equal(t, "MCPServer", resp.Body.SourceRef.Kind)
+	obj := resp.Body.Object
+	assert.Equal(t, "MCPServer", obj["kind"])
+	assert.Equal(t, kmcpv1alpha1.GroupVersion.String(), obj["apiVersion"])
+	conditions := obj["status"].(map[string]any)["conditions"].([]any)
+	require.Len(t, conditions, 1)
+	assert.Equal(t, "Server is ready", conditions[0].(map[string]any)["message"])
+	env := obj["spec"].(map[string]any)["deployment"].(map[string]any)["env"].(map[string]any)
+	assert.Equal(t, redactedValue, env["API_TOKEN"])
+	assert.Equal(t, "/data", env["ROOT"])
+
+	for name, input := range map[string]*ServerSourceInput{
+		"not discovered":      {ServerName: "manual"},
+		"source not in cache": {ServerName: "tools%2Fgone"},
+		"unknown server":      {ServerName: "missing"},
+		"unknown version":     {ServerName: "tools%2Ffilesystem", Version: "2.0.0"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := h.getServerSource(ctx, input)
+			var apiErr *ErrorResponse
+			require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+			assert.Equal(t, http.StatusNotFound, apiErr.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+type ValidateServerInput struct {
+	ServerName  string `path:"serverName" json:"serverName"`
+	Version     string `path:"version" json:"version"`
+	Environment string `query:"environment" json:"environment,omitempty" doc:"Environment the server would be deployed into; defaults to the local cluster"`
+}
+
+// CatalogValidationResponse reports whether a catalog entry can be deployed
+type CatalogValidationResponse struct {
+	Name        string                         `json:"name"`
+	Version     string                         `json:"version"`
+	Environment string                         `json:"environment,omitempty"`
+	Deployable  bool                           `json:"deployable" doc:"Whether every check passed"`
+	Findings    []controller.ValidationFinding `json:"findings"`
+}
+
+// registerValidateRoute registers the endpoint checking whether a server
+// version can be deployed
+func (h *ServerHandler) registerValidateRoute(api huma.API, pathPrefix string, tags []string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "validate-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/validate",
+		Summary:     "Check whether an MCP server version can be deployed, without deploying it",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ValidateServerInput) (*Response[CatalogValidationResponse], error) {
+		return h.validateServer(ctx, input)
+	})
+}
+
+func (h *ServerHandler) validateServer(ctx context.Context, input *ValidateServerInput) (*Response[CatalogValidationResponse], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return nil, invalidVersion("Invalid version encoding", err)
+	}
+
+	reader := client.Reader(h.client)
+	if h.cache != nil {
+		reader = h.cache
+	}
+	server, err := findServerEntry(ctx, reader, serverName, version)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	if server == nil {
+		return nil, catalogNotFound("Server not found")
+	}
+
+	findings, err := controller.ValidateServerEntry(ctx, reader, server, input.Environment)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to validate server", err)
+	}
+	return &Response[CatalogValidationResponse]{
+		Body: CatalogValidationResponse{
+			Name:        server.Spec.Name,
+			Version:     server.Spec.Version,
+			Environment: input.Environment,
+			Deployable:  controller.Deployable(findings),
+			Findings:    findings,
+		},
+	}, nil
+}
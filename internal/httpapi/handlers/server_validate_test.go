@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestServerHandler_ValidateServer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&agentregistryv1alpha1.MCPServerCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/search", "1.0.0"), Namespace: "agentregistry"},
+				Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+					Name:     "org/search",
+					Version:  "1.0.0",
+					Remotes:  []agentregistryv1alpha1.Transport{{Type: "streamable-http", URL: "https://search.example.com/mcp"}},
+					Metadata: verifiedPublisher,
+				},
+			},
+			&agentregistryv1alpha1.MCPServerCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("org/draft", "0.1.0"), Namespace: "agentregistry"},
+				Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/draft", Version: "0.1.0"},
+			},
+			&agentregistryv1alpha1.DiscoveryConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+				Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+					Environments: []agentregistryv1alpha1.Environment{
+						{Name: "prod", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "prod-gke"}, DeployEnabled: true},
+					},
+				},
+			},
+		).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		Build()
+	h := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := h.validateServer(ctx, &ValidateServerInput{ServerName: "org%2Fsearch", Version: "1.0.0", Environment: "prod"})
+	require.NoError(t, err)
+	assert.True(t, resp.Body.Deployable)
+	assert.Equal(t, "prod", resp.Body.Environment)
+	require.Len(t, resp.Body.Findings, 3)
+	for _, finding := range resp.Body.Findings {
+		assert.True(t, finding.Passed, finding.Check)
+	}
+
+	resp, err = h.validateServer(ctx, &ValidateServerInput{ServerName: "org%2Fdraft", Version: "0.1.0"})
+	require.NoError(t, err)
+	assert.False(t, resp.Body.Deployable)
+	var failed []string
+	for _, finding := range resp.Body.Findings {
+		if !finding.Passed {
+			failed = append(failed, finding.Check)
+		}
+	}
+	assert.Equal(t, []string{controller.CheckPackages, controller.CheckPublisher}, failed)
+
+	_, err = h.validateServer(ctx, &ValidateServerInput{ServerName: "org%2Fsearch", Version: "9.9.9"})
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+	assert.Equal(t, http.StatusNotFound, apiErr.GetStatus())
+}
@@ -3,20 +3,26 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
 	"github.com/agentregistry-dev/agentregistry/internal/conversion"
+	"github.com/agentregistry-dev/agentregistry/internal/search"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
 	"github.com/agentregistry-dev/agentregistry/internal/validation"
 )
 
@@ -51,6 +57,7 @@ type ServerJSON struct {
 	Title       string          `json:"title,omitempty"`
 	Description string          `json:"description,omitempty"`
 	WebsiteURL  string          `json:"websiteUrl,omitempty"`
+	IconURL     string          `json:"iconUrl,omitempty"`
 	Repository  *RepositoryJSON `json:"repository,omitempty"`
 	Packages    []PackageJSON   `json:"packages,omitempty"`
 	Remotes     []TransportJSON `json:"remotes,omitempty"`
@@ -74,6 +81,14 @@ type KeyValueJSON struct {
 	Description string `json:"description,omitempty"`
 	Value       string `json:"value,omitempty"`
 	Required    bool   `json:"required,omitempty"`
+	// SecretRef reads an environment variable from a Kubernetes Secret in
+	// the deployment's namespace instead of Value
+	SecretRef *SecretKeyRefJSON `json:"secretRef,omitempty"`
+}
+
+type SecretKeyRefJSON struct {
+	SecretName string `json:"secretName" minLength:"1"`
+	Key        string `json:"key,omitempty" doc:"Key within the Secret; must match the variable name when set"`
 }
 
 type PackageJSON struct {
@@ -82,6 +97,7 @@ type PackageJSON struct {
 	Identifier           string         `json:"identifier"`
 	Version              string         `json:"version,omitempty"`
 	FileSHA256           string         `json:"fileSha256,omitempty"`
+	Digest               string         `json:"digest,omitempty"`
 	RuntimeHint          string         `json:"runtimeHint,omitempty"`
 	Transport            TransportJSON  `json:"transport"`
 	RuntimeArguments     []ArgumentJSON `json:"runtimeArguments,omitempty"`
@@ -113,6 +129,14 @@ type ServerMeta struct {
 	IsDiscovered      bool                   `json:"isDiscovered,omitempty"`
 	UsedBy            []ServerUsageRefJSON   `json:"usedBy,omitempty"`
 	Publisher         *PublisherInfoJSON     `json:"publisher,omitempty"`
+	HasAttestations   bool                   `json:"hasAttestations,omitempty"`
+	DeploymentCount   int64                  `json:"deploymentCount,omitempty"`
+	Featured          bool                   `json:"featured,omitempty"`
+	// ReplacedBy is the server to use instead of this deprecated one
+	ReplacedBy *agentregistryv1alpha1.CatalogEntryReference `json:"replacedBy,omitempty"`
+	// Deployments summarizes the RegistryDeployments of this version; set
+	// with ?includeDeployments=true
+	Deployments *DeploymentSummary `json:"deployments,omitempty"`
 }
 
 type OfficialMeta struct {
@@ -135,10 +159,18 @@ type ServerListResponse struct {
 
 // Input types
 type ListServersInput struct {
-	Cursor  string `query:"cursor" json:"cursor,omitempty"`
-	Limit   int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
-	Search  string `query:"search" json:"search,omitempty"`
-	Version string `query:"version" json:"version,omitempty"`
+	Cursor             string `query:"cursor" json:"cursor,omitempty"`
+	Limit              int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
+	Search             string `query:"search" json:"search,omitempty" doc:"Match name, title and description, ignoring case; exact and prefix name matches rank first"`
+	SearchFields       string `query:"searchFields" json:"searchFields,omitempty" doc:"Comma-separated fields to search: name, title, description. Defaults to all."`
+	Version            string `query:"version" json:"version,omitempty"`
+	Sort               string `query:"sort" json:"sort,omitempty" enum:"popularity" doc:"popularity lists the most deployed versions first"`
+	Featured           bool   `query:"featured" json:"featured,omitempty" doc:"List only entries featured by the registry curators"`
+	IncludeDeployments bool   `query:"includeDeployments" json:"includeDeployments,omitempty" doc:"Summarize the deployments of each version in _meta.deployments"`
+}
+
+type PopularServersInput struct {
+	Limit int `query:"limit" json:"limit,omitempty" default:"10" minimum:"1" maximum:"100"`
 }
 
 type ServerDetailInput struct {
@@ -150,6 +182,12 @@ type ServerVersionDetailInput struct {
 	Version    string `path:"version" json:"version"`
 }
 
+type DeleteServerVersionInput struct {
+	ServerName string `path:"serverName" json:"serverName"`
+	Version    string `path:"version" json:"version"`
+	Force      bool   `query:"force" json:"force,omitempty" doc:"Delete even if the version still has active deployments"`
+}
+
 type CreateServerInput struct {
 	Body ServerJSON
 }
@@ -172,6 +210,17 @@ func (h *ServerHandler) RegisterRoutes(api huma.API, pathPrefix string, isAdmin
 		return h.listServers(ctx, input, isAdmin)
 	})
 
+	// Most deployed servers
+	huma.Register(api, huma.Operation{
+		OperationID: "list-popular-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/popular",
+		Summary:     "List the most deployed MCP servers",
+		Tags:        tags,
+	}, func(ctx context.Context, input *PopularServersInput) (*Response[ServerListResponse], error) {
+		return h.listPopularServers(ctx, input)
+	})
+
 	// Get server by name (latest version)
 	huma.Register(api, huma.Operation{
 		OperationID: "get-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -205,8 +254,40 @@ func (h *ServerHandler) RegisterRoutes(api huma.API, pathPrefix string, isAdmin
 		return h.listServerVersions(ctx, input)
 	})
 
+	// Compare two versions of a server
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-server-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/diff",
+		Summary:     "Compare two versions of an MCP server",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerDiffInput) (*Response[ServerDiff], error) {
+		return h.diffServerVersions(ctx, input)
+	})
+
+	// Follow the deprecation replacedBy chain to the recommended server
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-replacement" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/replacement",
+		Summary:     "Resolve the replacement for a deprecated MCP server",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ServerDetailInput) (*Response[ServerReplacement], error) {
+		return h.getServerReplacement(ctx, input)
+	})
+
+	// Supply-chain attestations of a server version
+	h.registerAttestationRoutes(api, pathPrefix, tags, isAdmin)
+
+	// Deployability checks of a server version
+	h.registerValidateRoute(api, pathPrefix, tags)
+
 	// Admin-only endpoints (mutations).
 	if isAdmin {
+		// Live discovered resource behind a discovered server; its spec may
+		// carry inline env values, so it is not exposed publicly
+		h.registerSourceRoute(api, pathPrefix, tags)
+
 		// Create server (push)
 		huma.Register(api, huma.Operation{
 			OperationID: "push-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -228,10 +309,29 @@ func (h *ServerHandler) RegisterRoutes(api huma.API, pathPrefix string, isAdmin
 		}, func(ctx context.Context, input *CreateServerInput) (*Response[ServerResponse], error) {
 			return h.createServer(ctx, input)
 		})
+
+		// Delete a server version
+		huma.Register(api, huma.Operation{
+			OperationID: "delete-server-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
+			Method:      http.MethodDelete,
+			Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
+			Summary:     "Delete MCP server version",
+			Tags:        tags,
+		}, func(ctx context.Context, input *DeleteServerVersionInput) (*Response[EmptyResponse], error) {
+			return h.deleteServerVersion(ctx, input)
+		})
+
+		// Deprecate a server version in favor of a successor
+		h.registerDeprecationRoute(api, pathPrefix, tags)
 	}
 }
 
 func (h *ServerHandler) listServers(ctx context.Context, input *ListServersInput, isAdmin bool) (*Response[ServerListResponse], error) {
+	scope, err := search.ParseScope(input.SearchFields)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid searchFields", err)
+	}
+
 	var serverList agentregistryv1alpha1.MCPServerCatalogList
 
 	listOpts := []client.ListOption{}
@@ -254,18 +354,21 @@ func (h *ServerHandler) listServers(ctx context.Context, input *ListServersInput
 		deploymentMap = make(map[string]*agentregistryv1alpha1.RegistryDeployment)
 	}
 
+	ranked := search.RankWithin(serverList.Items, input.Search, scope, search.MCPServerFields)
+	if input.Sort == SortPopularity {
+		sortByPopularity(ranked, func(s *agentregistryv1alpha1.MCPServerCatalog) int64 { return s.Status.DeploymentCount })
+	}
+
 	// Apply additional filters
 	servers := make([]ServerResponse, 0, len(serverList.Items))
-	for _, s := range serverList.Items {
-		// Filter by search term
-		if input.Search != "" && !strings.Contains(strings.ToLower(s.Spec.Name), strings.ToLower(input.Search)) {
-			continue
-		}
-
+	for _, s := range ranked {
 		// Filter by specific version
 		if input.Version != "" && input.Version != "latest" && s.Spec.Version != input.Version {
 			continue
 		}
+		if input.Featured && !IsFeatured(&s) {
+			continue
+		}
 
 		// Get deployment status for this server
 		key := s.Spec.Name + "/" + s.Spec.Version
@@ -282,6 +385,18 @@ func (h *ServerHandler) listServers(ctx context.Context, input *ListServersInput
 		servers = servers[:limit]
 	}
 
+	// Summarize only the page returned, each through the deployment index
+	if input.IncludeDeployments {
+		for i := range servers {
+			entry := &servers[i].Server
+			summary, err := summarizeDeployments(ctx, h.listFromCacheOrClient, agentregistryv1alpha1.ResourceTypeMCP, entry.Name, entry.Version)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to summarize deployments", err)
+			}
+			servers[i].Meta.Deployments = summary
+		}
+	}
+
 	return &Response[ServerListResponse]{
 		Body: ServerListResponse{
 			Servers: servers,
@@ -292,6 +407,66 @@ func (h *ServerHandler) listServers(ctx context.Context, input *ListServersInput
 	}, nil
 }
 
+// listPopularServers lists the servers deployed at least once, most deployed
+// first. Each server is represented by its latest version, with
+// _meta.deploymentCount summed across all of its versions.
+func (h *ServerHandler) listPopularServers(ctx context.Context, input *PopularServersInput) (*Response[ServerListResponse], error) {
+	var serverList agentregistryv1alpha1.MCPServerCatalogList
+	if err := h.listFromCacheOrClient(ctx, &serverList); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to list servers", err)
+	}
+
+	totals := make(map[string]int64)
+	latest := make(map[string]*agentregistryv1alpha1.MCPServerCatalog)
+	for i := range serverList.Items {
+		s := &serverList.Items[i]
+		totals[s.Spec.Name] += s.Status.DeploymentCount
+		if cur, ok := latest[s.Spec.Name]; !ok || s.Status.IsLatest ||
+			(!cur.Status.IsLatest && semver.Compare(s.Spec.Version, cur.Spec.Version) > 0) {
+			latest[s.Spec.Name] = s
+		}
+	}
+
+	popular := make([]*agentregistryv1alpha1.MCPServerCatalog, 0, len(latest))
+	for name, s := range latest {
+		if totals[name] > 0 {
+			popular = append(popular, s)
+		}
+	}
+	sort.Slice(popular, func(i, j int) bool {
+		if ti, tj := totals[popular[i].Spec.Name], totals[popular[j].Spec.Name]; ti != tj {
+			return ti > tj
+		}
+		return popular[i].Spec.Name < popular[j].Spec.Name
+	})
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if len(popular) > limit {
+		popular = popular[:limit]
+	}
+
+	deploymentMap, err := h.buildDeploymentMap(ctx)
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("Failed to list RegistryDeployments, continuing without deployment status")
+		deploymentMap = make(map[string]*agentregistryv1alpha1.RegistryDeployment)
+	}
+	servers := make([]ServerResponse, 0, len(popular))
+	for _, s := range popular {
+		resp := h.convertToServerResponse(s, deploymentMap[s.Spec.Name+"/"+s.Spec.Version])
+		resp.Meta.DeploymentCount = totals[s.Spec.Name]
+		servers = append(servers, resp)
+	}
+
+	return &Response[ServerListResponse]{
+		Body: ServerListResponse{
+			Servers:  servers,
+			Metadata: ListMetadata{Count: len(servers)},
+		},
+	}, nil
+}
+
 // buildDeploymentMap creates a map of resourceName/version to RegistryDeployment
 func (h *ServerHandler) buildDeploymentMap(ctx context.Context) (map[string]*agentregistryv1alpha1.RegistryDeployment, error) {
 	var deploymentList agentregistryv1alpha1.RegistryDeploymentList
@@ -314,16 +489,15 @@ func (h *ServerHandler) buildDeploymentMap(ctx context.Context) (map[string]*age
 // getDeploymentForServer looks up a RegistryDeployment for a specific server by name and version
 func (h *ServerHandler) getDeploymentForServer(ctx context.Context, resourceName, version string) (*agentregistryv1alpha1.RegistryDeployment, error) {
 	var deploymentList agentregistryv1alpha1.RegistryDeploymentList
-	if err := h.listFromCacheOrClient(ctx, &deploymentList); err != nil {
+	if err := h.listFromCacheOrClient(ctx, &deploymentList, client.MatchingFields{
+		controller.IndexDeploymentResourceVersion: controller.NameVersionKey(resourceName, version),
+	}); err != nil {
 		return nil, err
 	}
 
 	for i := range deploymentList.Items {
-		deployment := &deploymentList.Items[i]
-		if deployment.Spec.ResourceType == agentregistryv1alpha1.ResourceTypeMCP &&
-			deployment.Spec.ResourceName == resourceName &&
-			deployment.Spec.Version == version {
-			return deployment, nil
+		if deploymentList.Items[i].Spec.ResourceType == agentregistryv1alpha1.ResourceTypeMCP {
+			return &deploymentList.Items[i], nil
 		}
 	}
 
@@ -333,7 +507,7 @@ func (h *ServerHandler) getDeploymentForServer(ctx context.Context, resourceName
 func (h *ServerHandler) getServer(ctx context.Context, input *ServerDetailInput, isAdmin bool) (*Response[ServerResponse], error) {
 	serverName, err := url.PathUnescape(input.ServerName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		return nil, invalidName("Invalid server name encoding", err)
 	}
 
 	var serverList agentregistryv1alpha1.MCPServerCatalogList
@@ -348,11 +522,22 @@ func (h *ServerHandler) getServer(ctx context.Context, input *ServerDetailInput,
 		return nil, huma.Error500InternalServerError("Failed to get server", err)
 	}
 
+	// Fall back to computing latest on the fly when the IsLatest flag has not
+	// been set yet (e.g. before the controller has reconciled a new version).
 	if len(serverList.Items) == 0 {
-		return nil, huma.Error404NotFound("Server not found")
+		if err := h.listFromCacheOrClient(ctx, &serverList, client.MatchingFields{
+			controller.IndexMCPServerName: serverName,
+		}); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get server", err)
+		}
+	}
+
+	latest := semver.LatestIndex(serverList.Items, func(s agentregistryv1alpha1.MCPServerCatalog) string { return s.Spec.Version })
+	if latest < 0 {
+		return nil, catalogNotFound("Server not found")
 	}
 
-	server := &serverList.Items[0]
+	server := &serverList.Items[latest]
 
 	// Fetch deployment for this server
 	deployment, err := h.getDeploymentForServer(ctx, server.Spec.Name, server.Spec.Version)
@@ -368,11 +553,11 @@ func (h *ServerHandler) getServer(ctx context.Context, input *ServerDetailInput,
 func (h *ServerHandler) getServerVersion(ctx context.Context, input *ServerVersionDetailInput, isAdmin bool) (*Response[ServerResponse], error) {
 	serverName, err := url.PathUnescape(input.ServerName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		return nil, invalidName("Invalid server name encoding", err)
 	}
 	version, err := url.PathUnescape(input.Version)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		return nil, invalidVersion("Invalid version encoding", err)
 	}
 
 	var serverList agentregistryv1alpha1.MCPServerCatalogList
@@ -396,18 +581,79 @@ func (h *ServerHandler) getServerVersion(ctx context.Context, input *ServerVersi
 		}
 	}
 
-	return nil, huma.Error404NotFound("Server version not found")
+	return nil, catalogNotFound("Server version not found")
+}
+
+// deleteServerVersion deletes a server version, refusing with 409 while
+// deployments of it are still active unless force is set
+func (h *ServerHandler) deleteServerVersion(ctx context.Context, input *DeleteServerVersionInput) (*Response[EmptyResponse], error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, invalidName("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return nil, invalidVersion("Invalid version encoding", err)
+	}
+
+	var serverList agentregistryv1alpha1.MCPServerCatalogList
+	if err := h.listFromCacheOrClient(ctx, &serverList, client.MatchingFields{
+		controller.IndexMCPServerName: serverName,
+	}); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	var server *agentregistryv1alpha1.MCPServerCatalog
+	for i := range serverList.Items {
+		if serverList.Items[i].Spec.Version == version {
+			server = &serverList.Items[i]
+			break
+		}
+	}
+	if server == nil {
+		return nil, catalogNotFound("Server version not found")
+	}
+
+	if !input.Force {
+		reader := client.Reader(h.client)
+		if h.cache != nil {
+			reader = h.cache
+		}
+		deployments, err := ListReferencingDeployments(ctx, reader, agentregistryv1alpha1.ResourceTypeMCP, serverName)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check deployments of server version", err)
+		}
+		if active := ActiveDeployments(DeploymentsOfVersion(deployments, version)); len(active) > 0 {
+			names := make([]string, 0, len(active))
+			for _, d := range active {
+				names = append(names, fmt.Sprintf("%s (%s)", d.Name, d.Status.Phase))
+			}
+			return nil, huma.Error409Conflict(fmt.Sprintf(
+				"Server version has %d active deployment(s): %s; delete them first or retry with force=true",
+				len(active), strings.Join(names, ", ")))
+		}
+	}
+
+	if err := h.client.Delete(ctx, server); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, catalogNotFound("Server version not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to delete server version", err)
+	}
+
+	return &Response[EmptyResponse]{
+		Body: EmptyResponse{Message: "Server version deleted successfully"},
+	}, nil
 }
 
 func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInput) (*Response[ServerResponse], error) {
 	// Validate server name
 	if err := validation.ValidateServerName(input.Body.Name); err != nil {
-		return nil, huma.Error400BadRequest("Invalid server name", err)
+		return nil, invalidName("Invalid server name", err)
 	}
 
 	// Validate version
 	if err := validation.ValidateSemanticVersion(input.Body.Version); err != nil {
-		return nil, huma.Error400BadRequest("Invalid version", err)
+		return nil, invalidVersion("Invalid version", err)
 	}
 
 	// Validate repository URL if provided
@@ -417,6 +663,40 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 		}
 	}
 
+	if input.Body.IconURL != "" {
+		if err := validation.ValidateIconURL(input.Body.IconURL); err != nil {
+			return nil, huma.Error400BadRequest("Invalid icon URL", err)
+		}
+	}
+
+	// Validate remote URLs now rather than when a deployment parses them
+	for _, r := range input.Body.Remotes {
+		if err := validation.ValidateTransportURL(r.Type, r.URL); err != nil {
+			return nil, huma.Error400BadRequest("Invalid remote URL", err)
+		}
+	}
+
+	// Validate pinned image digests
+	for _, p := range input.Body.Packages {
+		if p.Digest == "" {
+			continue
+		}
+		if err := validation.ValidateDigest(p.Digest); err != nil {
+			return nil, huma.Error400BadRequest("Invalid package digest", err)
+		}
+	}
+
+	// KMCP exposes every key of a referenced Secret as a variable of the
+	// same name, so a Secret key cannot be renamed when it is deployed
+	for _, p := range input.Body.Packages {
+		for _, e := range p.EnvironmentVariables {
+			if e.SecretRef != nil && e.SecretRef.Key != "" && e.SecretRef.Key != e.Name {
+				return nil, huma.Error400BadRequest("Invalid secret reference",
+					fmt.Errorf("environment variable %s cannot read key %q of Secret %s: the key must match the variable name", e.Name, e.SecretRef.Key, e.SecretRef.SecretName))
+			}
+		}
+	}
+
 	crName := GenerateCRName(input.Body.Name, input.Body.Version)
 
 	server := &agentregistryv1alpha1.MCPServerCatalog{
@@ -433,6 +713,7 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 			Title:       input.Body.Title,
 			Description: input.Body.Description,
 			WebsiteURL:  input.Body.WebsiteURL,
+			IconURL:     input.Body.IconURL,
 		},
 	}
 
@@ -454,6 +735,7 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 			Identifier:      p.Identifier,
 			Version:         p.Version,
 			FileSHA256:      p.FileSHA256,
+			Digest:          p.Digest,
 			RuntimeHint:     p.RuntimeHint,
 			Transport: agentregistryv1alpha1.Transport{
 				Type: p.Transport.Type,
@@ -489,12 +771,16 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 			})
 		}
 		for _, e := range p.EnvironmentVariables {
-			pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, agentregistryv1alpha1.KeyValueInput{
+			envVar := agentregistryv1alpha1.KeyValueInput{
 				Name:        e.Name,
 				Description: e.Description,
 				Value:       e.Value,
 				Required:    e.Required,
-			})
+			}
+			if e.SecretRef != nil {
+				envVar.SecretRef = &agentregistryv1alpha1.SecretKeyRef{SecretName: e.SecretRef.SecretName, Key: e.SecretRef.Key}
+			}
+			pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, envVar)
 		}
 		server.Spec.Packages = append(server.Spec.Packages, pkg)
 	}
@@ -503,7 +789,7 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 	for _, r := range input.Body.Remotes {
 		remote := agentregistryv1alpha1.Transport{
 			Type: r.Type,
-			URL:  r.URL,
+			URL:  validation.NormalizeTransportURL(r.URL),
 		}
 		for _, h := range r.Headers {
 			remote.Headers = append(remote.Headers, agentregistryv1alpha1.KeyValueInput{
@@ -516,8 +802,22 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 		server.Spec.Remotes = append(server.Spec.Remotes, remote)
 	}
 
+	if errs := validation.ValidateServerLimits(&server.Spec, config.GetCatalogLimits()); len(errs) > 0 {
+		return nil, huma.Error400BadRequest("Server exceeds the catalog size limits", errs.ToAggregate().Errors()...)
+	}
+
+	if err := h.checkServerNameConflict(ctx, server); err != nil {
+		return nil, err
+	}
+	if err := rejectDuplicateEntry(ctx, h.client, &agentregistryv1alpha1.MCPServerCatalogList{}, server, server.Spec.Name, server.Spec.Version); err != nil {
+		return nil, err
+	}
+
 	// Create the CR
 	if err := h.client.Create(ctx, server); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, huma.Error409Conflict("Server "+input.Body.Name+" version "+input.Body.Version+" already exists", err)
+		}
 		return nil, huma.Error500InternalServerError("Failed to create server", err)
 	}
 
@@ -526,10 +826,42 @@ func (h *ServerHandler) createServer(ctx context.Context, input *CreateServerInp
 	}, nil
 }
 
+// checkServerNameConflict rejects creating server when its CR name is already
+// taken, or when its name sanitizes to the same value as a different server's
+// name (e.g. "org/server" and "org.server"), which would otherwise make the two
+// logical servers share CR names across versions.
+func (h *ServerHandler) checkServerNameConflict(ctx context.Context, server *agentregistryv1alpha1.MCPServerCatalog) error {
+	var existing agentregistryv1alpha1.MCPServerCatalog
+	err := h.client.Get(ctx, client.ObjectKeyFromObject(server), &existing)
+	switch {
+	case err == nil:
+		if existing.Spec.Name != server.Spec.Name {
+			return huma.Error409Conflict(fmt.Sprintf("Server name %q conflicts with existing server %q", server.Spec.Name, existing.Spec.Name))
+		}
+		return huma.Error409Conflict(fmt.Sprintf("Server %s version %s already exists", server.Spec.Name, server.Spec.Version))
+	case !apierrors.IsNotFound(err):
+		return huma.Error500InternalServerError("Failed to check for existing server", err)
+	}
+
+	var siblings agentregistryv1alpha1.MCPServerCatalogList
+	if err := h.client.List(ctx, &siblings,
+		client.InNamespace(server.Namespace),
+		client.MatchingLabels{"agentregistry.dev/name": server.Labels["agentregistry.dev/name"]},
+	); err != nil {
+		return huma.Error500InternalServerError("Failed to check for existing server", err)
+	}
+	for _, s := range siblings.Items {
+		if s.Spec.Name != server.Spec.Name {
+			return huma.Error409Conflict(fmt.Sprintf("Server name %q conflicts with existing server %q", server.Spec.Name, s.Spec.Name))
+		}
+	}
+	return nil
+}
+
 func (h *ServerHandler) listServerVersions(ctx context.Context, input *ServerDetailInput) (*Response[ServerListResponse], error) {
 	serverName, err := url.PathUnescape(input.ServerName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		return nil, invalidName("Invalid server name encoding", err)
 	}
 
 	var serverList agentregistryv1alpha1.MCPServerCatalogList
@@ -538,6 +870,7 @@ func (h *ServerHandler) listServerVersions(ctx context.Context, input *ServerDet
 	}); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to list server versions", err)
 	}
+	semver.SortDescendingFunc(serverList.Items, func(s agentregistryv1alpha1.MCPServerCatalog) string { return s.Spec.Version })
 
 	// Fetch all RegistryDeployments to build a lookup map
 	deploymentMap, err := h.buildDeploymentMap(ctx)
@@ -586,6 +919,7 @@ func (h *ServerHandler) convertToServerResponse(s *agentregistryv1alpha1.MCPServ
 			Identifier:           pkg.Identifier,
 			Version:              pkg.Version,
 			FileSHA256:           pkg.FileSHA256,
+			Digest:               pkg.Digest,
 			RuntimeHint:          pkg.RuntimeHint,
 			Transport:            convertTransport(pkg.Transport),
 			RuntimeArguments:     convertArguments(pkg.RuntimeArguments),
@@ -607,6 +941,7 @@ func (h *ServerHandler) convertToServerResponse(s *agentregistryv1alpha1.MCPServ
 		Title:       s.Spec.Title,
 		Description: s.Spec.Description,
 		WebsiteURL:  s.Spec.WebsiteURL,
+		IconURL:     s.Spec.IconURL,
 		Repository:  repoJSON,
 		Packages:    packages,
 		Remotes:     remotes,
@@ -632,14 +967,8 @@ func (h *ServerHandler) convertToServerResponse(s *agentregistryv1alpha1.MCPServ
 	}
 
 	// Check for discovery labels to determine source
-	if s.Labels != nil {
-		if s.Labels["agentregistry.dev/discovered"] == "true" {
-			resp.Meta.IsDiscovered = true
-			resp.Meta.Source = "discovery"
-		} else if source := s.Labels["agentregistry.dev/resource-source"]; source != "" {
-			resp.Meta.Source = source
-		}
-	}
+	resp.Meta.IsDiscovered = s.Labels["agentregistry.dev/discovered"] == "true"
+	resp.Meta.Source = agentregistryv1alpha1.ResourceSourceOf(s.Labels)
 
 	// Include publisher-provided metadata if available
 	if s.Spec.Metadata != nil && len(s.Spec.Metadata.Raw) > 0 {
@@ -680,6 +1009,10 @@ func (h *ServerHandler) convertToServerResponse(s *agentregistryv1alpha1.MCPServ
 
 	// Map governance/publisher verification from status
 	resp.Meta.Publisher = convertPublisherVerification(s.Status.Publisher)
+	resp.Meta.HasAttestations = s.Annotations[agentregistryv1alpha1.AnnotationAttestations] != ""
+	resp.Meta.DeploymentCount = s.Status.DeploymentCount
+	resp.Meta.Featured = IsFeatured(s)
+	resp.Meta.ReplacedBy = s.Spec.ReplacedBy
 
 	return resp
 }
@@ -687,7 +1020,8 @@ func (h *ServerHandler) convertToServerResponse(s *agentregistryv1alpha1.MCPServ
 // convertRegistryDeploymentToDeploymentInfo converts a RegistryDeployment to DeploymentInfo
 func (h *ServerHandler) convertRegistryDeploymentToDeploymentInfo(d *agentregistryv1alpha1.RegistryDeployment) *DeploymentInfo {
 	info := &DeploymentInfo{
-		Namespace: d.Spec.Namespace,
+		Namespace:  d.Spec.Namespace,
+		Namespaces: d.Spec.Namespaces,
 	}
 
 	// Determine ready status based on phase
@@ -752,6 +1086,9 @@ func convertKeyValues(kvs []conversion.KeyValueJSON) []KeyValueJSON {
 			Value:       kv.Value,
 			Required:    kv.Required,
 		}
+		if kv.SecretRef != nil {
+			result[i].SecretRef = &SecretKeyRefJSON{SecretName: kv.SecretRef.SecretName, Key: kv.SecretRef.Key}
+		}
 	}
 	return result
 }
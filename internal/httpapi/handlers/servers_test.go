@@ -3,9 +3,12 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
 )
 
 func setupTestClient(t *testing.T) client.Client {
@@ -80,6 +84,171 @@ func TestServerHandler_CreateServer_InvalidVersion(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid version")
 }
 
+func TestServerHandler_CreateServer_InvalidDigest(t *testing.T) {
+	handler := NewServerHandler(setupTestClient(t), nil, zerolog.Nop())
+
+	input := &CreateServerInput{
+		Body: ServerJSON{
+			Name:    "my-test-server",
+			Version: "1.0.0",
+			Packages: []PackageJSON{
+				{RegistryType: "oci", Identifier: "ghcr.io/org/server:1.0.0", Digest: "sha256:not-a-digest"},
+			},
+		},
+	}
+
+	_, err := handler.createServer(context.Background(), input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid package digest")
+}
+
+func TestServerHandler_CreateServer_RenamedSecretKey(t *testing.T) {
+	handler := NewServerHandler(setupTestClient(t), nil, zerolog.Nop())
+
+	input := &CreateServerInput{
+		Body: ServerJSON{
+			Name:    "my-test-server",
+			Version: "1.0.0",
+			Packages: []PackageJSON{
+				{
+					RegistryType: "oci",
+					Identifier:   "ghcr.io/org/server:1.0.0",
+					EnvironmentVariables: []KeyValueJSON{
+						{Name: "API_KEY", SecretRef: &SecretKeyRefJSON{SecretName: "creds", Key: "token"}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := handler.createServer(context.Background(), input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid secret reference")
+
+	// A key matching the variable name is accepted
+	input.Body.Packages[0].EnvironmentVariables[0].SecretRef.Key = "API_KEY"
+	_, err = handler.createServer(context.Background(), input)
+	require.NoError(t, err)
+}
+
+func TestServerHandler_CreateServer_IconURL(t *testing.T) {
+	c := setupTestClient(t)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := handler.createServer(ctx, &CreateServerInput{
+		Body: ServerJSON{Name: "my-test-server", Version: "1.0.0", IconURL: "https://example.com/icon.png"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/icon.png", resp.Body.Server.IconURL)
+
+	created := &agentregistryv1alpha1.MCPServerCatalog{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "my-test-server-1-0-0"}, created))
+	assert.Equal(t, "https://example.com/icon.png", created.Spec.IconURL)
+
+	_, err = handler.createServer(ctx, &CreateServerInput{
+		Body: ServerJSON{Name: "other-server", Version: "1.0.0", IconURL: "http://example.com/icon.png"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid icon URL")
+}
+
+func TestServerHandler_CreateServer_RemoteURL(t *testing.T) {
+	c := setupTestClient(t)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	_, err := handler.createServer(ctx, &CreateServerInput{
+		Body: ServerJSON{Name: "my-test-server", Version: "1.0.0", Remotes: []TransportJSON{
+			{Type: "streamable-http", URL: "https://mcp.example.com/mcp/"},
+		}},
+	})
+	require.NoError(t, err)
+
+	created := &agentregistryv1alpha1.MCPServerCatalog{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "my-test-server-1-0-0"}, created))
+	assert.Equal(t, "https://mcp.example.com/mcp", created.Spec.Remotes[0].URL)
+
+	for _, remote := range []TransportJSON{
+		{Type: "streamable-http", URL: "mcp.example.com/mcp"},
+		{Type: "sse", URL: "https:///sse"},
+		{Type: "streamable-http"},
+	} {
+		_, err := handler.createServer(ctx, &CreateServerInput{
+			Body: ServerJSON{Name: "other-server", Version: "1.0.0", Remotes: []TransportJSON{remote}},
+		})
+		require.Error(t, err, remote.URL)
+		assert.Equal(t, http.StatusBadRequest, err.(huma.StatusError).GetStatus())
+		assert.Contains(t, err.Error(), "Invalid remote URL")
+	}
+}
+
+func TestServerHandler_CreateServer_SizeLimits(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_MAX_DESCRIPTION_LENGTH", "8")
+	t.Setenv("AGENTREGISTRY_MAX_PACKAGES", "1")
+	t.Setenv("AGENTREGISTRY_MAX_ENV_VARS", "1")
+	c := setupTestClient(t)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	_, err := handler.createServer(ctx, &CreateServerInput{
+		Body: ServerJSON{Name: "my-test-server", Version: "1.0.0", Description: "Searches",
+			Packages: []PackageJSON{{RegistryType: "oci", Identifier: "ghcr.io/org/search:1.0.0",
+				EnvironmentVariables: []KeyValueJSON{{Name: "LOG_LEVEL"}}}}},
+	})
+	require.NoError(t, err)
+
+	_, err = handler.createServer(ctx, &CreateServerInput{
+		Body: ServerJSON{Name: "other-server", Version: "1.0.0", Description: "Searches the web",
+			Packages: []PackageJSON{
+				{RegistryType: "oci", Identifier: "ghcr.io/org/other:1.0.0",
+					EnvironmentVariables: []KeyValueJSON{{Name: "LOG_LEVEL"}, {Name: "API_KEY"}}},
+				{RegistryType: "npm", Identifier: "@org/other"},
+			}},
+	})
+	var resp *ErrorResponse
+	require.True(t, errors.As(err, &resp))
+	assert.Equal(t, http.StatusBadRequest, resp.GetStatus())
+	require.Len(t, resp.Details, 3)
+	assert.Contains(t, resp.Details[0], "spec.description")
+	assert.Contains(t, resp.Details[1], "spec.packages")
+	assert.Contains(t, resp.Details[2], "spec.packages[0].environmentVariables")
+	require.Error(t, c.Get(ctx, client.ObjectKey{Name: "other-server-1-0-0"}, &agentregistryv1alpha1.MCPServerCatalog{}))
+}
+
+func TestServerHandler_CreateServer_Conflicts(t *testing.T) {
+	c := setupTestClient(t)
+	ctx := context.Background()
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+
+	create := func(name, version string) error {
+		_, err := handler.createServer(ctx, &CreateServerInput{Body: ServerJSON{Name: name, Version: version}})
+		return err
+	}
+
+	require.NoError(t, create("org/my-server", "1.0.0"))
+
+	// A new version of the same server is allowed
+	require.NoError(t, create("org/my-server", "1.1.0"))
+
+	// Same name and version is a 409, not a 500
+	err := create("org/my-server", "1.0.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	assert.Equal(t, http.StatusConflict, err.(huma.StatusError).GetStatus())
+
+	// A different server whose name sanitizes to the same CR name is rejected
+	err = create("org-my/server", "1.0.0")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.(huma.StatusError).GetStatus())
+	assert.Contains(t, err.Error(), "conflicts with existing server")
+
+	// ... including for a version that does not exist yet
+	err = create("org-my/server", "2.0.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with existing server")
+}
+
 func TestServerHandler_CreateServer_InvalidName(t *testing.T) {
 	c := setupTestClient(t)
 	ctx := context.Background()
@@ -325,3 +494,266 @@ func TestServerHandler_ConvertToServerResponse_WithDeployment(t *testing.T) {
 	assert.True(t, resp.Meta.Deployment.Ready)
 	assert.Equal(t, "running", resp.Meta.Deployment.Message)
 }
+
+func setupServerVersionsTestClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerIsLatest, func(obj client.Object) []string {
+			if obj.(*agentregistryv1alpha1.MCPServerCatalog).Status.IsLatest {
+				return []string{"true"}
+			}
+			return []string{"false"}
+		}).
+		WithObjects(objs...).
+		Build()
+}
+
+func newVersionedTestServer(version string, isLatest bool) *agentregistryv1alpha1.MCPServerCatalog {
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName("versioned-server", version)},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "versioned-server",
+			Version: version,
+		},
+		Status: agentregistryv1alpha1.MCPServerCatalogStatus{IsLatest: isLatest},
+	}
+}
+
+func TestServerHandler_ListServerVersions_NewestFirst(t *testing.T) {
+	c := setupServerVersionsTestClient(t,
+		newVersionedTestServer("1.0.0-rc1", false),
+		newVersionedTestServer("1.10.0", true),
+		newVersionedTestServer("1.0.0", false),
+		newVersionedTestServer("1.9.0", false),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.listServerVersions(context.Background(), &ServerDetailInput{ServerName: "versioned-server"})
+	require.NoError(t, err)
+
+	versions := make([]string, 0, len(resp.Body.Servers))
+	for _, s := range resp.Body.Servers {
+		versions = append(versions, s.Server.Version)
+	}
+	assert.Equal(t, []string{"1.10.0", "1.9.0", "1.0.0", "1.0.0-rc1"}, versions)
+}
+
+func TestServerHandler_GetServer_LatestFallback(t *testing.T) {
+	// No version has been flagged as latest yet, so the handler computes it.
+	c := setupServerVersionsTestClient(t,
+		newVersionedTestServer("1.0.0", false),
+		newVersionedTestServer("2.0.0-rc1", false),
+		newVersionedTestServer("1.2.0", false),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.getServer(context.Background(), &ServerDetailInput{ServerName: "versioned-server"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0-rc1", resp.Body.Server.Version)
+
+	_, err = handler.getServer(context.Background(), &ServerDetailInput{ServerName: "missing"}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestServerHandler_Popularity(t *testing.T) {
+	entry := func(name, version string, isLatest bool, deployments int64) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, version)},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: version},
+			Status:     agentregistryv1alpha1.MCPServerCatalogStatus{IsLatest: isLatest, DeploymentCount: deployments},
+		}
+	}
+	c := setupServerVersionsTestClient(t,
+		entry("search", "1.0.0", false, 4),
+		entry("search", "2.0.0", true, 1),
+		entry("github", "1.0.0", true, 3),
+		entry("unused", "1.0.0", true, 0),
+	)
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := handler.listServers(ctx, &ListServersInput{Sort: SortPopularity}, false)
+	require.NoError(t, err)
+	var listed []string
+	for _, s := range resp.Body.Servers {
+		listed = append(listed, s.Server.Name+"@"+s.Server.Version)
+	}
+	assert.Equal(t, []string{"search@1.0.0", "github@1.0.0", "search@2.0.0", "unused@1.0.0"}, listed)
+	assert.Equal(t, int64(4), resp.Body.Servers[0].Meta.DeploymentCount)
+
+	// Popular servers are ranked by their deployments across all versions and
+	// shown at their latest version
+	popular, err := handler.listPopularServers(ctx, &PopularServersInput{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, popular.Body.Servers, 2)
+	assert.Equal(t, "search", popular.Body.Servers[0].Server.Name)
+	assert.Equal(t, "2.0.0", popular.Body.Servers[0].Server.Version)
+	assert.Equal(t, int64(5), popular.Body.Servers[0].Meta.DeploymentCount)
+	assert.Equal(t, "github", popular.Body.Servers[1].Server.Name)
+
+	popular, err = handler.listPopularServers(ctx, &PopularServersInput{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, popular.Body.Servers, 1)
+	assert.Equal(t, "search", popular.Body.Servers[0].Server.Name)
+}
+
+func TestServerHandler_ListServers_SearchRanking(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	entry := func(name, title, description string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, "1.0.0")},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0", Title: title, Description: description},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		entry("my-github-helper", "", ""),
+		entry("scm-bridge", "", "Mirrors GitHub issues"),
+		entry("github-actions", "", ""),
+		entry("gitlab", "GitLab", ""),
+		entry("github", "", ""),
+	).Build()
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+
+	resp, err := handler.listServers(context.Background(), &ListServersInput{Search: "github"}, false)
+	require.NoError(t, err)
+	var names []string
+	for _, s := range resp.Body.Servers {
+		names = append(names, s.Server.Name)
+	}
+	assert.Equal(t, []string{"github", "github-actions", "my-github-helper", "scm-bridge"}, names)
+
+	// The limit applies after ranking so the best matches are kept
+	resp, err = handler.listServers(context.Background(), &ListServersInput{Search: "github", Limit: 1}, false)
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Servers, 1)
+	assert.Equal(t, "github", resp.Body.Servers[0].Server.Name)
+}
+
+func TestServerHandler_ListServers_SearchFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	entry := func(name, title, description string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, "1.0.0")},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0", Title: title, Description: description},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		entry("gh-mcp", "GitHub MCP", ""),
+		entry("scm-bridge", "", "Mirrors GITHUB issues"),
+		entry("github-actions", "", ""),
+		entry("gitlab", "GitLab", ""),
+	).Build()
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	find := func(fields string) []string {
+		t.Helper()
+		resp, err := handler.listServers(context.Background(), &ListServersInput{Search: "github", SearchFields: fields}, false)
+		require.NoError(t, err)
+		var names []string
+		for _, s := range resp.Body.Servers {
+			names = append(names, s.Server.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"github-actions", "gh-mcp", "scm-bridge"}, find(""), "title and description match, ignoring case")
+	assert.Equal(t, []string{"github-actions"}, find("name"))
+	assert.Equal(t, []string{"gh-mcp"}, find("title"))
+	assert.Equal(t, []string{"gh-mcp", "scm-bridge"}, find("title, description"))
+
+	_, err := handler.listServers(context.Background(), &ListServersInput{Search: "github", SearchFields: "name,tags"}, false)
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+	assert.Equal(t, http.StatusBadRequest, apiErr.GetStatus())
+}
+
+func TestServerHandler_ListServers_IncludeDeployments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	entry := func(name string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, "1.0.0")},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0"},
+		}
+	}
+	deployment := func(name, server string, resourceType agentregistryv1alpha1.ResourceType, phase agentregistryv1alpha1.DeploymentPhase, namespaces ...string) client.Object {
+		d := &agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: server,
+				Version:      "1.0.0",
+				ResourceType: resourceType,
+				Namespaces:   namespaces,
+			},
+			Status: agentregistryv1alpha1.RegistryDeploymentStatus{Phase: phase},
+		}
+		if len(namespaces) == 1 {
+			d.Spec.Namespace, d.Spec.Namespaces = namespaces[0], nil
+		}
+		return d
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.RegistryDeployment{}, controller.IndexDeploymentResourceVersion, controller.DeploymentResourceVersionIndex).
+		WithObjects(
+			entry("undeployed"),
+			entry("single"),
+			entry("multi"),
+			deployment("single", "single", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning, "tools"),
+			deployment("multi-a", "multi", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning, "dev", "staging"),
+			deployment("multi-b", "multi", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhasePending, "prod"),
+			deployment("multi-c", "multi", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseFailed, "dev"),
+			deployment("multi-d", "multi", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhasePaused, "dev"),
+			// An agent of the same name and version is not counted
+			deployment("multi-agent", "multi", agentregistryv1alpha1.ResourceTypeAgent, agentregistryv1alpha1.DeploymentPhaseRunning, "agents"),
+		).
+		WithStatusSubresource(&agentregistryv1alpha1.RegistryDeployment{}).
+		Build()
+	handler := NewServerHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	// Not requested, no summary
+	resp, err := handler.listServers(ctx, &ListServersInput{}, false)
+	require.NoError(t, err)
+	for _, s := range resp.Body.Servers {
+		assert.Nil(t, s.Meta.Deployments, s.Server.Name)
+	}
+
+	resp, err = handler.listServers(ctx, &ListServersInput{IncludeDeployments: true}, false)
+	require.NoError(t, err)
+	summaries := map[string]*DeploymentSummary{}
+	for _, s := range resp.Body.Servers {
+		summaries[s.Server.Name] = s.Meta.Deployments
+	}
+	require.Len(t, summaries, 3)
+	assert.Equal(t, &DeploymentSummary{}, summaries["undeployed"])
+	assert.Equal(t, &DeploymentSummary{Total: 1, Running: 1, Namespaces: []string{"tools"}}, summaries["single"])
+	assert.Equal(t, &DeploymentSummary{
+		Total:      4,
+		Running:    1,
+		Pending:    1,
+		Failed:     1,
+		Namespaces: []string{"dev", "prod", "staging"},
+	}, summaries["multi"])
+}
+
+func TestConvertRegistryDeploymentToDeploymentInfo_Namespaces(t *testing.T) {
+	deployment := newReferencingDeployment("multi", "org/multi", "1.0.0", agentregistryv1alpha1.ResourceTypeMCP, agentregistryv1alpha1.DeploymentPhaseRunning)
+	deployment.Spec.Namespace = ""
+	deployment.Spec.Namespaces = []string{"team-a", "team-b"}
+
+	for name, info := range map[string]*DeploymentInfo{
+		"server": NewServerHandler(nil, nil, zerolog.Nop()).convertRegistryDeploymentToDeploymentInfo(deployment),
+		"agent":  NewAgentHandler(nil, nil, zerolog.Nop()).convertRegistryDeploymentToDeploymentInfo(deployment),
+	} {
+		assert.Empty(t, info.Namespace, name)
+		assert.Equal(t, []string{"team-a", "team-b"}, info.Namespaces, name)
+		assert.True(t, info.Ready, name)
+	}
+}
@@ -16,6 +16,8 @@ import (
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/search"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
 )
 
 // SkillHandler handles skill catalog operations
@@ -25,6 +27,14 @@ type SkillHandler struct {
 	logger zerolog.Logger
 }
 
+// listFromCacheOrClient lists resources from cache if available, otherwise from client
+func (h *SkillHandler) listFromCacheOrClient(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if h.cache != nil {
+		return h.cache.List(ctx, list, opts...)
+	}
+	return h.client.List(ctx, list, opts...)
+}
+
 // NewSkillHandler creates a new skill handler
 func NewSkillHandler(c client.Client, cache cache.Cache, logger zerolog.Logger) *SkillHandler {
 	return &SkillHandler{
@@ -78,6 +88,7 @@ type SkillMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty"`
 	UsedBy            []SkillUsageRefJSON    `json:"usedBy,omitempty"`
 	Publisher         *PublisherInfoJSON     `json:"publisher,omitempty"`
+	Featured          bool                   `json:"featured,omitempty"`
 }
 
 type SkillResponse struct {
@@ -92,11 +103,13 @@ type SkillListResponse struct {
 
 // Input types
 type ListSkillsInput struct {
-	Cursor   string `query:"cursor" json:"cursor,omitempty"`
-	Limit    int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
-	Search   string `query:"search" json:"search,omitempty"`
-	Category string `query:"category" json:"category,omitempty"`
-	Version  string `query:"version" json:"version,omitempty"`
+	Cursor       string `query:"cursor" json:"cursor,omitempty"`
+	Limit        int    `query:"limit" json:"limit,omitempty" default:"30" minimum:"1" maximum:"100"`
+	Search       string `query:"search" json:"search,omitempty" doc:"Match name, title and description, ignoring case; exact and prefix name matches rank first"`
+	SearchFields string `query:"searchFields" json:"searchFields,omitempty" doc:"Comma-separated fields to search: name, title, description. Defaults to all."`
+	Category     string `query:"category" json:"category,omitempty"`
+	Version      string `query:"version" json:"version,omitempty"`
+	Featured     bool   `query:"featured" json:"featured,omitempty" doc:"List only entries featured by the registry curators"`
 }
 
 type SkillDetailInput struct {
@@ -190,26 +203,28 @@ func (h *SkillHandler) RegisterRoutes(api huma.API, pathPrefix string, isAdmin b
 }
 
 func (h *SkillHandler) listSkills(ctx context.Context, input *ListSkillsInput, isAdmin bool) (*Response[SkillListResponse], error) {
+	scope, err := search.ParseScope(input.SearchFields)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid searchFields", err)
+	}
+
 	var skillList agentregistryv1alpha1.SkillCatalogList
 
 	listOpts := []client.ListOption{}
 
+	// Filter by latest version if requested
 	if input.Version == "latest" {
 		listOpts = append(listOpts, client.MatchingFields{
 			controller.IndexSkillIsLatest: "true",
 		})
 	}
 
-	if err := h.cache.List(ctx, &skillList, listOpts...); err != nil {
+	if err := h.listFromCacheOrClient(ctx, &skillList, listOpts...); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to list skills", err)
 	}
 
 	skills := make([]SkillResponse, 0, len(skillList.Items))
-	for _, s := range skillList.Items {
-		if input.Search != "" && !strings.Contains(strings.ToLower(s.Spec.Name), strings.ToLower(input.Search)) {
-			continue
-		}
-
+	for _, s := range search.RankWithin(skillList.Items, input.Search, scope, search.SkillFields) {
 		if input.Category != "" && s.Spec.Category != input.Category {
 			continue
 		}
@@ -217,6 +232,9 @@ func (h *SkillHandler) listSkills(ctx context.Context, input *ListSkillsInput, i
 		if input.Version != "" && input.Version != "latest" && s.Spec.Version != input.Version {
 			continue
 		}
+		if input.Featured && !IsFeatured(&s) {
+			continue
+		}
 
 		skills = append(skills, h.convertToSkillResponse(&s))
 	}
@@ -242,7 +260,7 @@ func (h *SkillHandler) listSkills(ctx context.Context, input *ListSkillsInput, i
 func (h *SkillHandler) getSkill(ctx context.Context, input *SkillDetailInput, isAdmin bool) (*Response[SkillResponse], error) {
 	skillName, err := url.PathUnescape(input.SkillName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		return nil, invalidName("Invalid skill name encoding", err)
 	}
 
 	var skillList agentregistryv1alpha1.SkillCatalogList
@@ -257,23 +275,34 @@ func (h *SkillHandler) getSkill(ctx context.Context, input *SkillDetailInput, is
 		return nil, huma.Error500InternalServerError("Failed to get skill", err)
 	}
 
+	// Fall back to computing latest on the fly when the IsLatest flag has not
+	// been set yet.
 	if len(skillList.Items) == 0 {
-		return nil, huma.Error404NotFound("Skill not found")
+		if err := h.cache.List(ctx, &skillList, client.MatchingFields{
+			controller.IndexSkillName: skillName,
+		}); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get skill", err)
+		}
+	}
+
+	latest := semver.LatestIndex(skillList.Items, func(s agentregistryv1alpha1.SkillCatalog) string { return s.Spec.Version })
+	if latest < 0 {
+		return nil, catalogNotFound("Skill not found")
 	}
 
 	return &Response[SkillResponse]{
-		Body: h.convertToSkillResponse(&skillList.Items[0]),
+		Body: h.convertToSkillResponse(&skillList.Items[latest]),
 	}, nil
 }
 
 func (h *SkillHandler) getSkillVersion(ctx context.Context, input *SkillVersionDetailInput, isAdmin bool) (*Response[SkillResponse], error) {
 	skillName, err := url.PathUnescape(input.SkillName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		return nil, invalidName("Invalid skill name encoding", err)
 	}
 	version, err := url.PathUnescape(input.Version)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		return nil, invalidVersion("Invalid version encoding", err)
 	}
 
 	var skillList agentregistryv1alpha1.SkillCatalogList
@@ -291,7 +320,7 @@ func (h *SkillHandler) getSkillVersion(ctx context.Context, input *SkillVersionD
 		}
 	}
 
-	return nil, huma.Error404NotFound("Skill version not found")
+	return nil, catalogNotFound("Skill version not found")
 }
 
 func (h *SkillHandler) createSkill(ctx context.Context, input *CreateSkillInput) (*Response[SkillResponse], error) {
@@ -342,6 +371,10 @@ func (h *SkillHandler) createSkill(ctx context.Context, input *CreateSkillInput)
 		})
 	}
 
+	if err := rejectDuplicateEntry(ctx, h.client, &agentregistryv1alpha1.SkillCatalogList{}, skill, skill.Spec.Name, skill.Spec.Version); err != nil {
+		return nil, err
+	}
+
 	if err := h.client.Create(ctx, skill); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to create skill", err)
 	}
@@ -354,7 +387,7 @@ func (h *SkillHandler) createSkill(ctx context.Context, input *CreateSkillInput)
 func (h *SkillHandler) listSkillVersions(ctx context.Context, input *SkillDetailInput) (*Response[SkillListResponse], error) {
 	skillName, err := url.PathUnescape(input.SkillName)
 	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid skill name encoding", err)
+		return nil, invalidName("Invalid skill name encoding", err)
 	}
 
 	var skillList agentregistryv1alpha1.SkillCatalogList
@@ -363,6 +396,7 @@ func (h *SkillHandler) listSkillVersions(ctx context.Context, input *SkillDetail
 	}); err != nil {
 		return nil, huma.Error500InternalServerError("Failed to list skill versions", err)
 	}
+	semver.SortDescendingFunc(skillList.Items, func(s agentregistryv1alpha1.SkillCatalog) string { return s.Spec.Version })
 
 	skills := make([]SkillResponse, 0, len(skillList.Items))
 	for _, s := range skillList.Items {
@@ -458,6 +492,7 @@ func (h *SkillHandler) convertToSkillResponse(s *agentregistryv1alpha1.SkillCata
 
 	// Map governance/publisher verification from status
 	resp.Meta.Publisher = convertPublisherVerification(s.Status.Publisher)
+	resp.Meta.Featured = IsFeatured(s)
 
 	return resp
 }
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
 )
 
 func setupSkillTestClient(t *testing.T) client.Client {
@@ -215,3 +216,50 @@ func TestSkillHandler_ConvertToSkillResponse_WithUsedBy(t *testing.T) {
 	assert.Equal(t, "my-agent", resp.Meta.UsedBy[0].Name)
 	assert.Equal(t, "Agent", resp.Meta.UsedBy[0].Kind)
 }
+
+// ---------------------------------------------------------------------------
+// listSkills
+// ---------------------------------------------------------------------------
+
+func TestSkillHandler_ListSkills_Version(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	skill := func(name, version string, isLatest bool) client.Object {
+		return &agentregistryv1alpha1.SkillCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, version)},
+			Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: name, Version: version},
+			Status:     agentregistryv1alpha1.SkillCatalogStatus{IsLatest: isLatest},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, controller.IndexSkillIsLatest, func(obj client.Object) []string {
+			if obj.(*agentregistryv1alpha1.SkillCatalog).Status.IsLatest {
+				return []string{"true"}
+			}
+			return []string{"false"}
+		}).
+		WithObjects(
+			skill("terraform", "1.0.0", false),
+			skill("terraform", "2.0.0", true),
+			skill("helm", "1.0.0", true),
+		).
+		Build()
+	handler := NewSkillHandler(c, nil, zerolog.Nop())
+	ctx := context.Background()
+
+	versions := func(input *ListSkillsInput) []string {
+		t.Helper()
+		resp, err := handler.listSkills(ctx, input, false)
+		require.NoError(t, err)
+		var out []string
+		for _, s := range resp.Body.Skills {
+			out = append(out, s.Skill.Name+"@"+s.Skill.Version)
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{"terraform@2.0.0", "helm@1.0.0"}, versions(&ListSkillsInput{Version: "latest"}))
+	assert.ElementsMatch(t, []string{"terraform@1.0.0", "helm@1.0.0"}, versions(&ListSkillsInput{Version: "1.0.0"}))
+	assert.Len(t, versions(&ListSkillsInput{}), 3)
+}
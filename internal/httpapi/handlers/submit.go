@@ -3,8 +3,8 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -13,6 +13,8 @@ import (
 	"github.com/rs/zerolog"
 	"gopkg.in/yaml.v3"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/internal/enrichment"
 )
 
 // SubmitHandler handles resource submission from external repositories.
@@ -23,6 +25,8 @@ import (
 type SubmitHandler struct {
 	client client.Client
 	logger zerolog.Logger
+	// fetcher fetches manifests; nil uses the shared enrichment client
+	fetcher *enrichment.Client
 }
 
 // NewSubmitHandler creates a new submit handler
@@ -232,33 +236,26 @@ func (h *SubmitHandler) fetchManifest(ctx context.Context, repo *repoInfo) (*Age
 
 	h.logger.Debug().Str("rawURL", rawURL).Msg("fetching manifest")
 
-	// Create HTTP request with timeout
+	// Fetched through the enrichment client so repeated submissions of a
+	// repository are rate limited and served from its cache
+	fetcher := h.fetcher
+	if fetcher == nil {
+		fetcher = enrichment.Shared()
+	}
 	httpCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(httpCtx, "GET", rawURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
+	body, err := fetcher.Get(httpCtx, rawURL)
 	if err != nil {
+		var statusErr *enrichment.StatusError
+		if errors.As(err, &statusErr) {
+			if statusErr.StatusCode == http.StatusNotFound {
+				return nil, fmt.Errorf(".agentregistry.yaml not found in repository root (branch: %s)", repo.Branch)
+			}
+			return nil, fmt.Errorf("failed to fetch manifest: HTTP %d", statusErr.StatusCode)
+		}
 		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf(".agentregistry.yaml not found in repository root (branch: %s)", repo.Branch)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch manifest: HTTP %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read manifest: %w", err)
-	}
 
 	var manifest AgentRegistryManifest
 	if err := yaml.Unmarshal(body, &manifest); err != nil {
@@ -1,10 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/enrichment"
 )
 
 func TestParseRepositoryURL(t *testing.T) {
@@ -126,3 +135,46 @@ func TestValidateManifest(t *testing.T) {
 		})
 	}
 }
+
+// rewriteTransport sends every request to target, keeping the path
+type rewriteTransport struct{ target *url.URL }
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSubmitHandler_FetchManifest(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/org/server/main/.agentregistry.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("kind: mcp-server\nname: server\nversion: 1.0.0\n"))
+	}))
+	defer srv.Close()
+	target, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	h := NewSubmitHandler(nil, zerolog.Nop())
+	h.fetcher = enrichment.NewClient(enrichment.Options{
+		HTTPClient: &http.Client{Transport: rewriteTransport{target: target}},
+		CacheTTL:   time.Minute,
+	})
+
+	repo := &repoInfo{Host: "github.com", Owner: "org", Repo: "server", Branch: "main"}
+	manifest, err := h.fetchManifest(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, "server", manifest.Name)
+
+	// Resubmitting the repository is served from the enrichment cache
+	_, err = h.fetchManifest(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = h.fetchManifest(context.Background(), &repoInfo{Host: "github.com", Owner: "org", Repo: "missing", Branch: "main"})
+	assert.ErrorContains(t, err, ".agentregistry.yaml not found")
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// PubliclyVisible reports whether obj may be served to non-admin callers. With
+// config.StrictPublicVisibility every catalog entry that is not published and
+// active is hidden; otherwise everything is visible.
+func PubliclyVisible(obj client.Object) bool {
+	return !config.StrictPublicVisibility() || controller.CatalogEntryActive(obj)
+}
+
+// VisibleTo reports whether obj may be served to the caller recorded in ctx:
+// it must be PubliclyVisible and, when it has VisibilityGroups, the caller
+// must be an admin or in one of them
+func VisibleTo(ctx context.Context, obj client.Object) bool {
+	return PubliclyVisible(obj) && CallerFromContext(ctx).CanSee(visibilityGroups(obj))
+}
+
+// filterVisible drops the catalog entries of list that are not VisibleTo the
+// caller recorded in ctx
+func filterVisible(ctx context.Context, list client.ObjectList) {
+	switch l := list.(type) {
+	case *agentregistryv1alpha1.MCPServerCatalogList:
+		l.Items = slices.DeleteFunc(l.Items, func(s agentregistryv1alpha1.MCPServerCatalog) bool { return !VisibleTo(ctx, &s) })
+	case *agentregistryv1alpha1.AgentCatalogList:
+		l.Items = slices.DeleteFunc(l.Items, func(a agentregistryv1alpha1.AgentCatalog) bool { return !VisibleTo(ctx, &a) })
+	case *agentregistryv1alpha1.SkillCatalogList:
+		l.Items = slices.DeleteFunc(l.Items, func(s agentregistryv1alpha1.SkillCatalog) bool { return !VisibleTo(ctx, &s) })
+	case *agentregistryv1alpha1.ModelCatalogList:
+		l.Items = slices.DeleteFunc(l.Items, func(m agentregistryv1alpha1.ModelCatalog) bool { return !VisibleTo(ctx, &m) })
+	}
+}
+
+// getVisible reads key into obj with get and reports entries hidden from the
+// caller as not found, so callers cannot tell them from missing ones
+func getVisible(ctx context.Context, get func(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if !VisibleTo(ctx, obj) {
+		return apierrors.NewNotFound(schema.GroupResource{Group: agentregistryv1alpha1.GroupVersion.Group}, key.Name)
+	}
+	return nil
+}
+
+// publicCache is a cache whose reads only return the catalog entries visible
+// to the caller
+type publicCache struct {
+	cache.Cache
+}
+
+func (c publicCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return getVisible(ctx, c.Cache.Get, key, obj, opts...)
+}
+
+func (c publicCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Cache.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	filterVisible(ctx, list)
+	return nil
+}
+
+// publicClient is a client whose reads only return the catalog entries
+// visible to the caller. Writes are passed through unchanged.
+type publicClient struct {
+	client.Client
+}
+
+func (c publicClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return getVisible(ctx, c.Client.Get, key, obj, opts...)
+}
+
+func (c publicClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	filterVisible(ctx, list)
+	return nil
+}
+
+// PublicCache wraps c so that its reads only return the catalog entries
+// VisibleTo the caller recorded in the context. It returns nil for a nil c.
+func PublicCache(c cache.Cache) cache.Cache {
+	if c == nil {
+		return nil
+	}
+	return publicCache{Cache: c}
+}
+
+// PublicClient wraps c so that its reads only return the catalog entries
+// VisibleTo the caller recorded in the context
+func PublicClient(c client.Client) client.Client {
+	if c == nil {
+		return nil
+	}
+	return publicClient{Client: c}
+}
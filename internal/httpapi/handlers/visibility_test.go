@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// readerCache serves cache reads from a client
+type readerCache struct {
+	cache.Cache
+	reader client.Reader
+}
+
+func (c *readerCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *readerCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+// setupVisibilityTestClient returns a client holding, for servers, agents,
+// skills and models alike, a published "visible" entry at 1.0.0 and hidden
+// entries at 2.0.0 (never published), 3.0.0 (deprecated) and 4.0.0
+// (soft-deleted, and flagged latest)
+func setupVisibilityTestClient(t *testing.T) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	now := metav1.Now()
+	type entry struct {
+		version     string
+		published   bool
+		annotations map[string]string
+	}
+	entries := []entry{
+		{version: "1.0.0", published: true},
+		{version: "2.0.0"},
+		{version: "3.0.0", published: true, annotations: map[string]string{agentregistryv1alpha1.AnnotationDeprecated: "true"}},
+		{version: "4.0.0", published: true, annotations: map[string]string{agentregistryv1alpha1.AnnotationSoftDeleted: "true"}},
+	}
+	var objs []client.Object
+	for _, e := range entries {
+		meta := metav1.ObjectMeta{Name: GenerateCRName("visible", e.version), Annotations: e.annotations}
+		var publishedAt *metav1.Time
+		if e.published {
+			publishedAt = &now
+		}
+		isLatest := e.version == "4.0.0"
+		objs = append(objs,
+			&agentregistryv1alpha1.MCPServerCatalog{
+				ObjectMeta: meta,
+				Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "visible", Version: e.version},
+				Status:     agentregistryv1alpha1.MCPServerCatalogStatus{Published: e.published, PublishedAt: publishedAt, IsLatest: isLatest},
+			},
+			&agentregistryv1alpha1.AgentCatalog{
+				ObjectMeta: meta,
+				Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "visible", Version: e.version},
+				Status:     agentregistryv1alpha1.AgentCatalogStatus{Published: e.published, PublishedAt: publishedAt, IsLatest: isLatest},
+			},
+			&agentregistryv1alpha1.SkillCatalog{
+				ObjectMeta: meta,
+				Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: "visible", Version: e.version},
+				Status:     agentregistryv1alpha1.SkillCatalogStatus{Published: e.published, PublishedAt: publishedAt, IsLatest: isLatest},
+			},
+			&agentregistryv1alpha1.ModelCatalog{
+				ObjectMeta: meta,
+				Spec:       agentregistryv1alpha1.ModelCatalogSpec{Name: "visible-" + e.version, Provider: "OpenAI", Model: "gpt-4o"},
+				Status:     agentregistryv1alpha1.ModelCatalogStatus{Published: e.published, PublishedAt: publishedAt},
+			},
+		)
+	}
+
+	isLatest := func(latest bool) []string {
+		if latest {
+			return []string{"true"}
+		}
+		return []string{"false"}
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerIsLatest, func(obj client.Object) []string {
+			return isLatest(obj.(*agentregistryv1alpha1.MCPServerCatalog).Status.IsLatest)
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.AgentCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentIsLatest, func(obj client.Object) []string {
+			return isLatest(obj.(*agentregistryv1alpha1.AgentCatalog).Status.IsLatest)
+		}).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, controller.IndexSkillName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.SkillCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, controller.IndexSkillIsLatest, func(obj client.Object) []string {
+			return isLatest(obj.(*agentregistryv1alpha1.SkillCatalog).Status.IsLatest)
+		}).
+		WithIndex(&agentregistryv1alpha1.ModelCatalog{}, controller.IndexModelName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.ModelCatalog).Spec.Name}
+		}).
+		Build()
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+	assert.Equal(t, http.StatusNotFound, apiErr.GetStatus())
+}
+
+func TestPublicVisibility_StrictMode(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_STRICT_PUBLIC_VISIBILITY", "true")
+	c := setupVisibilityTestClient(t)
+	publicClient, publicCache := PublicClient(c), PublicCache(&readerCache{reader: c})
+	ctx := context.Background()
+
+	t.Run("servers", func(t *testing.T) {
+		h := NewServerHandler(publicClient, publicCache, zerolog.Nop())
+
+		list, err := h.listServers(ctx, &ListServersInput{Limit: 30}, false)
+		require.NoError(t, err)
+		require.Len(t, list.Body.Servers, 1)
+		assert.Equal(t, "1.0.0", list.Body.Servers[0].Server.Version)
+
+		latest, err := h.getServer(ctx, &ServerDetailInput{ServerName: "visible"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Body.Server.Version, "hidden latest versions are skipped")
+
+		for _, version := range []string{"2.0.0", "3.0.0", "4.0.0"} {
+			_, err := h.getServerVersion(ctx, &ServerVersionDetailInput{ServerName: "visible", Version: version}, false)
+			assertNotFound(t, err)
+		}
+
+		versions, err := h.listServerVersions(ctx, &ServerDetailInput{ServerName: "visible"})
+		require.NoError(t, err)
+		assert.Len(t, versions.Body.Servers, 1)
+	})
+
+	t.Run("agents", func(t *testing.T) {
+		h := NewAgentHandler(publicClient, publicCache, zerolog.Nop())
+
+		list, err := h.listAgents(ctx, &ListAgentsInput{Limit: 30}, false)
+		require.NoError(t, err)
+		require.Len(t, list.Body.Agents, 1)
+		assert.Equal(t, "1.0.0", list.Body.Agents[0].Agent.Version)
+
+		latest, err := h.getAgent(ctx, &AgentDetailInput{AgentName: "visible"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Body.Agent.Version)
+
+		_, err = h.getAgentVersion(ctx, &AgentVersionDetailInput{AgentName: "visible", Version: "2.0.0"}, false)
+		assertNotFound(t, err)
+	})
+
+	t.Run("skills", func(t *testing.T) {
+		h := NewSkillHandler(publicClient, publicCache, zerolog.Nop())
+
+		list, err := h.listSkills(ctx, &ListSkillsInput{Limit: 30}, false)
+		require.NoError(t, err)
+		require.Len(t, list.Body.Skills, 1)
+
+		latest, err := h.getSkill(ctx, &SkillDetailInput{SkillName: "visible"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Body.Skill.Version)
+
+		_, err = h.getSkillVersion(ctx, &SkillVersionDetailInput{SkillName: "visible", Version: "3.0.0"}, false)
+		assertNotFound(t, err)
+	})
+
+	t.Run("models", func(t *testing.T) {
+		h := NewModelHandler(publicClient, publicCache, zerolog.Nop())
+
+		list, err := h.listModels(ctx, &ListModelsInput{Limit: 30}, false)
+		require.NoError(t, err)
+		require.Len(t, list.Body.Models, 1)
+		assert.Equal(t, "visible-1.0.0", list.Body.Models[0].Model.Name)
+
+		_, err = h.getModel(ctx, &ModelDetailInput{ModelName: "visible-4.0.0"}, false)
+		assertNotFound(t, err)
+	})
+
+	t.Run("get by object name", func(t *testing.T) {
+		var server agentregistryv1alpha1.MCPServerCatalog
+		require.NoError(t, publicCache.Get(ctx, client.ObjectKey{Name: GenerateCRName("visible", "1.0.0")}, &server))
+		assert.NoError(t, publicClient.Get(ctx, client.ObjectKey{Name: GenerateCRName("visible", "1.0.0")}, &server))
+
+		err := publicCache.Get(ctx, client.ObjectKey{Name: GenerateCRName("visible", "2.0.0")}, &server)
+		assert.True(t, apierrors.IsNotFound(err), "unpublished entries read as not found, got %v", err)
+	})
+}
+
+func TestPublicVisibility_Default(t *testing.T) {
+	c := setupVisibilityTestClient(t)
+	ctx := context.Background()
+	h := NewServerHandler(PublicClient(c), PublicCache(&readerCache{reader: c}), zerolog.Nop())
+
+	list, err := h.listServers(ctx, &ListServersInput{Limit: 30}, false)
+	require.NoError(t, err)
+	assert.Len(t, list.Body.Servers, 4)
+
+	_, err = h.getServerVersion(ctx, &ServerVersionDetailInput{ServerName: "visible", Version: "2.0.0"}, false)
+	assert.NoError(t, err)
+}
+
+func TestPublicVisibility_Groups(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	server := func(name string, groups ...string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: GenerateCRName(name, "1.0.0")},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0", VisibilityGroups: groups},
+			Status:     agentregistryv1alpha1.MCPServerCatalogStatus{IsLatest: true},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(server("everyone"), server("platform", "platform-team"), server("shared", "platform-team", "data-team")).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerIsLatest, func(obj client.Object) []string {
+			return []string{"true"}
+		}).
+		Build()
+	h := NewServerHandler(PublicClient(c), PublicCache(&readerCache{reader: c}), zerolog.Nop())
+
+	for name, tc := range map[string]struct {
+		caller  Caller
+		visible []string
+	}{
+		"anonymous":     {caller: Caller{}, visible: []string{"everyone"}},
+		"other group":   {caller: Caller{Groups: []string{"sales"}}, visible: []string{"everyone"}},
+		"data team":     {caller: Caller{Groups: []string{"sales", "data-team"}}, visible: []string{"everyone", "shared"}},
+		"platform team": {caller: Caller{Groups: []string{"platform-team"}}, visible: []string{"everyone", "platform", "shared"}},
+		"admin":         {caller: Caller{Admin: true}, visible: []string{"everyone", "platform", "shared"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := WithCaller(context.Background(), tc.caller)
+
+			list, err := h.listServers(ctx, &ListServersInput{Limit: 30}, false)
+			require.NoError(t, err)
+			var names []string
+			for _, s := range list.Body.Servers {
+				names = append(names, s.Server.Name)
+			}
+			assert.ElementsMatch(t, tc.visible, names)
+
+			for _, name := range []string{"everyone", "platform", "shared"} {
+				_, err := h.getServer(ctx, &ServerDetailInput{ServerName: name}, false)
+				if slices.Contains(tc.visible, name) {
+					assert.NoError(t, err, name)
+				} else {
+					assertNotFound(t, err)
+				}
+			}
+		})
+	}
+}
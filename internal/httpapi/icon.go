@@ -0,0 +1,209 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
+	"github.com/agentregistry-dev/agentregistry/internal/safehttp"
+	"github.com/agentregistry-dev/agentregistry/internal/semver"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
+)
+
+const (
+	// maxIconBytes caps the size of a proxied icon
+	maxIconBytes = 256 << 10 // 256 KiB
+	// iconCacheTTL is how long a fetched icon is served from memory, and
+	// how long clients may cache it
+	iconCacheTTL = time.Hour
+	// iconCacheMaxEntries bounds the number of icons kept in memory
+	iconCacheMaxEntries = 512
+)
+
+// iconContentTypes are the image types served as icons
+var iconContentTypes = map[string]bool{
+	"image/png":                true,
+	"image/jpeg":               true,
+	"image/gif":                true,
+	"image/webp":               true,
+	"image/svg+xml":            true,
+	"image/x-icon":             true,
+	"image/vnd.microsoft.icon": true,
+}
+
+type ServerIconInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name"`
+	Version    string `query:"version" doc:"Server version; defaults to the latest"`
+}
+
+type ServerIconResponse struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	// Icons are served from the API origin, so SVG scripts must not run
+	ContentSecurityPolicy string `header:"Content-Security-Policy"`
+	ContentTypeOptions    string `header:"X-Content-Type-Options"`
+	Body                  []byte
+}
+
+// registerIconRoutes registers the public icon endpoint
+func (s *Server) registerIconRoutes() {
+	huma.Register(s.api, huma.Operation{
+		OperationID: "get-server-icon",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{serverName}/icon",
+		Summary:     "Get the icon of a server",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerIconInput) (*ServerIconResponse, error) {
+		return s.getServerIcon(ctx, input)
+	})
+}
+
+func (s *Server) getServerIcon(ctx context.Context, input *ServerIconInput) (*ServerIconResponse, error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	if err := handlers.PublicCache(s.cache).List(ctx, &list, client.MatchingFields{controller.IndexMCPServerName: serverName}); err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+	index := -1
+	if input.Version != "" {
+		for i := range list.Items {
+			if list.Items[i].Spec.Version == input.Version {
+				index = i
+				break
+			}
+		}
+	} else {
+		index = semver.LatestIndex(list.Items, func(s agentregistryv1alpha1.MCPServerCatalog) string { return s.Spec.Version })
+	}
+	if index < 0 {
+		return nil, huma.Error404NotFound("Server not found")
+	}
+	iconURL := list.Items[index].Spec.IconURL
+	if iconURL == "" {
+		return nil, huma.Error404NotFound("Server has no icon")
+	}
+
+	icon, err := s.fetchIcon(ctx, iconURL)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerIconResponse{
+		ContentType:           icon.contentType,
+		CacheControl:          fmt.Sprintf("public, max-age=%d", int(iconCacheTTL.Seconds())),
+		ContentSecurityPolicy: "default-src 'none'; style-src 'unsafe-inline'; sandbox",
+		ContentTypeOptions:    "nosniff",
+		Body:                  icon.data,
+	}, nil
+}
+
+// fetchIcon returns the icon at iconURL from the cache, fetching it with the
+// same SSRF protections as import sources when it is not cached
+func (s *Server) fetchIcon(ctx context.Context, iconURL string) (cachedIcon, error) {
+	if icon, ok := s.icons.get(iconURL); ok {
+		return icon, nil
+	}
+
+	if err := validation.ValidateIconURL(iconURL); err != nil {
+		return cachedIcon{}, huma.Error502BadGateway("Invalid icon URL")
+	}
+	httpClient := s.iconClient
+	if httpClient == nil {
+		httpClient = safehttp.NewClient(10*time.Second, s.importTLS)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, iconURL, nil)
+	if err != nil {
+		return cachedIcon{}, huma.Error502BadGateway("Invalid icon URL")
+	}
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// Like import, do not reveal why an internal target was unreachable
+		s.logger.Warn().Err(err).Str("icon", iconURL).Msg("icon fetch failed")
+		return cachedIcon{}, huma.Error502BadGateway("Failed to fetch icon")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return cachedIcon{}, huma.Error502BadGateway(fmt.Sprintf("Icon source returned status %d", resp.StatusCode))
+	}
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !iconContentTypes[contentType] {
+		return cachedIcon{}, huma.Error502BadGateway("Icon source did not return an image")
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIconBytes+1))
+	if err != nil {
+		return cachedIcon{}, huma.Error502BadGateway("Failed to read icon")
+	}
+	if len(data) > maxIconBytes {
+		return cachedIcon{}, huma.Error502BadGateway(fmt.Sprintf("Icon exceeds %d bytes", maxIconBytes))
+	}
+
+	icon := cachedIcon{contentType: contentType, data: data}
+	s.icons.put(iconURL, icon)
+	return icon, nil
+}
+
+type cachedIcon struct {
+	contentType string
+	data        []byte
+	expires     time.Time
+}
+
+// iconCache keeps fetched icons in memory for iconCacheTTL
+type iconCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedIcon
+	now     func() time.Time
+}
+
+func newIconCache() *iconCache {
+	return &iconCache{entries: make(map[string]cachedIcon), now: time.Now}
+}
+
+func (c *iconCache) get(key string) (cachedIcon, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	icon, ok := c.entries[key]
+	if !ok || c.now().After(icon.expires) {
+		return cachedIcon{}, false
+	}
+	return icon, true
+}
+
+func (c *iconCache) put(key string, icon cachedIcon) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= iconCacheMaxEntries {
+		// Drop expired icons first, then the one closest to expiry
+		oldest := ""
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			} else if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+				oldest = k
+			}
+		}
+		if len(c.entries) >= iconCacheMaxEntries && oldest != "" {
+			delete(c.entries, oldest)
+		}
+	}
+	icon.expires = now.Add(iconCacheTTL)
+	c.entries[key] = icon
+}
@@ -0,0 +1,144 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func setupIconTestServer(t *testing.T, servers ...client.Object) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithObjects(servers...).
+		Build()
+	return NewServer(c, &mockCache{client: c}, zerolog.Nop())
+}
+
+func iconServer(name, version, iconURL string) *agentregistryv1alpha1.MCPServerCatalog {
+	return &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-" + version, Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "io.example/" + name,
+			Version: version,
+			IconURL: iconURL,
+		},
+	}
+}
+
+func TestGetServerIcon_ProxiesAndCaches(t *testing.T) {
+	var fetches atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		switch r.URL.Path {
+		case "/search.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png-bytes"))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+		case "/huge.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(make([]byte, maxIconBytes+1))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	server := setupIconTestServer(t,
+		iconServer("search", "1.0.0", ts.URL+"/old.png"),
+		iconServer("search", "2.0.0", ts.URL+"/search.png"),
+		iconServer("html", "1.0.0", ts.URL+"/page.html"),
+		iconServer("huge", "1.0.0", ts.URL+"/huge.png"),
+		iconServer("plain", "1.0.0", ""),
+	)
+	server.iconClient = ts.Client()
+	ctx := context.Background()
+
+	// The latest version's icon is served and cached
+	for i := 0; i < 3; i++ {
+		resp, err := server.getServerIcon(ctx, &ServerIconInput{ServerName: "io.example%2Fsearch"})
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", resp.ContentType)
+		assert.Equal(t, []byte("png-bytes"), resp.Body)
+		assert.Equal(t, "public, max-age=3600", resp.CacheControl)
+		assert.Equal(t, "nosniff", resp.ContentTypeOptions)
+	}
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// Once the cached icon expires it is fetched again
+	server.icons.now = func() time.Time { return time.Now().Add(2 * iconCacheTTL) }
+	_, err := server.getServerIcon(ctx, &ServerIconInput{ServerName: "io.example%2Fsearch"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+
+	statusOf := func(input *ServerIconInput) int {
+		_, err := server.getServerIcon(ctx, input)
+		var statusErr huma.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		return statusErr.GetStatus()
+	}
+	assert.Equal(t, http.StatusBadGateway, statusOf(&ServerIconInput{ServerName: "io.example%2Fsearch", Version: "1.0.0"}))
+	assert.Equal(t, http.StatusBadGateway, statusOf(&ServerIconInput{ServerName: "io.example%2Fhtml"}), "non-image content")
+	assert.Equal(t, http.StatusBadGateway, statusOf(&ServerIconInput{ServerName: "io.example%2Fhuge"}), "over the size cap")
+	assert.Equal(t, http.StatusNotFound, statusOf(&ServerIconInput{ServerName: "io.example%2Fplain"}))
+	assert.Equal(t, http.StatusNotFound, statusOf(&ServerIconInput{ServerName: "io.example%2Fmissing"}))
+}
+
+func TestGetServerIcon_BlocksPrivateTargets(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png-bytes"))
+	}))
+	defer ts.Close()
+
+	// Without an injected client the import SSRF protections apply, so the
+	// loopback test server cannot be reached
+	server := setupIconTestServer(t, iconServer("local", "1.0.0", ts.URL+"/icon.png"))
+	_, err := server.getServerIcon(context.Background(), &ServerIconInput{ServerName: "io.example%2Flocal"})
+	var statusErr huma.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadGateway, statusErr.GetStatus())
+}
+
+func TestIconCache_EvictsWhenFull(t *testing.T) {
+	c := newIconCache()
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	for i := 0; i < iconCacheMaxEntries; i++ {
+		now = now.Add(time.Second)
+		c.put(fmt.Sprintf("icon-%d", i), cachedIcon{contentType: "image/png"})
+	}
+	first := "icon-0"
+	_, ok := c.get(first)
+	require.True(t, ok)
+
+	c.put("new", cachedIcon{contentType: "image/png"})
+	assert.Len(t, c.entries, iconCacheMaxEntries)
+	_, ok = c.get(first)
+	assert.False(t, ok, "the icon closest to expiry is evicted")
+	_, ok = c.get("new")
+	assert.True(t, ok)
+}
@@ -0,0 +1,174 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/conversion"
+	"github.com/agentregistry-dev/agentregistry/internal/safehttp"
+)
+
+const (
+	// maxImportBytes caps the size of each fetched page to avoid unbounded
+	// memory use from a hostile or oversized source
+	maxImportBytes = 10 << 20 // 10 MiB
+
+	// maxImportPages bounds how many pages of a paginated source are followed
+	maxImportPages = 50
+)
+
+// importPage is one page of an import source. Sources are either a bare array
+// of servers or an object with a servers field. The official MCP registry
+// additionally wraps each server as {"server": {...}, "_meta": {...}} and
+// paginates with metadata.nextCursor.
+type importPage struct {
+	Servers  []importServerJSON `json:"servers"`
+	Metadata struct {
+		NextCursor string `json:"nextCursor,omitempty"`
+	} `json:"metadata"`
+}
+
+// importServerJSON is a server entry that is either flat or wrapped in a
+// server field
+type importServerJSON struct {
+	conversion.ExternalServerJSON
+	Server *conversion.ExternalServerJSON `json:"server,omitempty"`
+}
+
+func (e importServerJSON) unwrap() conversion.ExternalServerJSON {
+	if e.Server != nil {
+		return *e.Server
+	}
+	return e.ExternalServerJSON
+}
+
+// parseImportPage detects the format of a page and returns its servers and
+// the cursor of the next page, if any
+func parseImportPage(body []byte) ([]conversion.ExternalServerJSON, string, error) {
+	var page importPage
+	// Try parsing as array first
+	if err := json.Unmarshal(body, &page.Servers); err != nil {
+		// Try parsing as object with servers field
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, "", err
+		}
+	}
+
+	servers := make([]conversion.ExternalServerJSON, 0, len(page.Servers))
+	for _, s := range page.Servers {
+		servers = append(servers, s.unwrap())
+	}
+	return servers, page.Metadata.NextCursor, nil
+}
+
+// fetchImportSource fetches every page of source, following nextCursor until
+// it is exhausted or maxImportPages is reached. A failure on the first page
+// fails the import; later failures stop pagination and are returned as
+// messages so the servers already fetched can still be imported. A source
+// listing more than the configured maximum number of servers fails the import.
+func (s *Server) fetchImportSource(ctx context.Context, source string) ([]conversion.ExternalServerJSON, []string, error) {
+	httpClient := s.importClient
+	if httpClient == nil {
+		httpClient = safehttp.NewClient(30*time.Second, s.importTLS)
+	}
+
+	maxServers := config.GetImportLimits().MaxServers
+	var servers []conversion.ExternalServerJSON
+	var pageErrors []string
+	seen := make(map[string]bool)
+	pageURL := source
+	for page := 1; ; page++ {
+		pageServers, nextCursor, err := s.fetchImportPage(ctx, httpClient, pageURL)
+		if err != nil {
+			if page == 1 {
+				return nil, nil, err
+			}
+			pageErrors = append(pageErrors, fmt.Sprintf("page %d: %v", page, err))
+			break
+		}
+		servers = append(servers, pageServers...)
+		if len(servers) > maxServers {
+			return nil, nil, huma.Error422UnprocessableEntity(fmt.Sprintf(
+				"Source lists more than %d servers; import a smaller source or raise AGENTREGISTRY_IMPORT_MAX_SERVERS", maxServers))
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		if seen[nextCursor] {
+			pageErrors = append(pageErrors, fmt.Sprintf("page %d: source repeated cursor %q", page, nextCursor))
+			break
+		}
+		if page == maxImportPages {
+			pageErrors = append(pageErrors, fmt.Sprintf("stopped after %d pages", maxImportPages))
+			break
+		}
+		seen[nextCursor] = true
+
+		pageURL, err = withCursor(source, nextCursor)
+		if err != nil {
+			pageErrors = append(pageErrors, fmt.Sprintf("page %d: %v", page+1, err))
+			break
+		}
+	}
+	return servers, pageErrors, nil
+}
+
+// fetchImportPage fetches and parses a single page
+func (s *Server) fetchImportPage(ctx context.Context, httpClient *http.Client, pageURL string) ([]conversion.ExternalServerJSON, string, error) {
+	// We intentionally do NOT forward caller-supplied headers
+	// (input.Body.Headers) — they could be used to reach authenticated
+	// internal endpoints or smuggle credentials to arbitrary hosts.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", huma.Error400BadRequest("Invalid source URL", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// Do not surface the underlying dial error verbatim — it can confirm
+		// the existence/reachability of internal hosts (SSRF oracle).
+		s.logger.Warn().Err(err).Str("source", pageURL).Msg("import fetch failed")
+		return nil, "", huma.Error502BadGateway("Failed to fetch from source")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Do not reflect the upstream response body back to the caller.
+		return nil, "", huma.Error502BadGateway(
+			fmt.Sprintf("Source returned status %d", resp.StatusCode),
+		)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImportBytes))
+	if err != nil {
+		return nil, "", huma.Error502BadGateway("Failed to read response body")
+	}
+
+	servers, nextCursor, err := parseImportPage(body)
+	if err != nil {
+		return nil, "", huma.Error400BadRequest("Failed to parse server data", err)
+	}
+	return servers, nextCursor, nil
+}
+
+// withCursor returns source with its cursor query parameter set
+func withCursor(source, cursor string) (string, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("cursor", cursor)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
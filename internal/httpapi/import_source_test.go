@@ -0,0 +1,233 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+func TestParseImportPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantNames  []string
+		wantCursor string
+	}{
+		{"array", `[{"name":"org/a","version":"1.0.0"}]`, []string{"org/a"}, ""},
+		{"servers object", `{"servers":[{"name":"org/a","version":"1.0.0"}]}`, []string{"org/a"}, ""},
+		{
+			"official registry page",
+			`{"servers":[{"server":{"name":"org/a","version":"1.0.0"},"_meta":{}}],"metadata":{"nextCursor":"abc","count":1}}`,
+			[]string{"org/a"},
+			"abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, cursor, err := parseImportPage([]byte(tt.body))
+			require.NoError(t, err)
+			var names []string
+			for _, s := range servers {
+				names = append(names, s.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantCursor, cursor)
+		})
+	}
+
+	_, _, err := parseImportPage([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestImportFromSource_Paginated(t *testing.T) {
+	var failSecondPage bool
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"servers":[{"server":{"name":"org/first","version":"1.0.0"}}],"metadata":{"nextCursor":"page-2"}}`)
+		case "page-2":
+			if failSecondPage {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprint(w, `{"servers":[{"server":{"name":"org/second","version":"2.0.0"}}],"metadata":{}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	server, c := setupTestServer(t)
+	server.importClient = ts.Client()
+	ctx := context.Background()
+
+	resp, err := server.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL + "/v0/servers"}})
+	require.NoError(t, err)
+	assert.True(t, resp.Body.Success)
+	assert.Equal(t, "Imported 2, updated 0, skipped 0 servers", resp.Body.Message)
+
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	require.NoError(t, c.List(ctx, &list))
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.Spec.Name)
+	}
+	assert.ElementsMatch(t, []string{"org/first", "org/second"}, names)
+
+	// A failing later page is reported without discarding earlier pages
+	failSecondPage = true
+	server, _ = setupTestServer(t)
+	server.importClient = ts.Client()
+	resp, err = server.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL + "/v0/servers"}})
+	require.NoError(t, err)
+	assert.False(t, resp.Body.Success)
+	assert.Equal(t, "Imported 1, updated 0, skipped 0 servers, 1 errors", resp.Body.Message)
+	require.Len(t, resp.Body.Errors, 1)
+	assert.Contains(t, resp.Body.Errors[0], "page 2")
+	assert.Contains(t, resp.Body.Errors[0], "status 500")
+}
+
+func TestImportFromSource_RepeatedCursor(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"servers":[{"name":"org/loop-%s","version":"1.0.0"}],"metadata":{"nextCursor":"same"}}`, r.URL.Query().Get("cursor"))
+	}))
+	defer ts.Close()
+
+	server, _ := setupTestServer(t)
+	server.importClient = ts.Client()
+
+	servers, pageErrors, err := server.fetchImportSource(context.Background(), ts.URL)
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+	require.Len(t, pageErrors, 1)
+	assert.Contains(t, pageErrors[0], "repeated cursor")
+}
+
+func TestImportFromSource_ValidatesRemoteURLs(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name":"org/good","version":"1.0.0","remotes":[{"type":"streamable-http","url":"https://mcp.example.com/mcp/"}]},
+			{"name":"org/schemeless","version":"1.0.0","remotes":[{"type":"sse","url":"mcp.example.com/sse"}]}
+		]`)
+	}))
+	defer ts.Close()
+
+	server, c := setupTestServer(t)
+	server.importClient = ts.Client()
+	ctx := context.Background()
+
+	resp, err := server.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL}})
+	require.NoError(t, err)
+	assert.False(t, resp.Body.Success)
+	assert.Equal(t, "Imported 1, updated 0, skipped 0 servers, 1 errors", resp.Body.Message)
+	require.Len(t, resp.Body.Errors, 1)
+	assert.Contains(t, resp.Body.Errors[0], "org/schemeless")
+
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	require.NoError(t, c.List(ctx, &list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "https://mcp.example.com/mcp", list.Items[0].Spec.Remotes[0].URL)
+}
+
+func TestImportFromSource_EnforcesSizeLimits(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_MAX_REMOTES", "1")
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name":"org/good","version":"1.0.0","remotes":[{"type":"streamable-http","url":"https://mcp.example.com/mcp"}]},
+			{"name":"org/big","version":"1.0.0","remotes":[
+				{"type":"streamable-http","url":"https://a.example.com/mcp"},
+				{"type":"streamable-http","url":"https://b.example.com/mcp"}
+			]}
+		]`)
+	}))
+	defer ts.Close()
+
+	server, c := setupTestServer(t)
+	server.importClient = ts.Client()
+	ctx := context.Background()
+
+	resp, err := server.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL}})
+	require.NoError(t, err)
+	assert.Equal(t, "Imported 1, updated 0, skipped 0 servers, 1 errors", resp.Body.Message)
+	require.Len(t, resp.Body.Errors, 1)
+	assert.Contains(t, resp.Body.Errors[0], "org/big")
+	assert.Contains(t, resp.Body.Errors[0], "spec.remotes")
+
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	require.NoError(t, c.List(ctx, &list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "org/good", list.Items[0].Spec.Name)
+}
+
+func TestImportFromSource_MaxServers(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_IMPORT_MAX_SERVERS", "3")
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Each page lists two servers, so the cap is crossed on the second
+		page := r.URL.Query().Get("cursor")
+		fmt.Fprintf(w, `{"servers":[{"name":"org/a%[1]s","version":"1.0.0"},{"name":"org/b%[1]s","version":"1.0.0"}],"metadata":{"nextCursor":"%[1]sx"}}`, page)
+	}))
+	defer ts.Close()
+
+	server, c := setupTestServer(t)
+	server.importClient = ts.Client()
+	ctx := context.Background()
+
+	_, err := server.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL}})
+	var statusErr huma.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, statusErr.GetStatus())
+	assert.Contains(t, err.Error(), "more than 3 servers")
+
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	require.NoError(t, c.List(ctx, &list))
+	assert.Empty(t, list.Items, "nothing is written when the cap is exceeded")
+}
+
+func TestImportFromSource_Concurrent(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_IMPORT_CONCURRENCY", "4")
+	var entries []string
+	for i := range 25 {
+		entries = append(entries, fmt.Sprintf(`{"name":"org/server-%d","version":"1.0.0"}`, i))
+	}
+	// An invalid entry in the middle is reported alongside the others
+	entries[10] = `{"name":"org/bad","version":"1.0.0","remotes":[{"type":"sse","url":"not-a-url"}]}`
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "["+strings.Join(entries, ",")+"]")
+	}))
+	defer ts.Close()
+
+	server, c := setupTestServer(t)
+	server.importClient = ts.Client()
+	ctx := context.Background()
+
+	resp, err := server.importFromSource(ctx, &ImportInput{Body: ImportRequest{Source: ts.URL}})
+	require.NoError(t, err)
+	assert.Equal(t, "Imported 24, updated 0, skipped 0 servers, 1 errors", resp.Body.Message)
+	require.Len(t, resp.Body.Errors, 1)
+	assert.Contains(t, resp.Body.Errors[0], "org/bad")
+
+	var list agentregistryv1alpha1.MCPServerCatalogList
+	require.NoError(t, c.List(ctx, &list))
+	assert.Len(t, list.Items, 24)
+}
+
+func TestImportFromSource_RejectsNonHTTPSSchemes(t *testing.T) {
+	server, _ := setupTestServer(t)
+	for _, source := range []string{"file:///etc/passwd", "gopher://registry.example.com/", "http://registry.example.com/v0/servers"} {
+		t.Run(source, func(t *testing.T) {
+			_, err := server.importFromSource(context.Background(), &ImportInput{Body: ImportRequest{Source: source}})
+			var statusErr huma.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, http.StatusBadRequest, statusErr.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package httpapi
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// RecomputeResult counts the resources requeued for recomputation. Failed
+// resources were not requeued and are logged; running the recompute again
+// retries them.
+type RecomputeResult struct {
+	Servers     int `json:"servers"`
+	Agents      int `json:"agents"`
+	Skills      int `json:"skills"`
+	Deployments int `json:"deployments"`
+	Failed      int `json:"failed"`
+}
+
+type RecomputeResponse struct {
+	Body RecomputeResult
+}
+
+// recomputeAll requeues every catalog entry with a reconciler and every
+// deployment, so derived state such as IsLatest, the catalog status,
+// verification conditions and deployment status is recomputed from scratch.
+// Requeueing only stamps ReconcileTriggerAnnotation, so it is safe to repeat.
+func (s *Server) recomputeAll(ctx context.Context) (*RecomputeResponse, error) {
+	var result RecomputeResult
+	for _, kind := range []struct {
+		list  client.ObjectList
+		count *int
+	}{
+		{&agentregistryv1alpha1.MCPServerCatalogList{}, &result.Servers},
+		{&agentregistryv1alpha1.AgentCatalogList{}, &result.Agents},
+		{&agentregistryv1alpha1.SkillCatalogList{}, &result.Skills},
+		{&agentregistryv1alpha1.RegistryDeploymentList{}, &result.Deployments},
+	} {
+		if err := s.client.List(ctx, kind.list); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list resources to recompute", err)
+		}
+		objs, err := meta.ExtractList(kind.list)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list resources to recompute", err)
+		}
+		for _, o := range objs {
+			obj := o.(client.Object)
+			if err := controller.TriggerReconcile(ctx, s.client, obj); err != nil {
+				s.logger.Warn().Err(err).Str("namespace", obj.GetNamespace()).Str("name", obj.GetName()).
+					Msg("failed to requeue resource for recompute")
+				result.Failed++
+				continue
+			}
+			*kind.count++
+		}
+	}
+
+	s.logger.Warn().Bool("audit", true).
+		Str("action", "recompute").
+		Interface("requeued", result).
+		Msg("catalog and deployment state recompute requested")
+
+	return &RecomputeResponse{Body: result}, nil
+}
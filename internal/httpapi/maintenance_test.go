@@ -0,0 +1,56 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestRecomputeAll(t *testing.T) {
+	server, c := setupTestServer(t)
+	ctx := context.Background()
+
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "agentregistry"}
+	}
+	objs := []client.Object{
+		&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: objectMeta("search-1-0-0"), Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "search", Version: "1.0.0"}},
+		&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: objectMeta("search-2-0-0"), Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "search", Version: "2.0.0"}},
+		&agentregistryv1alpha1.AgentCatalog{ObjectMeta: objectMeta("helper"), Spec: agentregistryv1alpha1.AgentCatalogSpec{Name: "helper", Version: "1.0.0"}},
+		&agentregistryv1alpha1.SkillCatalog{ObjectMeta: objectMeta("summarize"), Spec: agentregistryv1alpha1.SkillCatalogSpec{Name: "summarize", Version: "1.0.0"}},
+		&agentregistryv1alpha1.RegistryDeployment{ObjectMeta: objectMeta("search-deployment"), Spec: agentregistryv1alpha1.RegistryDeploymentSpec{ResourceName: "search", Version: "2.0.0"}},
+	}
+	for _, obj := range objs {
+		require.NoError(t, c.Create(ctx, obj))
+	}
+
+	// Running it again requeues everything again
+	for range 2 {
+		resp, err := server.recomputeAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, RecomputeResult{Servers: 2, Agents: 1, Skills: 1, Deployments: 1}, resp.Body)
+	}
+
+	for _, obj := range objs {
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+		assert.NotEmpty(t, obj.GetAnnotations()[controller.ReconcileTriggerAnnotation], "%s was not requeued", obj.GetName())
+	}
+}
+
+func TestRecomputeAll_RequiresAuth(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/v0/maintenance/recompute", nil)
+	rec := httptest.NewRecorder()
+	server.mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
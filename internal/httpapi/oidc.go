@@ -0,0 +1,163 @@
+package httpapi
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
+)
+
+// oidcKeyRefreshInterval bounds how often the signing keys are fetched again
+// when a token names a key that is not loaded, e.g. after a key rotation
+const oidcKeyRefreshInterval = time.Minute
+
+// oidcVerifier validates OIDC ID and access tokens against the signing keys
+// of their issuer and returns the groups they carry
+type oidcVerifier struct {
+	issuer   string
+	audience string
+	jwksURL  string
+	client   *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func newOIDCVerifier(issuer, audience, jwksURL string) *oidcVerifier {
+	return &oidcVerifier{
+		issuer:   issuer,
+		audience: audience,
+		jwksURL:  jwksURL,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	Groups []string `json:"groups"`
+}
+
+// Groups validates token, checking its signature, issuer, audience and expiry,
+// and returns its groups claim
+func (v *oidcVerifier) Groups(token string) ([]string, error) {
+	var claims oidcClaims
+	_, err := jwt.ParseWithClaims(token, &claims, v.signingKey,
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return claims.Groups, nil
+}
+
+// signingKey returns the key token was signed with, fetching the issuer's keys
+// when it is not loaded
+func (v *oidcVerifier) signingKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(v.fetchedAt) < oidcKeyRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	v.fetchedAt = time.Now()
+	keys, err := v.fetchKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	v.keys = keys
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// fetchKeys reads the RSA keys of the issuer's JSON Web Key Set
+func (v *oidcVerifier) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	resp, err := v.client.Get(v.jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if err := errors.Join(errN, errE); err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+// callerMiddleware records in the request context who is calling, so catalog
+// reads can apply the VisibilityGroups of entries: an admin token holder, a
+// caller with a valid OIDC token and its groups, or an anonymous caller.
+// Invalid tokens make an anonymous caller; authMiddleware still rejects them
+// on admin routes.
+func (s *Server) callerMiddleware(ctx huma.Context, next func(huma.Context)) {
+	caller := s.caller(ctx.Header("Authorization"))
+	next(huma.WithContext(ctx, handlers.WithCaller(ctx.Context(), caller)))
+}
+
+// caller identifies the caller presenting authHeader
+func (s *Server) caller(authHeader string) handlers.Caller {
+	token, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok || token == "" {
+		return handlers.Caller{}
+	}
+
+	s.tokensMu.RLock()
+	admin := s.allowedTokens[token]
+	s.tokensMu.RUnlock()
+	if admin {
+		return handlers.Caller{Admin: true}
+	}
+
+	if s.oidc == nil {
+		return handlers.Caller{}
+	}
+	groups, err := s.oidc.Groups(token)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("ignoring invalid OIDC token")
+		return handlers.Caller{}
+	}
+	return handlers.Caller{Groups: groups}
+}
@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+const (
+	testOIDCIssuer   = "https://issuer.example.com/v2.0"
+	testOIDCAudience = "agentregistry"
+)
+
+// newTestOIDCIssuer serves a JSON Web Key Set holding a fresh key and returns
+// a function signing tokens with it
+func newTestOIDCIssuer(t *testing.T) (jwksURL string, sign func(claims jwt.MapClaims) string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": "test-key",
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(jwks.Close)
+
+	return jwks.URL, func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+}
+
+func TestOIDCVerifier_Groups(t *testing.T) {
+	jwksURL, sign := newTestOIDCIssuer(t)
+	v := newOIDCVerifier(testOIDCIssuer, testOIDCAudience, jwksURL)
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":    testOIDCIssuer,
+			"aud":    testOIDCAudience,
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"platform-team"},
+		}
+		for k, val := range overrides {
+			c[k] = val
+		}
+		return c
+	}
+
+	groups, err := v.Groups(sign(claims(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform-team"}, groups)
+
+	for name, token := range map[string]string{
+		"expired":        sign(claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"other issuer":   sign(claims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+		"other audience": sign(claims(jwt.MapClaims{"aud": "someone-else"})),
+		"no expiry":      sign(claims(jwt.MapClaims{"exp": nil})),
+		"not a token":    "opaque",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := v.Groups(token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVisibilityGroups_PublicAPI(t *testing.T) {
+	jwksURL, sign := newTestOIDCIssuer(t)
+	server := func(name string, groups ...string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "io.example/" + name, Version: "1.0.0", VisibilityGroups: groups},
+		}
+	}
+	s, _ := setupFeaturedTestServer(t, server("open"), server("internal", "platform-team"))
+	s.oidc = newOIDCVerifier(testOIDCIssuer, testOIDCAudience, jwksURL)
+
+	list := func(token string) []string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body struct {
+			Servers []struct {
+				Server struct {
+					Name string `json:"name"`
+				} `json:"server"`
+			} `json:"servers"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		var names []string
+		for _, s := range body.Servers {
+			names = append(names, s.Server.Name)
+		}
+		return names
+	}
+	token := func(groups ...string) string {
+		return sign(jwt.MapClaims{"iss": testOIDCIssuer, "aud": testOIDCAudience, "exp": time.Now().Add(time.Hour).Unix(), "groups": groups})
+	}
+
+	assert.ElementsMatch(t, []string{"io.example/open"}, list(""), "anonymous callers only see unrestricted entries")
+	assert.ElementsMatch(t, []string{"io.example/open"}, list("not-a-jwt"), "invalid tokens are treated as anonymous")
+	assert.ElementsMatch(t, []string{"io.example/open"}, list(token("sales")))
+	assert.ElementsMatch(t, []string{"io.example/open", "io.example/internal"}, list(token("sales", "platform-team")))
+	assert.ElementsMatch(t, []string{"io.example/open", "io.example/internal"}, list("curator-token"), "admins see every entry")
+}
@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/internal/catalogexport"
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
+)
+
+// componentSchemaPrefix is the prefix of huma's references between the
+// schemas of the OpenAPI components
+const componentSchemaPrefix = "#/components/schemas/"
+
+// catalogBodyTypes are the bodies the create endpoints of each catalog type
+// accept
+var catalogBodyTypes = map[string]reflect.Type{
+	catalogexport.TypeServers: reflect.TypeFor[handlers.ServerJSON](),
+	catalogexport.TypeAgents:  reflect.TypeFor[handlers.AgentJSON](),
+	catalogexport.TypeSkills:  reflect.TypeFor[handlers.SkillJSON](),
+	catalogexport.TypeModels:  reflect.TypeFor[handlers.ModelJSON](),
+}
+
+type SchemaInput struct {
+	Type string `path:"type" enum:"servers,agents,skills,models"`
+}
+
+type SchemaResponse struct {
+	Body map[string]any
+}
+
+// registerSchemaRoutes registers the public endpoint serving the JSON Schema
+// of each catalog type's create body
+func (s *Server) registerSchemaRoutes() {
+	// Resolve the component names up front so requests only read the registry
+	registry := s.api.OpenAPI().Components.Schemas
+	names := make(map[string]string, len(catalogBodyTypes))
+	for catalogType, t := range catalogBodyTypes {
+		names[catalogType] = strings.TrimPrefix(registry.Schema(t, true, "").Ref, componentSchemaPrefix)
+	}
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "get-catalog-schema",
+		Method:      http.MethodGet,
+		Path:        "/v0/schema/{type}",
+		Summary:     "Get the JSON Schema of a catalog type's create body",
+		Tags:        []string{"utility"},
+	}, func(ctx context.Context, input *SchemaInput) (*SchemaResponse, error) {
+		schema, err := componentJSONSchema(registry, names[input.Type])
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to build schema", err)
+		}
+		return &SchemaResponse{Body: schema}, nil
+	})
+}
+
+// componentJSONSchema returns the OpenAPI component schema name as a
+// standalone JSON Schema document. The components it references are
+// included under $defs, with the references rewritten to point there.
+func componentJSONSchema(registry huma.Registry, name string) (map[string]any, error) {
+	components := registry.Map()
+	toMap := func(name string) (map[string]any, error) {
+		data, err := json.Marshal(components[name])
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]any
+		err = json.Unmarshal(data, &m)
+		return m, err
+	}
+
+	root, err := toMap(name)
+	if err != nil {
+		return nil, err
+	}
+	defs := map[string]any{}
+	pending := rewriteSchemaRefs(root)
+	for len(pending) > 0 {
+		ref := pending[0]
+		pending = pending[1:]
+		if _, done := defs[ref]; done || ref == name {
+			continue
+		}
+		def, err := toMap(ref)
+		if err != nil {
+			return nil, err
+		}
+		defs[ref] = def
+		pending = append(pending, rewriteSchemaRefs(def)...)
+	}
+
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = name
+	if len(defs) > 0 {
+		root["$defs"] = defs
+	}
+	return root, nil
+}
+
+// rewriteSchemaRefs points the component references in v to $defs and
+// returns the names of the referenced components
+func rewriteSchemaRefs(v any) []string {
+	var refs []string
+	switch node := v.(type) {
+	case map[string]any:
+		for key, child := range node {
+			if ref, ok := child.(string); ok && key == "$ref" && strings.HasPrefix(ref, componentSchemaPrefix) {
+				name := strings.TrimPrefix(ref, componentSchemaPrefix)
+				node[key] = "#/$defs/" + name
+				refs = append(refs, name)
+				continue
+			}
+			refs = append(refs, rewriteSchemaRefs(child)...)
+		}
+	case []any:
+		for _, child := range node {
+			refs = append(refs, rewriteSchemaRefs(child)...)
+		}
+	}
+	return refs
+}
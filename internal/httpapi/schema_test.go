@@ -0,0 +1,57 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogSchema(t *testing.T) {
+	s, _ := setupFeaturedTestServer(t)
+	getSchema := func(catalogType string) (int, map[string]any) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/schema/"+catalogType, nil))
+		var schema map[string]any
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &schema))
+		}
+		return rec.Code, schema
+	}
+
+	code, schema := getSchema("servers")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Contains(t, schema["required"], "name")
+	properties := schema["properties"].(map[string]any)
+	defs := schema["$defs"].(map[string]any)
+	for _, field := range []string{"packages", "remotes"} {
+		property, ok := properties[field].(map[string]any)
+		require.True(t, ok, "%s is a property", field)
+		ref := property["items"].(map[string]any)["$ref"].(string)
+		require.True(t, strings.HasPrefix(ref, "#/$defs/"), "%s items reference $defs, got %s", field, ref)
+		def, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		require.True(t, ok, "%s is defined", ref)
+		assert.NotEmpty(t, def["properties"])
+	}
+	// Definitions referenced only from other definitions are included too
+	for name, def := range defs {
+		data, err := json.Marshal(def)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "#/components/schemas/", "%s references are rewritten", name)
+	}
+
+	for _, catalogType := range []string{"agents", "skills", "models"} {
+		code, schema := getSchema(catalogType)
+		require.Equal(t, http.StatusOK, code, catalogType)
+		assert.NotEmpty(t, schema["properties"], catalogType)
+	}
+
+	code, _ = getSchema("deployments")
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
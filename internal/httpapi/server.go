@@ -2,22 +2,22 @@ package httpapi
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/fs"
 	"net"
 	"net/http"
 	"os"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/rs/cors"
 	"github.com/rs/zerolog"
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -26,7 +26,10 @@ import (
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/conversion"
 	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
+	"github.com/agentregistry-dev/agentregistry/internal/safehttp"
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 )
 
@@ -42,16 +45,35 @@ type Server struct {
 	api            huma.API
 	authEnabled    bool
 	allowedTokens  map[string]bool // Simple token allowlist for now
+	tokenNames     []string        // Secret keys of the loaded tokens, sorted
+	tokensMu       sync.RWMutex    // Guards allowedTokens and tokenNames, which reloads replace
 	wrappedHandler http.Handler    // Wrapped handler with UI serving
+	importClient   *http.Client    // Client for import sources; nil uses safehttp.NewClient
+	importTLS      *tls.Config     // TLS settings for import sources; nil uses Go's defaults
+	iconClient     *http.Client    // Client for proxied icons; nil uses safehttp.NewClient
+	icons          *iconCache
+	oidc           *oidcVerifier // Validates OIDC tokens for catalog visibility groups; nil when OIDC is not configured
 }
 
 // ServerOption is a functional option for configuring the server
 type ServerOption func(*Server)
 
+// WithImportTLSConfig sets the TLS settings, e.g. a custom CA bundle, used when
+// fetching import sources
+func WithImportTLSConfig(cfg *tls.Config) ServerOption {
+	return func(s *Server) {
+		s.importTLS = cfg
+	}
+}
+
 // NewServer creates a new HTTP API server
 func NewServer(c client.Client, cache cache.Cache, logger zerolog.Logger, opts ...ServerOption) *Server {
 	mux := http.NewServeMux()
 
+	// Route every huma error, including request validation failures raised
+	// by huma itself, through the structured error body
+	huma.NewError = handlers.NewError
+
 	apiConfig := huma.DefaultConfig("Agent Registry API", "1.0.0")
 	apiConfig.Info.Description = "Kubernetes-native agent and MCP server registry"
 
@@ -68,6 +90,10 @@ func NewServer(c client.Client, cache cache.Cache, logger zerolog.Logger, opts .
 		api:           api,
 		authEnabled:   authEnabled,
 		allowedTokens: make(map[string]bool),
+		icons:         newIconCache(),
+	}
+	if config.IsOIDCConfigured() {
+		s.oidc = newOIDCVerifier(config.OIDCIssuer(), config.OIDCAudience(), config.OIDCJWKSURL())
 	}
 
 	// Apply options
@@ -134,7 +160,10 @@ func (s *Server) checkAdminToken(authHeader string) (int, string) {
 	}
 
 	token := parts[1]
-	if !s.allowedTokens[token] {
+	s.tokensMu.RLock()
+	allowed := s.allowedTokens[token]
+	s.tokensMu.RUnlock()
+	if !allowed {
 		s.logger.Warn().Str("token_prefix", token[:min(8, len(token))]).Msg("invalid admin token")
 		return http.StatusUnauthorized, "Invalid token"
 	}
@@ -160,44 +189,35 @@ func (s *Server) requireAdminToken(next http.HandlerFunc) http.HandlerFunc {
 // Reads from Secret "agentregistry-api-tokens" in the controller namespace
 // Each key in the secret data is treated as a valid token
 func (s *Server) loadTokensFromSecret() {
-	// Get namespace from configuration
-	namespace := config.GetNamespace()
-
-	// Try to read tokens from Secret
-	secret := &corev1.Secret{}
-	err := s.client.Get(context.Background(), client.ObjectKey{
-		Namespace: namespace,
-		Name:      "agentregistry-api-tokens",
-	}, secret)
-
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			s.logger.Warn().Msg("Secret 'agentregistry-api-tokens' not found - admin API is fail-closed and will reject ALL /admin/* requests until tokens are configured")
-		} else {
-			s.logger.Error().Err(err).Msg("failed to read API tokens secret")
-		}
-		return
+	tokens, err := s.readTokenSecret(context.Background())
+	switch {
+	case apierrors.IsNotFound(err):
+		s.logger.Warn().Msg("Secret 'agentregistry-api-tokens' not found - admin API is fail-closed and will reject ALL /admin/* requests until tokens are configured")
+	case err != nil:
+		s.logger.Error().Err(err).Msg("failed to read API tokens secret")
+	default:
+		s.setTokens(tokens)
+		s.logger.Info().Int("count", len(tokens)).Msg("loaded API tokens from secret")
 	}
 
-	// Each key in the secret is a token name, value is the token
-	for name, tokenBytes := range secret.Data {
-		token := strings.TrimSpace(string(tokenBytes))
-		if token != "" {
-			s.allowedTokens[token] = true
-			s.logger.Debug().Str("name", name).Msg("loaded API token")
-		}
+	if reason := s.tokenHealthProblem(); reason != "" {
+		s.logger.Error().Msg(reason)
 	}
-
-	s.logger.Info().Int("count", len(s.allowedTokens)).Msg("loaded API tokens from secret")
 }
 
 // registerRoutes registers all HTTP routes
 func (s *Server) registerRoutes() {
+	// Trace every huma-routed operation, including those rejected by auth
+	s.api.UseMiddleware(s.tracingMiddleware)
+
 	// Enforce mandatory admin auth on every huma-routed operation. The
 	// middleware itself scopes enforcement to /admin/* paths and lets public
 	// /v0/* routes through.
 	s.api.UseMiddleware(s.authMiddleware)
 
+	// Record the caller so catalog reads can apply visibility groups
+	s.api.UseMiddleware(s.callerMiddleware)
+
 	// Create handlers with cache access
 	serverHandler := handlers.NewServerHandler(s.client, s.cache, s.logger)
 	agentHandler := handlers.NewAgentHandler(s.client, s.cache, s.logger)
@@ -206,11 +226,13 @@ func (s *Server) registerRoutes() {
 	deploymentHandler := handlers.NewDeploymentHandler(s.client, s.cache, s.logger)
 	environmentHandler := handlers.NewEnvironmentHandler(s.client, s.cache, s.logger)
 
-	// Register public API endpoints (v0)
-	serverHandler.RegisterRoutes(s.api, "/v0", false)
-	agentHandler.RegisterRoutes(s.api, "/v0", false)
-	skillHandler.RegisterRoutes(s.api, "/v0", false)
-	modelHandler.RegisterRoutes(s.api, "/v0", false)
+	// Register public API endpoints (v0). Catalog reads go through a view that
+	// hides entries that are not publicly visible in strict mode.
+	publicClient, publicCache := handlers.PublicClient(s.client), handlers.PublicCache(s.cache)
+	handlers.NewServerHandler(publicClient, publicCache, s.logger).RegisterRoutes(s.api, "/v0", false)
+	handlers.NewAgentHandler(publicClient, publicCache, s.logger).RegisterRoutes(s.api, "/v0", false)
+	handlers.NewSkillHandler(publicClient, publicCache, s.logger).RegisterRoutes(s.api, "/v0", false)
+	handlers.NewModelHandler(publicClient, publicCache, s.logger).RegisterRoutes(s.api, "/v0", false)
 	deploymentHandler.RegisterRoutes(s.api, "/v0", false)
 	environmentHandler.RegisterRoutes(s.api, "/v0", false)
 
@@ -224,6 +246,18 @@ func (s *Server) registerRoutes() {
 	// Register admin utility endpoints
 	s.registerAdminUtilityRoutes()
 
+	// Server icons, proxied so the UI does not load third-party URLs
+	s.registerIconRoutes()
+
+	// Admin token listing and reload
+	s.registerTokenRoutes()
+
+	// Curated entries for the homepage, set by admins
+	s.registerFeaturedRoutes()
+
+	// JSON Schemas of the catalog create bodies, for form UIs and validators
+	s.registerSchemaRoutes()
+
 	// Register submit endpoint. Submission is a public PROPOSE flow: it fetches
 	// and validates a manifest from a repository but does not write to the
 	// cluster, so it needs no auth. Registered under /v0 (kept at /admin/v0 too
@@ -232,6 +266,10 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("/v0/submit", submitHandler.Submit)
 	s.mux.HandleFunc("/admin/v0/submit", submitHandler.Submit)
 
+	// Deployment event stream (public, read-only). Server-sent events cannot be
+	// expressed as a huma operation, so it is served from the mux directly.
+	s.mux.HandleFunc("GET /v0/deployments/{name}/events", deploymentHandler.StreamEvents)
+
 	// Version endpoint (public)
 	s.mux.HandleFunc("/v0/version", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -293,7 +331,17 @@ type HealthResponse struct {
 }
 
 type HealthStatus struct {
-	Status string `json:"status"`
+	Status string `json:"status" enum:"healthy,degraded"`
+	Reason string `json:"reason,omitempty" doc:"Why the server is degraded"`
+}
+
+// healthStatus reports the server as degraded when it is misconfigured in a
+// way that looks like an outage to clients
+func (s *Server) healthStatus() HealthStatus {
+	if reason := s.tokenHealthProblem(); reason != "" {
+		return HealthStatus{Status: "degraded", Reason: reason}
+	}
+	return HealthStatus{Status: "healthy"}
 }
 
 type ImportRequest struct {
@@ -315,8 +363,9 @@ type ImportResponse struct {
 }
 
 type ImportResult struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
 }
 
 // registerAdminUtilityRoutes registers admin utility endpoints
@@ -332,7 +381,7 @@ func (s *Server) registerAdminUtilityRoutes() {
 		Summary:     "Health check",
 		Tags:        []string{"utility"},
 	}, func(ctx context.Context, input *struct{}) (*HealthResponse, error) {
-		return &HealthResponse{Body: HealthStatus{Status: "healthy"}}, nil
+		return &HealthResponse{Body: s.healthStatus()}, nil
 	})
 
 	huma.Register(s.api, huma.Operation{
@@ -354,7 +403,7 @@ func (s *Server) registerAdminUtilityRoutes() {
 		Tags:        tags,
 	}, func(ctx context.Context, input *struct{}) (*HealthResponse, error) {
 		return &HealthResponse{
-			Body: HealthStatus{Status: "healthy"},
+			Body: s.healthStatus(),
 		}, nil
 	})
 
@@ -379,6 +428,29 @@ func (s *Server) registerAdminUtilityRoutes() {
 	}, func(ctx context.Context, input *ImportInput) (*ImportResponse, error) {
 		return s.importFromSource(ctx, input)
 	})
+
+	// Export, in the format accepted by import
+	huma.Register(s.api, huma.Operation{
+		OperationID: "admin-export",
+		Method:      http.MethodGet,
+		Path:        "/admin/v0/export",
+		Summary:     "Export the catalog as an MCP registry bundle",
+		Tags:        tags,
+	}, func(ctx context.Context, input *ExportInput) (*ExportResponse, error) {
+		return s.exportCatalog(ctx, input)
+	})
+
+	// Requeue everything for recomputation, e.g. after a bad import or an
+	// upgrade that changes how status is derived
+	huma.Register(s.api, huma.Operation{
+		OperationID: "admin-maintenance-recompute",
+		Method:      http.MethodPost,
+		Path:        "/admin/v0/maintenance/recompute",
+		Summary:     "Requeue every catalog entry and deployment for recomputation",
+		Tags:        tags,
+	}, func(ctx context.Context, input *struct{}) (*RecomputeResponse, error) {
+		return s.recomputeAll(ctx)
+	})
 }
 
 func (s *Server) getStats(ctx context.Context) (*StatsResponse, error) {
@@ -430,59 +502,20 @@ func (s *Server) importFromSource(ctx context.Context, input *ImportInput) (*Imp
 
 	// Validate the source URL (https-only) before fetching. Per-IP SSRF
 	// filtering happens in the safe client's dial control after DNS resolution.
-	if _, err := validateImportURL(input.Body.Source); err != nil {
+	if _, err := safehttp.ValidateURL(input.Body.Source); err != nil {
 		return nil, huma.Error400BadRequest("Invalid source URL", err)
 	}
 
-	// Fetch data from source. We intentionally do NOT forward caller-supplied
-	// headers (input.Body.Headers) — they could be used to reach authenticated
-	// internal endpoints or smuggle credentials to arbitrary hosts.
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, input.Body.Source, nil)
-	if err != nil {
-		return nil, huma.Error400BadRequest("Invalid source URL", err)
-	}
-	req.Header.Set("Accept", "application/json")
-
-	httpClient := newSafeHTTPClient(30 * time.Second)
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		// Do not surface the underlying dial error verbatim — it can confirm
-		// the existence/reachability of internal hosts (SSRF oracle).
-		s.logger.Warn().Err(err).Str("source", input.Body.Source).Msg("import fetch failed")
-		return nil, huma.Error502BadGateway("Failed to fetch from source")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		// Do not reflect the upstream response body back to the caller.
-		return nil, huma.Error502BadGateway(
-			fmt.Sprintf("Source returned status %d", resp.StatusCode),
-		)
-	}
+	importLimits := config.GetImportLimits()
+	ctx, cancel := context.WithTimeout(ctx, importLimits.Timeout)
+	defer cancel()
 
-	// Parse response - support both array of servers and object with servers
-	// field. Cap the body size to avoid unbounded memory use from a hostile
-	// or oversized source.
-	const maxImportBytes = 10 << 20 // 10 MiB
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImportBytes))
+	servers, pageErrors, err := s.fetchImportSource(ctx, input.Body.Source)
 	if err != nil {
-		return nil, huma.Error502BadGateway("Failed to read response body")
-	}
-
-	var servers []ExternalServerJSON
-	// Try parsing as array first
-	if err := json.Unmarshal(body, &servers); err != nil {
-		// Try parsing as object with servers field
-		var wrapper struct {
-			Servers []ExternalServerJSON `json:"servers"`
-		}
-		if err := json.Unmarshal(body, &wrapper); err != nil {
-			return nil, huma.Error400BadRequest("Failed to parse server data", err)
-		}
-		servers = wrapper.Servers
+		return nil, err
 	}
 
-	if len(servers) == 0 {
+	if len(servers) == 0 && len(pageErrors) == 0 {
 		return &ImportResponse{
 			Body: ImportResult{
 				Success: true,
@@ -491,61 +524,42 @@ func (s *Server) importFromSource(ctx context.Context, input *ImportInput) (*Imp
 		}, nil
 	}
 
-	// Import each server
+	// Create or update the servers on a bounded number of workers, keeping the
+	// results in source order so errors are reported deterministically
+	outcomes := make([]importOutcome, len(servers))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range min(importLimits.Concurrency, len(servers)) {
+		wg.Go(func() {
+			for i := range work {
+				outcomes[i] = s.importServer(ctx, servers[i], input.Body.Update)
+			}
+		})
+	}
+	for i := range servers {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
 	imported := 0
 	updated := 0
 	skipped := 0
-	var errors []string
-
-	for _, extServer := range servers {
-		if extServer.Name == "" || extServer.Version == "" {
-			skipped++
-			continue
-		}
-
-		crName := handlers.GenerateCRName(extServer.Name, extServer.Version)
-
-		// Check if server already exists
-		existing := &agentregistryv1alpha1.MCPServerCatalog{}
-		err := s.client.Get(ctx, client.ObjectKey{Namespace: config.GetNamespace(), Name: crName}, existing)
-		if err == nil {
-			// Server exists
-			if !input.Body.Update {
-				skipped++
-				continue
-			}
-			// Update existing server
-			existing.Spec = s.convertExternalToSpec(extServer)
-			if err := s.client.Update(ctx, existing); err != nil {
-				errors = append(errors, fmt.Sprintf("%s: %v", extServer.Name, err))
-				continue
-			}
+	errors := pageErrors
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.err != nil:
+			errors = append(errors, outcome.err.Error())
+		case outcome.result == importImported:
+			imported++
+		case outcome.result == importUpdated:
 			updated++
-			continue
-		}
-
-		if !apierrors.IsNotFound(err) {
-			errors = append(errors, fmt.Sprintf("%s: %v", extServer.Name, err))
-			continue
-		}
-
-		// Create new server
-		server := &agentregistryv1alpha1.MCPServerCatalog{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: crName,
-				Labels: map[string]string{
-					"agentregistry.dev/name":    handlers.SanitizeK8sName(extServer.Name),
-					"agentregistry.dev/version": handlers.SanitizeK8sName(extServer.Version),
-				},
-			},
-			Spec: s.convertExternalToSpec(extServer),
-		}
-
-		if err := s.client.Create(ctx, server); err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", extServer.Name, err))
-			continue
+		case outcome.result == importSkipped:
+			skipped++
 		}
-		imported++
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		errors = append(errors, fmt.Sprintf("import timed out after %s", importLimits.Timeout))
 	}
 
 	// Build result message
@@ -558,65 +572,89 @@ func (s *Server) importFromSource(ctx context.Context, input *ImportInput) (*Imp
 		Body: ImportResult{
 			Success: len(errors) == 0,
 			Message: message,
+			Errors:  errors,
 		},
 	}, nil
 }
 
-// ExternalServerJSON represents a server from external registries (MCP Registry format)
-type ExternalServerJSON struct {
-	Name        string                  `json:"name"`
-	Version     string                  `json:"version"`
-	Title       string                  `json:"title,omitempty"`
-	Description string                  `json:"description,omitempty"`
-	WebsiteURL  string                  `json:"websiteUrl,omitempty"`
-	Repository  *ExternalRepositoryJSON `json:"repository,omitempty"`
-	Packages    []ExternalPackageJSON   `json:"packages,omitempty"`
-	Remotes     []ExternalTransportJSON `json:"remotes,omitempty"`
-}
+// importResult is what importing a single server did
+type importResult int
 
-type ExternalRepositoryJSON struct {
-	URL       string `json:"url,omitempty"`
-	Source    string `json:"source,omitempty"`
-	ID        string `json:"id,omitempty"`
-	Subfolder string `json:"subfolder,omitempty"`
-}
+const (
+	importImported importResult = iota
+	importUpdated
+	importSkipped
+)
 
-type ExternalTransportJSON struct {
-	Type    string                 `json:"type"`
-	URL     string                 `json:"url,omitempty"`
-	Headers []ExternalKeyValueJSON `json:"headers,omitempty"`
+type importOutcome struct {
+	result importResult
+	err    error
 }
 
-type ExternalKeyValueJSON struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	Value       string `json:"value,omitempty"`
-	Required    bool   `json:"required,omitempty"`
-}
+// importServer creates the catalog entry of extServer, or updates an existing
+// one when update is set
+func (s *Server) importServer(ctx context.Context, extServer conversion.ExternalServerJSON, update bool) importOutcome {
+	failed := func(err error) importOutcome {
+		return importOutcome{err: fmt.Errorf("%s: %v", extServer.Name, err)}
+	}
+	if extServer.Name == "" || extServer.Version == "" {
+		return importOutcome{result: importSkipped}
+	}
+	if err := validateExternalRemotes(extServer); err != nil {
+		return failed(err)
+	}
+	spec := s.convertExternalToSpec(extServer)
+	if errs := validation.ValidateServerLimits(&spec, config.GetCatalogLimits()); len(errs) > 0 {
+		return failed(errs.ToAggregate())
+	}
+
+	crName := handlers.GenerateCRName(extServer.Name, extServer.Version)
+
+	// Check if server already exists
+	existing := &agentregistryv1alpha1.MCPServerCatalog{}
+	err := s.client.Get(ctx, client.ObjectKey{Namespace: config.GetNamespace(), Name: crName}, existing)
+	if err == nil {
+		if !update {
+			return importOutcome{result: importSkipped}
+		}
+		existing.Spec = spec
+		if err := s.client.Update(ctx, existing); err != nil {
+			return failed(err)
+		}
+		return importOutcome{result: importUpdated}
+	}
+	if !apierrors.IsNotFound(err) {
+		return failed(err)
+	}
 
-type ExternalPackageJSON struct {
-	RegistryType         string                 `json:"registryType"`
-	RegistryBaseURL      string                 `json:"registryBaseUrl,omitempty"`
-	Identifier           string                 `json:"identifier"`
-	Version              string                 `json:"version,omitempty"`
-	FileSHA256           string                 `json:"fileSha256,omitempty"`
-	RuntimeHint          string                 `json:"runtimeHint,omitempty"`
-	Transport            ExternalTransportJSON  `json:"transport"`
-	RuntimeArguments     []ExternalArgumentJSON `json:"runtimeArguments,omitempty"`
-	PackageArguments     []ExternalArgumentJSON `json:"packageArguments,omitempty"`
-	EnvironmentVariables []ExternalKeyValueJSON `json:"environmentVariables,omitempty"`
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: crName,
+			Labels: map[string]string{
+				"agentregistry.dev/name":    handlers.SanitizeK8sName(extServer.Name),
+				"agentregistry.dev/version": handlers.SanitizeK8sName(extServer.Version),
+			},
+		},
+		Spec: spec,
+	}
+	if err := s.client.Create(ctx, server); err != nil {
+		return failed(err)
+	}
+	return importOutcome{result: importImported}
 }
 
-type ExternalArgumentJSON struct {
-	Name        string `json:"name"`
-	Type        string `json:"type,omitempty"`
-	Description string `json:"description,omitempty"`
-	Value       string `json:"value,omitempty"`
-	Required    bool   `json:"required,omitempty"`
-	Multiple    bool   `json:"multiple,omitempty"`
+// validateExternalRemotes rejects an imported server whose remote URLs could
+// not be deployed
+func validateExternalRemotes(ext conversion.ExternalServerJSON) error {
+	for _, r := range ext.Remotes {
+		if err := validation.ValidateTransportURL(r.Type, r.URL); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *Server) convertExternalToSpec(ext ExternalServerJSON) agentregistryv1alpha1.MCPServerCatalogSpec {
+func (s *Server) convertExternalToSpec(ext conversion.ExternalServerJSON) agentregistryv1alpha1.MCPServerCatalogSpec {
 	spec := agentregistryv1alpha1.MCPServerCatalogSpec{
 		Name:        ext.Name,
 		Version:     ext.Version,
@@ -625,6 +663,14 @@ func (s *Server) convertExternalToSpec(ext ExternalServerJSON) agentregistryv1al
 		WebsiteURL:  ext.WebsiteURL,
 	}
 
+	// The catalog keeps a single icon: the first one that can be served
+	for _, icon := range ext.Icons {
+		if validation.ValidateIconURL(icon.Src) == nil {
+			spec.IconURL = icon.Src
+			break
+		}
+	}
+
 	if ext.Repository != nil {
 		spec.Repository = &agentregistryv1alpha1.Repository{
 			URL:       ext.Repository.URL,
@@ -641,6 +687,7 @@ func (s *Server) convertExternalToSpec(ext ExternalServerJSON) agentregistryv1al
 			Identifier:      p.Identifier,
 			Version:         p.Version,
 			FileSHA256:      p.FileSHA256,
+			Digest:          p.Digest,
 			RuntimeHint:     p.RuntimeHint,
 			Transport: agentregistryv1alpha1.Transport{
 				Type: p.Transport.Type,
@@ -689,7 +736,7 @@ func (s *Server) convertExternalToSpec(ext ExternalServerJSON) agentregistryv1al
 	for _, r := range ext.Remotes {
 		remote := agentregistryv1alpha1.Transport{
 			Type: r.Type,
-			URL:  r.URL,
+			URL:  validation.NormalizeTransportURL(r.URL),
 		}
 		for _, h := range r.Headers {
 			remote.Headers = append(remote.Headers, agentregistryv1alpha1.KeyValueInput{
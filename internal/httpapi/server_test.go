@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/conversion"
 )
 
 // mockCache implements cache.Cache for testing
@@ -269,16 +270,16 @@ func TestServer_PublicEndpointOpen(t *testing.T) {
 func TestConvertExternalToSpec(t *testing.T) {
 	server, _ := setupTestServer(t)
 
-	external := ExternalServerJSON{
+	external := conversion.ExternalServerJSON{
 		Name:        "test-convert",
 		Version:     "1.0.0",
 		Title:       "Test Convert",
 		Description: "Test conversion",
-		Packages: []ExternalPackageJSON{
+		Packages: []conversion.ExternalPackageJSON{
 			{
 				RegistryType: "npm",
 				Identifier:   "@test/server",
-				Transport: ExternalTransportJSON{
+				Transport: conversion.ExternalTransportJSON{
 					Type: "stdio",
 				},
 			},
@@ -451,19 +452,19 @@ func TestRequireAdminToken_MuxHandler(t *testing.T) {
 func TestConvertExternalToSpec_FullPayload(t *testing.T) {
 	server, _ := setupTestServer(t)
 
-	ext := ExternalServerJSON{
+	ext := conversion.ExternalServerJSON{
 		Name:        "full-server",
 		Version:     "2.0.0",
 		Title:       "Full Server",
 		Description: "All fields populated",
 		WebsiteURL:  "https://example.com",
-		Repository: &ExternalRepositoryJSON{
+		Repository: &conversion.ExternalRepositoryJSON{
 			URL:       "https://github.com/org/repo",
 			Source:    "github",
 			ID:        "repo-123",
 			Subfolder: "packages/server",
 		},
-		Packages: []ExternalPackageJSON{
+		Packages: []conversion.ExternalPackageJSON{
 			{
 				RegistryType:    "npm",
 				RegistryBaseURL: "https://registry.npmjs.org",
@@ -471,28 +472,28 @@ func TestConvertExternalToSpec_FullPayload(t *testing.T) {
 				Version:         "2.0.0",
 				FileSHA256:      "abc123",
 				RuntimeHint:     "node",
-				Transport: ExternalTransportJSON{
+				Transport: conversion.ExternalTransportJSON{
 					Type: "stdio",
-					Headers: []ExternalKeyValueJSON{
+					Headers: []conversion.ExternalKeyValueJSON{
 						{Name: "X-Token", Value: "tok", Required: true},
 					},
 				},
-				RuntimeArguments: []ExternalArgumentJSON{
+				RuntimeArguments: []conversion.ExternalArgumentJSON{
 					{Name: "port", Type: "integer", Required: true},
 				},
-				PackageArguments: []ExternalArgumentJSON{
+				PackageArguments: []conversion.ExternalArgumentJSON{
 					{Name: "config", Type: "string", Value: "default.json"},
 				},
-				EnvironmentVariables: []ExternalKeyValueJSON{
+				EnvironmentVariables: []conversion.ExternalKeyValueJSON{
 					{Name: "NODE_ENV", Value: "production"},
 				},
 			},
 		},
-		Remotes: []ExternalTransportJSON{
+		Remotes: []conversion.ExternalTransportJSON{
 			{
 				Type: "streamable-http",
 				URL:  "https://api.example.com/mcp",
-				Headers: []ExternalKeyValueJSON{
+				Headers: []conversion.ExternalKeyValueJSON{
 					{Name: "Authorization", Value: "Bearer x"},
 				},
 			},
@@ -541,7 +542,7 @@ func TestConvertExternalToSpec_FullPayload(t *testing.T) {
 func TestConvertExternalToSpec_EmptyOptionalFields(t *testing.T) {
 	server, _ := setupTestServer(t)
 
-	ext := ExternalServerJSON{
+	ext := conversion.ExternalServerJSON{
 		Name:    "minimal",
 		Version: "1.0.0",
 	}
@@ -0,0 +1,138 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+// apiTokensSecretName is the Secret in the controller namespace holding the
+// admin API tokens, one per key
+const apiTokensSecretName = "agentregistry-api-tokens"
+
+// TokenList lists the names of the loaded admin tokens; values are never
+// returned
+type TokenList struct {
+	Names   []string `json:"names"`
+	Count   int      `json:"count"`
+	Message string   `json:"message,omitempty"`
+}
+
+type TokenListResponse struct {
+	Body TokenList
+}
+
+// registerTokenRoutes registers the admin endpoints that manage the loaded
+// API tokens. Like every /admin/* route they require a valid token, so a
+// reload can only be triggered by someone already holding one.
+func (s *Server) registerTokenRoutes() {
+	tags := []string{"admin", "utility"}
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "admin-list-tokens",
+		Method:      http.MethodGet,
+		Path:        "/admin/v0/tokens",
+		Summary:     "List the names of the loaded API tokens",
+		Tags:        tags,
+	}, func(ctx context.Context, input *struct{}) (*TokenListResponse, error) {
+		s.tokensMu.RLock()
+		names := append([]string{}, s.tokenNames...)
+		s.tokensMu.RUnlock()
+		return &TokenListResponse{Body: TokenList{Names: names, Count: len(names)}}, nil
+	})
+
+	huma.Register(s.api, huma.Operation{
+		OperationID: "admin-reload-tokens",
+		Method:      http.MethodPost,
+		Path:        "/admin/v0/tokens/reload",
+		Summary:     "Reload the API tokens from their Secret",
+		Tags:        tags,
+	}, func(ctx context.Context, input *struct{}) (*TokenListResponse, error) {
+		return s.reloadTokens(ctx)
+	})
+}
+
+// reloadTokens re-reads the tokens Secret and replaces the loaded tokens. A
+// missing or empty Secret is rejected and the current tokens are kept, since
+// applying it would lock every client, including the caller, out of the
+// admin API.
+func (s *Server) reloadTokens(ctx context.Context) (*TokenListResponse, error) {
+	tokens, err := s.readTokenSecret(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, huma.Error409Conflict(fmt.Sprintf("Secret %s not found; keeping the loaded tokens", apiTokensSecretName))
+		}
+		return nil, huma.Error500InternalServerError("Failed to read API tokens secret", err)
+	}
+	if len(tokens) == 0 {
+		return nil, huma.Error409Conflict(fmt.Sprintf("Secret %s contains no tokens; keeping the loaded tokens", apiTokensSecretName))
+	}
+
+	names := s.setTokens(tokens)
+	s.logger.Info().Int("count", len(names)).Strs("names", names).Msg("reloaded API tokens from secret")
+	return &TokenListResponse{Body: TokenList{
+		Names:   names,
+		Count:   len(names),
+		Message: "API tokens reloaded",
+	}}, nil
+}
+
+// readTokenSecret reads the tokens Secret and returns its non-empty tokens
+// keyed by name
+func (s *Server) readTokenSecret(ctx context.Context) (map[string]string, error) {
+	secret := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: config.GetNamespace(), Name: apiTokensSecretName}, secret); err != nil {
+		return nil, err
+	}
+	tokens := make(map[string]string, len(secret.Data))
+	for name, tokenBytes := range secret.Data {
+		if token := strings.TrimSpace(string(tokenBytes)); token != "" {
+			tokens[name] = token
+		}
+	}
+	return tokens, nil
+}
+
+// tokenHealthProblem explains why no client can authenticate when auth is
+// enabled but no tokens are loaded and OIDC sign-in is not configured, and
+// returns "" otherwise. /admin/v0/health then rejects every request too, so
+// the public /v0/health reports it as well.
+func (s *Server) tokenHealthProblem() string {
+	if !s.authEnabled || config.IsOIDCConfigured() {
+		return ""
+	}
+	s.tokensMu.RLock()
+	loaded := len(s.allowedTokens)
+	s.tokensMu.RUnlock()
+	if loaded > 0 {
+		return ""
+	}
+	return fmt.Sprintf("auth is enabled but no API tokens are loaded and OIDC is not configured; every /admin/* request is rejected until Secret %s in namespace %s holds at least one token", apiTokensSecretName, config.GetNamespace())
+}
+
+// setTokens replaces the loaded tokens and returns their sorted names
+func (s *Server) setTokens(tokens map[string]string) []string {
+	allowed := make(map[string]bool, len(tokens))
+	names := make([]string, 0, len(tokens))
+	for name, token := range tokens {
+		allowed[token] = true
+		names = append(names, name)
+		s.logger.Debug().Str("name", name).Msg("loaded API token")
+	}
+	sort.Strings(names)
+
+	s.tokensMu.Lock()
+	s.allowedTokens = allowed
+	s.tokenNames = names
+	s.tokensMu.Unlock()
+	return append([]string{}, names...)
+}
@@ -0,0 +1,133 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+func TestServer_TokenReload(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: apiTokensSecretName, Namespace: config.GetNamespace()},
+		Data:       map[string][]byte{"ci": []byte("ci-token"), "alice": []byte(" alice-token\n")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	server := NewServer(c, &mockCache{client: c}, zerolog.Nop())
+	server.loadTokensFromSecret()
+	ctx := context.Background()
+
+	do := func(method, path, token string) (*httptest.ResponseRecorder, TokenList) {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		server.mux.ServeHTTP(rec, req)
+		var list TokenList
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		}
+		return rec, list
+	}
+
+	// Names are listed, values never are
+	rec, list := do(http.MethodGet, "/admin/v0/tokens", "ci-token")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"alice", "ci"}, list.Names)
+	assert.NotContains(t, rec.Body.String(), "ci-token")
+	assert.NotContains(t, rec.Body.String(), "alice-token")
+
+	// Reloading requires an already valid token
+	rec, _ = do(http.MethodPost, "/admin/v0/tokens/reload", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec, _ = do(http.MethodPost, "/admin/v0/tokens/reload", "bob-token")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// Rotate: alice's token is revoked and bob's added
+	secret.Data = map[string][]byte{"ci": []byte("ci-token"), "bob": []byte("bob-token")}
+	require.NoError(t, c.Update(ctx, secret))
+	rec, _ = do(http.MethodGet, "/admin/v0/tokens", "bob-token")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "changes apply only after a reload")
+
+	rec, list = do(http.MethodPost, "/admin/v0/tokens/reload", "alice-token")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"bob", "ci"}, list.Names)
+	assert.Equal(t, 2, list.Count)
+
+	rec, _ = do(http.MethodGet, "/admin/v0/tokens", "bob-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec, _ = do(http.MethodGet, "/admin/v0/tokens", "alice-token")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// An emptied or deleted Secret would lock everyone out, so it is not applied
+	secret.Data = map[string][]byte{"ci": []byte("  ")}
+	require.NoError(t, c.Update(ctx, secret))
+	rec, _ = do(http.MethodPost, "/admin/v0/tokens/reload", "ci-token")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	require.NoError(t, c.Delete(ctx, secret))
+	rec, _ = do(http.MethodPost, "/admin/v0/tokens/reload", "ci-token")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec, list = do(http.MethodGet, "/admin/v0/tokens", "bob-token")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"bob", "ci"}, list.Names)
+}
+
+func TestServer_HealthDegradedWithoutTokens(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_AUTH_ENABLED", "true")
+	t.Setenv("AZURE_AD_TENANT_ID", "")
+	t.Setenv("AZURE_AD_CLIENT_ID", "")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	server := NewServer(c, &mockCache{client: c}, zerolog.Nop())
+	server.loadTokensFromSecret()
+
+	health := func(path, token string) HealthStatus {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		server.mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var status HealthStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		return status
+	}
+
+	// The Secret is missing, so no admin request can authenticate
+	status := health("/v0/health", "")
+	assert.Equal(t, "degraded", status.Status)
+	assert.Contains(t, status.Reason, apiTokensSecretName)
+
+	// OIDC sign-in is an alternative to tokens
+	t.Setenv("AZURE_AD_TENANT_ID", "tenant")
+	t.Setenv("AZURE_AD_CLIENT_ID", "client")
+	assert.Equal(t, "healthy", health("/v0/health", "").Status)
+	t.Setenv("AZURE_AD_CLIENT_ID", "")
+
+	require.NoError(t, c.Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: apiTokensSecretName, Namespace: config.GetNamespace()},
+		Data:       map[string][]byte{"ci": []byte("ci-token")},
+	}))
+	server.loadTokensFromSecret()
+	assert.Equal(t, HealthStatus{Status: "healthy"}, health("/admin/v0/health", "ci-token"))
+	assert.Equal(t, HealthStatus{Status: "healthy"}, health("/v0/health", ""))
+}
@@ -0,0 +1,60 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/agentregistry-dev/agentregistry/internal/tracing"
+)
+
+// tracingMiddleware runs every huma-routed request in a span named after its
+// route, joining the caller's trace when the request carries one
+func (s *Server) tracingMiddleware(ctx huma.Context, next func(huma.Context)) {
+	route := ctx.Operation().Path
+	parent := otel.GetTextMapPropagator().Extract(ctx.Context(), headerCarrier{ctx})
+	spanCtx, span := tracing.Tracer().Start(parent, ctx.Method()+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.request.method", ctx.Method()),
+			attribute.String("http.route", route),
+			attribute.String("url.path", ctx.URL().Path),
+			attribute.String("agentregistry.operation", ctx.Operation().OperationID),
+		),
+	)
+	defer span.End()
+
+	next(huma.WithContext(ctx, spanCtx))
+
+	status := ctx.Status()
+	if status == 0 {
+		status = http.StatusOK
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
+
+// headerCarrier reads trace propagation headers from a huma request
+type headerCarrier struct {
+	ctx huma.Context
+}
+
+func (c headerCarrier) Get(key string) string {
+	return c.ctx.Header(key)
+}
+
+func (c headerCarrier) Set(string, string) {}
+
+func (c headerCarrier) Keys() []string {
+	var keys []string
+	c.ctx.EachHeader(func(name, _ string) {
+		keys = append(keys, name)
+	})
+	return keys
+}
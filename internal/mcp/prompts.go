@@ -81,6 +81,7 @@ func (s *MCPServer) handlePromptSkill(_ context.Context, _ mcp.GetPromptRequest)
    - Add version="latest" to see only the latest version of each entry
    - Add search="keyword" to filter by name
 3. Call get_catalog with type and name for full details (packages, transports, endpoints, tools, etc.)
+4. Call get_catalog_history with type and name to see every version and when it was published
 
 ### Deploy a resource to Kubernetes
 1. Find the resource: list_catalog type="servers" (or "agents") to browse, then get_catalog for details
@@ -97,7 +98,7 @@ func (s *MCPServer) handlePromptSkill(_ context.Context, _ mcp.GetPromptRequest)
 ### Analyze dependencies (AI-powered)
 These tools use MCP sampling to invoke your LLM for analysis. They degrade gracefully to raw data if sampling is unavailable.
 - recommend_servers: describe a use case, get matching server recommendations
-- analyze_agent_dependencies: provide an agent name, get its full dependency tree (MCP servers, models, missing deps)
+- analyze_agent_dependencies: provide an agent name, get its full dependency tree (MCP servers, models, required skills, missing deps)
 - generate_deployment_plan: provide comma-separated resource names, get ordered deployment steps
 
 ### Manage catalog entries
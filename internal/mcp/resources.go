@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -11,6 +12,7 @@ import (
 
 	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
 )
 
 func (s *MCPServer) registerResources() {
@@ -98,7 +100,7 @@ func (s *MCPServer) handleResourceStats(ctx context.Context, request mcp.ReadRes
 
 func (s *MCPServer) handleResourceServers(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	var list agentregistryv1alpha1.MCPServerCatalogList
-	if err := s.cache.List(ctx, &list); err != nil {
+	if err := s.catalog.List(ctx, &list); err != nil {
 		return nil, err
 	}
 	return marshalToResourceContents(request.Params.URI, summarizeServers(list.Items))
@@ -111,7 +113,7 @@ func (s *MCPServer) handleResourceServerByName(ctx context.Context, request mcp.
 	}
 
 	var list agentregistryv1alpha1.MCPServerCatalogList
-	if err := s.cache.List(ctx, &list, client.MatchingFields{
+	if err := s.catalog.List(ctx, &list, client.MatchingFields{
 		controller.IndexMCPServerName:     name,
 		controller.IndexMCPServerIsLatest: "true",
 	}); err != nil {
@@ -125,7 +127,7 @@ func (s *MCPServer) handleResourceServerByName(ctx context.Context, request mcp.
 
 func (s *MCPServer) handleResourceAgents(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	var list agentregistryv1alpha1.AgentCatalogList
-	if err := s.cache.List(ctx, &list); err != nil {
+	if err := s.catalog.List(ctx, &list); err != nil {
 		return nil, err
 	}
 	return marshalToResourceContents(request.Params.URI, summarizeAgents(list.Items))
@@ -138,7 +140,7 @@ func (s *MCPServer) handleResourceAgentByName(ctx context.Context, request mcp.R
 	}
 
 	var list agentregistryv1alpha1.AgentCatalogList
-	if err := s.cache.List(ctx, &list, client.MatchingFields{
+	if err := s.catalog.List(ctx, &list, client.MatchingFields{
 		controller.IndexAgentName:     name,
 		controller.IndexAgentIsLatest: "true",
 	}); err != nil {
@@ -152,7 +154,7 @@ func (s *MCPServer) handleResourceAgentByName(ctx context.Context, request mcp.R
 
 func (s *MCPServer) handleResourceSkills(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	var list agentregistryv1alpha1.SkillCatalogList
-	if err := s.cache.List(ctx, &list); err != nil {
+	if err := s.catalog.List(ctx, &list); err != nil {
 		return nil, err
 	}
 
@@ -181,7 +183,7 @@ func (s *MCPServer) handleResourceSkillByName(ctx context.Context, request mcp.R
 	}
 
 	var list agentregistryv1alpha1.SkillCatalogList
-	if err := s.cache.List(ctx, &list, client.MatchingFields{
+	if err := s.catalog.List(ctx, &list, client.MatchingFields{
 		controller.IndexSkillName:     name,
 		controller.IndexSkillIsLatest: "true",
 	}); err != nil {
@@ -195,7 +197,7 @@ func (s *MCPServer) handleResourceSkillByName(ctx context.Context, request mcp.R
 
 func (s *MCPServer) handleResourceModels(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	var list agentregistryv1alpha1.ModelCatalogList
-	if err := s.cache.List(ctx, &list); err != nil {
+	if err := s.catalog.List(ctx, &list); err != nil {
 		return nil, err
 	}
 	return marshalToResourceContents(request.Params.URI, summarizeModels(list.Items))
@@ -208,7 +210,7 @@ func (s *MCPServer) handleResourceModelByName(ctx context.Context, request mcp.R
 	}
 
 	var list agentregistryv1alpha1.ModelCatalogList
-	if err := s.cache.List(ctx, &list, client.MatchingFields{
+	if err := s.catalog.List(ctx, &list, client.MatchingFields{
 		controller.IndexModelName: name,
 	}); err != nil {
 		return nil, err
@@ -245,6 +247,7 @@ func (s *MCPServer) handleResourceEnvironments(ctx context.Context, request mcp.
 	if err := s.client.List(ctx, &list, client.InNamespace("agentregistry")); err != nil {
 		return nil, err
 	}
+	handlers.SortDiscoveryConfigs(list.Items)
 
 	type envBrief struct {
 		Name      string `json:"name"`
@@ -263,5 +266,6 @@ func (s *MCPServer) handleResourceEnvironments(ctx context.Context, request mcp.
 			})
 		}
 	}
+	sort.SliceStable(envs, func(i, j int) bool { return envs[i].Name < envs[j].Name })
 	return marshalToResourceContents(request.Params.URI, envs)
 }
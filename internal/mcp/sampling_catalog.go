@@ -0,0 +1,41 @@
+package mcp
+
+import "fmt"
+
+// defaultSamplingMaxCatalogEntries caps how many entries of each catalog list
+// the recommend and analysis tools include when
+// AGENTREGISTRY_SAMPLING_MAX_CATALOG_ENTRIES is unset.
+//
+// mcp-go's sampling round-trip returns one complete message, so there is no
+// streaming to spread a long answer over; bounding the catalog injected into
+// the prompt is what keeps a large registry from producing an oversized
+// prompt and response.
+const defaultSamplingMaxCatalogEntries = 200
+
+// WithSamplingMaxCatalogEntries overrides how many entries of each catalog
+// list are included in sampling prompts and analysis results. Non-positive
+// values are ignored.
+func WithSamplingMaxCatalogEntries(n int) ServerOption {
+	return func(s *MCPServer) {
+		if n > 0 {
+			s.maxCatalogEntries = n
+		}
+	}
+}
+
+// catalogLimit returns the configured number of catalog entries per list
+func (s *MCPServer) catalogLimit() int {
+	if s.maxCatalogEntries <= 0 {
+		return defaultSamplingMaxCatalogEntries
+	}
+	return s.maxCatalogEntries
+}
+
+// limitCatalog returns the first limit items and, when some were dropped, a
+// note saying how many of how many kind are shown
+func limitCatalog[T any](items []T, limit int, kind string) ([]T, string) {
+	if limit <= 0 || len(items) <= limit {
+		return items, ""
+	}
+	return items[:limit], fmt.Sprintf("Only the first %d of %d %s are included; the list was truncated.", limit, len(items), kind)
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+func TestLimitCatalog(t *testing.T) {
+	items, note := limitCatalog([]string{"a", "b"}, 2, "servers")
+	assert.Equal(t, []string{"a", "b"}, items)
+	assert.Empty(t, note)
+
+	items, note = limitCatalog([]string{"a", "b", "c"}, 2, "servers")
+	assert.Equal(t, []string{"a", "b"}, items)
+	assert.Equal(t, "Only the first 2 of 3 servers are included; the list was truncated.", note)
+
+	prompt := buildRecommendationPrompt("MCP servers", "files", "[]", note)
+	assert.Contains(t, prompt, "</CATALOG>\n"+note)
+	assert.NotContains(t, buildRecommendationPrompt("MCP servers", "files", "[]", ""), "truncated")
+}
+
+func TestSamplingCatalog_Truncated(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerIsLatest, func(obj client.Object) []string {
+			return []string{fmt.Sprint(obj.(*agentregistryv1alpha1.MCPServerCatalog).Status.IsLatest)}
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.AgentCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentIsLatest, func(obj client.Object) []string {
+			return []string{fmt.Sprint(obj.(*agentregistryv1alpha1.AgentCatalog).Status.IsLatest)}
+		}).
+		WithObjects(&agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "planner-1-0-0", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: "planner", Version: "1.0.0"},
+			Status:     agentregistryv1alpha1.AgentCatalogStatus{IsLatest: true},
+		})
+	for _, name := range []string{"charlie", "alpha", "bravo"} {
+		builder.WithObjects(&agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-1-0-0", Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0"},
+			Status:     agentregistryv1alpha1.MCPServerCatalogStatus{IsLatest: true},
+		})
+	}
+	c := builder.Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false, WithSamplingMaxCatalogEntries(2))
+	ctx := context.Background()
+
+	// Without a session sampling is unavailable and the truncated catalog is
+	// returned as is
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"description": "read local files"}
+	result, err := s.handleRecommendServers(ctx, request)
+	require.NoError(t, err)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Only the first 2 of 3 servers are included")
+	assert.Contains(t, text, `"alpha"`)
+	assert.Contains(t, text, `"bravo"`)
+	assert.NotContains(t, text, `"charlie"`, "entries are kept in name order")
+
+	request.Params.Arguments = map[string]any{"name": "planner"}
+	result, err = s.handleAnalyzeAgentDependencies(ctx, request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var analysis struct {
+		Servers   []serverBrief `json:"servers"`
+		Truncated []string      `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &analysis))
+	assert.Len(t, analysis.Servers, 2)
+	assert.Equal(t, []string{"Only the first 2 of 3 servers are included; the list was truncated."}, analysis.Truncated)
+}
@@ -13,6 +13,10 @@ import (
 // samplingMaxTokens is the per-call MaxTokens cap sent to the client model.
 const samplingMaxTokens = 4096
 
+// defaultSamplingTimeout bounds a sampling round-trip when
+// AGENTREGISTRY_SAMPLING_TIMEOUT is unset.
+const defaultSamplingTimeout = 2 * time.Minute
+
 // samplingGuard throttles the LLM sampling path to bound cost and prevent abuse.
 //
 // It enforces two independent limits:
@@ -105,3 +109,12 @@ func envInt(key string, def int) int {
 	}
 	return def
 }
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return def
+}
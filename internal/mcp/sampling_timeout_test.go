@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+)
+
+// hangingSession is a client session whose sampling callback never returns,
+// even when its context is cancelled.
+type hangingSession struct {
+	release chan struct{}
+}
+
+func (s *hangingSession) Initialize()                                         {}
+func (s *hangingSession) Initialized() bool                                   { return true }
+func (s *hangingSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *hangingSession) SessionID() string                                   { return "hanging" }
+
+func (s *hangingSession) RequestSampling(context.Context, mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	<-s.release
+	return nil, context.Canceled
+}
+
+// readerCache serves cache reads from a client.Reader.
+type readerCache struct {
+	cache.Cache
+	reader client.Reader
+}
+
+func (c *readerCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *readerCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func newSamplingTestServer(t *testing.T, timeout time.Duration) *MCPServer {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "filesystem-1-0-0", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "filesystem", Version: "1.0.0"},
+		Status:     agentregistryv1alpha1.MCPServerCatalogStatus{IsLatest: true},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(server).
+		WithStatusSubresource(server).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerIsLatest, func(obj client.Object) []string {
+			if obj.(*agentregistryv1alpha1.MCPServerCatalog).Status.IsLatest {
+				return []string{"true"}
+			}
+			return []string{"false"}
+		}).
+		Build()
+
+	return NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false, WithSamplingTimeout(timeout))
+}
+
+func TestRecommendServers_SamplingTimeoutFallsBack(t *testing.T) {
+	s := newSamplingTestServer(t, 100*time.Millisecond)
+
+	session := &hangingSession{release: make(chan struct{})}
+	t.Cleanup(func() { close(session.release) })
+	ctx := s.mcpServer.WithContext(context.Background(), session)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"description": "read local files"}
+
+	start := time.Now()
+	result, err := s.handleRecommendServers(ctx, request)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "tool must not hang on an unanswered sampling request")
+
+	require.False(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Sampling unavailable (sampling timed out")
+	assert.Contains(t, text, "filesystem")
+}
+
+func TestSamplingDeadline(t *testing.T) {
+	s := &MCPServer{samplingTimeout: time.Minute}
+	assert.Equal(t, time.Minute, s.samplingDeadline(context.Background()))
+
+	// A sooner tool request deadline shortens the sampling timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.LessOrEqual(t, s.samplingDeadline(ctx), time.Second)
+
+	// A later request deadline does not extend it.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	assert.Equal(t, time.Minute, s.samplingDeadline(ctx))
+
+	assert.Equal(t, defaultSamplingTimeout, (&MCPServer{}).samplingDeadline(context.Background()))
+}
@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/agentregistry-dev/agentregistry/internal/config"
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 )
 
@@ -31,20 +32,43 @@ type MCPServer struct {
 	mcpServer     *server.MCPServer
 	httpServer    *server.StreamableHTTPServer
 	samplingGuard *samplingGuard
+	// samplingTimeout bounds a single sampling round-trip. When it expires the
+	// calling tool falls back to returning raw data.
+	samplingTimeout time.Duration
+	// maxCatalogEntries caps each catalog list injected into sampling prompts
+	// and analysis results
+	maxCatalogEntries int
+	// catalog is the view of cache the catalog read tools and resources use,
+	// hiding entries that are not publicly visible in strict mode or are
+	// restricted to visibility groups the caller is not in
+	catalog cache.Cache
 }
 
 // ServerOption is a functional option for configuring the MCP server
 type ServerOption func(*MCPServer)
 
+// WithSamplingTimeout overrides the maximum time a tool waits for the client to
+// answer a sampling request. Non-positive values are ignored.
+func WithSamplingTimeout(d time.Duration) ServerOption {
+	return func(s *MCPServer) {
+		if d > 0 {
+			s.samplingTimeout = d
+		}
+	}
+}
+
 // NewMCPServer creates a new MCP server with all registry tools, resources, and prompts registered.
 func NewMCPServer(c client.Client, cache cache.Cache, logger zerolog.Logger, authEnabled bool, opts ...ServerOption) *MCPServer {
 	s := &MCPServer{
-		client:        c,
-		cache:         cache,
-		logger:        logger.With().Str("component", "mcp").Logger(),
-		authEnabled:   authEnabled,
-		allowedTokens: make(map[string]bool),
-		samplingGuard: newSamplingGuard(),
+		client:            c,
+		cache:             cache,
+		catalog:           handlers.PublicCache(cache),
+		logger:            logger.With().Str("component", "mcp").Logger(),
+		authEnabled:       authEnabled,
+		allowedTokens:     make(map[string]bool),
+		samplingGuard:     newSamplingGuard(),
+		samplingTimeout:   envDuration("AGENTREGISTRY_SAMPLING_TIMEOUT", defaultSamplingTimeout),
+		maxCatalogEntries: envInt("AGENTREGISTRY_SAMPLING_MAX_CATALOG_ENTRIES", defaultSamplingMaxCatalogEntries),
 	}
 
 	// Apply options
@@ -181,7 +205,8 @@ func (s *MCPServer) authMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		// MCP tokens are admin tokens, which see every catalog entry
+		next.ServeHTTP(w, r.WithContext(handlers.WithCaller(r.Context(), handlers.Caller{Admin: true})))
 	})
 }
 
@@ -196,9 +221,10 @@ func (s *MCPServer) requestSampling(ctx context.Context, systemPrompt string, us
 	// correctly declared sampling during initialize. Checking here would incorrectly reject
 	// those clients.
 	//
-	// Instead we rely on the detached context timeout below as the safety net: if no GET
-	// stream is open to deliver the sampling request, RequestSampling will fail fast or
-	// time out after 5 minutes rather than hanging indefinitely.
+	// Instead we rely on the sampling timeout below as the safety net: if no GET stream
+	// is open to deliver the sampling request, or the client never answers, the call
+	// is abandoned once the timeout expires and the tool degrades to raw data.
+	//
 	// Enforce rate limit + aggregate token budget before issuing the (costly)
 	// sampling round-trip, so a caller cannot drive unbounded model usage.
 	if err := s.samplingGuard.allow(samplingMaxTokens); err != nil {
@@ -215,7 +241,7 @@ func (s *MCPServer) requestSampling(ctx context.Context, systemPrompt string, us
 		Bool("initialized", session.Initialized()).
 		Msg("attempting sampling")
 
-	// Use a detached context with a generous timeout for the sampling call.
+	// Use a detached context for the sampling call.
 	// The tools/call POST connection may be cancelled or time out on the client side
 	// before the sampling round-trip (LLM inference) completes. Using the request ctx
 	// directly would cause the pending sampling request to be cleaned up, resulting in
@@ -223,24 +249,44 @@ func (s *MCPServer) requestSampling(ctx context.Context, systemPrompt string, us
 	//
 	// We detach from the request lifecycle but carry the session value forward —
 	// mcp-go's RequestSampling reads the session from context to call RequestSampling
-	// on the session object, so it must be present.
-	samplingCtx, cancel := context.WithTimeout(s.mcpServer.WithContext(context.Background(), session), 5*time.Minute)
+	// on the session object, so it must be present. The request deadline, if any, is
+	// still honoured through samplingDeadline.
+	timeout := s.samplingDeadline(ctx)
+	samplingCtx, cancel := context.WithTimeout(s.mcpServer.WithContext(context.Background(), session), timeout)
 	defer cancel()
 
-	result, err := s.mcpServer.RequestSampling(samplingCtx, mcp.CreateMessageRequest{
-		CreateMessageParams: mcp.CreateMessageParams{
-			Messages: []mcp.SamplingMessage{
-				{
-					Role:    mcp.RoleUser,
-					Content: mcp.TextContent{Type: "text", Text: userMessage},
+	type samplingOutcome struct {
+		result *mcp.CreateMessageResult
+		err    error
+	}
+	// Run the round-trip in a goroutine so a session that ignores context
+	// cancellation cannot block the tool handler past the timeout.
+	done := make(chan samplingOutcome, 1)
+	go func() {
+		result, err := s.mcpServer.RequestSampling(samplingCtx, mcp.CreateMessageRequest{
+			CreateMessageParams: mcp.CreateMessageParams{
+				Messages: []mcp.SamplingMessage{
+					{
+						Role:    mcp.RoleUser,
+						Content: mcp.TextContent{Type: "text", Text: userMessage},
+					},
 				},
+				SystemPrompt: systemPrompt,
+				MaxTokens:    samplingMaxTokens,
 			},
-			SystemPrompt: systemPrompt,
-			MaxTokens:    samplingMaxTokens,
-		},
-	})
-	if err != nil {
-		return "", err
+		})
+		done <- samplingOutcome{result: result, err: err}
+	}()
+
+	var result *mcp.CreateMessageResult
+	select {
+	case outcome := <-done:
+		if outcome.err != nil {
+			return "", outcome.err
+		}
+		result = outcome.result
+	case <-samplingCtx.Done():
+		return "", fmt.Errorf("sampling timed out after %s", timeout)
 	}
 
 	if textContent, ok := result.Content.(mcp.TextContent); ok {
@@ -249,6 +295,22 @@ func (s *MCPServer) requestSampling(ctx context.Context, systemPrompt string, us
 	return "Sampling returned non-text content", nil
 }
 
+// samplingDeadline returns how long a sampling call may take: the configured
+// sampling timeout, shortened to the tool request's own deadline when that is
+// sooner.
+func (s *MCPServer) samplingDeadline(ctx context.Context) time.Duration {
+	timeout := s.samplingTimeout
+	if timeout <= 0 {
+		timeout = defaultSamplingTimeout
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = max(remaining, 0)
+		}
+	}
+	return timeout
+}
+
 // zerologAdapter bridges mcp-go's util.Logger interface to our zerolog.Logger,
 // so mcp-go errors (e.g. failed sampling delivery) appear in structured logs.
 type zerologAdapter struct {
@@ -0,0 +1,790 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/controller"
+	"github.com/agentregistry-dev/agentregistry/internal/httpapi/handlers"
+)
+
+func TestListCatalog_FilterBySource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	discovered := map[string]string{"agentregistry.dev/discovered": "true"}
+	deployed := map[string]string{agentregistryv1alpha1.LabelResourceSource: agentregistryv1alpha1.ResourceSourceDeployment}
+	meta := func(name string, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "agentregistry", Labels: labels}
+	}
+
+	objs := []client.Object{
+		&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: meta("server-discovered", discovered), Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "server-discovered", Version: "1.0.0"}},
+		&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: meta("server-manual", nil), Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "server-manual", Version: "1.0.0"}},
+		&agentregistryv1alpha1.MCPServerCatalog{ObjectMeta: meta("server-deployed", deployed), Spec: agentregistryv1alpha1.MCPServerCatalogSpec{Name: "server-deployed", Version: "1.0.0"}},
+		&agentregistryv1alpha1.AgentCatalog{ObjectMeta: meta("agent-discovered", discovered), Spec: agentregistryv1alpha1.AgentCatalogSpec{Name: "agent-discovered", Version: "1.0.0"}},
+		&agentregistryv1alpha1.AgentCatalog{ObjectMeta: meta("agent-manual", nil), Spec: agentregistryv1alpha1.AgentCatalogSpec{Name: "agent-manual", Version: "1.0.0"}},
+		&agentregistryv1alpha1.SkillCatalog{ObjectMeta: meta("skill-discovered", discovered), Spec: agentregistryv1alpha1.SkillCatalogSpec{Name: "skill-discovered", Version: "1.0.0"}},
+		&agentregistryv1alpha1.SkillCatalog{ObjectMeta: meta("skill-manual", nil), Spec: agentregistryv1alpha1.SkillCatalogSpec{Name: "skill-manual", Version: "1.0.0"}},
+		&agentregistryv1alpha1.ModelCatalog{ObjectMeta: meta("model-discovered", discovered), Spec: agentregistryv1alpha1.ModelCatalogSpec{Name: "model-discovered"}},
+		&agentregistryv1alpha1.ModelCatalog{ObjectMeta: meta("model-manual", nil), Spec: agentregistryv1alpha1.ModelCatalogSpec{Name: "model-manual"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	listNames := func(catalogType, source string) []string {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"type": catalogType, "source": source}
+		result, err := s.handleListCatalog(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+
+		var entries []struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &entries))
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+
+	for _, catalogType := range []string{"servers", "agents", "skills", "models"} {
+		t.Run(catalogType, func(t *testing.T) {
+			prefix := map[string]string{"servers": "server", "agents": "agent", "skills": "skill", "models": "model"}[catalogType]
+			assert.Equal(t, []string{prefix + "-discovered"}, listNames(catalogType, "discovery"))
+			assert.Equal(t, []string{prefix + "-manual"}, listNames(catalogType, "manual"))
+		})
+	}
+
+	assert.Equal(t, []string{"server-deployed"}, listNames("servers", "deployment"))
+	assert.Len(t, listNames("servers", ""), 3)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"type": "servers", "source": "elsewhere"}
+	result, err := s.handleListCatalog(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestCatalogReadTools_StrictPublicVisibility(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_STRICT_PUBLIC_VISIBILITY", "true")
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	now := metav1.Now()
+	server := func(name string, published bool, annotations map[string]string) client.Object {
+		s := &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry", Annotations: annotations},
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: "1.0.0"},
+		}
+		if published {
+			s.Status = agentregistryv1alpha1.MCPServerCatalogStatus{Published: true, PublishedAt: &now}
+		}
+		return s
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			server("active", true, nil),
+			server("draft", false, nil),
+			server("deprecated", true, map[string]string{agentregistryv1alpha1.AnnotationDeprecated: "true"}),
+			server("deleted", true, map[string]string{agentregistryv1alpha1.AnnotationSoftDeleted: "true"}),
+		).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerNameVersion, controller.MCPServerNameVersionIndex).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+	ctx := context.Background()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"type": "servers"}
+	result, err := s.handleListCatalog(ctx, request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	var entries []struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "active", entries[0].Name)
+
+	for _, name := range []string{"active", "draft", "deprecated", "deleted"} {
+		request.Params.Arguments = map[string]any{"type": "servers", "name": name, "version": "1.0.0"}
+		result, err := s.handleGetCatalog(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, name != "active", result.IsError, name)
+	}
+
+	contents, err := s.handleResourceServers(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: "registry://servers"}})
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.NotContains(t, contents[0].(mcp.TextResourceContents).Text, "draft")
+}
+
+func TestCompareEnvironments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	inEnv := func(env, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: env + "-" + name, Namespace: "agentregistry", Labels: map[string]string{controller.EnvironmentLabel: env}}
+	}
+	server := func(env, name, version string, groups ...string) client.Object {
+		return &agentregistryv1alpha1.MCPServerCatalog{
+			ObjectMeta: inEnv(env, name+"-"+strings.ReplaceAll(version, ".", "-")),
+			Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: name, Version: version, VisibilityGroups: groups},
+		}
+	}
+	agent := func(env, name, version string) client.Object {
+		return &agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: inEnv(env, name),
+			Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: name, Version: version},
+		}
+	}
+	skill := func(env, name, version string) client.Object {
+		return &agentregistryv1alpha1.SkillCatalog{
+			ObjectMeta: inEnv(env, name),
+			Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: name, Version: version},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			// Shared at the same version
+			server("staging", "search", "1.0.0"), server("prod", "search", "1.0.0"),
+			// Shared at different versions; the highest version of each side counts
+			server("staging", "weather", "2.0.0"), server("staging", "weather", "1.5.0"), server("prod", "weather", "1.4.0"),
+			// Only in one environment
+			server("staging", "beta-tool", "0.1.0"), server("prod", "legacy", "3.0.0"),
+			// Hidden from anonymous callers
+			server("prod", "internal", "1.0.0", "platform-team"),
+			agent("staging", "helper", "1.1.0"), agent("prod", "helper", "1.0.0"),
+			skill("prod", "summarize", "1.0.0"),
+		).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	compare := func(from, to string) handlers.EnvironmentComparison {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"from": from, "to": to}
+		result, err := s.handleCompareEnvironments(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var comparison handlers.EnvironmentComparison
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &comparison))
+		return comparison
+	}
+
+	// What is in prod but not staging, and what differs
+	got := compare("staging", "prod")
+	assert.Equal(t, []handlers.CatalogEntryRef{{Name: "legacy", Version: "3.0.0"}}, got.MCPServers.Added, "entries restricted to visibility groups are not reported")
+	assert.Equal(t, []handlers.CatalogEntryRef{{Name: "beta-tool", Version: "0.1.0"}}, got.MCPServers.Removed)
+	assert.Equal(t, []handlers.CatalogVersionChange{{Name: "weather", FromVersion: "2.0.0", ToVersion: "1.4.0"}}, got.MCPServers.VersionChanged)
+	assert.Equal(t, []handlers.CatalogVersionChange{{Name: "helper", FromVersion: "1.1.0", ToVersion: "1.0.0"}}, got.Agents.VersionChanged)
+	assert.Empty(t, got.Agents.Added)
+	assert.Equal(t, []handlers.CatalogEntryRef{{Name: "summarize", Version: "1.0.0"}}, got.Skills.Added)
+
+	// Comparing in the other direction swaps added and removed
+	got = compare("prod", "staging")
+	assert.Equal(t, []handlers.CatalogEntryRef{{Name: "beta-tool", Version: "0.1.0"}}, got.MCPServers.Added)
+	assert.Equal(t, []handlers.CatalogEntryRef{{Name: "legacy", Version: "3.0.0"}}, got.MCPServers.Removed)
+
+	// An environment compared with itself has no drift
+	got = compare("prod", "prod")
+	assert.Empty(t, got.MCPServers.Added)
+	assert.Empty(t, got.MCPServers.Removed)
+	assert.Empty(t, got.MCPServers.VersionChanged)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"from": "staging"}
+	result, err := s.handleCompareEnvironments(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestDeleteCatalog_BlockedByActiveDeployments(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-1-0-0", Namespace: "agentregistry"},
+		Spec:       agentregistryv1alpha1.MCPServerCatalogSpec{Name: "org/search", Version: "1.0.0"},
+	}
+	deployment := &agentregistryv1alpha1.RegistryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "search-deploy", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+			ResourceName: "org/search",
+			Version:      "1.0.0",
+			ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+			Namespace:    "default",
+		},
+		Status: agentregistryv1alpha1.RegistryDeploymentStatus{Phase: agentregistryv1alpha1.DeploymentPhaseRunning},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(server, deployment).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerNameVersion, controller.MCPServerNameVersionIndex).
+		WithIndex(&agentregistryv1alpha1.RegistryDeployment{}, controller.IndexDeploymentResourceName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.RegistryDeployment).Spec.ResourceName}
+		}).
+		Build()
+	// Mutating tools are only served with auth enabled
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), true)
+	ctx := context.Background()
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(s.handleListCatalogDeployments, map[string]any{"type": "servers", "name": "org/search"})
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"name": "search-deploy"`)
+
+	result = call(s.handleDeleteCatalog, map[string]any{"type": "servers", "name": "org/search"})
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "1 active deployment(s): search-deploy (1.0.0, Running)")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(server), &agentregistryv1alpha1.MCPServerCatalog{}))
+
+	result = call(s.handleDeleteCatalog, map[string]any{"type": "servers", "name": "org/search", "force": true})
+	require.False(t, result.IsError)
+	err := c.Get(ctx, client.ObjectKeyFromObject(server), &agentregistryv1alpha1.MCPServerCatalog{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeployCatalogItem_DefaultNamespace(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES", "")
+	t.Setenv("POD_NAMESPACE", "")
+	t.Setenv("AGENTREGISTRY_DEFAULT_DEPLOY_NAMESPACE", "agents")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), true)
+	ctx := context.Background()
+
+	deploy := func(name, namespace string) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{
+			"resourceName": name,
+			"version":      "1.0.0",
+			"resourceType": "agent",
+			"namespace":    namespace,
+		}
+		result, err := s.handleDeployCatalogItem(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := deploy("org/defaulted", "")
+	require.False(t, result.IsError, result.Content[0].(mcp.TextContent).Text)
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "org-defaulted-1-0-0"}, &deployment))
+	assert.Equal(t, "agents", deployment.Spec.Namespace)
+	// Named and labeled like deployments created through the HTTP API
+	assert.Equal(t, handlers.GenerateCRName("org/defaulted", "1.0.0"), deployment.Name)
+	assert.Equal(t, "1-0-0", deployment.Labels["agentregistry.dev/version"])
+
+	result = deploy("org/explicit", "agentregistry")
+	require.False(t, result.IsError, result.Content[0].(mcp.TextContent).Text)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "org-explicit-1-0-0"}, &deployment))
+	assert.Equal(t, "agentregistry", deployment.Spec.Namespace)
+}
+
+func TestDeployCatalogItem_Environment(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&agentregistryv1alpha1.DiscoveryConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+				Environments: []agentregistryv1alpha1.Environment{
+					{Name: "prod", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "prod-gke"}, DeployEnabled: true},
+					{Name: "audit", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "audit-gke"}},
+				},
+			},
+		}).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), true)
+	ctx := context.Background()
+
+	deploy := func(name, environment string) (string, bool) {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{
+			"resourceName": name,
+			"version":      "1.0.0",
+			"resourceType": "agent",
+			"environment":  environment,
+		}
+		result, err := s.handleDeployCatalogItem(ctx, request)
+		require.NoError(t, err)
+		return result.Content[0].(mcp.TextContent).Text, result.IsError
+	}
+
+	text, isError := deploy("org/missing", "staging")
+	assert.True(t, isError)
+	assert.Contains(t, text, `"staging" is not declared`)
+
+	text, isError = deploy("org/audited", "audit")
+	assert.True(t, isError)
+	assert.Contains(t, text, "deployEnabled false")
+
+	var list agentregistryv1alpha1.RegistryDeploymentList
+	require.NoError(t, c.List(ctx, &list))
+	assert.Empty(t, list.Items)
+
+	text, isError = deploy("org/prod", "prod")
+	require.False(t, isError, text)
+	var deployment agentregistryv1alpha1.RegistryDeployment
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "agentregistry", Name: "org-prod-1-0-0"}, &deployment))
+	assert.Equal(t, "prod", deployment.Spec.Environment)
+	assert.Empty(t, deployment.Spec.Namespace, "the namespace is derived from the environment")
+}
+
+func TestDeployCatalogItem_ConfigValidation(t *testing.T) {
+	t.Setenv("AGENTREGISTRY_ALLOWED_DEPLOY_NAMESPACES", "")
+	t.Setenv("POD_NAMESPACE", "")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	server := &agentregistryv1alpha1.MCPServerCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "org-github-1-0-0", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.MCPServerCatalogSpec{
+			Name:    "org/github",
+			Version: "1.0.0",
+			Packages: []agentregistryv1alpha1.Package{{
+				RegistryType: "oci",
+				Identifier:   "ghcr.io/org/github:1.0.0",
+				Transport:    agentregistryv1alpha1.Transport{Type: "stdio"},
+				EnvironmentVariables: []agentregistryv1alpha1.KeyValueInput{
+					{Name: "GITHUB_TOKEN", Required: true},
+				},
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(server).
+		WithIndex(&agentregistryv1alpha1.MCPServerCatalog{}, controller.IndexMCPServerName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.MCPServerCatalog).Spec.Name}
+		}).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), true)
+	ctx := context.Background()
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (string, bool) {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result.Content[0].(mcp.TextContent).Text, result.IsError
+	}
+	deployArgs := func(strict bool) map[string]any {
+		return map[string]any{
+			"resourceName": "org/github",
+			"version":      "1.0.0",
+			"resourceType": "mcp",
+			"config":       map[string]any{"GITHUB_TOKNE": "token"},
+			"strict":       strict,
+		}
+	}
+
+	text, isError := call(s.handleDeployCatalogItem, deployArgs(true))
+	assert.True(t, isError)
+	assert.Contains(t, text, "unknown config keys are ignored: GITHUB_TOKNE")
+	assert.Contains(t, text, "required config keys are missing: GITHUB_TOKEN")
+
+	text, isError = call(s.handleDeployCatalogItem, deployArgs(false))
+	require.False(t, isError, text)
+	assert.Contains(t, text, "Warning: unknown config keys are ignored: GITHUB_TOKNE")
+	assert.Contains(t, text, "Warning: required config keys are missing: GITHUB_TOKEN")
+
+	text, isError = call(s.handleUpdateDeploymentConfig, map[string]any{
+		"name":   "org-github-1-0-0",
+		"config": map[string]any{"GITHUB_TOKEN": "token"},
+	})
+	require.False(t, isError, text)
+	assert.Contains(t, text, "Warning: unknown config keys are ignored: GITHUB_TOKNE")
+	assert.NotContains(t, text, "missing")
+}
+
+func TestCreateCatalog_ModelValidation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), true)
+	ctx := context.Background()
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := s.handleCreateCatalog(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+	model := func(provider, baseURL string) map[string]any {
+		return map[string]any{
+			"type": "models", "name": "claude", "version": "1.0.0",
+			"provider": provider, "model": "claude-3-opus", "base_url": baseURL,
+		}
+	}
+
+	result := call(model("Antropic", ""))
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "accepted: OpenAI, Anthropic")
+
+	result = call(model("anthropic", "not a url"))
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "must use http or https")
+
+	result = call(model("anthropic", "https://api.anthropic.com"))
+	require.False(t, result.IsError)
+
+	var list agentregistryv1alpha1.ModelCatalogList
+	require.NoError(t, c.List(ctx, &list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "https://api.anthropic.com", list.Items[0].Spec.BaseURL)
+}
+
+func TestListCatalog_SearchRanking(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	agent := func(name, description string) client.Object {
+		return &agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.AgentCatalogSpec{Name: name, Version: "1.0.0", Description: description},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		agent("triage-bot", "Triages GitHub issues"),
+		agent("my-github-helper", ""),
+		agent("github", ""),
+		agent("github-reviewer", ""),
+	).Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"type": "agents", "search": "github"}
+	result, err := s.handleListCatalog(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var entries []struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &entries))
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"github", "github-reviewer", "my-github-helper", "triage-bot"}, names)
+
+	// searchFields restricts the match to the listed fields
+	request.Params.Arguments = map[string]any{"type": "agents", "search": "github", "searchFields": "description"}
+	result, err = s.handleListCatalog(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "triage-bot", entries[0].Name)
+
+	request.Params.Arguments = map[string]any{"type": "agents", "search": "github", "searchFields": "tags"}
+	result, err = s.handleListCatalog(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestListCatalog_SkillVersion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	skill := func(name, version string, isLatest bool) client.Object {
+		return &agentregistryv1alpha1.SkillCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-" + strings.ReplaceAll(version, ".", "-"), Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: name, Version: version},
+			Status:     agentregistryv1alpha1.SkillCatalogStatus{IsLatest: isLatest},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			skill("summarize", "1.0.0", false),
+			skill("summarize", "1.2.0", true),
+			skill("translate", "1.0.0", true),
+		).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, controller.IndexSkillIsLatest, func(obj client.Object) []string {
+			if obj.(*agentregistryv1alpha1.SkillCatalog).Status.IsLatest {
+				return []string{"true"}
+			}
+			return []string{"false"}
+		}).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	list := func(version string) []string {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"type": "skills", "version": version}
+		result, err := s.handleListCatalog(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+
+		var entries []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &entries))
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Name+"@"+e.Version)
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{"summarize@1.2.0", "translate@1.0.0"}, list("latest"))
+	assert.ElementsMatch(t, []string{"summarize@1.0.0", "translate@1.0.0"}, list("1.0.0"))
+	assert.Len(t, list(""), 3)
+}
+
+func TestGetCatalogHistory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+
+	published := func(day int) *metav1.Time {
+		ts := metav1.NewTime(time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC))
+		return &ts
+	}
+	skill := func(version string, status agentregistryv1alpha1.SkillCatalogStatus) client.Object {
+		return &agentregistryv1alpha1.SkillCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "summarize-" + strings.ReplaceAll(version, ".", "-"), Namespace: "agentregistry"},
+			Spec:       agentregistryv1alpha1.SkillCatalogSpec{Name: "summarize", Version: version},
+			Status:     status,
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			skill("1.2.0", agentregistryv1alpha1.SkillCatalogStatus{Published: true, PublishedAt: published(3), IsLatest: true, Status: agentregistryv1alpha1.CatalogStatusActive}),
+			skill("1.0.0", agentregistryv1alpha1.SkillCatalogStatus{Published: true, PublishedAt: published(1), Status: agentregistryv1alpha1.CatalogStatusDeprecated}),
+			skill("1.10.0-rc.1", agentregistryv1alpha1.SkillCatalogStatus{}),
+		).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, controller.IndexSkillName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.SkillCatalog).Spec.Name}
+		}).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := s.handleGetCatalogHistory(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"type": "skills", "name": "summarize"})
+	require.False(t, result.IsError, result.Content)
+	var history []catalogVersion
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &history))
+	require.Len(t, history, 3)
+
+	assert.Equal(t, []string{"1.10.0-rc.1", "1.2.0", "1.0.0"}, []string{history[0].Version, history[1].Version, history[2].Version})
+	assert.False(t, history[0].Published)
+	assert.Nil(t, history[0].PublishedAt)
+	assert.True(t, history[1].IsLatest)
+	assert.True(t, history[1].PublishedAt.Equal(published(3)))
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusActive, history[1].Status)
+	assert.False(t, history[2].IsLatest)
+	assert.Equal(t, agentregistryv1alpha1.CatalogStatusDeprecated, history[2].Status)
+
+	assert.True(t, call(map[string]any{"type": "skills", "name": "missing"}).IsError)
+	assert.True(t, call(map[string]any{"type": "widgets", "name": "summarize"}).IsError)
+}
+
+func TestGetDiscoveryMap_StableOrdering(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	dc := &agentregistryv1alpha1.DiscoveryConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "agentregistry"},
+		Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+			Environments: []agentregistryv1alpha1.Environment{{Name: "staging"}, {Name: "prod"}, {Name: "dev"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dc).Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	result, err := s.handleGetDiscoveryMap(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var configs []struct {
+		Environments []struct {
+			Name string `json:"name"`
+		} `json:"environments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &configs))
+	require.Len(t, configs, 1)
+	var names []string
+	for _, env := range configs[0].Environments {
+		names = append(names, env.Name)
+	}
+	assert.Equal(t, []string{"dev", "prod", "staging"}, names)
+}
+
+func TestValidateCatalog(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&agentregistryv1alpha1.AgentCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "org-researcher-1-0-0", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.AgentCatalogSpec{
+				Name:           "org/researcher",
+				Version:        "1.0.0",
+				Image:          "ghcr.io/org/researcher:1.0.0",
+				RequiredSkills: []string{"summarize"},
+			},
+		}).
+		WithIndex(&agentregistryv1alpha1.AgentCatalog{}, controller.IndexAgentNameVersion, controller.AgentNameVersionIndex).
+		WithIndex(&agentregistryv1alpha1.SkillCatalog{}, controller.IndexSkillName, func(obj client.Object) []string {
+			return []string{obj.(*agentregistryv1alpha1.SkillCatalog).Spec.Name}
+		}).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := s.handleValidateCatalog(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"type": "agents", "name": "org/researcher", "version": "1.0.0"})
+	require.False(t, result.IsError, result.Content)
+	var validation catalogValidation
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &validation))
+	assert.Equal(t, "1.0.0", validation.Version)
+	assert.False(t, validation.Deployable)
+	failed := map[string]string{}
+	for _, finding := range validation.Findings {
+		if !finding.Passed {
+			failed[finding.Check] = finding.Message
+		}
+	}
+	assert.Equal(t, map[string]string{
+		controller.CheckPublisher: "missing publisher metadata: both org_is_verified and publisher_identity_verified_by_jwt are required",
+		controller.CheckSkill:     "skill summarize is not in the catalog",
+	}, failed)
+
+	assert.True(t, call(map[string]any{"type": "agents", "name": "org/researcher", "version": "2.0.0"}).IsError)
+	assert.True(t, call(map[string]any{"type": "skills", "name": "summarize"}).IsError)
+}
+
+func TestTestEnvironmentConnection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&agentregistryv1alpha1.DiscoveryConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.DiscoveryConfigSpec{
+				Environments: []agentregistryv1alpha1.Environment{
+					{Name: "dev", Cluster: agentregistryv1alpha1.ClusterConfig{Name: "dev-kind"}, Namespaces: []string{"tools"}},
+				},
+			},
+		}).
+		Build()
+
+	oldFactory := controller.RemoteClientFactory
+	controller.RemoteClientFactory = func(*agentregistryv1alpha1.Environment, *runtime.Scheme) (client.WithWatch, error) {
+		return nil, errors.New("connection refused")
+	}
+	t.Cleanup(func() { controller.RemoteClientFactory = oldFactory })
+
+	call := func(s *MCPServer, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := s.handleTestEnvironmentConnection(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), true)
+
+	result := call(s, map[string]any{"configName": "discovery", "environment": "dev"})
+	require.False(t, result.IsError, result.Content)
+	var connection controller.EnvironmentConnection
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &connection))
+	assert.False(t, connection.Connected)
+	assert.Equal(t, "connection refused", connection.Error)
+	assert.Equal(t, "dev-kind", connection.Cluster)
+
+	assert.True(t, call(s, map[string]any{"configName": "discovery", "environment": "prod"}).IsError)
+	assert.True(t, call(s, map[string]any{"configName": "missing", "environment": "dev"}).IsError)
+
+	unauthenticated := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+	assert.True(t, call(unauthenticated, map[string]any{"configName": "discovery", "environment": "dev"}).IsError)
+}
+
+func TestGetDeployment_Namespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, agentregistryv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&agentregistryv1alpha1.RegistryDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "org-multi-1-0-0", Namespace: "agentregistry"},
+			Spec: agentregistryv1alpha1.RegistryDeploymentSpec{
+				ResourceName: "org/multi",
+				Version:      "1.0.0",
+				ResourceType: agentregistryv1alpha1.ResourceTypeMCP,
+				Namespaces:   []string{"team-a", "team-b"},
+			},
+		}).
+		Build()
+	s := NewMCPServer(c, &readerCache{reader: c}, zerolog.Nop(), false)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"name": "org-multi-1-0-0"}
+	result, err := s.handleGetDeployment(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	var detail struct {
+		Namespace  string   `json:"namespace"`
+		Namespaces []string `json:"namespaces"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &detail))
+	assert.Empty(t, detail.Namespace)
+	assert.Equal(t, []string{"team-a", "team-b"}, detail.Namespaces)
+}
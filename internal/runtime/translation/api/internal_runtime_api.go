@@ -4,12 +4,27 @@ import (
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // DesiredState represents the desired set of MCPServevrs the user wishes to run locally
 type DesiredState struct {
 	MCPServers []*MCPServer `json:"mcpServers"`
 	Agents     []*Agent     `json:"agents"`
+	HelmCharts []*HelmChart `json:"helmCharts,omitempty"`
+}
+
+// HelmChart represents a Helm chart release to install
+type HelmChart struct {
+	// Name is the release name
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Repository is the chart repository URL (https:// or oci://)
+	Repository string `json:"repository"`
+	Chart      string `json:"chart"`
+	Version    string `json:"version,omitempty"`
+	// Values are the chart values to install the release with
+	Values map[string]any `json:"values,omitempty"`
 }
 
 // Agent represents a single Agent configuration
@@ -60,6 +75,8 @@ type RemoteMCPServer struct {
 	Port    uint32
 	Path    string
 	Headers []HeaderValue
+	// TransportType is TransportTypeHTTP or TransportTypeSSE; empty means HTTP
+	TransportType TransportType
 }
 
 type HeaderValue struct {
@@ -73,7 +90,7 @@ type LocalMCPServer struct {
 	Deployment MCPServerDeployment `json:"deployment"`
 	// TransportType defines the type of mcp server being run
 	TransportType TransportType `json:"transportType"`
-	// HTTP defines the configuration for an HTTP transport.(only for TransportTypeHTTP)
+	// HTTP defines the configuration for an HTTP transport.(only for TransportTypeHTTP and TransportTypeSSE)
 	HTTP *HTTPTransport `json:"http,omitempty"`
 }
 
@@ -92,6 +109,9 @@ const (
 
 	// TransportTypeHTTP indicates that the MCP server uses Streamable HTTP for communication.
 	TransportTypeHTTP TransportType = "http"
+
+	// TransportTypeSSE indicates that the MCP server uses the legacy HTTP+SSE transport for communication.
+	TransportTypeSSE TransportType = "sse"
 )
 
 // MCPServerDeployment
@@ -107,12 +127,28 @@ type MCPServerDeployment struct {
 
 	// Env defines the environment variables to set in the container.
 	Env map[string]string `json:"env,omitempty"`
+
+	// SecretEnv defines the environment variables whose values are read from
+	// Secrets in the target namespace rather than set inline.
+	SecretEnv []SecretEnvVar `json:"secretEnv,omitempty"`
+
+	// ImagePullSecrets names the Secrets used to pull Image.
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+}
+
+// SecretEnvVar is an environment variable taken from a key of a Secret
+type SecretEnvVar struct {
+	Name       string `json:"name"`
+	SecretName string `json:"secretName"`
+	Key        string `json:"key"`
 }
 
 type AgentDeployment struct {
 	Image string            `json:"image,omitempty"`
 	Env   map[string]string `json:"env,omitempty"`
 	Port  uint16            `json:"port,omitempty"`
+	// ImagePullSecrets names the Secrets used to pull Image
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 }
 
 type AIRuntimeConfig struct {
@@ -124,4 +160,11 @@ type KubernetesRuntimeConfig struct {
 	RemoteMCPServers []*v1alpha2.RemoteMCPServer `json:"remoteMCPServers"`
 	MCPServers       []*kmcpv1alpha1.MCPServer   `json:"mcpServers"`
 	ConfigMaps       []*corev1.ConfigMap         `json:"configMaps,omitempty"`
+	// ServiceAccounts carry the image pull secrets of MCPServers, whose pods
+	// kmcp runs under a ServiceAccount named after the server
+	ServiceAccounts []*corev1.ServiceAccount `json:"serviceAccounts,omitempty"`
+	// HelmRepositories and HelmReleases are Flux source and helm-controller
+	// resources, kept unstructured so the Flux API types are not a dependency
+	HelmRepositories []*unstructured.Unstructured `json:"helmRepositories,omitempty"`
+	HelmReleases     []*unstructured.Unstructured `json:"helmReleases,omitempty"`
 }
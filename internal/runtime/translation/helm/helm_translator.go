@@ -0,0 +1,150 @@
+// Package helm translates Helm chart releases into Flux resources. Charts are
+// not rendered in-process: each release becomes a HelmRepository source and a
+// HelmRelease that the Flux helm-controller in the target cluster installs,
+// upgrades and uninstalls.
+package helm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+)
+
+const (
+	// DefaultNamespace is used for releases that do not set a namespace
+	DefaultNamespace = "default"
+
+	// reconcileInterval is how often Flux re-checks the source and release
+	reconcileInterval = "10m"
+)
+
+var (
+	// HelmRepositoryGVK is the Flux source API HelmRepository kind
+	HelmRepositoryGVK = schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "HelmRepository"}
+	// HelmReleaseGVK is the Flux helm-controller HelmRelease kind
+	HelmReleaseGVK = schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+)
+
+type translator struct {
+	defaultNamespace string
+}
+
+// NewTranslator returns a runtime translator that renders Helm charts as Flux
+// HelmRepository and HelmRelease resources.
+func NewTranslator() api.RuntimeTranslator {
+	return &translator{defaultNamespace: DefaultNamespace}
+}
+
+// TranslateRuntimeConfig translates the desired Helm charts into Flux resources.
+// MCP servers and agents are not supported by this runtime.
+func (t *translator) TranslateRuntimeConfig(
+	ctx context.Context,
+	desired *api.DesiredState,
+) (*api.AIRuntimeConfig, error) {
+	if len(desired.MCPServers) > 0 || len(desired.Agents) > 0 {
+		return nil, fmt.Errorf("helm runtime only supports Helm charts")
+	}
+
+	repositories := make([]*unstructured.Unstructured, 0, len(desired.HelmCharts))
+	releases := make([]*unstructured.Unstructured, 0, len(desired.HelmCharts))
+	for _, chart := range desired.HelmCharts {
+		repo, release, err := t.translateChart(chart)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repo)
+		releases = append(releases, release)
+	}
+
+	return &api.AIRuntimeConfig{
+		Kubernetes: &api.KubernetesRuntimeConfig{
+			HelmRepositories: repositories,
+			HelmReleases:     releases,
+		},
+	}, nil
+}
+
+// translateChart renders the HelmRepository and HelmRelease for a chart. Both
+// share the release name; the repository lives next to the release.
+func (t *translator) translateChart(chart *api.HelmChart) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	if chart.Name == "" {
+		return nil, nil, fmt.Errorf("release name must be specified for Helm chart %s", chart.Chart)
+	}
+	if chart.Repository == "" || chart.Chart == "" {
+		return nil, nil, fmt.Errorf("chart repository and name must be specified for Helm release %s", chart.Name)
+	}
+
+	namespace := t.defaultNamespace
+	if chart.Namespace != "" {
+		namespace = chart.Namespace
+	}
+
+	repoSpec := map[string]any{
+		"url":      chart.Repository,
+		"interval": reconcileInterval,
+	}
+	if strings.HasPrefix(chart.Repository, "oci://") {
+		repoSpec["type"] = "oci"
+	}
+	repo := newObject(HelmRepositoryGVK, chart.Name, namespace)
+	repo.Object["spec"] = repoSpec
+
+	chartSpec := map[string]any{
+		"chart": chart.Chart,
+		"sourceRef": map[string]any{
+			"kind":      HelmRepositoryGVK.Kind,
+			"name":      chart.Name,
+			"namespace": namespace,
+		},
+	}
+	if chart.Version != "" {
+		chartSpec["version"] = chart.Version
+	}
+	releaseSpec := map[string]any{
+		"interval":    reconcileInterval,
+		"releaseName": chart.Name,
+		"chart":       map[string]any{"spec": chartSpec},
+	}
+	if len(chart.Values) > 0 {
+		releaseSpec["values"] = chart.Values
+	}
+	release := newObject(HelmReleaseGVK, chart.Name, namespace)
+	release.Object["spec"] = releaseSpec
+
+	return repo, release, nil
+}
+
+func newObject(gvk schema.GroupVersionKind, name, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetLabels(map[string]string{"aregistry.ai/managed": "true"})
+	return obj
+}
+
+// ParseChartReference splits a chart reference of the form <repository>/<chart>,
+// e.g. "https://charts.example.com/my-server" or "oci://ghcr.io/org/charts/my-server",
+// into the repository URL and chart name.
+func ParseChartReference(ref string) (repository, chart string, err error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid chart reference %q: %w", ref, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" && u.Scheme != "oci" {
+		return "", "", fmt.Errorf("invalid chart reference %q: scheme must be https, http or oci", ref)
+	}
+
+	idx := strings.LastIndex(ref, "/")
+	repository, chart = ref[:idx], ref[idx+1:]
+	if chart == "" || u.Host == "" || len(repository) <= len(u.Scheme+"://") {
+		return "", "", fmt.Errorf("invalid chart reference %q: expected <repository>/<chart>", ref)
+	}
+	return repository, chart, nil
+}
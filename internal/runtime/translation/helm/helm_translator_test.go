@@ -0,0 +1,101 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+)
+
+func TestTranslateRuntimeConfig_HelmChart(t *testing.T) {
+	translator := NewTranslator()
+
+	desired := &api.DesiredState{
+		HelmCharts: []*api.HelmChart{{
+			Name:       "my-server",
+			Namespace:  "mcp",
+			Repository: "oci://ghcr.io/org/charts",
+			Chart:      "my-server",
+			Version:    "1.2.3",
+			Values:     map[string]any{"replicaCount": "2"},
+		}},
+	}
+
+	config, err := translator.TranslateRuntimeConfig(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+	if len(config.Kubernetes.HelmRepositories) != 1 || len(config.Kubernetes.HelmReleases) != 1 {
+		t.Fatalf("Expected 1 HelmRepository and 1 HelmRelease, got %d and %d",
+			len(config.Kubernetes.HelmRepositories), len(config.Kubernetes.HelmReleases))
+	}
+
+	repo := config.Kubernetes.HelmRepositories[0]
+	if repo.GroupVersionKind() != HelmRepositoryGVK {
+		t.Errorf("Expected %s, got %s", HelmRepositoryGVK, repo.GroupVersionKind())
+	}
+	if repo.GetNamespace() != "mcp" {
+		t.Errorf("Expected namespace mcp, got %s", repo.GetNamespace())
+	}
+	if repoType, _, _ := unstructured.NestedString(repo.Object, "spec", "type"); repoType != "oci" {
+		t.Errorf("Expected oci repository type, got %q", repoType)
+	}
+
+	release := config.Kubernetes.HelmReleases[0]
+	if release.GroupVersionKind() != HelmReleaseGVK {
+		t.Errorf("Expected %s, got %s", HelmReleaseGVK, release.GroupVersionKind())
+	}
+	if version, _, _ := unstructured.NestedString(release.Object, "spec", "chart", "spec", "version"); version != "1.2.3" {
+		t.Errorf("Expected chart version 1.2.3, got %q", version)
+	}
+	if source, _, _ := unstructured.NestedString(release.Object, "spec", "chart", "spec", "sourceRef", "name"); source != repo.GetName() {
+		t.Errorf("Expected sourceRef %s, got %q", repo.GetName(), source)
+	}
+	if replicas, _, _ := unstructured.NestedString(release.Object, "spec", "values", "replicaCount"); replicas != "2" {
+		t.Errorf("Expected value replicaCount=2, got %q", replicas)
+	}
+}
+
+func TestTranslateRuntimeConfig_RejectsMCPServers(t *testing.T) {
+	_, err := NewTranslator().TranslateRuntimeConfig(context.Background(), &api.DesiredState{
+		MCPServers: []*api.MCPServer{{Name: "server"}},
+	})
+	if err == nil {
+		t.Fatal("Expected error for MCP servers in helm runtime")
+	}
+}
+
+func TestParseChartReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		wantRepo string
+		wantName string
+		wantErr  bool
+	}{
+		{ref: "https://charts.example.com/my-server", wantRepo: "https://charts.example.com", wantName: "my-server"},
+		{ref: "oci://ghcr.io/org/charts/my-server", wantRepo: "oci://ghcr.io/org/charts", wantName: "my-server"},
+		{ref: "my-server", wantErr: true},
+		{ref: "https://charts.example.com/", wantErr: true},
+		{ref: "ftp://charts.example.com/my-server", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			repo, name, err := ParseChartReference(tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got repo=%q chart=%q", repo, name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseChartReference failed: %v", err)
+			}
+			if repo != tt.wantRepo || name != tt.wantName {
+				t.Errorf("Expected %q/%q, got %q/%q", tt.wantRepo, tt.wantName, repo, name)
+			}
+		})
+	}
+}
@@ -53,6 +53,7 @@ func (t *translator) TranslateRuntimeConfig(
 
 	remoteMCPs := make([]*v1alpha2.RemoteMCPServer, 0)
 	mcpServers := make([]*kmcpv1alpha1.MCPServer, 0)
+	serviceAccounts := make([]*corev1.ServiceAccount, 0)
 	for _, server := range desired.MCPServers {
 		switch server.MCPServerType {
 		case api.MCPServerTypeRemote:
@@ -73,6 +74,9 @@ func (t *translator) TranslateRuntimeConfig(
 				return nil, err
 			}
 			mcpServers = append(mcpServers, resource)
+			if len(server.Local.Deployment.ImagePullSecrets) > 0 {
+				serviceAccounts = append(serviceAccounts, translateMCPServerServiceAccount(resource, server.Local.Deployment.ImagePullSecrets))
+			}
 		}
 	}
 
@@ -82,6 +86,7 @@ func (t *translator) TranslateRuntimeConfig(
 			RemoteMCPServers: remoteMCPs,
 			MCPServers:       mcpServers,
 			ConfigMaps:       configMaps,
+			ServiceAccounts:  serviceAccounts,
 		},
 	}, nil
 }
@@ -116,6 +121,9 @@ func (t *translator) translateAgent(agent *api.Agent) (*v1alpha2.Agent, error) {
 	sharedSpec := v1alpha2.SharedDeploymentSpec{
 		Env: envVars,
 	}
+	for _, secret := range agent.Deployment.ImagePullSecrets {
+		sharedSpec.ImagePullSecrets = append(sharedSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
 
 	// If agent has resolved MCP servers, add ConfigMap volume mount
 	if len(agent.ResolvedMCPServers) > 0 {
@@ -177,6 +185,10 @@ func (t *translator) translateRemoteMCPServer(server *api.MCPServer) (*v1alpha2.
 	}
 
 	url := buildRemoteMCPURL(server.Remote.Host, server.Remote.Port, server.Remote.Path)
+	protocol := v1alpha2.RemoteMCPServerProtocolStreamableHttp
+	if server.Remote.TransportType == api.TransportTypeSSE {
+		protocol = v1alpha2.RemoteMCPServerProtocolSse
+	}
 	namespace := t.defaultNamespace
 	// Use namespace from MCPServer if set (propagated from agent's deployment config)
 	if server.Namespace != "" {
@@ -197,7 +209,7 @@ func (t *translator) translateRemoteMCPServer(server *api.MCPServer) (*v1alpha2.
 		},
 		Spec: v1alpha2.RemoteMCPServerSpec{
 			Description: server.Name,
-			Protocol:    v1alpha2.RemoteMCPServerProtocolStreamableHttp,
+			Protocol:    protocol,
 			URL:         url,
 		},
 	}, nil
@@ -208,7 +220,7 @@ func (t *translator) translateLocalMCPServer(server *api.MCPServer) (*kmcpv1alph
 	if server.Local == nil {
 		return nil, fmt.Errorf("local MCP server config missing for %s", server.Name)
 	}
-	if server.Local.TransportType == api.TransportTypeHTTP && server.Local.HTTP == nil {
+	if (server.Local.TransportType == api.TransportTypeHTTP || server.Local.TransportType == api.TransportTypeSSE) && server.Local.HTTP == nil {
 		return nil, fmt.Errorf("HTTP transport config missing for %s", server.Name)
 	}
 
@@ -228,6 +240,11 @@ func (t *translator) translateLocalMCPServer(server *api.MCPServer) (*kmcpv1alph
 		Args:  server.Local.Deployment.Args,
 		Env:   server.Local.Deployment.Env,
 	}
+	secretRefs, err := mcpServerSecretRefs(server.Local.Deployment.SecretEnv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret environment for %s: %w", server.Name, err)
+	}
+	deployment.SecretRefs = secretRefs
 	fmt.Printf("[DEBUG] kagent translateLocalMCPServer: name=%s, image=%s, cmd=%q, args=%v\n",
 		server.Name, deployment.Image, deployment.Cmd, deployment.Args)
 
@@ -236,7 +253,9 @@ func (t *translator) translateLocalMCPServer(server *api.MCPServer) (*kmcpv1alph
 	}
 
 	switch server.Local.TransportType {
-	case api.TransportTypeHTTP:
+	case api.TransportTypeHTTP, api.TransportTypeSSE:
+		// KMCP has no separate SSE transport: SSE servers are exposed through
+		// the HTTP transport, with the target path pointing at the SSE endpoint
 		spec.TransportType = kmcpv1alpha1.TransportType("http")
 		spec.HTTPTransport = &kmcpv1alpha1.HTTPTransport{
 			TargetPort: server.Local.HTTP.Port,
@@ -268,6 +287,30 @@ func (t *translator) translateLocalMCPServer(server *api.MCPServer) (*kmcpv1alph
 	}, nil
 }
 
+// translateMCPServerServiceAccount renders the ServiceAccount kmcp runs the
+// MCPServer's pods under, which is named after the server, with the image
+// pull secrets its image needs. kmcp's MCPServer has no pull secrets field, so
+// they are set on the ServiceAccount and added to the pods at admission.
+func translateMCPServerServiceAccount(server *kmcpv1alpha1.MCPServer, pullSecrets []string) *corev1.ServiceAccount {
+	sa := &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      server.Name,
+			Namespace: server.Namespace,
+			Labels: map[string]string{
+				"aregistry.ai/managed": "true",
+			},
+		},
+	}
+	for _, secret := range pullSecrets {
+		sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	return sa
+}
+
 // translateAgentConfigMap creates a ConfigMap containing the mcp-servers.json for an agent
 // This file is mounted into the agent's pod at /config/mcp-servers.json
 // The BYO agent then reads this file and connects to the MCP servers
@@ -366,3 +409,22 @@ func sanitizeK8sName(value string) string {
 	}
 	return result
 }
+
+// mcpServerSecretRefs maps secret environment variables onto the Secrets a
+// KMCP MCPServer loads with envFrom. KMCP exposes every key of a referenced
+// Secret as a variable of the same name, so a key cannot be renamed.
+func mcpServerSecretRefs(secretEnv []api.SecretEnvVar) ([]corev1.LocalObjectReference, error) {
+	var refs []corev1.LocalObjectReference
+	seen := make(map[string]bool)
+	for _, v := range secretEnv {
+		if v.Key != v.Name {
+			return nil, fmt.Errorf("environment variable %s cannot read key %q of Secret %s: the key must match the variable name", v.Name, v.Key, v.SecretName)
+		}
+		if seen[v.SecretName] {
+			continue
+		}
+		seen[v.SecretName] = true
+		refs = append(refs, corev1.LocalObjectReference{Name: v.SecretName})
+	}
+	return refs, nil
+}
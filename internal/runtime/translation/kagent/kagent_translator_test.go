@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/agentregistry-dev/agentregistry/internal/runtime/translation/api"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 )
 
 func TestTranslateRuntimeConfig_AgentOnly(t *testing.T) {
@@ -55,6 +56,29 @@ func TestTranslateRuntimeConfig_AgentOnly(t *testing.T) {
 	}
 }
 
+func TestTranslateRuntimeConfig_AgentImagePullSecrets(t *testing.T) {
+	desired := &api.DesiredState{
+		Agents: []*api.Agent{{
+			Name:    "private-agent",
+			Version: "v1",
+			Deployment: api.AgentDeployment{
+				Image:            "registry.example.com/private/agent:v1",
+				ImagePullSecrets: []string{"registry-a", "registry-b"},
+			},
+		}},
+	}
+
+	config, err := NewTranslator().TranslateRuntimeConfig(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+
+	secrets := config.Kubernetes.Agents[0].Spec.BYO.Deployment.ImagePullSecrets
+	if len(secrets) != 2 || secrets[0].Name != "registry-a" || secrets[1].Name != "registry-b" {
+		t.Errorf("Expected image pull secrets registry-a and registry-b, got %v", secrets)
+	}
+}
+
 func TestTranslateRuntimeConfig_RemoteMCP(t *testing.T) {
 	translator := NewTranslator()
 	ctx := context.Background()
@@ -141,6 +165,166 @@ func TestTranslateRuntimeConfig_LocalMCP(t *testing.T) {
 	}
 }
 
+func TestTranslateRuntimeConfig_LocalMCPSecretEnv(t *testing.T) {
+	translator := NewTranslator()
+	ctx := context.Background()
+
+	desired := &api.DesiredState{
+		MCPServers: []*api.MCPServer{
+			{
+				Name:          "secret-server",
+				MCPServerType: api.MCPServerTypeLocal,
+				Local: &api.LocalMCPServer{
+					TransportType: api.TransportTypeStdio,
+					Deployment: api.MCPServerDeployment{
+						Image: "mcp-image:latest",
+						Env:   map[string]string{"LOG_LEVEL": "info"},
+						SecretEnv: []api.SecretEnvVar{
+							{Name: "API_KEY", SecretName: "search-creds", Key: "API_KEY"},
+							{Name: "TOKEN", SecretName: "search-creds", Key: "TOKEN"},
+							{Name: "DB_PASSWORD", SecretName: "db-creds", Key: "DB_PASSWORD"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	config, err := translator.TranslateRuntimeConfig(ctx, desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+	if len(config.Kubernetes.MCPServers) != 1 {
+		t.Fatalf("Expected 1 MCPServer, got %d", len(config.Kubernetes.MCPServers))
+	}
+	deployment := config.Kubernetes.MCPServers[0].Spec.Deployment
+
+	// Each Secret is referenced once and loaded with envFrom
+	if len(deployment.SecretRefs) != 2 || deployment.SecretRefs[0].Name != "search-creds" || deployment.SecretRefs[1].Name != "db-creds" {
+		t.Errorf("Expected secret refs [search-creds db-creds], got %+v", deployment.SecretRefs)
+	}
+	// Secret variables are not inlined, so they cannot reach the ConfigMap
+	// KMCP renders from the inline env
+	if len(deployment.Env) != 1 || deployment.Env["LOG_LEVEL"] != "info" {
+		t.Errorf("Expected only LOG_LEVEL inline, got %v", deployment.Env)
+	}
+
+	desired.MCPServers[0].Local.Deployment.SecretEnv = []api.SecretEnvVar{
+		{Name: "API_KEY", SecretName: "search-creds", Key: "search-api-key"},
+	}
+	if _, err := translator.TranslateRuntimeConfig(ctx, desired); err == nil {
+		t.Error("Expected error for a secret key that differs from the variable name")
+	}
+}
+
+func TestTranslateRuntimeConfig_LocalMCPImagePullSecrets(t *testing.T) {
+	translator := NewTranslator()
+
+	desired := &api.DesiredState{
+		MCPServers: []*api.MCPServer{
+			{
+				Name:          "private-server",
+				MCPServerType: api.MCPServerTypeLocal,
+				Namespace:     "team-a",
+				Local: &api.LocalMCPServer{
+					TransportType: api.TransportTypeStdio,
+					Deployment: api.MCPServerDeployment{
+						Image:            "registry.example.com/private/mcp:1.0.0",
+						ImagePullSecrets: []string{"regcred", "mirror-creds"},
+					},
+				},
+			},
+		},
+	}
+
+	config, err := translator.TranslateRuntimeConfig(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+	if len(config.Kubernetes.ServiceAccounts) != 1 {
+		t.Fatalf("Expected 1 ServiceAccount, got %d", len(config.Kubernetes.ServiceAccounts))
+	}
+	// kmcp runs the server's pods under a ServiceAccount named after it
+	sa := config.Kubernetes.ServiceAccounts[0]
+	server := config.Kubernetes.MCPServers[0]
+	if sa.Name != server.Name || sa.Namespace != "team-a" {
+		t.Errorf("Expected ServiceAccount team-a/%s, got %s/%s", server.Name, sa.Namespace, sa.Name)
+	}
+	if len(sa.ImagePullSecrets) != 2 || sa.ImagePullSecrets[0].Name != "regcred" || sa.ImagePullSecrets[1].Name != "mirror-creds" {
+		t.Errorf("Expected pull secrets [regcred mirror-creds], got %+v", sa.ImagePullSecrets)
+	}
+
+	desired.MCPServers[0].Local.Deployment.ImagePullSecrets = nil
+	config, err = translator.TranslateRuntimeConfig(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+	if len(config.Kubernetes.ServiceAccounts) != 0 {
+		t.Errorf("Expected no ServiceAccount without pull secrets, got %d", len(config.Kubernetes.ServiceAccounts))
+	}
+}
+
+func TestTranslateRuntimeConfig_SSE(t *testing.T) {
+	translator := NewTranslator()
+	ctx := context.Background()
+
+	desired := &api.DesiredState{
+		MCPServers: []*api.MCPServer{
+			{
+				Name:          "sse-remote",
+				MCPServerType: api.MCPServerTypeRemote,
+				Remote: &api.RemoteMCPServer{
+					Host:          "example.com",
+					Port:          8080,
+					Path:          "/sse",
+					TransportType: api.TransportTypeSSE,
+				},
+			},
+			{
+				Name:          "sse-local",
+				MCPServerType: api.MCPServerTypeLocal,
+				Local: &api.LocalMCPServer{
+					TransportType: api.TransportTypeSSE,
+					Deployment:    api.MCPServerDeployment{Image: "mcp-image:latest"},
+					HTTP:          &api.HTTPTransport{Port: 3000, Path: "/sse"},
+				},
+			},
+		},
+	}
+
+	config, err := translator.TranslateRuntimeConfig(ctx, desired)
+	if err != nil {
+		t.Fatalf("TranslateRuntimeConfig failed: %v", err)
+	}
+
+	if len(config.Kubernetes.RemoteMCPServers) != 1 {
+		t.Fatalf("Expected 1 RemoteMCPServer, got %d", len(config.Kubernetes.RemoteMCPServers))
+	}
+	remote := config.Kubernetes.RemoteMCPServers[0]
+	if remote.Spec.Protocol != v1alpha2.RemoteMCPServerProtocolSse {
+		t.Errorf("Expected protocol %s, got %s", v1alpha2.RemoteMCPServerProtocolSse, remote.Spec.Protocol)
+	}
+	if remote.Spec.URL != "http://example.com:8080/sse" {
+		t.Errorf("Expected URL http://example.com:8080/sse, got %s", remote.Spec.URL)
+	}
+
+	if len(config.Kubernetes.MCPServers) != 1 {
+		t.Fatalf("Expected 1 MCPServer, got %d", len(config.Kubernetes.MCPServers))
+	}
+	local := config.Kubernetes.MCPServers[0]
+	if local.Spec.TransportType != "http" {
+		t.Errorf("Expected transport http, got %s", local.Spec.TransportType)
+	}
+	if local.Spec.HTTPTransport == nil || local.Spec.HTTPTransport.TargetPort != 3000 || local.Spec.HTTPTransport.TargetPath != "/sse" {
+		t.Errorf("Expected HTTP transport on port 3000 path /sse, got %+v", local.Spec.HTTPTransport)
+	}
+
+	desired.MCPServers[1].Local.HTTP = nil
+	if _, err := translator.TranslateRuntimeConfig(ctx, desired); err == nil {
+		t.Error("Expected error for SSE server without HTTP config")
+	}
+}
+
 func TestTranslateRuntimeConfig_AgentWithMCPServers(t *testing.T) {
 	translator := NewTranslator()
 	ctx := context.Background()
@@ -200,10 +200,11 @@ func translateRemoteMCPServer(
 		Name:          GenerateInternalName(registryServer.Name),
 		MCPServerType: api.MCPServerTypeRemote,
 		Remote: &api.RemoteMCPServer{
-			Host:    u.host,
-			Port:    u.port,
-			Path:    u.path,
-			Headers: headers,
+			Host:          u.host,
+			Port:          u.port,
+			Path:          u.path,
+			Headers:       headers,
+			TransportType: remoteTransportType(remoteInfo.Type),
 		},
 	}, nil
 }
@@ -280,7 +281,7 @@ func translateLocalMCPServer(
 	case "stdio":
 		transportType = api.TransportTypeStdio
 	default:
-		transportType = api.TransportTypeHTTP
+		transportType = remoteTransportType(packageInfo.Transport.Type)
 		u, err := parseUrl(packageInfo.Transport.URL)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse transport url: %v", err)
@@ -307,6 +308,15 @@ func translateLocalMCPServer(
 	}, nil
 }
 
+// remoteTransportType maps a registry transport type to the runtime HTTP-based
+// transport, keeping SSE servers distinct from streamable HTTP ones
+func remoteTransportType(transport string) api.TransportType {
+	if transport == "sse" {
+		return api.TransportTypeSSE
+	}
+	return api.TransportTypeHTTP
+}
+
 type parsedUrl struct {
 	host string
 	port uint32
@@ -1,7 +1,10 @@
-package httpapi
+// Package safehttp provides an HTTP client for fetching user-supplied URLs
+// that refuses to reach private, loopback and link-local addresses.
+package safehttp
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,14 +13,14 @@ import (
 	"time"
 )
 
-// errBlockedAddress is returned by the dial control when a connection target
+// ErrBlockedAddress is returned by the dial control when a connection target
 // resolves to a disallowed (private/loopback/link-local/metadata) address.
-var errBlockedAddress = fmt.Errorf("connection to non-public address blocked")
+var ErrBlockedAddress = fmt.Errorf("connection to non-public address blocked")
 
-// validateImportURL enforces an https-only scheme on a user-supplied import
-// source URL. Per-IP filtering happens later in the dial control, after DNS
-// resolution, so that a hostname cannot smuggle a private target past us.
-func validateImportURL(raw string) (*url.URL, error) {
+// ValidateURL enforces an https-only scheme on a user-supplied URL. Per-IP
+// filtering happens later in the dial control, after DNS resolution, so that a
+// hostname cannot smuggle a private target past us.
+func ValidateURL(raw string) (*url.URL, error) {
 	u, err := url.Parse(raw)
 	if err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
@@ -31,10 +34,10 @@ func validateImportURL(raw string) (*url.URL, error) {
 	return u, nil
 }
 
-// isDisallowedIP reports whether an IP must not be dialed by the server-side
-// fetcher. It blocks loopback, link-local (incl. the cloud metadata range
+// IsDisallowedIP reports whether an IP must not be dialed for a user-supplied
+// URL. It blocks loopback, link-local (incl. the cloud metadata range
 // 169.254.0.0/16), private RFC1918/ULA, unspecified, and multicast addresses.
-func isDisallowedIP(ip net.IP) bool {
+func IsDisallowedIP(ip net.IP) bool {
 	if ip == nil {
 		return true
 	}
@@ -57,17 +60,17 @@ func safeDialControl(_ string, address string, _ syscall.RawConn) error {
 		return err
 	}
 	ip := net.ParseIP(host)
-	if isDisallowedIP(ip) {
-		return errBlockedAddress
+	if IsDisallowedIP(ip) {
+		return ErrBlockedAddress
 	}
 	return nil
 }
 
-// newSafeHTTPClient builds an http.Client suitable for fetching untrusted,
+// NewClient builds an http.Client suitable for fetching untrusted,
 // user-supplied URLs. It blocks non-public targets at dial time and refuses to
 // follow redirects (a redirect could otherwise point at an internal address
-// after the initial allow check).
-func newSafeHTTPClient(timeout time.Duration) *http.Client {
+// after the initial allow check). A nil tlsConfig uses Go's defaults.
+func NewClient(timeout time.Duration, tlsConfig *tls.Config) *http.Client {
 	dialer := &net.Dialer{
 		Timeout: 10 * time.Second,
 		Control: safeDialControl,
@@ -78,6 +81,7 @@ func newSafeHTTPClient(timeout time.Duration) *http.Client {
 		},
 		// No proxy from environment; the request target is the only egress.
 		Proxy:                 nil,
+		TLSClientConfig:       tlsConfig,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ResponseHeaderTimeout: timeout,
 	}
@@ -1,4 +1,4 @@
-package httpapi
+package safehttp
 
 import (
 	"net"
@@ -7,7 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestValidateImportURL(t *testing.T) {
+func TestValidateURL(t *testing.T) {
 	tests := []struct {
 		name    string
 		url     string
@@ -22,7 +22,7 @@ func TestValidateImportURL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := validateImportURL(tt.url)
+			_, err := ValidateURL(tt.url)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -46,9 +46,9 @@ func TestIsDisallowedIP(t *testing.T) {
 		"fe80::1",         // link-local v6
 	}
 	for _, s := range blocked {
-		assert.True(t, isDisallowedIP(net.ParseIP(s)), "expected %s to be blocked", s)
+		assert.True(t, IsDisallowedIP(net.ParseIP(s)), "expected %s to be blocked", s)
 	}
-	assert.True(t, isDisallowedIP(nil), "nil IP must be blocked")
+	assert.True(t, IsDisallowedIP(nil), "nil IP must be blocked")
 
 	allowed := []string{
 		"8.8.8.8",
@@ -57,6 +57,6 @@ func TestIsDisallowedIP(t *testing.T) {
 		"2606:2800:220:1:248:1893:25c8:1946",
 	}
 	for _, s := range allowed {
-		assert.False(t, isDisallowedIP(net.ParseIP(s)), "expected %s to be allowed", s)
+		assert.False(t, IsDisallowedIP(net.ParseIP(s)), "expected %s to be allowed", s)
 	}
 }
@@ -0,0 +1,25 @@
+package search
+
+import (
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+)
+
+// MCPServerFields returns the searchable fields of an MCP server entry
+func MCPServerFields(s agentregistryv1alpha1.MCPServerCatalog) Fields {
+	return Fields{Name: s.Spec.Name, Title: s.Spec.Title, Description: s.Spec.Description}
+}
+
+// AgentFields returns the searchable fields of an agent entry
+func AgentFields(a agentregistryv1alpha1.AgentCatalog) Fields {
+	return Fields{Name: a.Spec.Name, Title: a.Spec.Title, Description: a.Spec.Description}
+}
+
+// SkillFields returns the searchable fields of a skill entry
+func SkillFields(s agentregistryv1alpha1.SkillCatalog) Fields {
+	return Fields{Name: s.Spec.Name, Title: s.Spec.Title, Description: s.Spec.Description}
+}
+
+// ModelFields returns the searchable fields of a model entry
+func ModelFields(m agentregistryv1alpha1.ModelCatalog) Fields {
+	return Fields{Name: m.Spec.Name, Description: m.Spec.Description}
+}
@@ -0,0 +1,141 @@
+// Package search ranks catalog entries against a free-text query, shared by
+// the HTTP handlers and the MCP tools.
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Match scores, best first. An entry's score is the best of its matches.
+const (
+	// ScoreNone means the entry does not match the query
+	ScoreNone = iota
+	// ScoreText is a match in the title or description
+	ScoreText
+	// ScoreNameSubstring is a match anywhere in the name
+	ScoreNameSubstring
+	// ScoreNamePrefix is a name starting with the query
+	ScoreNamePrefix
+	// ScoreExact is a name equal to the query
+	ScoreExact
+)
+
+// Fields are the parts of a catalog entry a query is matched against
+type Fields struct {
+	Name        string
+	Title       string
+	Description string
+}
+
+// Names of the Fields a Scope can restrict a query to
+const (
+	FieldName        = "name"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+)
+
+// Scope restricts which Fields a query is matched against. The zero Scope
+// matches all of them.
+type Scope struct {
+	fields map[string]bool
+}
+
+// ParseScope parses a comma-separated list of field names, e.g.
+// "title,description". An empty list is the zero Scope.
+func ParseScope(list string) (Scope, error) {
+	var scope Scope
+	for _, field := range strings.Split(list, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		switch field {
+		case "":
+			continue
+		case FieldName, FieldTitle, FieldDescription:
+		default:
+			return Scope{}, fmt.Errorf("unknown search field %q, expected %s, %s or %s", field, FieldName, FieldTitle, FieldDescription)
+		}
+		if scope.fields == nil {
+			scope.fields = make(map[string]bool)
+		}
+		scope.fields[field] = true
+	}
+	return scope, nil
+}
+
+// includes reports whether the scope matches queries against field
+func (s Scope) includes(field string) bool {
+	return len(s.fields) == 0 || s.fields[field]
+}
+
+// Score returns how well an entry matches query, ignoring case. The name is
+// compared both in full and by its last path segment, so "github" is an
+// exact match for "io.github.org/github".
+func Score(query string, fields Fields) int {
+	return Scope{}.Score(query, fields)
+}
+
+// Score is like the package-level Score, considering only the fields in s
+func (s Scope) Score(query string, fields Fields) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return ScoreNone
+	}
+
+	if s.includes(FieldName) {
+		name := strings.ToLower(fields.Name)
+		shortName := name[strings.LastIndex(name, "/")+1:]
+		switch {
+		case name == query || shortName == query:
+			return ScoreExact
+		case strings.HasPrefix(name, query) || strings.HasPrefix(shortName, query):
+			return ScoreNamePrefix
+		case strings.Contains(name, query):
+			return ScoreNameSubstring
+		}
+	}
+	if (s.includes(FieldTitle) && strings.Contains(strings.ToLower(fields.Title), query)) ||
+		(s.includes(FieldDescription) && strings.Contains(strings.ToLower(fields.Description), query)) {
+		return ScoreText
+	}
+	return ScoreNone
+}
+
+// Rank returns the items matching query ordered by descending score, with
+// ties ordered by name and otherwise kept in their original order. An empty
+// query returns items unchanged.
+func Rank[T any](items []T, query string, fields func(T) Fields) []T {
+	return RankWithin(items, query, Scope{}, fields)
+}
+
+// RankWithin is like Rank, matching query only against the fields in scope
+func RankWithin[T any](items []T, query string, scope Scope, fields func(T) Fields) []T {
+	if strings.TrimSpace(query) == "" {
+		return items
+	}
+
+	type scored struct {
+		item  T
+		name  string
+		score int
+	}
+	matches := make([]scored, 0, len(items))
+	for _, item := range items {
+		f := fields(item)
+		if score := scope.Score(query, f); score != ScoreNone {
+			matches = append(matches, scored{item: item, name: f.Name, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	ranked := make([]T, len(matches))
+	for i, m := range matches {
+		ranked[i] = m.item
+	}
+	return ranked
+}
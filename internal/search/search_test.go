@@ -0,0 +1,92 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		fields Fields
+		want   int
+	}{
+		{"exact", "github", Fields{Name: "github"}, ScoreExact},
+		{"exact ignores case", "GitHub", Fields{Name: "github"}, ScoreExact},
+		{"exact last path segment", "github", Fields{Name: "io.example/github"}, ScoreExact},
+		{"prefix", "git", Fields{Name: "github"}, ScoreNamePrefix},
+		{"prefix of last path segment", "git", Fields{Name: "io.example/github-tools"}, ScoreNamePrefix},
+		{"substring", "github", Fields{Name: "my-github-helper"}, ScoreNameSubstring},
+		{"title", "github", Fields{Name: "scm", Title: "GitHub bridge"}, ScoreText},
+		{"description", "github", Fields{Name: "scm", Description: "Talks to github"}, ScoreText},
+		{"no match", "github", Fields{Name: "gitlab", Title: "GitLab"}, ScoreNone},
+		{"empty query", "", Fields{Name: "github"}, ScoreNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Score(tt.query, tt.fields))
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	catalog := []Fields{
+		{Name: "zz-tools", Description: "Utilities for GitHub"},
+		{Name: "my-github-helper"},
+		{Name: "github-actions"},
+		{Name: "gitlab"},
+		{Name: "another-github-helper"},
+		{Name: "io.example/github"},
+		{Name: "aa-scm", Title: "GitHub"},
+		{Name: "github"},
+	}
+	ranked := Rank(catalog, "github", func(f Fields) Fields { return f })
+
+	var names []string
+	for _, f := range ranked {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{
+		"github",
+		"io.example/github",
+		"github-actions",
+		"another-github-helper",
+		"my-github-helper",
+		"aa-scm",
+		"zz-tools",
+	}, names)
+
+	// An empty query keeps every item in its original order
+	assert.Equal(t, catalog, Rank(catalog, " ", func(f Fields) Fields { return f }))
+}
+
+func TestRank_KeepsVersionOrderForTies(t *testing.T) {
+	type entry struct{ name, version string }
+	entries := []entry{{"github", "2.0.0"}, {"github-actions", "1.0.0"}, {"github", "1.0.0"}}
+	ranked := Rank(entries, "github", func(e entry) Fields { return Fields{Name: e.name} })
+	assert.Equal(t, []entry{{"github", "2.0.0"}, {"github", "1.0.0"}, {"github-actions", "1.0.0"}}, ranked)
+}
+
+func TestScope(t *testing.T) {
+	fields := Fields{Name: "gh-mcp", Title: "GitHub MCP", Description: "Reads github issues"}
+
+	scope, err := ParseScope("")
+	require.NoError(t, err)
+	assert.Equal(t, ScoreText, scope.Score("github", fields), "the zero scope matches all fields")
+
+	scope, err = ParseScope("name")
+	require.NoError(t, err)
+	assert.Equal(t, ScoreNone, scope.Score("github", fields))
+	assert.Equal(t, ScoreNamePrefix, scope.Score("gh", fields))
+
+	scope, err = ParseScope(" Description ,")
+	require.NoError(t, err)
+	assert.Equal(t, ScoreText, scope.Score("issues", fields))
+	assert.Equal(t, ScoreNone, scope.Score("mcp", fields))
+
+	_, err = ParseScope("name,tags")
+	assert.ErrorContains(t, err, `unknown search field "tags"`)
+}
@@ -0,0 +1,74 @@
+// Package semver provides semantic version parsing, comparison and sorting
+// shared by the controllers and the HTTP handlers.
+package semver
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/agentregistry-dev/agentregistry/internal/validation"
+)
+
+// IsValid reports whether version is a full semantic version (MAJOR.MINOR.PATCH
+// with optional pre-release and build metadata). A leading "v" is accepted.
+func IsValid(version string) bool {
+	return validation.IsSemanticVersion(version)
+}
+
+// canonical returns version with the "v" prefix expected by x/mod/semver.
+func canonical(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
+
+// Compare returns -1, 0 or 1 depending on whether a is lower than, equal to
+// or greater than b.
+//
+// Pre-release versions sort before their release (1.0.0-rc1 < 1.0.0) and
+// build metadata is ignored (1.0.0+a == 1.0.0+b). A valid semantic version is
+// always greater than an invalid one; two invalid versions are compared
+// lexically so that ordering stays deterministic.
+func Compare(a, b string) int {
+	validA, validB := IsValid(a), IsValid(b)
+	switch {
+	case validA && validB:
+		return semver.Compare(canonical(a), canonical(b))
+	case validA:
+		return 1
+	case validB:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// SortDescending sorts versions newest-first in place.
+func SortDescending(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return Compare(versions[i], versions[j]) > 0
+	})
+}
+
+// SortDescendingFunc sorts items newest-first in place, using version to
+// extract the version string of each item.
+func SortDescendingFunc[T any](items []T, version func(T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return Compare(version(items[i]), version(items[j])) > 0
+	})
+}
+
+// LatestIndex returns the index of the item with the highest version, or -1
+// if items is empty. Ties keep the first item.
+func LatestIndex[T any](items []T, version func(T) string) int {
+	latest := -1
+	for i := range items {
+		if latest < 0 || Compare(version(items[i]), version(items[latest])) > 0 {
+			latest = i
+		}
+	}
+	return latest
+}
@@ -0,0 +1,77 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"equal", "1.0.0", "1.0.0", 0},
+		{"v prefix is ignored", "v1.2.3", "1.2.3", 0},
+		{"patch", "1.0.1", "1.0.0", 1},
+		{"minor", "1.1.0", "1.2.0", -1},
+		{"major beats minor", "2.0.0", "1.99.0", 1},
+		{"numeric not lexical", "1.10.0", "1.9.0", 1},
+		{"pre-release before release", "1.0.0-rc1", "1.0.0", -1},
+		{"pre-release ordering", "1.0.0-alpha", "1.0.0-beta", -1},
+		{"numeric pre-release identifiers", "1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"build metadata ignored", "1.0.0+build.1", "1.0.0+build.2", 0},
+		{"build metadata vs none", "1.0.0+sha.abc", "1.0.0", 0},
+		{"pre-release with build metadata", "1.0.0-rc1+build", "1.0.0", -1},
+		{"valid beats invalid", "0.0.1", "latest", 1},
+		{"invalid loses to valid", "not-a-version", "0.1.0", -1},
+		{"short version is invalid", "1.0", "0.0.1", -1},
+		{"empty is invalid", "", "0.0.1", -1},
+		{"two invalid compare lexically", "beta", "alpha", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compare(tt.a, tt.b))
+			assert.Equal(t, -tt.want, Compare(tt.b, tt.a), "comparison must be antisymmetric")
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for version, want := range map[string]bool{
+		"1.0.0":           true,
+		"v1.0.0":          true,
+		"1.0.0-rc1":       true,
+		"1.0.0+build.5":   true,
+		"1.0.0-rc.1+b.2":  true,
+		"1.0":             false,
+		"latest":          false,
+		"":                false,
+		"01.0.0":          false,
+		"1.0.0-":          false,
+		"1.0.0+":          false,
+		"1.0.0-rc1.01":    false,
+		"1.0.0.0":         false,
+		"v1.0.0-beta.x.1": true,
+	} {
+		assert.Equal(t, want, IsValid(version), version)
+	}
+}
+
+func TestSortDescending(t *testing.T) {
+	versions := []string{"1.0.0-rc1", "0.9.0", "latest", "1.10.0", "1.0.0", "1.9.0", "1.0.0-beta"}
+	SortDescending(versions)
+	assert.Equal(t, []string{"1.10.0", "1.9.0", "1.0.0", "1.0.0-rc1", "1.0.0-beta", "0.9.0", "latest"}, versions)
+}
+
+func TestLatestIndex(t *testing.T) {
+	type item struct{ v string }
+	items := []item{{"1.0.0"}, {"2.0.0-rc1"}, {"1.5.0"}, {"dev"}}
+	assert.Equal(t, 1, LatestIndex(items, func(i item) string { return i.v }))
+	assert.Equal(t, -1, LatestIndex([]item{}, func(i item) string { return i.v }))
+
+	items = append(items, item{"2.0.0"})
+	assert.Equal(t, 4, LatestIndex(items, func(i item) string { return i.v }))
+}
@@ -0,0 +1,184 @@
+// Package tlsconfig loads the TLS trust settings used for outbound connections
+// to remote clusters and external registries: an optional CA bundle trusted in
+// addition to the system roots, and an explicit opt-out of verification.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+// DefaultCABundleKey is the Secret or ConfigMap key read when a reference does
+// not name one
+const DefaultCABundleKey = "ca.crt"
+
+// Options are the TLS trust settings for outbound connections. The zero value
+// verifies certificates against the system roots only.
+type Options struct {
+	// CABundle holds PEM certificates trusted in addition to the system roots
+	CABundle []byte
+	// InsecureSkipVerify disables certificate verification entirely
+	InsecureSkipVerify bool
+}
+
+// Load builds Options from the controller configuration, reading the CA
+// bundles referenced by AGENTREGISTRY_TLS_CA_BUNDLE_SECRET and
+// AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP from the controller namespace. It uses
+// reader directly so it can run before the manager cache has started.
+func Load(ctx context.Context, reader client.Reader, logger zerolog.Logger) (Options, error) {
+	opts := Options{InsecureSkipVerify: config.TLSInsecureSkipVerify()}
+	namespace := config.GetNamespace()
+
+	if ref := config.TLSCABundleSecret(); ref != "" {
+		bundle, err := LoadCABundleFromSecret(ctx, reader, namespace, ref)
+		if err != nil {
+			return Options{}, err
+		}
+		opts.CABundle = appendPEM(opts.CABundle, bundle)
+	}
+	if ref := config.TLSCABundleConfigMap(); ref != "" {
+		bundle, err := LoadCABundleFromConfigMap(ctx, reader, namespace, ref)
+		if err != nil {
+			return Options{}, err
+		}
+		opts.CABundle = appendPEM(opts.CABundle, bundle)
+	}
+
+	if opts.InsecureSkipVerify {
+		logger.Warn().Msg("TLS certificate verification is DISABLED for remote clusters and import sources " +
+			"(AGENTREGISTRY_TLS_INSECURE_SKIP_VERIFY=true); connections are open to interception, do not use this in production")
+	}
+	if len(opts.CABundle) > 0 {
+		logger.Info().Msg("trusting custom CA bundle for remote clusters and import sources")
+	}
+	return opts, nil
+}
+
+// LoadCABundleFromSecret reads a PEM CA bundle from the Secret referenced as
+// "name" or "name/key" in namespace
+func LoadCABundleFromSecret(ctx context.Context, reader client.Reader, namespace, ref string) ([]byte, error) {
+	name, key := parseRef(ref)
+	var secret corev1.Secret
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get CA bundle secret %s/%s: %w", namespace, name, err)
+	}
+	bundle, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("CA bundle secret %s/%s has no key %q", namespace, name, key)
+	}
+	if err := ValidateCABundle(bundle); err != nil {
+		return nil, fmt.Errorf("CA bundle secret %s/%s key %q: %w", namespace, name, key, err)
+	}
+	return bundle, nil
+}
+
+// LoadCABundleFromConfigMap reads a PEM CA bundle from the ConfigMap
+// referenced as "name" or "name/key" in namespace
+func LoadCABundleFromConfigMap(ctx context.Context, reader client.Reader, namespace, ref string) ([]byte, error) {
+	name, key := parseRef(ref)
+	var cm corev1.ConfigMap
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cm); err != nil {
+		return nil, fmt.Errorf("failed to get CA bundle configmap %s/%s: %w", namespace, name, err)
+	}
+	var bundle []byte
+	if data, ok := cm.Data[key]; ok {
+		bundle = []byte(data)
+	} else if data, ok := cm.BinaryData[key]; ok {
+		bundle = data
+	} else {
+		return nil, fmt.Errorf("CA bundle configmap %s/%s has no key %q", namespace, name, key)
+	}
+	if err := ValidateCABundle(bundle); err != nil {
+		return nil, fmt.Errorf("CA bundle configmap %s/%s key %q: %w", namespace, name, key, err)
+	}
+	return bundle, nil
+}
+
+// ValidateCABundle returns an error unless bundle holds at least one PEM
+// encoded certificate
+func ValidateCABundle(bundle []byte) error {
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("no valid PEM certificates found")
+	}
+	return nil
+}
+
+// TLSConfig returns the tls.Config for HTTP clients, or nil when the options
+// are the zero value and Go's defaults apply
+func (o Options) TLSConfig() *tls.Config {
+	if o.InsecureSkipVerify {
+		return &tls.Config{InsecureSkipVerify: true} //nolint:gosec // explicit operator opt-in, warned about in Load
+	}
+	if len(o.CABundle) == 0 {
+		return nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	pool.AppendCertsFromPEM(o.CABundle)
+	return &tls.Config{RootCAs: pool}
+}
+
+// ApplyToRESTConfig adds the options to a remote cluster config. The CA bundle
+// is trusted alongside the cluster's own CA, from CAData or CAFile. A config
+// without one would verify against the system roots, which client-go cannot
+// combine with CA data, so the bundle replaces them for that cluster and a
+// warning is logged. Skipping verification drops the CA data, which client-go
+// refuses to combine with Insecure.
+func (o Options) ApplyToRESTConfig(cfg *rest.Config, logger zerolog.Logger) error {
+	if o.InsecureSkipVerify {
+		cfg.Insecure = true
+		cfg.CAData = nil
+		cfg.CAFile = ""
+		return nil
+	}
+	if len(o.CABundle) == 0 {
+		return nil
+	}
+	clusterCA := cfg.CAData
+	if len(clusterCA) == 0 && cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read cluster CA file: %w", err)
+		}
+		clusterCA = data
+	}
+	if len(clusterCA) == 0 {
+		logger.Warn().Str("host", cfg.Host).
+			Msg("cluster config has no CA; verifying it against the custom CA bundle only, without the system roots")
+	}
+	cfg.CAData = appendPEM(clusterCA, o.CABundle)
+	cfg.CAFile = ""
+	return nil
+}
+
+// parseRef splits a "name" or "name/key" reference
+func parseRef(ref string) (name, key string) {
+	name, key, _ = strings.Cut(strings.TrimSpace(ref), "/")
+	if key == "" {
+		key = DefaultCABundleKey
+	}
+	return name, key
+}
+
+// appendPEM concatenates PEM bundles without modifying a
+func appendPEM(a, b []byte) []byte {
+	out := make([]byte, 0, len(a)+len(b)+1)
+	out = append(out, a...)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		out = append(out, '\n')
+	}
+	return append(out, b...)
+}
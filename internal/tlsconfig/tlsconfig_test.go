@@ -0,0 +1,178 @@
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testCA returns a self-signed PEM certificate and its parsed form
+func testCA(t *testing.T, cn string) ([]byte, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), cert
+}
+
+func TestLoad(t *testing.T) {
+	secretCA, secretCert := testCA(t, "secret-ca")
+	configMapCA, configMapCert := testCA(t, "configmap-ca")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "corp-ca", Namespace: "agentregistry"},
+			Data:       map[string][]byte{"ca.crt": secretCA},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "trust", Namespace: "agentregistry"},
+			Data:       map[string]string{"bundle.pem": string(configMapCA), "broken.pem": "not a certificate"},
+		},
+	).Build()
+	ctx := context.Background()
+	logger := zerolog.New(io.Discard)
+
+	t.Setenv("POD_NAMESPACE", "agentregistry")
+	t.Setenv("AGENTREGISTRY_TLS_INSECURE_SKIP_VERIFY", "")
+
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET", "")
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP", "")
+		opts, err := Load(ctx, c, logger)
+		require.NoError(t, err)
+		assert.Equal(t, Options{}, opts)
+		assert.Nil(t, opts.TLSConfig())
+	})
+
+	t.Run("secret and configmap", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET", "corp-ca")
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP", "trust/bundle.pem")
+		opts, err := Load(ctx, c, logger)
+		require.NoError(t, err)
+
+		pool := x509.NewCertPool()
+		require.True(t, pool.AppendCertsFromPEM(opts.CABundle))
+		for _, cert := range []*x509.Certificate{secretCert, configMapCert} {
+			_, err := cert.Verify(x509.VerifyOptions{Roots: pool})
+			assert.NoError(t, err, cert.Subject.CommonName)
+		}
+
+		tlsConfig := opts.TLSConfig()
+		require.NotNil(t, tlsConfig)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+		_, err = secretCert.Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs})
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET", "corp-ca/tls.crt")
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP", "")
+		_, err := Load(ctx, c, logger)
+		assert.ErrorContains(t, err, `has no key "tls.crt"`)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET", "")
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP", "absent")
+		_, err := Load(ctx, c, logger)
+		assert.ErrorContains(t, err, "failed to get CA bundle configmap agentregistry/absent")
+	})
+
+	t.Run("invalid PEM", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET", "")
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP", "trust/broken.pem")
+		_, err := Load(ctx, c, logger)
+		assert.ErrorContains(t, err, "no valid PEM certificates found")
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_SECRET", "")
+		t.Setenv("AGENTREGISTRY_TLS_CA_BUNDLE_CONFIGMAP", "")
+		t.Setenv("AGENTREGISTRY_TLS_INSECURE_SKIP_VERIFY", "true")
+		opts, err := Load(ctx, c, logger)
+		require.NoError(t, err)
+		assert.True(t, opts.InsecureSkipVerify)
+		assert.True(t, opts.TLSConfig().InsecureSkipVerify)
+	})
+}
+
+func TestApplyToRESTConfig(t *testing.T) {
+	clusterCA, clusterCert := testCA(t, "cluster-ca")
+	extraCA, extraCert := testCA(t, "extra-ca")
+
+	trustsBoth := func(cfg *rest.Config) {
+		t.Helper()
+		pool := x509.NewCertPool()
+		require.True(t, pool.AppendCertsFromPEM(cfg.CAData))
+		for _, cert := range []*x509.Certificate{clusterCert, extraCert} {
+			_, err := cert.Verify(x509.VerifyOptions{Roots: pool})
+			assert.NoError(t, err, cert.Subject.CommonName)
+		}
+	}
+
+	cfg := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: clusterCA}}
+	require.NoError(t, Options{CABundle: extraCA}.ApplyToRESTConfig(cfg, zerolog.Nop()))
+	trustsBoth(cfg)
+
+	// A cluster CA file is combined with the bundle too
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, clusterCA, 0o600))
+	cfg = &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: caFile}}
+	require.NoError(t, Options{CABundle: extraCA}.ApplyToRESTConfig(cfg, zerolog.Nop()))
+	trustsBoth(cfg)
+	assert.Empty(t, cfg.CAFile)
+
+	cfg = &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: filepath.Join(t.TempDir(), "missing.crt")}}
+	assert.Error(t, Options{CABundle: extraCA}.ApplyToRESTConfig(cfg, zerolog.Nop()))
+
+	// Without a cluster CA the bundle is applied on its own, with a warning
+	var logs bytes.Buffer
+	cfg = &rest.Config{Host: "https://private.example.com"}
+	require.NoError(t, Options{CABundle: extraCA}.ApplyToRESTConfig(cfg, zerolog.New(&logs)))
+	assert.Equal(t, extraCA, cfg.CAData)
+	assert.Contains(t, logs.String(), "without the system roots")
+	assert.Contains(t, logs.String(), "private.example.com")
+
+	cfg = &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: clusterCA}}
+	require.NoError(t, Options{InsecureSkipVerify: true}.ApplyToRESTConfig(cfg, zerolog.Nop()))
+	assert.True(t, cfg.Insecure)
+	assert.Empty(t, cfg.CAData)
+
+	cfg = &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: clusterCA}}
+	require.NoError(t, Options{}.ApplyToRESTConfig(cfg, zerolog.Nop()))
+	assert.Equal(t, clusterCA, cfg.CAData)
+}
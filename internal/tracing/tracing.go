@@ -0,0 +1,91 @@
+// Package tracing configures OpenTelemetry tracing for the controller. Spans
+// are exported over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set; otherwise the global no-op tracer
+// provider stays in place and spans cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/agentregistry-dev/agentregistry/internal/version"
+)
+
+// TracerName is the instrumentation scope of the spans created here
+const TracerName = "github.com/agentregistry-dev/agentregistry"
+
+// ServiceName is reported as service.name unless OTEL_SERVICE_NAME overrides it
+const ServiceName = "agentregistry"
+
+// Span attributes describing the resource a span works on
+const (
+	KeyNamespace    = attribute.Key("k8s.namespace.name")
+	KeyObject       = attribute.Key("agentregistry.object")
+	KeyResourceName = attribute.Key("agentregistry.resource.name")
+	KeyResourceType = attribute.Key("agentregistry.resource.type")
+	KeyVersion      = attribute.Key("agentregistry.version")
+	KeyEnvironment  = attribute.Key("agentregistry.environment")
+	KeyPhase        = attribute.Key("agentregistry.phase")
+)
+
+// Tracer returns the tracer of the global tracer provider. It is looked up on
+// every call so spans follow a provider installed after startup, as tests do.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Enabled reports whether an OTLP endpoint is configured
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a tracer provider exporting spans to the configured OTLP
+// endpoint, along with W3C trace context propagation. The standard OTEL_*
+// variables configure the exporter. Without an endpoint it installs nothing.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(ServiceName), semconv.ServiceVersion(version.Version)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// End marks span as failed when err is set and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+// ValidateServerLimits checks an MCP server catalog spec against the catalog
+// size limits and returns one error per field that exceeds them
+func ValidateServerLimits(spec *agentregistryv1alpha1.MCPServerCatalogSpec, limits config.CatalogLimits) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if len(spec.Description) > limits.MaxDescriptionLength {
+		errs = append(errs, field.TooLong(specPath.Child("description"), "", limits.MaxDescriptionLength))
+	}
+	if len(spec.Packages) > limits.MaxPackages {
+		errs = append(errs, field.TooMany(specPath.Child("packages"), len(spec.Packages), limits.MaxPackages))
+	}
+	if len(spec.Remotes) > limits.MaxRemotes {
+		errs = append(errs, field.TooMany(specPath.Child("remotes"), len(spec.Remotes), limits.MaxRemotes))
+	}
+	for i, pkg := range spec.Packages {
+		pkgPath := specPath.Child("packages").Index(i)
+		if len(pkg.EnvironmentVariables) > limits.MaxEnvVars {
+			errs = append(errs, field.TooMany(pkgPath.Child("environmentVariables"), len(pkg.EnvironmentVariables), limits.MaxEnvVars))
+		}
+		if len(pkg.RuntimeArguments) > limits.MaxArguments {
+			errs = append(errs, field.TooMany(pkgPath.Child("runtimeArguments"), len(pkg.RuntimeArguments), limits.MaxArguments))
+		}
+		if len(pkg.PackageArguments) > limits.MaxArguments {
+			errs = append(errs, field.TooMany(pkgPath.Child("packageArguments"), len(pkg.PackageArguments), limits.MaxArguments))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	agentregistryv1alpha1 "github.com/agentregistry-dev/agentregistry/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/internal/config"
+)
+
+func TestValidateServerLimits(t *testing.T) {
+	limits := config.CatalogLimits{
+		MaxDescriptionLength: 10,
+		MaxPackages:          2,
+		MaxRemotes:           1,
+		MaxEnvVars:           2,
+		MaxArguments:         1,
+	}
+	pkg := func(envVars, runtimeArgs, packageArgs int) agentregistryv1alpha1.Package {
+		return agentregistryv1alpha1.Package{
+			EnvironmentVariables: make([]agentregistryv1alpha1.KeyValueInput, envVars),
+			RuntimeArguments:     make([]agentregistryv1alpha1.Argument, runtimeArgs),
+			PackageArguments:     make([]agentregistryv1alpha1.Argument, packageArgs),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		spec      agentregistryv1alpha1.MCPServerCatalogSpec
+		wantField string
+	}{
+		{"within limits", agentregistryv1alpha1.MCPServerCatalogSpec{
+			Description: "0123456789",
+			Packages:    []agentregistryv1alpha1.Package{pkg(2, 1, 1), pkg(0, 0, 0)},
+			Remotes:     make([]agentregistryv1alpha1.Transport, 1),
+		}, ""},
+		{"description", agentregistryv1alpha1.MCPServerCatalogSpec{Description: "01234567890"}, "spec.description"},
+		{"packages", agentregistryv1alpha1.MCPServerCatalogSpec{Packages: make([]agentregistryv1alpha1.Package, 3)}, "spec.packages"},
+		{"remotes", agentregistryv1alpha1.MCPServerCatalogSpec{Remotes: make([]agentregistryv1alpha1.Transport, 2)}, "spec.remotes"},
+		{"env vars", agentregistryv1alpha1.MCPServerCatalogSpec{
+			Packages: []agentregistryv1alpha1.Package{pkg(0, 0, 0), pkg(3, 0, 0)},
+		}, "spec.packages[1].environmentVariables"},
+		{"runtime arguments", agentregistryv1alpha1.MCPServerCatalogSpec{
+			Packages: []agentregistryv1alpha1.Package{pkg(0, 2, 0)},
+		}, "spec.packages[0].runtimeArguments"},
+		{"package arguments", agentregistryv1alpha1.MCPServerCatalogSpec{
+			Packages: []agentregistryv1alpha1.Package{pkg(0, 0, 2)},
+		}, "spec.packages[0].packageArguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateServerLimits(&tt.spec, limits)
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("ValidateServerLimits() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("ValidateServerLimits() = %v, want one error", errs)
+			}
+			if errs[0].Field != tt.wantField {
+				t.Errorf("error field = %q, want %q", errs[0].Field, tt.wantField)
+			}
+			if !strings.Contains(errs[0].Error(), "at most") && !strings.Contains(errs[0].Error(), "more than") {
+				t.Errorf("error %q does not name the limit", errs[0].Error())
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 
 	"golang.org/x/mod/semver"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 var (
@@ -14,6 +16,9 @@ var (
 	// Allows: 1.0.0, 1.0.0-alpha, 1.0.0-alpha.1, 1.0.0+build, 1.0.0-alpha+build
 	semanticVersionRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
 
+	// digestRegex matches a sha256 OCI content digest
+	digestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
 	// nameRegex matches valid Kubernetes resource names
 	nameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
 
@@ -25,8 +30,37 @@ var (
 
 	// ErrInvalidName is returned when a name is invalid
 	ErrInvalidName = fmt.Errorf("invalid name format")
+
+	// ErrInvalidDigest is returned when an image digest is invalid
+	ErrInvalidDigest = fmt.Errorf("invalid digest format")
+
+	// ErrInvalidMetadata is returned when a label or annotation is invalid
+	ErrInvalidMetadata = fmt.Errorf("invalid label or annotation")
+
+	// ErrInvalidProvider is returned when a model provider is not recognised
+	ErrInvalidProvider = fmt.Errorf("invalid model provider")
+
+	// knownModelProviders lists the model providers accepted on create, in
+	// the casing they are documented with
+	knownModelProviders = []string{
+		"OpenAI",
+		"Anthropic",
+		"AzureOpenAI",
+		"Azure",
+		"Ollama",
+		"Gemini",
+		"Google",
+		"GeminiVertexAI",
+		"AnthropicVertexAI",
+		"Bedrock",
+		"Mistral",
+	}
 )
 
+// customModelProvider is the escape hatch for providers not in
+// knownModelProviders, either on its own or as a "custom:<name>" prefix
+const customModelProvider = "custom"
+
 // ValidateSemanticVersion checks if a version string follows semantic versioning.
 // It accepts both with and without 'v' prefix.
 func ValidateSemanticVersion(version string) error {
@@ -60,6 +94,47 @@ func IsSemanticVersion(version string) bool {
 	return semanticVersionRegex.MatchString(v) && semver.IsValid("v"+v)
 }
 
+// ValidateDigest checks that an OCI image digest has the form sha256:<64 hex>.
+func ValidateDigest(digest string) error {
+	if !digestRegex.MatchString(digest) {
+		return fmt.Errorf("%w: %q (expected sha256: followed by 64 lowercase hex characters)", ErrInvalidDigest, digest)
+	}
+	return nil
+}
+
+// ValidateLabels checks that label keys and values follow Kubernetes syntax.
+func ValidateLabels(labels map[string]string) error {
+	for _, key := range sortedKeys(labels) {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("%w: label key %q: %s", ErrInvalidMetadata, key, strings.Join(errs, "; "))
+		}
+		if errs := k8svalidation.IsValidLabelValue(labels[key]); len(errs) > 0 {
+			return fmt.Errorf("%w: label %q value: %s", ErrInvalidMetadata, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// ValidateAnnotations checks that annotation keys follow Kubernetes syntax.
+func ValidateAnnotations(annotations map[string]string) error {
+	for _, key := range sortedKeys(annotations) {
+		if errs := k8svalidation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+			return fmt.Errorf("%w: annotation key %q: %s", ErrInvalidMetadata, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order so errors are deterministic
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // ValidateURL checks if a string is a valid URL.
 // It accepts both absolute URLs (with scheme) and relative URLs.
 func ValidateURL(urlStr string) error {
@@ -91,6 +166,106 @@ func ValidateURL(urlStr string) error {
 	return nil
 }
 
+// ValidateModelProvider checks that a model provider is one of the known
+// providers, compared case-insensitively. Providers outside the known set are
+// accepted when given as "custom" or "custom:<name>".
+func ValidateModelProvider(provider string) error {
+	accepted := strings.Join(knownModelProviders, ", ") + ", " + customModelProvider + "[:<name>]"
+	if provider == "" {
+		return fmt.Errorf("%w: provider cannot be empty (accepted: %s)", ErrInvalidProvider, accepted)
+	}
+
+	for _, known := range knownModelProviders {
+		if strings.EqualFold(provider, known) {
+			return nil
+		}
+	}
+
+	prefix, name, hasName := strings.Cut(provider, ":")
+	if strings.EqualFold(prefix, customModelProvider) && (!hasName || strings.TrimSpace(name) != "") {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q is not a known provider (accepted: %s)", ErrInvalidProvider, provider, accepted)
+}
+
+// ValidateModelBaseURL checks that a model base URL is an absolute http or
+// https URL with a host.
+func ValidateModelBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: base URL %q must use http or https", ErrInvalidURL, baseURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: base URL %q has no host", ErrInvalidURL, baseURL)
+	}
+	return nil
+}
+
+// ValidateIconURL checks that an icon URL is an absolute https URL with a
+// host; icons are fetched server-side, so plain http is not accepted.
+func ValidateIconURL(iconURL string) error {
+	u, err := url.Parse(iconURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: icon URL %q must use https", ErrInvalidURL, iconURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: icon URL %q has no host", ErrInvalidURL, iconURL)
+	}
+	return nil
+}
+
+// httpTransportTypes are the transport types reached over HTTP, whose URL
+// must be absolute
+var httpTransportTypes = map[string]bool{
+	"streamable-http": true,
+	"sse":             true,
+	"http":            true,
+}
+
+// ValidateTransportURL checks that the URL of an HTTP-based transport
+// (streamable-http, sse or http) is an absolute http or https URL with a
+// host. URLs of other transport types are not checked.
+func ValidateTransportURL(transportType, transportURL string) error {
+	if !httpTransportTypes[transportType] {
+		return nil
+	}
+	if transportURL == "" {
+		return fmt.Errorf("%w: %s transport requires a URL", ErrInvalidURL, transportType)
+	}
+	u, err := url.Parse(transportURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: transport URL %q must use http or https", ErrInvalidURL, transportURL)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%w: transport URL %q has no host", ErrInvalidURL, transportURL)
+	}
+	return nil
+}
+
+// NormalizeTransportURL trims surrounding whitespace and trailing slashes
+// from the path of a transport URL, so equivalent URLs are stored the same
+// way. URLs that do not parse are returned trimmed but otherwise unchanged.
+func NormalizeTransportURL(transportURL string) string {
+	transportURL = strings.TrimSpace(transportURL)
+	u, err := url.Parse(transportURL)
+	if err != nil || transportURL == "" {
+		return transportURL
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	return u.String()
+}
+
 // ValidateRepositoryURL checks if a string is a valid repository URL.
 // It requires either a valid URL with http/https/git scheme or a GitHub/GitLab shorthand (owner/repo).
 func ValidateRepositoryURL(repoURL string) error {
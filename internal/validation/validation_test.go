@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -284,3 +286,152 @@ func TestSanitizeVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDigest(t *testing.T) {
+	valid := "sha256:" + strings.Repeat("a1", 32)
+	tests := []struct {
+		name    string
+		digest  string
+		wantErr bool
+	}{
+		{"valid sha256", valid, false},
+		{"empty", "", true},
+		{"missing algorithm", strings.Repeat("a1", 32), true},
+		{"unsupported algorithm", "sha512:" + strings.Repeat("a1", 32), true},
+		{"too short", "sha256:abc123", true},
+		{"uppercase hex", "sha256:" + strings.Repeat("A1", 32), true},
+		{"non-hex characters", "sha256:" + strings.Repeat("zz", 32), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDigest(tt.digest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDigest(%q) error = %v, wantErr %v", tt.digest, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateModelProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		wantErr  bool
+	}{
+		{"known provider", "Anthropic", false},
+		{"case-insensitive", "openai", false},
+		{"vertex provider", "GeminiVertexAI", false},
+		{"custom", "custom", false},
+		{"custom with name", "Custom:my-gateway", false},
+		{"custom with empty name", "custom:", true},
+		{"typo", "Antropic", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateModelProvider(tt.provider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateModelProvider(%q) error = %v, wantErr %v", tt.provider, err, tt.wantErr)
+			}
+		})
+	}
+
+	err := ValidateModelProvider("Antropic")
+	if !errors.Is(err, ErrInvalidProvider) || !strings.Contains(err.Error(), "Anthropic, AzureOpenAI") {
+		t.Errorf("ValidateModelProvider error = %v, want ErrInvalidProvider listing accepted providers", err)
+	}
+}
+
+func TestValidateModelBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"https URL", "https://api.example.com/v1", false},
+		{"http URL with port", "http://ollama:11434", false},
+		{"relative URL", "/v1", true},
+		{"missing host", "https://", true},
+		{"unsupported scheme", "oci://registry.io/model", true},
+		{"not a URL", "api.example.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateModelBaseURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateModelBaseURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateIconURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"https URL", "https://example.com/icon.png", false},
+		{"http URL", "http://example.com/icon.png", true},
+		{"data URL", "data:image/png;base64,AAAA", true},
+		{"missing host", "https:///icon.png", true},
+		{"relative URL", "/icon.png", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIconURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateIconURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTransportURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		transportType string
+		url           string
+		wantErr       bool
+	}{
+		{"streamable-http URL", "streamable-http", "https://mcp.example.com/mcp", false},
+		{"sse URL with port", "sse", "http://mcp.example.com:8080/sse", false},
+		{"http URL", "http", "http://localhost:3000", false},
+		{"schemeless URL", "streamable-http", "mcp.example.com/mcp", true},
+		{"unsupported scheme", "sse", "ftp://mcp.example.com/sse", true},
+		{"empty host", "streamable-http", "https:///mcp", true},
+		{"port without host", "http", "http://:8080/mcp", true},
+		{"invalid port", "http", "http://mcp.example.com:port/mcp", true},
+		{"empty URL", "streamable-http", "", true},
+		{"stdio is not checked", "stdio", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransportURL(tt.transportType, tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransportURL(%q, %q) error = %v, wantErr %v", tt.transportType, tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeTransportURL(t *testing.T) {
+	tests := map[string]string{
+		"https://mcp.example.com/mcp/":     "https://mcp.example.com/mcp",
+		"https://mcp.example.com//":        "https://mcp.example.com",
+		" https://mcp.example.com/sse ":    "https://mcp.example.com/sse",
+		"https://mcp.example.com/mcp/?a=b": "https://mcp.example.com/mcp?a=b",
+		"http://localhost:8080":            "http://localhost:8080",
+		"":                                 "",
+	}
+	for in, want := range tests {
+		if got := NormalizeTransportURL(in); got != want {
+			t.Errorf("NormalizeTransportURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}